@@ -49,9 +49,13 @@ type APIClient struct {
 
 	// API Services
 
+	CouriersAPI *CouriersAPIService
+
 	CustomersAPI *CustomersAPIService
 
 	StaffAPI *StaffAPIService
+
+	TokensAPI *TokensAPIService
 }
 
 type service struct {
@@ -70,8 +74,10 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.common.client = c
 
 	// API Services
+	c.CouriersAPI = (*CouriersAPIService)(&c.common)
 	c.CustomersAPI = (*CustomersAPIService)(&c.common)
 	c.StaffAPI = (*StaffAPIService)(&c.common)
+	c.TokensAPI = (*TokensAPIService)(&c.common)
 
 	return c
 }
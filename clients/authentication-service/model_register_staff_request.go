@@ -29,6 +29,8 @@ type RegisterStaffRequest struct {
 	RestaurantId string `json:"restaurant_id" validate:"regexp=^[0-9a-fA-F]{24}$"`
 	// Password must be at least 8 characters long
 	Password string `json:"password"`
+	// Whether the staff user owns the restaurant. Owners can access the restaurant management operations
+	Owner *bool `json:"owner,omitempty"`
 }
 
 type _RegisterStaffRequest RegisterStaffRequest
@@ -43,6 +45,8 @@ func NewRegisterStaffRequest(staffId string, email string, restaurantId string,
 	this.Email = email
 	this.RestaurantId = restaurantId
 	this.Password = password
+	var owner bool = false
+	this.Owner = &owner
 	return &this
 }
 
@@ -51,6 +55,8 @@ func NewRegisterStaffRequest(staffId string, email string, restaurantId string,
 // but it doesn't guarantee that properties required by API are set
 func NewRegisterStaffRequestWithDefaults() *RegisterStaffRequest {
 	this := RegisterStaffRequest{}
+	var owner bool = false
+	this.Owner = &owner
 	return &this
 }
 
@@ -150,6 +156,38 @@ func (o *RegisterStaffRequest) SetPassword(v string) {
 	o.Password = v
 }
 
+// GetOwner returns the Owner field value if set, zero value otherwise.
+func (o *RegisterStaffRequest) GetOwner() bool {
+	if o == nil || IsNil(o.Owner) {
+		var ret bool
+		return ret
+	}
+	return *o.Owner
+}
+
+// GetOwnerOk returns a tuple with the Owner field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *RegisterStaffRequest) GetOwnerOk() (*bool, bool) {
+	if o == nil || IsNil(o.Owner) {
+		return nil, false
+	}
+	return o.Owner, true
+}
+
+// HasOwner returns a boolean if a field has been set.
+func (o *RegisterStaffRequest) HasOwner() bool {
+	if o != nil && !IsNil(o.Owner) {
+		return true
+	}
+
+	return false
+}
+
+// SetOwner gets a reference to the given bool and assigns it to the Owner field.
+func (o *RegisterStaffRequest) SetOwner(v bool) {
+	o.Owner = &v
+}
+
 func (o RegisterStaffRequest) MarshalJSON() ([]byte, error) {
 	toSerialize,err := o.ToMap()
 	if err != nil {
@@ -164,6 +202,9 @@ func (o RegisterStaffRequest) ToMap() (map[string]interface{}, error) {
 	toSerialize["email"] = o.Email
 	toSerialize["restaurant_id"] = o.RestaurantId
 	toSerialize["password"] = o.Password
+	if !IsNil(o.Owner) {
+		toSerialize["owner"] = o.Owner
+	}
 	return toSerialize, nil
 }
 
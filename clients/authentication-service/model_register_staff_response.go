@@ -24,6 +24,8 @@ var _ MappedNullable = &RegisterStaffResponse{}
 type RegisterStaffResponse struct {
 	// Unique staff identifier in the auth service
 	Id string `json:"id" validate:"regexp=^[0-9a-fA-F]{24}$"`
+	// Staff identifier carried by the tokens. When the restaurant is linked to existing credentials, it is the one of those credentials instead of the requested one, and the caller must map its own staff to it
+	StaffId string `json:"staff_id" validate:"regexp=^[0-9a-fA-F]{24}$"`
 	// Staff's email address
 	Email string `json:"email"`
 	// Unique restaurant identifier in the auth service
@@ -40,9 +42,10 @@ type _RegisterStaffResponse RegisterStaffResponse
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewRegisterStaffResponse(id string, email string, createdAt time.Time) *RegisterStaffResponse {
+func NewRegisterStaffResponse(id string, staffId string, email string, createdAt time.Time) *RegisterStaffResponse {
 	this := RegisterStaffResponse{}
 	this.Id = id
+	this.StaffId = staffId
 	this.Email = email
 	this.CreatedAt = createdAt
 	return &this
@@ -80,6 +83,30 @@ func (o *RegisterStaffResponse) SetId(v string) {
 	o.Id = v
 }
 
+// GetStaffId returns the StaffId field value
+func (o *RegisterStaffResponse) GetStaffId() string {
+	if o == nil {
+		var ret string
+		return ret
+	}
+
+	return o.StaffId
+}
+
+// GetStaffIdOk returns a tuple with the StaffId field value
+// and a boolean to check if the value has been set.
+func (o *RegisterStaffResponse) GetStaffIdOk() (*string, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return &o.StaffId, true
+}
+
+// SetStaffId sets field value
+func (o *RegisterStaffResponse) SetStaffId(v string) {
+	o.StaffId = v
+}
+
 // GetEmail returns the Email field value
 func (o *RegisterStaffResponse) GetEmail() string {
 	if o == nil {
@@ -203,6 +230,7 @@ func (o RegisterStaffResponse) MarshalJSON() ([]byte, error) {
 func (o RegisterStaffResponse) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
 	toSerialize["id"] = o.Id
+	toSerialize["staff_id"] = o.StaffId
 	toSerialize["email"] = o.Email
 	if !IsNil(o.RestaurantId) {
 		toSerialize["restaurant_id"] = o.RestaurantId
@@ -220,6 +248,7 @@ func (o *RegisterStaffResponse) UnmarshalJSON(data []byte) (err error) {
 	// that every required field exists as a key in the generic map.
 	requiredProperties := []string{
 		"id",
+		"staff_id",
 		"email",
 		"created_at",
 	}
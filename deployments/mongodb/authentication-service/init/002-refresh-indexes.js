@@ -1,6 +1,18 @@
 db = db.getSiblingDB('authentication_service');
 
+// Refresh tokens are stored as a keyed digest. The index on the plaintext token is dropped, if any, as the migrated
+// tokens no longer have that field.
+if (db.refresh_tokens.getIndexes().some(index => index.name === 'token_1')) {
+    db.refresh_tokens.dropIndex('token_1');
+}
+
+db.refresh_tokens.createIndex(
+    { token_hash: 1 },
+    {
+        unique: true,
+        partialFilterExpression: { token_hash: { $type: 'string' } }
+    }
+);
 db.refresh_tokens.createIndex(
-    { token: 1 },
-    { unique: true }
+    { family_id: 1 }
 );
\ No newline at end of file
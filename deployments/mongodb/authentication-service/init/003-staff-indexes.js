@@ -1,12 +1,23 @@
 db = db.getSiblingDB('authentication_service');
 
+// The staff credentials are not scoped to a restaurant anymore, but linked to all the restaurants of the staff user.
+// The documents stored with a single restaurant are migrated by the service, when STAFF_MIGRATE_LEGACY is set.
+if (db.staff.getIndexes().some(index => index.name === 'email_1_restaurant_id_1')) {
+    db.staff.dropIndex('email_1_restaurant_id_1');
+}
+
 db.staff.createIndex(
     {
-        email: 1,
-        restaurant_id: 1
+        email: 1
     },
     {
         unique: true,
         partialFilterExpression: { active: true }
     }
+);
+
+db.staff.createIndex(
+    {
+        'restaurants.restaurant_id': 1
+    }
 );
\ No newline at end of file
@@ -0,0 +1,14 @@
+db = db.getSiblingDB('authentication_service');
+
+db.password_reset_tokens.createIndex(
+    { token_hash: 1 },
+    { unique: true }
+);
+db.password_reset_tokens.createIndex(
+    { user_id: 1, role: 1, tenant_id: 1 }
+);
+// Expired tokens are useless, so MongoDB removes them as soon as they expire
+db.password_reset_tokens.createIndex(
+    { expires_at: 1 },
+    { expireAfterSeconds: 0 }
+);
\ No newline at end of file
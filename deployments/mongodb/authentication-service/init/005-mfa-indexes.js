@@ -0,0 +1,29 @@
+db = db.getSiblingDB('authentication_service');
+
+// The enrollments are not scoped to a tenant anymore, so a staff user linked to several restaurants is challenged in
+// all of them. The enrollments created per restaurant are reduced to one per user, keeping the confirmed one if any.
+if (db.mfa_enrollments.getIndexes().some(index => index.name === 'user_id_1_role_1_tenant_id_1')) {
+    db.mfa_enrollments.dropIndex('user_id_1_role_1_tenant_id_1');
+}
+db.mfa_enrollments.aggregate([
+    { $sort: { confirmed: -1, updated_at: -1 } },
+    { $group: { _id: { user_id: '$user_id', role: '$role' }, ids: { $push: '$_id' } } },
+    { $match: { 'ids.1': { $exists: true } } }
+]).forEach(group => {
+    db.mfa_enrollments.deleteMany({ _id: { $in: group.ids.slice(1) } });
+});
+db.mfa_enrollments.updateMany({ tenant_id: { $exists: true } }, { $unset: { tenant_id: '' } });
+
+db.mfa_enrollments.createIndex(
+    { user_id: 1, role: 1 },
+    { unique: true }
+);
+db.mfa_challenges.createIndex(
+    { token_hash: 1 },
+    { unique: true }
+);
+// Expired challenges are useless, so MongoDB removes them as soon as they expire
+db.mfa_challenges.createIndex(
+    { expires_at: 1 },
+    { expireAfterSeconds: 0 }
+);
\ No newline at end of file
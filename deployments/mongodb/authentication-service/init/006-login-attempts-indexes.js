@@ -0,0 +1,11 @@
+db = db.getSiblingDB('authentication_service');
+
+db.login_attempts.createIndex(
+    { key: 1 },
+    { unique: true }
+);
+// The failed logins are forgotten once they expire, which also ends any lock
+db.login_attempts.createIndex(
+    { expires_at: 1 },
+    { expireAfterSeconds: 0 }
+);
\ No newline at end of file
@@ -0,0 +1,9 @@
+db = db.getSiblingDB('authentication_service');
+
+db.admins.createIndex(
+    { email: 1 },
+    {
+        unique: true,
+        partialFilterExpression: { active: true }
+    }
+);
\ No newline at end of file
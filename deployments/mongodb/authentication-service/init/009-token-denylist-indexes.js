@@ -0,0 +1,8 @@
+db = db.getSiblingDB('authentication_service');
+
+// The access tokens revoked before their expiration are keyed by their jti. They can't be used once expired anyway,
+// so MongoDB removes them as soon as they expire
+db.token_denylist.createIndex(
+    { expires_at: 1 },
+    { expireAfterSeconds: 0 }
+);
\ No newline at end of file
@@ -0,0 +1,17 @@
+db = db.getSiblingDB('authentication_service');
+
+// The admins query the events of a user, or of a type, sorted by the most recent first
+db.auth_events.createIndex(
+    { subject: 1, created_at: -1 }
+);
+db.auth_events.createIndex(
+    { type: 1, created_at: -1 }
+);
+db.auth_events.createIndex(
+    { created_at: -1 }
+);
+// Each event is stored with its expiration, according to the retention configured when it was recorded
+db.auth_events.createIndex(
+    { expires_at: 1 },
+    { expireAfterSeconds: 0 }
+);
\ No newline at end of file
@@ -0,0 +1,14 @@
+db = db.getSiblingDB('authentication_service');
+
+db.magic_link_tokens.createIndex(
+    { token_hash: 1 },
+    { unique: true }
+);
+db.magic_link_tokens.createIndex(
+    { user_id: 1, role: 1, tenant_id: 1 }
+);
+// Expired tokens are useless, so MongoDB removes them as soon as they expire
+db.magic_link_tokens.createIndex(
+    { expires_at: 1 },
+    { expireAfterSeconds: 0 }
+);
\ No newline at end of file
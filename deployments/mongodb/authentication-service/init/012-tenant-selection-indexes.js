@@ -0,0 +1,11 @@
+db = db.getSiblingDB('authentication_service');
+
+db.tenant_selection_tokens.createIndex(
+    { token_hash: 1 },
+    { unique: true }
+);
+// Expired tokens are useless, so MongoDB removes them as soon as they expire
+db.tenant_selection_tokens.createIndex(
+    { expires_at: 1 },
+    { expireAfterSeconds: 0 }
+);
\ No newline at end of file
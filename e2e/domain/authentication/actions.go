@@ -1,22 +1,39 @@
 package authentication
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/e2e/pkg/api"
 )
 
 func (t *Token) GetClaims() (*Claims, error) {
+	jwks, err := api.DoGet[struct{}, JWKS](JWKSEndpoint, struct{}{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jwks: %w", err)
+	}
+
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(t.AccessToken, claims, func(token *jwt.Token) (any, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		kid, _ := token.Header["kid"].(string)
+		for _, key := range jwks.Keys {
+			if key.KeyID != kid {
+				continue
+			}
+			// Validate the signing method against the one of the published key
+			if token.Method.Alg() != key.Algorithm {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return key.PublicKey()
 		}
-		//TODO configure secret by env vars
-		return []byte("a-string-secret-at-least-256-bits-long"), nil
-	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+		return nil, fmt.Errorf("unknown key id: %s", kid)
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Name, jwt.SigningMethodEdDSA.Alg()}))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
@@ -33,3 +50,27 @@ func (c *Claims) GetTenant() (string, error) {
 	// Returning an error following the same jwt package's convention. For example, GetSubject() (string, error)
 	return c.Tenant, nil
 }
+
+// PublicKey decodes the public key of the JWK.
+func (k JWK) PublicKey() (any, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.KeyType)
+	}
+}
@@ -0,0 +1,8 @@
+package authentication
+
+import "github.com/alexgrauroca/practice-food-delivery-platform/e2e/pkg/api"
+
+var (
+	// JWKSEndpoint defines the API endpoint URL where the public keys used to verify the access tokens are published.
+	JWKSEndpoint = api.BaseURL + "/.well-known/jwks.json"
+)
@@ -45,3 +45,19 @@ type RefreshRequest struct {
 type RefreshResponse struct {
 	Token
 }
+
+// JWKS represents the JSON Web Key Set published by the authentication service.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK represents a public key used to verify the access tokens.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for signing and verifying access tokens.
+type Config struct {
+	// SigningKeyID is the kid published for the signing key. Only used by the token issuer.
+	SigningKeyID string `env:"AUTH_SIGNING_KEY_ID" envDefault:"default"`
+	// SigningKeyPath is the path to the PEM encoded private key. Only used by the token issuer.
+	SigningKeyPath string `env:"AUTH_SIGNING_KEY_PATH"`
+	// KeyRingPath is the path to the key ring manifest. When set, it takes precedence over SigningKeyPath. Only used by
+	// the token issuer.
+	KeyRingPath string `env:"AUTH_KEY_RING_PATH"`
+	// AllowEphemeralKey allows generating a random signing key when none is configured. Only meant for local
+	// development, as the tokens won't survive restarts nor scale out. Only used by the token issuer.
+	AllowEphemeralKey bool `env:"AUTH_ALLOW_EPHEMERAL_KEY" envDefault:"false"`
+	// KeyGracePeriod defines how long a retired key is still accepted for verification. Only used by the token issuer.
+	KeyGracePeriod time.Duration `env:"AUTH_KEY_GRACE_PERIOD" envDefault:"2h"`
+	// DenylistCacheSize is the number of revoked tokens kept in memory, in front of the denylist store. Only used by the
+	// token issuer.
+	DenylistCacheSize int `env:"AUTH_DENYLIST_CACHE_SIZE" envDefault:"10000"`
+	// JWKSURL is the address of the JWKS used to verify tokens by the services that do not issue them.
+	JWKSURL string `env:"AUTH_JWKS_URL" envDefault:"http://authentication-service:8080/.well-known/jwks.json"`
+	// JWKSCacheTTL defines how long the fetched JWKS is cached.
+	JWKSCacheTTL time.Duration `env:"AUTH_JWKS_CACHE_TTL" envDefault:"5m"`
+	// IntrospectionCacheSize is the number of introspected tokens kept in memory by the services that do not issue them.
+	IntrospectionCacheSize int `env:"AUTH_INTROSPECTION_CACHE_SIZE" envDefault:"10000"`
+	// IntrospectionCacheTTL defines how long an active token is cached by the services that do not issue them, which
+	// bounds how long a revoked token is still accepted.
+	IntrospectionCacheTTL time.Duration `env:"AUTH_INTROSPECTION_CACHE_TTL" envDefault:"30s"`
+}
+
+// LoadConfig loads the auth configuration from environment variables and logs any errors encountered during parsing.
+// It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load auth configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
@@ -3,6 +3,7 @@ package auth
 
 import (
 	"context"
+	"slices"
 
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 )
@@ -14,6 +15,12 @@ type ContextReader interface {
 	GetSubject(ctx context.Context) (string, bool)
 	RequireSubjectMatch(ctx context.Context, expectedSubject string) error
 	GetToken(ctx context.Context) (string, bool)
+	GetRole(ctx context.Context) (Role, bool)
+	GetTenant(ctx context.Context) (string, bool)
+	RequireTenantMatch(ctx context.Context, expectedTenant string) error
+	HasPermission(ctx context.Context, permission string) bool
+	GetActor(ctx context.Context) (string, bool)
+	RequireNotImpersonated(ctx context.Context) error
 }
 
 type contextReader struct {
@@ -66,3 +73,77 @@ func (r *contextReader) GetToken(ctx context.Context) (string, bool) {
 
 	return token, ok
 }
+
+// GetRole retrieves the token role from the given context.
+// It returns the role and a boolean indicating whether the role was found.
+func (r *contextReader) GetRole(ctx context.Context) (Role, bool) {
+	v := ctx.Value(roleCtxKey)
+	if v == nil {
+		return "", false
+	}
+	role, ok := v.(Role)
+
+	return role, ok
+}
+
+// GetTenant retrieves the token tenant from the given context.
+// It returns the tenant and a boolean indicating whether the tenant was found. Non-tenant tokens, such as the customer
+// ones, return an empty tenant.
+func (r *contextReader) GetTenant(ctx context.Context) (string, bool) {
+	v := ctx.Value(tenantCtxKey)
+	if v == nil {
+		return "", false
+	}
+	tenant, ok := v.(string)
+
+	return tenant, ok
+}
+
+// RequireTenantMatch checks if the tenant of the given context matches the expected value.
+// If the tenant is not found or does not match, it returns an error.
+func (r *contextReader) RequireTenantMatch(ctx context.Context, expectedTenant string) error {
+	tenant, ok := r.GetTenant(ctx)
+	if !ok {
+		r.logger.Warn("authentication context not found")
+		return ErrInvalidToken
+	}
+	if tenant == "" || tenant != expectedTenant {
+		r.logger.Warn(
+			"tenant mismatch with the token",
+			log.Field{Key: "tenant", Value: tenant},
+			log.Field{Key: "expectedTenant", Value: expectedTenant},
+		)
+		return ErrTenantMismatch
+	}
+	return nil
+}
+
+// HasPermission reports whether the permission is one of the permissions granted to the token of the given context.
+// It returns false when there is no authentication context.
+func (r *contextReader) HasPermission(ctx context.Context, permission string) bool {
+	permissions, ok := ctx.Value(permissionsCtxKey).([]string)
+	if !ok {
+		return false
+	}
+	return slices.Contains(permissions, permission)
+}
+
+// GetActor retrieves the subject of the platform admin impersonating the token subject from the given context.
+// It returns the actor and a boolean indicating whether the request is impersonated.
+func (r *contextReader) GetActor(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorCtxKey).(string)
+	if !ok || actor == "" {
+		return "", false
+	}
+	return actor, true
+}
+
+// RequireNotImpersonated checks that the request of the given context is performed by the token subject itself, so
+// the sensitive actions, such as the password changes, can't be performed while impersonating it.
+func (r *contextReader) RequireNotImpersonated(ctx context.Context) error {
+	if actor, ok := r.GetActor(ctx); ok {
+		r.logger.Warn("action not allowed while impersonating", log.Field{Key: "actor", Value: actor})
+		return ErrImpersonationNotAllowed
+	}
+	return nil
+}
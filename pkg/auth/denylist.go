@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+)
+
+// Denylist defines the store of the access tokens revoked before their expiration, identified by their jti claim.
+// The entries are only useful until the token expires, so the stores are expected to remove them on their own.
+//
+//go:generate mockgen -destination=./mocks/denylist_mock.go -package=auth_mocks github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth Denylist
+type Denylist interface {
+	Add(ctx context.Context, tokenID string, expiresAt time.Time) error
+	Contains(ctx context.Context, tokenID string) (bool, error)
+}
+
+type cachedDenylist struct {
+	store Denylist
+	cache *lruCache
+}
+
+// NewCachedDenylist creates a Denylist that keeps the most recently revoked tokens in an in-memory LRU cache, in front
+// of the given store. Only the revoked tokens are cached, so the tokens revoked by other instances are still found in
+// the store. The entries found in the store are cached until evicted, as their token can't be used once expired anyway.
+func NewCachedDenylist(store Denylist, clk clock.Clock, size int) Denylist {
+	return &cachedDenylist{
+		store: store,
+		cache: newLRUCache(clk, size),
+	}
+}
+
+func (d *cachedDenylist) Add(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if err := d.store.Add(ctx, tokenID, expiresAt); err != nil {
+		return err
+	}
+
+	d.cache.put(tokenID, true, expiresAt)
+	return nil
+}
+
+func (d *cachedDenylist) Contains(ctx context.Context, tokenID string) (bool, error) {
+	if _, found := d.cache.get(tokenID); found {
+		return true, nil
+	}
+
+	denied, err := d.store.Contains(ctx, tokenID)
+	if err != nil {
+		return false, err
+	}
+	if denied {
+		d.cache.put(tokenID, true, time.Time{})
+	}
+	return denied, nil
+}
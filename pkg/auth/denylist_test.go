@@ -0,0 +1,161 @@
+//go:build unit
+
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+)
+
+var errStore = errors.New("store error")
+
+func TestCachedDenylist_Add(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		storeSetup func(store *authmocks.MockDenylist)
+		wantErr    error
+		wantCached bool
+	}{
+		{
+			name: "when the store fails to add the token, then it should return the error without caching it",
+			storeSetup: func(store *authmocks.MockDenylist) {
+				store.EXPECT().Add(gomock.Any(), "fake-token-id", expiresAt).Return(errStore)
+				store.EXPECT().Contains(gomock.Any(), "fake-token-id").Return(false, nil)
+			},
+			wantErr:    errStore,
+			wantCached: false,
+		},
+		{
+			name: "when the token is added to the store, then it should be cached",
+			storeSetup: func(store *authmocks.MockDenylist) {
+				store.EXPECT().Add(gomock.Any(), "fake-token-id", expiresAt).Return(nil)
+			},
+			wantErr:    nil,
+			wantCached: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := authmocks.NewMockDenylist(gomock.NewController(t))
+			tt.storeSetup(store)
+			denylist := auth.NewCachedDenylist(store, clock.FixedClock{FixedTime: now}, 10)
+
+			err := denylist.Add(context.Background(), "fake-token-id", expiresAt)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Cached tokens are found without querying the store
+			denied, err := denylist.Contains(context.Background(), "fake-token-id")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCached, denied)
+		})
+	}
+}
+
+func TestCachedDenylist_Contains(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		cached     map[string]time.Time
+		size       int
+		storeSetup func(store *authmocks.MockDenylist)
+		want       bool
+		wantErr    error
+	}{
+		{
+			name: "when the token is neither cached nor stored, then it should not be denied",
+			size: 10,
+			storeSetup: func(store *authmocks.MockDenylist) {
+				store.EXPECT().Contains(gomock.Any(), "fake-token-id").Return(false, nil)
+			},
+			want:    false,
+			wantErr: nil,
+		},
+		{
+			name: "when the store fails, then it should return the error",
+			size: 10,
+			storeSetup: func(store *authmocks.MockDenylist) {
+				store.EXPECT().Contains(gomock.Any(), "fake-token-id").Return(false, errStore)
+			},
+			want:    false,
+			wantErr: errStore,
+		},
+		{
+			name: "when the token is only stored, then it should be denied",
+			size: 10,
+			storeSetup: func(store *authmocks.MockDenylist) {
+				store.EXPECT().Contains(gomock.Any(), "fake-token-id").Return(true, nil)
+			},
+			want:    true,
+			wantErr: nil,
+		},
+		{
+			name:   "when the token is cached, then it should be denied without querying the store",
+			cached: map[string]time.Time{"fake-token-id": now.Add(time.Minute)},
+			size:   10,
+			want:   true,
+		},
+		{
+			name:   "when the cached token already expired, then it should query the store",
+			cached: map[string]time.Time{"fake-token-id": now},
+			size:   10,
+			storeSetup: func(store *authmocks.MockDenylist) {
+				store.EXPECT().Contains(gomock.Any(), "fake-token-id").Return(false, nil)
+			},
+			want:    false,
+			wantErr: nil,
+		},
+		{
+			name: "when the cached token has been evicted, then it should query the store",
+			cached: map[string]time.Time{
+				"fake-token-id":       now.Add(time.Minute),
+				"fake-other-token-id": now.Add(time.Minute),
+			},
+			size: 1,
+			storeSetup: func(store *authmocks.MockDenylist) {
+				store.EXPECT().Contains(gomock.Any(), "fake-token-id").Return(true, nil)
+			},
+			want:    true,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := authmocks.NewMockDenylist(gomock.NewController(t))
+			denylist := auth.NewCachedDenylist(store, clock.FixedClock{FixedTime: now}, tt.size)
+
+			// The cached tokens are added in order, so the last one is the most recently used
+			for _, tokenID := range []string{"fake-token-id", "fake-other-token-id"} {
+				expiresAt, ok := tt.cached[tokenID]
+				if !ok {
+					continue
+				}
+				store.EXPECT().Add(gomock.Any(), tokenID, expiresAt).Return(nil)
+				require.NoError(t, denylist.Add(context.Background(), tokenID, expiresAt))
+			}
+			if tt.storeSetup != nil {
+				tt.storeSetup(store)
+			}
+
+			got, err := denylist.Contains(context.Background(), "fake-token-id")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -10,12 +10,30 @@ var (
 	ErrInvalidToken = errors.New("invalid token")
 	// ErrTokenExpired represents an error when the token has exceeded its expiration time
 	ErrTokenExpired = errors.New("token expired")
+	// ErrTokenRevoked represents an error when the token has been added to the denylist before its expiration time
+	ErrTokenRevoked = errors.New("token revoked")
 	// ErrAuthHeaderMissing represents an error when the Authorization header is not present in the request
 	ErrAuthHeaderMissing = errors.New("authorization header is missing")
 	// ErrInvalidAuthHeader represents an error when the Authorization header format does not match "Bearer <token>"
 	ErrInvalidAuthHeader = errors.New("invalid authorization header format")
 	// ErrSubjectMismatch represents an error when the subject in the token does not match the subject in the request
 	ErrSubjectMismatch = errors.New("subject mismatch")
+	// ErrTenantMismatch represents an error when the tenant in the token does not match the tenant in the request
+	ErrTenantMismatch = errors.New("tenant mismatch")
+	// ErrImpersonationNotAllowed represents an error when an impersonated request performs an action that only the
+	// user itself can perform, such as changing its password
+	ErrImpersonationNotAllowed = errors.New("impersonation not allowed")
+	// ErrKeyNotFound represents an error when there is no verification key matching the token's key ID (kid)
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrUnsupportedKey represents an error when a key type or algorithm is not supported for signing tokens
+	ErrUnsupportedKey = errors.New("unsupported key")
+	// ErrSigningKeyUnavailable represents an error when the key provider is not able to sign tokens, either because it
+	// only holds public keys or because none of its keys is active yet
+	ErrSigningKeyUnavailable = errors.New("signing key unavailable")
+	// ErrSigningKeyMissing represents an error when no signing key is configured and an ephemeral one is not allowed
+	ErrSigningKeyMissing = errors.New("signing key missing")
+	// ErrDuplicateKeyID represents an error when two keys of the same key ring share the same key ID (kid)
+	ErrDuplicateKeyID = errors.New("duplicate key id")
 )
 
 // HTTP errors
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+)
+
+// Introspector defines the check of the access tokens against the token issuer, for the services that can't look up
+// the denylist on their own. An access token is not active once it has been revoked.
+//
+//go:generate mockgen -destination=./mocks/introspector_mock.go -package=auth_mocks github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth Introspector
+type Introspector interface {
+	IntrospectAccessToken(ctx context.Context, token string) (bool, error)
+}
+
+// IntrospectionConfig represents the configuration of the cache in front of the Introspector.
+type IntrospectionConfig struct {
+	// CacheSize is the number of introspected tokens kept in memory.
+	CacheSize int
+	// CacheTTL defines how long an active token is cached, which bounds how long it is still accepted once revoked.
+	CacheTTL time.Duration
+}
+
+// revocationChecker reports whether an access token, whose claims have already been verified, has been revoked.
+type revocationChecker interface {
+	isRevoked(ctx context.Context, token string, claims *Claims) (bool, error)
+}
+
+type denylistChecker struct {
+	denylist Denylist
+}
+
+func (d denylistChecker) isRevoked(ctx context.Context, _ string, claims *Claims) (bool, error) {
+	return d.denylist.Contains(ctx, claims.ID)
+}
+
+type introspectionChecker struct {
+	introspector Introspector
+	clock        clock.Clock
+	ttl          time.Duration
+	cache        *lruCache
+}
+
+func newIntrospectionChecker(introspector Introspector, clk clock.Clock, cfg IntrospectionConfig) *introspectionChecker {
+	return &introspectionChecker{
+		introspector: introspector,
+		clock:        clk,
+		ttl:          cfg.CacheTTL,
+		cache:        newLRUCache(clk, cfg.CacheSize),
+	}
+}
+
+// isRevoked introspects the token, unless its state is cached. Revoked tokens are cached until they expire, as they
+// can't become active again, while active tokens are only cached for the configured TTL.
+func (i *introspectionChecker) isRevoked(ctx context.Context, token string, claims *Claims) (bool, error) {
+	if revoked, found := i.cache.get(claims.ID); found {
+		return revoked, nil
+	}
+
+	active, err := i.introspector.IntrospectAccessToken(ctx, token)
+	if err != nil {
+		return false, err
+	}
+
+	expiresAt := i.clock.Now().Add(i.ttl)
+	if claims.ExpiresAt != nil && (!active || claims.ExpiresAt.Before(expiresAt)) {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	i.cache.put(claims.ID, !active, expiresAt)
+	return !active, nil
+}
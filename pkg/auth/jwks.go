@@ -0,0 +1,285 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// JWKSPath is the well-known path where the authentication service publishes its public keys.
+	JWKSPath = "/.well-known/jwks.json"
+
+	// DefaultJWKSCacheTTL defines how long the fetched key set is considered fresh.
+	DefaultJWKSCacheTTL = 5 * time.Minute
+	// DefaultJWKSMinRefreshInterval defines the minimum time between two fetches triggered by unknown key IDs, so
+	// tokens signed with random kids cannot be used to flood the authentication service.
+	DefaultJWKSMinRefreshInterval = 10 * time.Second
+
+	keyUseSignature = "sig"
+	keyTypeRSA      = "RSA"
+	keyTypeOKP      = "OKP"
+	curveEd25519    = "Ed25519"
+)
+
+// JWKS represents a JSON Web Key Set as defined in RFC 7517.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK represents a single public JSON Web Key. Only RSA and Ed25519 (OKP) keys are supported.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA parameters
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP parameters
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// NewJWKS builds the JSON Web Key Set representation of the given verification keys.
+func NewJWKS(keys []VerificationKey) (JWKS, error) {
+	jwks := JWKS{Keys: make([]JWK, 0, len(keys))}
+	for _, key := range keys {
+		jwk, err := newJWK(key)
+		if err != nil {
+			return JWKS{}, err
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks, nil
+}
+
+func newJWK(key VerificationKey) (JWK, error) {
+	jwk := JWK{
+		KeyID:     key.ID,
+		Use:       keyUseSignature,
+		Algorithm: key.Algorithm,
+	}
+
+	switch pub := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = keyTypeRSA
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = keyTypeOKP
+		jwk.Curve = curveEd25519
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	default:
+		return JWK{}, ErrUnsupportedKey
+	}
+	return jwk, nil
+}
+
+// VerificationKey converts the JWK into a VerificationKey.
+func (k JWK) VerificationKey() (VerificationKey, error) {
+	key := VerificationKey{ID: k.KeyID, Algorithm: k.Algorithm}
+
+	switch {
+	case k.KeyType == keyTypeRSA && k.Algorithm == AlgorithmRS256:
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return VerificationKey{}, fmt.Errorf("decode modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return VerificationKey{}, fmt.Errorf("decode exponent: %w", err)
+		}
+		key.PublicKey = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	case k.KeyType == keyTypeOKP && k.Curve == curveEd25519 && k.Algorithm == AlgorithmEdDSA:
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return VerificationKey{}, fmt.Errorf("decode public key: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return VerificationKey{}, ErrUnsupportedKey
+		}
+		key.PublicKey = ed25519.PublicKey(x)
+	default:
+		return VerificationKey{}, ErrUnsupportedKey
+	}
+	return key, nil
+}
+
+// JWKSConfig holds the configuration options for fetching a remote JSON Web Key Set.
+type JWKSConfig struct {
+	URL                string
+	CacheTTL           time.Duration
+	MinRefreshInterval time.Duration
+	HTTPClient         *http.Client
+}
+
+type jwksKeyProvider struct {
+	logger log.Logger
+	config JWKSConfig
+	clock  clock.Clock
+
+	mu          sync.RWMutex
+	keys        map[string]VerificationKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+}
+
+// NewJWKSKeyProvider creates a KeyProvider that verifies tokens with the public keys published by the authentication
+// service. The key set is cached for the configured TTL, and refreshed earlier when a token with an unknown kid
+// arrives, so rotated keys are picked up without restarting the service. It cannot sign tokens.
+func NewJWKSKeyProvider(logger log.Logger, config JWKSConfig, clk clock.Clock) KeyProvider {
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = DefaultJWKSCacheTTL
+	}
+	if config.MinRefreshInterval <= 0 {
+		config.MinRefreshInterval = DefaultJWKSMinRefreshInterval
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	return &jwksKeyProvider{
+		logger: logger,
+		config: config,
+		clock:  clk,
+		keys:   make(map[string]VerificationKey),
+	}
+}
+
+func (p *jwksKeyProvider) SigningKey(_ context.Context) (SigningKey, error) {
+	return SigningKey{}, ErrSigningKeyUnavailable
+}
+
+func (p *jwksKeyProvider) VerificationKey(ctx context.Context, kid string) (VerificationKey, error) {
+	if p.isStale() {
+		p.refresh(ctx)
+	}
+
+	if key, ok := p.lookup(kid); ok {
+		return key, nil
+	}
+
+	// The key might have been rotated after the last fetch
+	if p.canRefresh() {
+		p.refresh(ctx)
+		if key, ok := p.lookup(kid); ok {
+			return key, nil
+		}
+	}
+
+	p.logger.WithContext(ctx).Warn("verification key not found", log.Field{Key: "kid", Value: kid})
+	return VerificationKey{}, ErrKeyNotFound
+}
+
+func (p *jwksKeyProvider) VerificationKeys(ctx context.Context) ([]VerificationKey, error) {
+	if p.isStale() {
+		p.refresh(ctx)
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	keys := make([]VerificationKey, 0, len(p.keys))
+	for _, key := range p.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (p *jwksKeyProvider) lookup(kid string) (VerificationKey, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *jwksKeyProvider) isStale() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// Failed fetches are retried after the minimum refresh interval, not on every request
+	now := p.clock.Now()
+	expired := now.Sub(p.fetchedAt) >= p.config.CacheTTL
+	return expired && now.Sub(p.lastAttempt) >= p.config.MinRefreshInterval
+}
+
+func (p *jwksKeyProvider) canRefresh() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.clock.Now().Sub(p.lastAttempt) >= p.config.MinRefreshInterval
+}
+
+// refresh fetches the remote key set. On failure, the previously cached keys are kept.
+func (p *jwksKeyProvider) refresh(ctx context.Context) {
+	logger := p.logger.WithContext(ctx)
+
+	p.mu.Lock()
+	p.lastAttempt = p.clock.Now()
+	p.mu.Unlock()
+
+	keys, err := p.fetch(ctx)
+	if err != nil {
+		logger.Error("failed to fetch the JWKS", err)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = keys
+	p.fetchedAt = p.clock.Now()
+	logger.Debug("JWKS refreshed", log.Field{Key: "keys", Value: len(keys)})
+}
+
+func (p *jwksKeyProvider) fetch(ctx context.Context) (map[string]VerificationKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create jwks request: %w", err)
+	}
+
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: unexpected status code %d", resp.StatusCode)
+	}
+
+	var jwks JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	keys := make(map[string]VerificationKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		key, err := jwk.VerificationKey()
+		if err != nil {
+			// Unknown key types are skipped, so new algorithms can be introduced without breaking older services
+			p.logger.WithContext(ctx).Warn(
+				"skipping unsupported JWK",
+				log.Field{Key: "kid", Value: jwk.KeyID},
+				log.Field{Key: "error", Value: err.Error()},
+			)
+			continue
+		}
+		keys[key.ID] = key
+	}
+	return keys, nil
+}
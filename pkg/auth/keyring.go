@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+)
+
+// DefaultKeyGracePeriod defines how long a retired key is still accepted for verification. It must cover the access
+// token lifetime plus the time the consumers cache the JWKS, so no valid token is rejected during a rotation.
+const DefaultKeyGracePeriod = 2 * time.Hour
+
+// ScheduledKey represents a signing key of a key ring, together with the moment it becomes the active signer.
+type ScheduledKey struct {
+	Key        SigningKey
+	ActiveFrom time.Time
+}
+
+type keyRing struct {
+	keys        []ScheduledKey
+	gracePeriod time.Duration
+	clock       clock.Clock
+}
+
+// NewKeyRing creates a KeyProvider that rotates the signing key following the given schedule.
+// At any moment, the active signer is the key with the latest ActiveFrom that is not in the future. A key is retired
+// when the next one becomes active, and it is still accepted for verification until its grace period ends. Keys
+// scheduled for the future are already published, so consumers caching the JWKS know them before they sign tokens.
+func NewKeyRing(keys []ScheduledKey, gracePeriod time.Duration, clk clock.Clock) (KeyProvider, error) {
+	if len(keys) == 0 {
+		return nil, ErrSigningKeyUnavailable
+	}
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultKeyGracePeriod
+	}
+
+	ids := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := ids[key.Key.ID]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateKeyID, key.Key.ID)
+		}
+		ids[key.Key.ID] = struct{}{}
+	}
+
+	sorted := slices.Clone(keys)
+	slices.SortStableFunc(sorted, func(a, b ScheduledKey) int {
+		return a.ActiveFrom.Compare(b.ActiveFrom)
+	})
+
+	return &keyRing{
+		keys:        sorted,
+		gracePeriod: gracePeriod,
+		clock:       clk,
+	}, nil
+}
+
+func (r *keyRing) SigningKey(_ context.Context) (SigningKey, error) {
+	now := r.clock.Now()
+
+	// Keys are sorted by activation, so the active signer is the last one already activated
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if !r.keys[i].ActiveFrom.After(now) {
+			return r.keys[i].Key, nil
+		}
+	}
+	return SigningKey{}, ErrSigningKeyUnavailable
+}
+
+func (r *keyRing) VerificationKey(_ context.Context, kid string) (VerificationKey, error) {
+	now := r.clock.Now()
+	for i, key := range r.keys {
+		if key.Key.ID == kid && !r.isExpired(i, now) {
+			return key.Key.VerificationKey(), nil
+		}
+	}
+	return VerificationKey{}, ErrKeyNotFound
+}
+
+func (r *keyRing) VerificationKeys(_ context.Context) ([]VerificationKey, error) {
+	now := r.clock.Now()
+	keys := make([]VerificationKey, 0, len(r.keys))
+	for i, key := range r.keys {
+		if !r.isExpired(i, now) {
+			keys = append(keys, key.Key.VerificationKey())
+		}
+	}
+	return keys, nil
+}
+
+// isExpired reports whether the grace period of the key at the given position has ended. A key is retired once the
+// next key of the schedule becomes active, so the last key never expires.
+func (r *keyRing) isExpired(i int, now time.Time) bool {
+	if i == len(r.keys)-1 {
+		return false
+	}
+	retiredAt := r.keys[i+1].ActiveFrom
+	return !now.Before(retiredAt.Add(r.gracePeriod))
+}
+
+// keyRingManifest describes the keys of a key ring stored in a JSON file.
+type keyRingManifest struct {
+	Keys []struct {
+		ID         string    `json:"kid"`
+		Path       string    `json:"path"`
+		ActiveFrom time.Time `json:"active_from"`
+	} `json:"keys"`
+}
+
+// LoadKeyRing creates a key ring from a JSON manifest listing the PEM encoded private keys and their activation time:
+//
+//	{"keys": [{"kid": "2025-01", "path": "2025-01.pem", "active_from": "2025-01-01T00:00:00Z"}]}
+//
+// Relative key paths are resolved from the manifest directory.
+func LoadKeyRing(path string, gracePeriod time.Duration, clk clock.Clock) (KeyProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key ring manifest: %w", err)
+	}
+
+	var manifest keyRingManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("decode key ring manifest: %w", err)
+	}
+
+	keys := make([]ScheduledKey, 0, len(manifest.Keys))
+	for _, entry := range manifest.Keys {
+		keyPath := entry.Path
+		if !filepath.IsAbs(keyPath) {
+			keyPath = filepath.Join(filepath.Dir(path), keyPath)
+		}
+
+		pemData, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("read signing key %s: %w", entry.ID, err)
+		}
+		key, err := ParseSigningKeyPEM(entry.ID, pemData)
+		if err != nil {
+			return nil, fmt.Errorf("parse signing key %s: %w", entry.ID, err)
+		}
+		keys = append(keys, ScheduledKey{Key: key, ActiveFrom: entry.ActiveFrom})
+	}
+	return NewKeyRing(keys, gracePeriod, clk)
+}
@@ -0,0 +1,214 @@
+//go:build unit
+
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+func TestKeyRing(t *testing.T) {
+	rotation := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	gracePeriod := time.Hour
+
+	oldKey, err := auth.GenerateSigningKey("old-kid")
+	require.NoError(t, err)
+	newKey, err := auth.GenerateSigningKey("new-kid")
+	require.NoError(t, err)
+	nextKey, err := auth.GenerateSigningKey("next-kid")
+	require.NoError(t, err)
+
+	schedule := []auth.ScheduledKey{
+		// Unordered on purpose, the key ring must sort the schedule
+		{Key: newKey, ActiveFrom: rotation},
+		{Key: oldKey, ActiveFrom: rotation.Add(-30 * 24 * time.Hour)},
+		{Key: nextKey, ActiveFrom: rotation.Add(30 * 24 * time.Hour)},
+	}
+
+	tests := []struct {
+		name             string
+		now              time.Time
+		wantSigner       string
+		wantVerification []string
+	}{
+		{
+			name:             "when the rotation has not happened yet, then the old key signs and the new one is published",
+			now:              rotation.Add(-time.Minute),
+			wantSigner:       oldKey.ID,
+			wantVerification: []string{oldKey.ID, newKey.ID, nextKey.ID},
+		},
+		{
+			name:             "when the new key becomes active, then it signs and the old key is still accepted",
+			now:              rotation,
+			wantSigner:       newKey.ID,
+			wantVerification: []string{oldKey.ID, newKey.ID, nextKey.ID},
+		},
+		{
+			name:             "when the grace period is about to end, then the old key is still accepted",
+			now:              rotation.Add(gracePeriod - time.Second),
+			wantSigner:       newKey.ID,
+			wantVerification: []string{oldKey.ID, newKey.ID, nextKey.ID},
+		},
+		{
+			name:             "when the grace period has ended, then the old key is no longer accepted",
+			now:              rotation.Add(gracePeriod),
+			wantSigner:       newKey.ID,
+			wantVerification: []string{newKey.ID, nextKey.ID},
+		},
+		{
+			name:             "when the last key becomes active, then it is never retired",
+			now:              rotation.Add(365 * 24 * time.Hour),
+			wantSigner:       nextKey.ID,
+			wantVerification: []string{nextKey.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			keys, err := auth.NewKeyRing(schedule, gracePeriod, clock.FixedClock{FixedTime: tt.now})
+			require.NoError(t, err)
+
+			signer, err := keys.SigningKey(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSigner, signer.ID)
+
+			verificationKeys, err := keys.VerificationKeys(ctx)
+			require.NoError(t, err)
+			ids := make([]string, 0, len(verificationKeys))
+			for _, key := range verificationKeys {
+				ids = append(ids, key.ID)
+			}
+			assert.Equal(t, tt.wantVerification, ids)
+
+			for _, key := range []auth.SigningKey{oldKey, newKey, nextKey} {
+				_, err := keys.VerificationKey(ctx, key.ID)
+				if slices.Contains(tt.wantVerification, key.ID) {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorIs(t, err, auth.ErrKeyNotFound)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyRing_TokensDuringRotation(t *testing.T) {
+	logger, _ := log.NewTest()
+	rotation := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	gracePeriod := time.Hour
+
+	oldKey, err := auth.GenerateSigningKey("old-kid")
+	require.NoError(t, err)
+	newKey, err := auth.GenerateSigningKey("new-kid")
+	require.NoError(t, err)
+	schedule := []auth.ScheduledKey{
+		{Key: oldKey, ActiveFrom: rotation.Add(-24 * time.Hour)},
+		{Key: newKey, ActiveFrom: rotation},
+	}
+
+	// A token issued right before the rotation, with a lifetime shorter than the grace period
+	issuedAt := clock.FixedClock{FixedTime: rotation.Add(-time.Minute)}
+	issuer, err := auth.NewKeyRing(schedule, gracePeriod, issuedAt)
+	require.NoError(t, err)
+	output, err := auth.NewService(logger, issuer, issuedAt).GenerateToken(context.Background(), auth.GenerateTokenInput{
+		ID:         "fake-id",
+		Expiration: int((30 * time.Minute).Seconds()),
+		Role:       "customer",
+	})
+	require.NoError(t, err)
+
+	// After the rotation, the token is still valid until it expires
+	verifiedAt := clock.FixedClock{FixedTime: rotation.Add(20 * time.Minute)}
+	verifier, err := auth.NewKeyRing(schedule, gracePeriod, verifiedAt)
+	require.NoError(t, err)
+	claims, err := auth.NewService(logger, verifier, verifiedAt).GetClaims(context.Background(), auth.GetClaimsInput{
+		AccessToken: output.AccessToken,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "fake-id", claims.Claims.Subject)
+}
+
+func TestNewKeyRing(t *testing.T) {
+	key, err := auth.GenerateSigningKey("fake-kid")
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		keys    []auth.ScheduledKey
+		wantErr error
+	}{
+		{
+			name:    "when there are no keys, then it should return a signing key unavailable error",
+			wantErr: auth.ErrSigningKeyUnavailable,
+		},
+		{
+			name: "when two keys share the same kid, then it should return a duplicate key id error",
+			keys: []auth.ScheduledKey{
+				{Key: key, ActiveFrom: now},
+				{Key: key, ActiveFrom: now.Add(time.Hour)},
+			},
+			wantErr: auth.ErrDuplicateKeyID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.NewKeyRing(tt.keys, time.Hour, clock.FixedClock{FixedTime: now})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestKeyRing_NoActiveKey(t *testing.T) {
+	key, err := auth.GenerateSigningKey("fake-kid")
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	keys, err := auth.NewKeyRing(
+		[]auth.ScheduledKey{{Key: key, ActiveFrom: now.Add(time.Hour)}},
+		time.Hour,
+		clock.FixedClock{FixedTime: now},
+	)
+	require.NoError(t, err)
+
+	_, err = keys.SigningKey(context.Background())
+	assert.ErrorIs(t, err, auth.ErrSigningKeyUnavailable)
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	dir := t.TempDir()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2025-01.pem"), keyPEM, 0o600))
+
+	manifest := `{"keys": [{"kid": "2025-01", "path": "2025-01.pem", "active_from": "2025-01-01T00:00:00Z"}]}`
+	manifestPath := filepath.Join(dir, "keyring.json")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(manifest), 0o600))
+
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	keys, err := auth.LoadKeyRing(manifestPath, time.Hour, clock.FixedClock{FixedTime: now})
+	require.NoError(t, err)
+
+	signer, err := keys.SigningKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", signer.ID)
+	assert.Equal(t, auth.AlgorithmRS256, signer.Algorithm)
+}
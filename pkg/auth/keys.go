@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms for the access tokens.
+const (
+	// AlgorithmRS256 represents the RSASSA-PKCS1-v1_5 using SHA-256 signing algorithm.
+	AlgorithmRS256 = "RS256"
+	// AlgorithmEdDSA represents the Edwards-curve signing algorithm, using Ed25519 keys.
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// supportedAlgorithms contains the signing algorithms accepted when validating a token.
+var supportedAlgorithms = []string{AlgorithmRS256, AlgorithmEdDSA}
+
+// KeyProvider defines the operations for retrieving the keys used to sign and verify tokens.
+//
+//go:generate mockgen -destination=./mocks/keys_mock.go -package=auth_mocks github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth KeyProvider
+type KeyProvider interface {
+	// SigningKey returns the key that must be used to sign new tokens.
+	SigningKey(ctx context.Context) (SigningKey, error)
+	// VerificationKey returns the public key identified by the given kid.
+	VerificationKey(ctx context.Context, kid string) (VerificationKey, error)
+	// VerificationKeys returns all the public keys currently accepted for verification.
+	VerificationKeys(ctx context.Context) ([]VerificationKey, error)
+}
+
+// SigningKey represents a private key used to sign tokens, identified by its key ID (kid).
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+}
+
+// VerificationKey represents a public key used to verify token signatures, identified by its key ID (kid).
+type VerificationKey struct {
+	ID        string
+	Algorithm string
+	PublicKey crypto.PublicKey
+}
+
+// NewSigningKey creates a SigningKey from the given private key, inferring the signing algorithm from its type.
+// Only RSA and Ed25519 keys are supported.
+func NewSigningKey(id string, privateKey crypto.Signer) (SigningKey, error) {
+	switch privateKey.(type) {
+	case *rsa.PrivateKey:
+		return SigningKey{ID: id, Algorithm: AlgorithmRS256, PrivateKey: privateKey}, nil
+	case ed25519.PrivateKey:
+		return SigningKey{ID: id, Algorithm: AlgorithmEdDSA, PrivateKey: privateKey}, nil
+	default:
+		return SigningKey{}, ErrUnsupportedKey
+	}
+}
+
+// GenerateSigningKey creates a new random Ed25519 SigningKey with the given key ID.
+func GenerateSigningKey(id string) (SigningKey, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("generate ed25519 key: %w", err)
+	}
+	return NewSigningKey(id, privateKey)
+}
+
+// ParseSigningKeyPEM parses a PKCS#8 (or PKCS#1 for RSA) PEM encoded private key into a SigningKey.
+func ParseSigningKeyPEM(id string, data []byte) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("decode pem: %w", ErrUnsupportedKey)
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return SigningKey{}, ErrUnsupportedKey
+	}
+	return NewSigningKey(id, signer)
+}
+
+// VerificationKey returns the public counterpart of the signing key.
+func (k SigningKey) VerificationKey() VerificationKey {
+	return VerificationKey{
+		ID:        k.ID,
+		Algorithm: k.Algorithm,
+		PublicKey: k.PrivateKey.Public(),
+	}
+}
+
+// signingMethod returns the jwt signing method matching the key algorithm.
+func (k SigningKey) signingMethod() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+type staticKeyProvider struct {
+	key SigningKey
+}
+
+// NewStaticKeyProvider creates a KeyProvider backed by a single signing key, which is also the only key accepted for
+// verification.
+func NewStaticKeyProvider(key SigningKey) KeyProvider {
+	return &staticKeyProvider{key: key}
+}
+
+func (p *staticKeyProvider) SigningKey(_ context.Context) (SigningKey, error) {
+	return p.key, nil
+}
+
+func (p *staticKeyProvider) VerificationKey(_ context.Context, kid string) (VerificationKey, error) {
+	if kid != p.key.ID {
+		return VerificationKey{}, ErrKeyNotFound
+	}
+	return p.key.VerificationKey(), nil
+}
+
+func (p *staticKeyProvider) VerificationKeys(_ context.Context) ([]VerificationKey, error) {
+	return []VerificationKey{p.key.VerificationKey()}, nil
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+)
+
+// lruCache is an in-memory LRU cache of the revocation state of the tokens, keyed by their jti claim. Each entry may
+// expire, so it is not kept longer than it is known to be true.
+type lruCache struct {
+	clock clock.Clock
+	size  int
+
+	mu      sync.Mutex
+	entries *list.List
+	index   map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	revoked   bool
+	expiresAt time.Time
+}
+
+func newLRUCache(clk clock.Clock, size int) *lruCache {
+	return &lruCache{
+		clock:   clk,
+		size:    size,
+		entries: list.New(),
+		index:   make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached revocation state of the token, discarding its entry once expired.
+func (l *lruCache) get(key string) (revoked bool, found bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.index[key]
+	if !ok {
+		return false, false
+	}
+
+	entry := element.Value.(lruEntry)
+	if !entry.expiresAt.IsZero() && !l.clock.Now().Before(entry.expiresAt) {
+		l.entries.Remove(element)
+		delete(l.index, key)
+		return false, false
+	}
+
+	l.entries.MoveToFront(element)
+	return entry.revoked, true
+}
+
+// put caches the revocation state of the token until expiresAt, or until evicted when it is zero. The least recently
+// used entry is evicted when the cache is full.
+func (l *lruCache) put(key string, revoked bool, expiresAt time.Time) {
+	if l.size <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := lruEntry{key: key, revoked: revoked, expiresAt: expiresAt}
+	if element, ok := l.index[key]; ok {
+		element.Value = entry
+		l.entries.MoveToFront(element)
+		return
+	}
+
+	l.index[key] = l.entries.PushFront(entry)
+	if l.entries.Len() > l.size {
+		oldest := l.entries.Back()
+		l.entries.Remove(oldest)
+		delete(l.index, oldest.Value.(lruEntry).key)
+	}
+}
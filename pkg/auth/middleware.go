@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 )
 
@@ -18,6 +20,10 @@ const (
 	bearerPrefix             = "Bearer "
 	subjectCtxKey contextKey = "token-subject"
 	tokenCtxKey contextKey   = "token"
+	roleCtxKey contextKey    = "token-role"
+	tenantCtxKey contextKey  = "token-tenant"
+	permissionsCtxKey contextKey = "token-permissions"
+	actorCtxKey contextKey = "token-actor"
 )
 
 // Middleware defines the interface for authentication-related middleware functions used
@@ -26,11 +32,21 @@ const (
 //go:generate mockgen -destination=./mocks/middleware_mock.go -package=auth_mocks github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth Middleware
 type Middleware interface {
 	RequireCustomer() gin.HandlerFunc
+	RequireStaff() gin.HandlerFunc
+	RequireStaffOwner() gin.HandlerFunc
+	RequireCourier() gin.HandlerFunc
+	RequirePlatformAdmin() gin.HandlerFunc
+	RequireServiceScope(scope string) gin.HandlerFunc
+	RequirePermission(permission string) gin.HandlerFunc
+	RequireRoles(roles ...Role) gin.HandlerFunc
+	// RequireTenantMatch must be chained after one of the other guards, as it relies on the authentication context
+	RequireTenantMatch(param string) gin.HandlerFunc
 }
 
 type middleware struct {
-	logger  log.Logger
-	service Service
+	logger      log.Logger
+	service     Service
+	revocations revocationChecker
 }
 
 // NewMiddleware creates a new instance of Middleware.
@@ -42,7 +58,105 @@ func NewMiddleware(logger log.Logger, service Service) Middleware {
 	}
 }
 
+// NewMiddlewareWithDenylist creates a new instance of Middleware that also rejects the tokens added to the denylist,
+// so they can be revoked before their expiration time.
+func NewMiddlewareWithDenylist(logger log.Logger, service Service, denylist Denylist) Middleware {
+	return &middleware{
+		logger:      logger,
+		service:     service,
+		revocations: denylistChecker{denylist: denylist},
+	}
+}
+
+// NewMiddlewareWithIntrospection creates a new instance of Middleware that also rejects the revoked tokens, as reported
+// by the introspection endpoint of the token issuer, for the services that do not hold the denylist. The results are
+// cached in memory, so the token issuer is not called on every request.
+func NewMiddlewareWithIntrospection(
+	logger log.Logger,
+	service Service,
+	introspector Introspector,
+	clk clock.Clock,
+	cfg IntrospectionConfig,
+) Middleware {
+	return &middleware{
+		logger:      logger,
+		service:     service,
+		revocations: newIntrospectionChecker(introspector, clk, cfg),
+	}
+}
+
 func (m *middleware) RequireCustomer() gin.HandlerFunc {
+	return m.RequireRoles(RoleCustomer)
+}
+
+func (m *middleware) RequireStaff() gin.HandlerFunc {
+	return m.RequireRoles(RoleStaff)
+}
+
+func (m *middleware) RequireCourier() gin.HandlerFunc {
+	return m.RequireRoles(RoleCourier)
+}
+
+func (m *middleware) RequirePlatformAdmin() gin.HandlerFunc {
+	return m.RequireRoles(RolePlatformAdmin)
+}
+
+func (m *middleware) RequireServiceScope(scope string) gin.HandlerFunc {
+	return m.authorize(func(claims *Claims) bool {
+		return claims.Role == string(RoleService) && claims.HasScope(scope)
+	})
+}
+
+func (m *middleware) RequirePermission(permission string) gin.HandlerFunc {
+	return m.authorize(func(claims *Claims) bool {
+		return claims.HasPermission(permission)
+	})
+}
+
+func (m *middleware) RequireStaffOwner() gin.HandlerFunc {
+	return m.authorize(func(claims *Claims) bool {
+		return claims.Role == string(RoleStaff) && claims.Owner
+	})
+}
+
+func (m *middleware) RequireRoles(roles ...Role) gin.HandlerFunc {
+	return m.authorize(func(claims *Claims) bool {
+		return slices.Contains(roles, Role(claims.Role))
+	})
+}
+
+func (m *middleware) RequireTenantMatch(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logger := m.logger.WithContext(c.Request.Context())
+
+		if _, ok := c.Get(string(tenantCtxKey)); !ok {
+			logger.Warn("authentication context not found")
+			m.handleAuthError(c, ErrInvalidToken)
+			return
+		}
+
+		tenant := c.GetString(string(tenantCtxKey))
+		expectedTenant := c.Param(param)
+		if tenant == "" || tenant != expectedTenant {
+			logger.Warn(
+				"cross-tenant access attempt",
+				log.Field{Key: "subject", Value: c.GetString(string(subjectCtxKey))},
+				log.Field{Key: "tenant", Value: tenant},
+				log.Field{Key: "expectedTenant", Value: expectedTenant},
+			)
+			c.AbortWithStatusJSON(
+				http.StatusForbidden,
+				newErrorResponse(CodeForbiddenError, MessageForbiddenError),
+			)
+			return
+		}
+		c.Next()
+	}
+}
+
+// authorize validates the token and checks the claims with the given rule, storing the authentication data in the
+// request context when access is granted.
+func (m *middleware) authorize(allowed func(claims *Claims) bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims, token, err := m.getClaims(c)
 		if err != nil {
@@ -50,7 +164,8 @@ func (m *middleware) RequireCustomer() gin.HandlerFunc {
 			return
 		}
 
-		if claims.Role != string(RoleCustomer) {
+		// Tenant users are always scoped to a tenant, as defined in the tenant's strategy
+		if !allowed(claims) || (claims.Role == string(RoleStaff) && claims.Tenant == "") {
 			c.AbortWithStatusJSON(
 				http.StatusForbidden,
 				newErrorResponse(CodeForbiddenError, MessageForbiddenError),
@@ -60,8 +175,25 @@ func (m *middleware) RequireCustomer() gin.HandlerFunc {
 
 		c.Set(string(tokenCtxKey), token)
 		c.Set(string(subjectCtxKey), claims.Subject)
+		c.Set(string(roleCtxKey), claims.Role)
+		c.Set(string(tenantCtxKey), claims.Tenant)
+		c.Set(string(permissionsCtxKey), claims.Permissions)
 		ctx := context.WithValue(c.Request.Context(), subjectCtxKey, claims.Subject)
 		ctx = context.WithValue(ctx, tokenCtxKey, token)
+		ctx = context.WithValue(ctx, roleCtxKey, Role(claims.Role))
+		ctx = context.WithValue(ctx, tenantCtxKey, claims.Tenant)
+		ctx = context.WithValue(ctx, permissionsCtxKey, claims.Permissions)
+		if claims.Actor != nil {
+			c.Set(string(actorCtxKey), claims.Actor.Subject)
+			ctx = context.WithValue(ctx, actorCtxKey, claims.Actor.Subject)
+			// Every log entry of an impersonated request is flagged with the actor
+			ctx = log.WithActor(ctx, claims.Actor.Subject)
+			m.logger.WithContext(ctx).Info(
+				"impersonated request",
+				log.Field{Key: "subject", Value: claims.Subject},
+				log.Field{Key: "actor", Value: claims.Actor.Subject},
+			)
+		}
 		c.Request = c.Request.WithContext(ctx)
 		c.Next()
 	}
@@ -78,9 +210,33 @@ func (m *middleware) getClaims(c *gin.Context) (*Claims, string, error) {
 		return nil, "", err
 	}
 
+	if err := m.checkRevocation(c.Request.Context(), token, output.Claims); err != nil {
+		return nil, "", err
+	}
 	return output.Claims, token, nil
 }
 
+// checkRevocation returns ErrTokenRevoked if the token has been revoked. The tokens issued before they had a jti claim
+// can't be revoked, so they are accepted until they expire.
+func (m *middleware) checkRevocation(ctx context.Context, token string, claims *Claims) error {
+	if m.revocations == nil || claims.ID == "" {
+		return nil
+	}
+
+	logger := m.logger.WithContext(ctx)
+
+	denied, err := m.revocations.isRevoked(ctx, token, claims)
+	if err != nil {
+		logger.Error("failed to check the token revocation", err)
+		return err
+	}
+	if denied {
+		logger.Warn("revoked token", log.Field{Key: "token_id", Value: claims.ID})
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
 func (m *middleware) handleAuthError(c *gin.Context, err error) {
 	code := CodeUnauthorizedError
 	msg := MessageUnauthorizedError
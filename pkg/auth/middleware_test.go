@@ -0,0 +1,541 @@
+//go:build unit
+
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const okJSON = `{"status": "ok"}`
+
+type middlewareTestCase struct {
+	name       string
+	token      auth.GenerateTokenInput
+	noToken    bool
+	route      string
+	wantJSON   string
+	wantStatus int
+}
+
+func TestMiddleware_Guards(t *testing.T) {
+	customer := auth.GenerateTokenInput{ID: "fake-customer-id", Role: string(auth.RoleCustomer)}
+	staff := auth.GenerateTokenInput{ID: "fake-staff-id", Role: string(auth.RoleStaff), TenantID: "fake-tenant"}
+	owner := auth.GenerateTokenInput{
+		ID:       "fake-owner-id",
+		Role:     string(auth.RoleStaff),
+		TenantID: "fake-tenant",
+		Owner:    true,
+	}
+	manager := auth.GenerateTokenInput{
+		ID:          "fake-manager-id",
+		Role:        string(auth.RoleStaff),
+		TenantID:    "fake-tenant",
+		Permissions: []string{auth.PermissionMenuRead, auth.PermissionMenuWrite},
+	}
+	staffWithoutTenant := auth.GenerateTokenInput{ID: "fake-staff-id", Role: string(auth.RoleStaff)}
+	courier := auth.GenerateTokenInput{ID: "fake-courier-id", Role: string(auth.RoleCourier)}
+	admin := auth.GenerateTokenInput{ID: "fake-admin-id", Role: string(auth.RolePlatformAdmin)}
+	registerService := auth.GenerateTokenInput{
+		ID:     "fake-service-id",
+		Role:   string(auth.RoleService),
+		Scopes: []string{"fake:scope", auth.ScopeAuthRegister},
+	}
+	otherService := auth.GenerateTokenInput{
+		ID:     "fake-service-id",
+		Role:   string(auth.RoleService),
+		Scopes: []string{"fake:scope"},
+	}
+	companyCourier := auth.GenerateTokenInput{
+		ID:       "fake-courier-id",
+		Role:     string(auth.RoleCourier),
+		TenantID: "fake-company",
+	}
+
+	tests := []middlewareTestCase{
+		{
+			name:       "when no token is provided, then it should return a 401 with unauthorized error",
+			noToken:    true,
+			route:      "/customer",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "when a customer accesses a customer route, then it should grant access",
+			token:      customer,
+			route:      "/customer",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when a staff accesses a customer route, then it should return a 403 with forbidden error",
+			token:      staff,
+			route:      "/customer",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a staff accesses a staff route, then it should grant access",
+			token:      staff,
+			route:      "/staff",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when a customer accesses a staff route, then it should return a 403 with forbidden error",
+			token:      customer,
+			route:      "/staff",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a staff token has no tenant, then it should return a 403 with forbidden error",
+			token:      staffWithoutTenant,
+			route:      "/staff",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a non owner staff accesses an owner route, then it should return a 403 with forbidden error",
+			token:      staff,
+			route:      "/owner",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when an owner accesses an owner route, then it should grant access",
+			token:      owner,
+			route:      "/owner",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when an independent courier accesses a courier route, then it should grant access",
+			token:      courier,
+			route:      "/courier",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when a company courier accesses a courier route, then it should grant access",
+			token:      companyCourier,
+			route:      "/courier",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when a staff accesses a courier route, then it should return a 403 with forbidden error",
+			token:      staff,
+			route:      "/courier",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a courier accesses a staff route, then it should return a 403 with forbidden error",
+			token:      companyCourier,
+			route:      "/staff",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a platform admin accesses an admin route, then it should grant access",
+			token:      admin,
+			route:      "/admin",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when an owner accesses an admin route, then it should return a 403 with forbidden error",
+			token:      owner,
+			route:      "/admin",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a platform admin accesses a staff route, then it should return a 403 with forbidden error",
+			token:      admin,
+			route:      "/staff",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a service with the required scope accesses a service route, then it should grant access",
+			token:      registerService,
+			route:      "/service",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "when a service without the required scope accesses a service route, " +
+				"then it should return a 403 with forbidden error",
+			token:      otherService,
+			route:      "/service",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when a platform admin accesses a service route, " +
+				"then it should return a 403 with forbidden error",
+			token:      admin,
+			route:      "/service",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a service accesses a customer route, then it should return a 403 with forbidden error",
+			token:      registerService,
+			route:      "/customer",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a customer accesses a route allowed to several roles, then it should grant access",
+			token:      customer,
+			route:      "/any",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when a staff accesses a route allowed to several roles, then it should grant access",
+			token:      staff,
+			route:      "/any",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when a staff accesses its own tenant, then it should grant access",
+			token:      staff,
+			route:      "/restaurants/fake-tenant",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when a staff with the permission accesses a permission route, then it should grant access",
+			token:      manager,
+			route:      "/menu",
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "when a staff without the permission accesses a permission route, then it should return a 403",
+			token:      staff,
+			route:      "/menu",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "when a staff accesses another tenant, then it should return a 403 with forbidden error",
+			token:      staff,
+			route:      "/restaurants/another-tenant",
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runMiddlewareTestCase(t, tt)
+		})
+	}
+}
+
+func runMiddlewareTestCase(t *testing.T, tt middlewareTestCase) {
+	gin.SetMode(gin.TestMode)
+	logger, _ := log.NewTest()
+	clk := clock.FixedClock{FixedTime: time.Now()}
+
+	key, err := auth.GenerateSigningKey("fake-kid")
+	require.NoError(t, err)
+	service := auth.NewService(logger, auth.NewStaticKeyProvider(key), clk)
+	m := auth.NewMiddleware(logger, service)
+
+	ok := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	}
+	router := gin.New()
+	router.GET("/customer", m.RequireCustomer(), ok)
+	router.GET("/staff", m.RequireStaff(), ok)
+	router.GET("/owner", m.RequireStaffOwner(), ok)
+	router.GET("/courier", m.RequireCourier(), ok)
+	router.GET("/admin", m.RequirePlatformAdmin(), ok)
+	router.GET("/service", m.RequireServiceScope(auth.ScopeAuthRegister), ok)
+	router.GET("/any", m.RequireRoles(auth.RoleCustomer, auth.RoleStaff), ok)
+	router.GET("/menu", m.RequirePermission(auth.PermissionMenuWrite), ok)
+	router.GET("/restaurants/:restaurantID", m.RequireStaff(), m.RequireTenantMatch("restaurantID"), ok)
+
+	req := httptest.NewRequest(http.MethodGet, tt.route, nil)
+	if !tt.noToken {
+		tt.token.Expiration = 3600
+		output, err := service.GenerateToken(context.Background(), tt.token)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+output.AccessToken)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, tt.wantStatus, w.Code)
+	assert.JSONEq(t, tt.wantJSON, w.Body.String())
+}
+
+func TestMiddleware_Denylist(t *testing.T) {
+	errDenylist := errors.New("denylist error")
+
+	tests := []struct {
+		name          string
+		denylistSetup func(denylist *authmocks.MockDenylist)
+		wantJSON      string
+		wantStatus    int
+	}{
+		{
+			name: "when the token is not in the denylist, then it should grant access",
+			denylistSetup: func(denylist *authmocks.MockDenylist) {
+				denylist.EXPECT().Contains(gomock.Any(), gomock.Not(gomock.Eq(""))).Return(false, nil)
+			},
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "when the token is in the denylist, then it should return a 401 with unauthorized error",
+			denylistSetup: func(denylist *authmocks.MockDenylist) {
+				denylist.EXPECT().Contains(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the denylist can not be checked, then it should return a 401 with unauthorized error",
+			denylistSetup: func(denylist *authmocks.MockDenylist) {
+				denylist.EXPECT().Contains(gomock.Any(), gomock.Any()).Return(false, errDenylist)
+			},
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger, _ := log.NewTest()
+			clk := clock.FixedClock{FixedTime: time.Now()}
+
+			key, err := auth.GenerateSigningKey("fake-kid")
+			require.NoError(t, err)
+			service := auth.NewService(logger, auth.NewStaticKeyProvider(key), clk)
+			denylist := authmocks.NewMockDenylist(gomock.NewController(t))
+			tt.denylistSetup(denylist)
+			m := auth.NewMiddlewareWithDenylist(logger, service, denylist)
+
+			router := gin.New()
+			router.GET("/customer", m.RequireCustomer(), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"status": "ok"})
+			})
+
+			output, err := service.GenerateToken(context.Background(), auth.GenerateTokenInput{
+				ID:         "fake-customer-id",
+				Expiration: 3600,
+				Role:       string(auth.RoleCustomer),
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/customer", nil)
+			req.Header.Set("Authorization", "Bearer "+output.AccessToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.JSONEq(t, tt.wantJSON, w.Body.String())
+		})
+	}
+}
+
+func TestMiddleware_Introspection(t *testing.T) {
+	errIntrospection := errors.New("introspection error")
+
+	tests := []struct {
+		name              string
+		introspectorSetup func(introspector *authmocks.MockIntrospector)
+		requests          int
+		wantJSON          string
+		wantStatus        int
+	}{
+		{
+			name: "when the token is active, then it should grant access and cache the result",
+			introspectorSetup: func(introspector *authmocks.MockIntrospector) {
+				introspector.EXPECT().IntrospectAccessToken(gomock.Any(), gomock.Any()).Return(true, nil).Times(1)
+			},
+			requests:   2,
+			wantJSON:   okJSON,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "when the token has been revoked, then it should return a 401 with unauthorized error " +
+				"and cache the result",
+			introspectorSetup: func(introspector *authmocks.MockIntrospector) {
+				introspector.EXPECT().IntrospectAccessToken(gomock.Any(), gomock.Any()).Return(false, nil).Times(1)
+			},
+			requests:   2,
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the token can not be introspected, then it should return a 401 with unauthorized error",
+			introspectorSetup: func(introspector *authmocks.MockIntrospector) {
+				introspector.EXPECT().IntrospectAccessToken(gomock.Any(), gomock.Any()).
+					Return(false, errIntrospection).Times(2)
+			},
+			requests:   2,
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger, _ := log.NewTest()
+			clk := clock.FixedClock{FixedTime: time.Now()}
+
+			key, err := auth.GenerateSigningKey("fake-kid")
+			require.NoError(t, err)
+			service := auth.NewService(logger, auth.NewStaticKeyProvider(key), clk)
+			introspector := authmocks.NewMockIntrospector(gomock.NewController(t))
+			tt.introspectorSetup(introspector)
+			m := auth.NewMiddlewareWithIntrospection(logger, service, introspector, clk, auth.IntrospectionConfig{
+				CacheSize: 10,
+				CacheTTL:  time.Minute,
+			})
+
+			router := gin.New()
+			router.GET("/customer", m.RequireCustomer(), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"status": "ok"})
+			})
+
+			output, err := service.GenerateToken(context.Background(), auth.GenerateTokenInput{
+				ID:         "fake-customer-id",
+				Expiration: 3600,
+				Role:       string(auth.RoleCustomer),
+			})
+			require.NoError(t, err)
+
+			for range tt.requests {
+				req := httptest.NewRequest(http.MethodGet, "/customer", nil)
+				req.Header.Set("Authorization", "Bearer "+output.AccessToken)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, tt.wantStatus, w.Code)
+				assert.JSONEq(t, tt.wantJSON, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestContextReader_AfterMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger, _ := log.NewTest()
+	clk := clock.FixedClock{FixedTime: time.Now()}
+
+	key, err := auth.GenerateSigningKey("fake-kid")
+	require.NoError(t, err)
+	service := auth.NewService(logger, auth.NewStaticKeyProvider(key), clk)
+	m := auth.NewMiddleware(logger, service)
+	reader := auth.NewContextReader(logger)
+
+	router := gin.New()
+	router.GET("/staff", m.RequireStaff(), func(c *gin.Context) {
+		ctx := c.Request.Context()
+		subject, _ := reader.GetSubject(ctx)
+		role, _ := reader.GetRole(ctx)
+		tenant, _ := reader.GetTenant(ctx)
+
+		assert.Equal(t, "fake-staff-id", subject)
+		assert.Equal(t, auth.RoleStaff, role)
+		assert.Equal(t, "fake-tenant", tenant)
+		assert.NoError(t, reader.RequireTenantMatch(ctx, "fake-tenant"))
+		assert.ErrorIs(t, reader.RequireTenantMatch(ctx, "another-tenant"), auth.ErrTenantMismatch)
+		assert.True(t, reader.HasPermission(ctx, auth.PermissionMenuRead))
+		assert.False(t, reader.HasPermission(ctx, auth.PermissionMenuWrite))
+		_, impersonated := reader.GetActor(ctx)
+		assert.False(t, impersonated)
+		assert.NoError(t, reader.RequireNotImpersonated(ctx))
+		c.Status(http.StatusNoContent)
+	})
+
+	output, err := service.GenerateToken(context.Background(), auth.GenerateTokenInput{
+		ID:          "fake-staff-id",
+		Expiration:  3600,
+		Role:        string(auth.RoleStaff),
+		TenantID:    "fake-tenant",
+		Permissions: []string{auth.PermissionMenuRead},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/staff", nil)
+	req.Header.Set("Authorization", "Bearer "+output.AccessToken)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	// Without the middleware, there is no authentication context
+	_, ok := reader.GetRole(context.Background())
+	assert.False(t, ok)
+	assert.ErrorIs(t, reader.RequireTenantMatch(context.Background(), "fake-tenant"), auth.ErrInvalidToken)
+	assert.False(t, reader.HasPermission(context.Background(), auth.PermissionMenuRead))
+}
+
+func TestContextReader_Impersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger, _ := log.NewTest()
+	clk := clock.FixedClock{FixedTime: time.Now()}
+
+	key, err := auth.GenerateSigningKey("fake-kid")
+	require.NoError(t, err)
+	service := auth.NewService(logger, auth.NewStaticKeyProvider(key), clk)
+	m := auth.NewMiddleware(logger, service)
+	reader := auth.NewContextReader(logger)
+
+	router := gin.New()
+	router.GET("/customer", m.RequireCustomer(), func(c *gin.Context) {
+		ctx := c.Request.Context()
+		subject, _ := reader.GetSubject(ctx)
+		actor, impersonated := reader.GetActor(ctx)
+
+		assert.Equal(t, "fake-customer-id", subject)
+		assert.True(t, impersonated)
+		assert.Equal(t, "fake-admin-id", actor)
+		assert.Equal(t, "fake-admin-id", log.ActorFromContext(ctx))
+		assert.ErrorIs(t, reader.RequireNotImpersonated(ctx), auth.ErrImpersonationNotAllowed)
+		c.Status(http.StatusNoContent)
+	})
+
+	output, err := service.GenerateToken(context.Background(), auth.GenerateTokenInput{
+		ID:         "fake-customer-id",
+		Expiration: 3600,
+		Role:       string(auth.RoleCustomer),
+		Actor:      "fake-admin-id",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/customer", nil)
+	req.Header.Set("Authorization", "Bearer "+output.AccessToken)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -3,9 +3,11 @@ package auth
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
@@ -14,6 +16,9 @@ import (
 // DefaultTokenType represents the default type of token used for authorization, typically set to "Bearer".
 const DefaultTokenType = "Bearer"
 
+// kidHeader is the JWT header holding the ID of the key used to sign the token.
+const kidHeader = "kid"
+
 // Service defines the interface for auth operations
 //
 //go:generate mockgen -destination=./mocks/service_mock.go -package=auth_mocks github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth Service
@@ -24,51 +29,79 @@ type Service interface {
 
 type service struct {
 	logger log.Logger
-	secret []byte
+	keys   KeyProvider
 	clock  clock.Clock
 }
 
-// NewService creates a new auth service instance
-func NewService(logger log.Logger, secret []byte, clock clock.Clock) Service {
+// NewService creates a new auth service instance.
+// Tokens are signed with the provider's signing key and verified with the public key matching their kid header.
+func NewService(logger log.Logger, keys KeyProvider, clock clock.Clock) Service {
 	return &service{
 		logger: logger,
-		secret: secret,
+		keys:   keys,
 		clock:  clock,
 	}
 }
 
 // GenerateTokenInput contains the required information to generate a JWT token
 type GenerateTokenInput struct {
-	ID         string
-	Expiration int // AccessToken expiration duration in seconds
-	Role       string
-	TenantID   string
+	ID          string
+	Expiration  int // AccessToken expiration duration in seconds
+	Role        string
+	TenantID    string
+	Owner       bool
+	Scopes      []string
+	Permissions []string
+	// Actor is the subject of the user impersonating the token subject, if any
+	Actor string
 }
 
-// GenerateTokenOutput contains the generated access token
+// GenerateTokenOutput contains the generated access token, along with its unique ID (jti) and expiration time, which
+// are needed to deny the token before it expires
 type GenerateTokenOutput struct {
 	AccessToken string
+	TokenID     string
+	ExpiresAt   time.Time
 }
 
-func (s service) GenerateToken(_ context.Context, input GenerateTokenInput) (GenerateTokenOutput, error) {
+func (s service) GenerateToken(ctx context.Context, input GenerateTokenInput) (GenerateTokenOutput, error) {
+	key, err := s.keys.SigningKey(ctx)
+	if err != nil {
+		return GenerateTokenOutput{}, err
+	}
+
 	now := s.clock.Now()
+	tokenID := uuid.NewString()
+	expiresAt := now.Add(time.Duration(input.Expiration) * time.Second)
 	claims := &Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			Subject:   input.ID,
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(input.Expiration) * time.Second)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 		},
-		Role:   input.Role,
-		Tenant: input.TenantID,
+		Role:        input.Role,
+		Tenant:      input.TenantID,
+		Owner:       input.Owner,
+		Scope:       strings.Join(input.Scopes, " "),
+		Permissions: input.Permissions,
+	}
+	if input.Actor != "" {
+		claims.Actor = &Actor{Subject: input.Actor}
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	accessToken, err := token.SignedString(s.secret)
+	token := jwt.NewWithClaims(key.signingMethod(), claims)
+	token.Header[kidHeader] = key.ID
+	accessToken, err := token.SignedString(key.PrivateKey)
 	if err != nil {
 		return GenerateTokenOutput{}, err
 	}
-	return GenerateTokenOutput{AccessToken: accessToken}, nil
+	return GenerateTokenOutput{
+		AccessToken: accessToken,
+		TokenID:     tokenID,
+		ExpiresAt:   expiresAt,
+	}, nil
 }
 
 // GetClaimsInput contains the access token from which to extract claims
@@ -81,15 +114,25 @@ type GetClaimsOutput struct {
 	Claims *Claims
 }
 
-func (s service) GetClaims(_ context.Context, input GetClaimsInput) (GetClaimsOutput, error) {
+func (s service) GetClaims(ctx context.Context, input GetClaimsInput) (GetClaimsOutput, error) {
 	// Parse and validate the token with explicit validation options
 	token, err := jwt.ParseWithClaims(input.AccessToken, &Claims{}, func(token *jwt.Token) (any, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, ok := token.Header[kidHeader].(string)
+		if !ok || kid == "" {
+			return nil, fmt.Errorf("missing %s header", kidHeader)
+		}
+
+		key, err := s.keys.VerificationKey(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+
+		// Validate the signing method against the one of the selected key
+		if token.Method.Alg() != key.Algorithm {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return s.secret, nil
-	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+		return key.PublicKey, nil
+	}, jwt.WithValidMethods(supportedAlgorithms), jwt.WithTimeFunc(s.clock.Now))
 	if err != nil {
 		return GetClaimsOutput{}, ErrInvalidToken
 	}
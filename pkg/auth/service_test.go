@@ -0,0 +1,195 @@
+//go:build unit
+
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+func TestService_GetClaims(t *testing.T) {
+	logger, _ := log.NewTest()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.FixedClock{FixedTime: now}
+
+	edKey, err := auth.GenerateSigningKey("ed-kid")
+	require.NoError(t, err)
+
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaKey, err := auth.NewSigningKey("rsa-kid", rsaPrivateKey)
+	require.NoError(t, err)
+
+	claims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "fake-id",
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+		Role:   "customer",
+		Tenant: "fake-tenant",
+	}
+
+	tests := []struct {
+		name      string
+		keys      auth.KeyProvider
+		token     func(t *testing.T) string
+		wantErr   error
+		wantClaim *auth.Claims
+	}{
+		{
+			name: "when the token is signed with the current EdDSA key, then it should return the claims",
+			keys: auth.NewStaticKeyProvider(edKey),
+			token: func(t *testing.T) string {
+				return generateToken(t, edKey, clk)
+			},
+			wantClaim: &claims,
+		},
+		{
+			name: "when the token is signed with the current RS256 key, then it should return the claims",
+			keys: auth.NewStaticKeyProvider(rsaKey),
+			token: func(t *testing.T) string {
+				return generateToken(t, rsaKey, clk)
+			},
+			wantClaim: &claims,
+		},
+		{
+			name: "when the token is signed with an unknown kid, then it should return an invalid token error",
+			keys: auth.NewStaticKeyProvider(edKey),
+			token: func(t *testing.T) string {
+				return generateToken(t, rsaKey, clk)
+			},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name: "when the token has no kid header, then it should return an invalid token error",
+			keys: auth.NewStaticKeyProvider(edKey),
+			token: func(t *testing.T) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+				signed, err := token.SignedString(edKey.PrivateKey)
+				require.NoError(t, err)
+				return signed
+			},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name: "when the token is signed with HS256, then it should return an invalid token error",
+			keys: auth.NewStaticKeyProvider(edKey),
+			token: func(t *testing.T) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+				token.Header["kid"] = edKey.ID
+				signed, err := token.SignedString([]byte("a-string-secret-at-least-256-bits-long"))
+				require.NoError(t, err)
+				return signed
+			},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name: "when the token algorithm does not match the key algorithm, then it should return an invalid token error",
+			keys: auth.NewStaticKeyProvider(auth.SigningKey{
+				ID:         edKey.ID,
+				Algorithm:  auth.AlgorithmEdDSA,
+				PrivateKey: rsaPrivateKey,
+			}),
+			token: func(t *testing.T) string {
+				return generateToken(t, auth.SigningKey{
+					ID:         edKey.ID,
+					Algorithm:  auth.AlgorithmRS256,
+					PrivateKey: rsaPrivateKey,
+				}, clk)
+			},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name: "when the token is expired, then it should return an invalid token error",
+			keys: auth.NewStaticKeyProvider(edKey),
+			token: func(t *testing.T) string {
+				return generateToken(t, edKey, clock.FixedClock{FixedTime: now.Add(-2 * time.Hour)})
+			},
+			wantErr: auth.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := auth.NewService(logger, tt.keys, clk)
+
+			output, err := service.GetClaims(context.Background(), auth.GetClaimsInput{AccessToken: tt.token(t)})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantClaim != nil {
+				assert.Equal(t, tt.wantClaim.Subject, output.Claims.Subject)
+				assert.Equal(t, tt.wantClaim.Role, output.Claims.Role)
+				assert.Equal(t, tt.wantClaim.Tenant, output.Claims.Tenant)
+				assert.True(t, tt.wantClaim.ExpiresAt.Equal(output.Claims.ExpiresAt.Time))
+			}
+		})
+	}
+}
+
+func TestService_GenerateToken(t *testing.T) {
+	logger, _ := log.NewTest()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.FixedClock{FixedTime: now}
+
+	key, err := auth.GenerateSigningKey("fake-kid")
+	require.NoError(t, err)
+	service := auth.NewService(logger, auth.NewStaticKeyProvider(key), clk)
+
+	input := auth.GenerateTokenInput{
+		ID:         "fake-id",
+		Expiration: int(time.Hour.Seconds()),
+		Role:       "customer",
+	}
+	first, err := service.GenerateToken(context.Background(), input)
+	require.NoError(t, err)
+	second, err := service.GenerateToken(context.Background(), input)
+	require.NoError(t, err)
+
+	// Every token has its own jti, even when issued with the same claims at the same time
+	assert.NotEmpty(t, first.TokenID)
+	assert.NotEqual(t, first.TokenID, second.TokenID)
+	assert.Equal(t, now.Add(time.Hour), first.ExpiresAt)
+
+	output, err := service.GetClaims(context.Background(), auth.GetClaimsInput{AccessToken: first.AccessToken})
+	require.NoError(t, err)
+	assert.Equal(t, first.TokenID, output.Claims.ID)
+	assert.True(t, first.ExpiresAt.Equal(output.Claims.ExpiresAt.Time))
+	assert.Nil(t, output.Claims.Actor)
+
+	// Impersonation tokens name the impersonating user in the act claim
+	input.Actor = "fake-admin-id"
+	impersonated, err := service.GenerateToken(context.Background(), input)
+	require.NoError(t, err)
+
+	output, err = service.GetClaims(context.Background(), auth.GetClaimsInput{AccessToken: impersonated.AccessToken})
+	require.NoError(t, err)
+	assert.Equal(t, &auth.Actor{Subject: "fake-admin-id"}, output.Claims.Actor)
+}
+
+func generateToken(t *testing.T, key auth.SigningKey, clk clock.Clock) string {
+	t.Helper()
+
+	logger, _ := log.NewTest()
+	service := auth.NewService(logger, auth.NewStaticKeyProvider(key), clk)
+	output, err := service.GenerateToken(context.Background(), auth.GenerateTokenInput{
+		ID:         "fake-id",
+		Expiration: int(time.Hour.Seconds()),
+		Role:       "customer",
+		TenantID:   "fake-tenant",
+	})
+	require.NoError(t, err)
+	return output.AccessToken
+}
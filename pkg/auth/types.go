@@ -1,6 +1,11 @@
 package auth
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 // Role represents a user role within the authentication system
 type Role string
@@ -8,6 +13,43 @@ type Role string
 const (
 	// RoleCustomer represents the role assigned to authenticated customers
 	RoleCustomer Role = "customer"
+	// RoleStaff represents the role assigned to authenticated restaurant staff. Staff tokens are scoped to a tenant
+	RoleStaff Role = "staff"
+	// RoleCourier represents the role assigned to authenticated couriers. Courier tokens are scoped to the delivery
+	// company they work for, if any
+	RoleCourier Role = "courier"
+	// RolePlatformAdmin represents the role assigned to authenticated platform administrators, who manage the
+	// credentials of any other user
+	RolePlatformAdmin Role = "platform_admin"
+	// RoleService represents the role assigned to the internal services authenticated with their client credentials.
+	// Service tokens are limited to the scopes granted to the service
+	RoleService Role = "service"
+)
+
+const (
+	// ScopeAuthRegister allows a service to register the credentials of new users in the authentication service
+	ScopeAuthRegister = "auth:register"
+	// ScopeAuthIntrospect allows a service to ask the authentication service whether a token is still active
+	ScopeAuthIntrospect = "auth:introspect"
+	// ScopeAuthRevoke allows a service to revoke the tokens issued by the authentication service
+	ScopeAuthRevoke = "auth:revoke"
+	// ScopeAuthManage allows a service to keep the credentials of the users it owns in sync with their profiles
+	ScopeAuthManage = "auth:manage"
+)
+
+const (
+	// PermissionRestaurantWrite allows a staff user to update the profile of its restaurant
+	PermissionRestaurantWrite = "restaurant:write"
+	// PermissionMenuRead allows a staff user to read the menu of its restaurant
+	PermissionMenuRead = "menu:read"
+	// PermissionMenuWrite allows a staff user to create, update and remove the menu items of its restaurant
+	PermissionMenuWrite = "menu:write"
+	// PermissionOrdersRead allows a staff user to read the orders of its restaurant
+	PermissionOrdersRead = "orders:read"
+	// PermissionOrdersWrite allows a staff user to update the preparation status of the orders of its restaurant
+	PermissionOrdersWrite = "orders:write"
+	// PermissionStaffManage allows a staff user to manage the other staff users of its restaurant
+	PermissionStaffManage = "staff:manage"
 )
 
 // Claims represent the authentication claims
@@ -15,4 +57,27 @@ type Claims struct {
 	jwt.RegisteredClaims
 	Role   string `json:"role"`
 	Tenant string `json:"tenant"`
+	// Owner flags the staff users that own the restaurant they are scoped to
+	Owner bool `json:"owner,omitempty"`
+	// Scope holds the space-delimited scopes granted to service tokens
+	Scope string `json:"scope,omitempty"`
+	// Permissions holds the fine-grained permissions granted to the user by its role within the tenant
+	Permissions []string `json:"permissions,omitempty"`
+	// Actor identifies the platform admin impersonating the subject. It is only set for impersonation tokens
+	Actor *Actor `json:"act,omitempty"`
+}
+
+// Actor represents the user acting on behalf of the subject of a token, as defined by the act claim of RFC 8693.
+type Actor struct {
+	Subject string `json:"sub"`
+}
+
+// HasScope reports whether the scope is one of the scopes granted by the claims.
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(strings.Fields(c.Scope), scope)
+}
+
+// HasPermission reports whether the permission is one of the permissions granted by the claims.
+func (c *Claims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
 }
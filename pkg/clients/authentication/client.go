@@ -1,33 +1,71 @@
 // Package authentication provides functionality for customer authentication and registration
-// through integration with the authentication service.
+// through integration with the authentication service, and to keep the credentials of the users in sync with their
+// profiles.
 package authentication
 
 import (
 	"context"
+	"net/http"
+	"sync"
 	"time"
 
+	"github.com/caarlos0/env/v10"
+
 	"github.com/alexgrauroca/practice-food-delivery-platform/authclient"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 )
 
+// serviceTokenLeeway is the time before its expiration at which a cached service token is renewed, so it does not
+// expire while a request is in flight.
+const serviceTokenLeeway = 30 * time.Second
+
 // Client defines the interface for interacting with the authentication service.
-// It provides methods for customer registration and authentication operations.
+// It provides methods to register the credentials of new users, to update, deactivate or delete the credentials of
+// existing customers, and to check whether an access token has been revoked.
 //
 //go:generate mockgen -destination=./mocks/authclient_mock.go -package=authentication_mocks github.com/alexgrauroca/practice-food-delivery-platform/pkg/clients/authentication Client
 type Client interface {
 	RegisterCustomer(ctx context.Context, req RegisterCustomerRequest) (RegisterCustomerResponse, error)
 	RegisterStaff(ctx context.Context, req RegisterStaffRequest) (RegisterStaffResponse, error)
+	RegisterCourier(ctx context.Context, req RegisterCourierRequest) (RegisterCourierResponse, error)
+	UpdateCustomerEmail(ctx context.Context, req UpdateCustomerEmailRequest) error
+	DeactivateCustomer(ctx context.Context, customerID string) error
+	DeleteCustomer(ctx context.Context, customerID string) error
+	IntrospectAccessToken(ctx context.Context, token string) (bool, error)
 }
 
 // Config holds the configuration options for the authentication client.
 type Config struct {
-	Debug bool
+	Debug bool `env:"AUTH_CLIENT_DEBUG" envDefault:"false"`
+	// ClientID and ClientSecret are the client credentials of the service, used to obtain the service tokens required
+	// by the internal endpoints of the authentication service.
+	ClientID     string `env:"AUTH_CLIENT_ID"`
+	ClientSecret string `env:"AUTH_CLIENT_SECRET"`
+}
+
+// LoadConfig loads the authentication client configuration from environment variables and logs any errors
+// encountered during parsing. It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load authentication client configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
 }
 
 type client struct {
-	logger log.Logger
-	conf   *authclient.Configuration
-	apicli *authclient.APIClient
+	logger       log.Logger
+	conf         *authclient.Configuration
+	apicli       *authclient.APIClient
+	clock        clock.Clock
+	clientID     string
+	clientSecret string
+
+	mu             sync.Mutex
+	token          string
+	tokenExpiresAt time.Time
 }
 
 // NewClient creates and initializes a new authentication client with the provided logger and configuration.
@@ -40,10 +78,52 @@ func NewClient(logger log.Logger, config Config) Client {
 
 	apiclient := authclient.NewAPIClient(conf)
 	return &client{
-		logger: logger,
-		conf:   conf,
-		apicli: apiclient,
+		logger:       logger,
+		conf:         conf,
+		apicli:       apiclient,
+		clock:        clock.RealClock{},
+		clientID:     config.ClientID,
+		clientSecret: config.ClientSecret,
+	}
+}
+
+// withServiceToken returns a copy of the context carrying the service token, which the API client sends as the
+// bearer token. The token is fetched from the authentication service and cached until it is about to expire. No scope
+// is requested, so the token carries all the scopes granted to the service.
+func (c *client) withServiceToken(ctx context.Context) (context.Context, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == "" || !c.clock.Now().Before(c.tokenExpiresAt) {
+		authreq := authclient.NewIssueServiceTokenRequest(c.clientID, c.clientSecret)
+		resp, r, err := c.apicli.TokensAPI.IssueServiceToken(ctx).IssueServiceTokenRequest(*authreq).Execute()
+		if err != nil {
+			c.logger.Warn(
+				"Failed to obtain service token",
+				log.Field{Key: "error", Value: err.Error()},
+				log.Field{Key: "response", Value: r},
+			)
+			return ctx, err
+		}
+
+		expiresIn := time.Duration(resp.GetExpiresIn()) * time.Second
+		c.token = resp.GetAccessToken()
+		c.tokenExpiresAt = c.clock.Now().Add(expiresIn - serviceTokenLeeway)
+	}
+
+	return context.WithValue(ctx, authclient.ContextAccessToken, c.token), nil
+}
+
+// resetServiceToken discards the cached service token when it is rejected by the authentication service, so a new
+// one is fetched on the next request.
+func (c *client) resetServiceToken(r *http.Response) {
+	if r == nil || r.StatusCode != http.StatusUnauthorized {
+		return
 	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
 }
 
 // RegisterCustomerRequest represents the data required to register a new customer
@@ -66,6 +146,11 @@ func (c *client) RegisterCustomer(ctx context.Context, req RegisterCustomerReque
 	c.logger.Info("Registering customer", log.Field{Key: "customerID", Value: req.CustomerID})
 
 	authreq := authclient.NewRegisterCustomerRequest(req.CustomerID, req.Email, req.Password)
+	ctx, err := c.withServiceToken(ctx)
+	if err != nil {
+		return RegisterCustomerResponse{}, err
+	}
+
 	resp, r, err := c.apicli.CustomersAPI.RegisterCustomer(ctx).RegisterCustomerRequest(*authreq).Execute()
 	if err != nil {
 		c.logger.Warn(
@@ -73,6 +158,7 @@ func (c *client) RegisterCustomer(ctx context.Context, req RegisterCustomerReque
 			log.Field{Key: "error", Value: err.Error()},
 			log.Field{Key: "response", Value: r},
 		)
+		c.resetServiceToken(r)
 		return RegisterCustomerResponse{}, err
 	}
 	c.logger.Info(
@@ -92,10 +178,12 @@ type RegisterStaffRequest struct {
 	Email        string
 	RestaurantID string
 	Password     string
+	Owner        bool
 }
 
 // RegisterStaffResponse contains the data returned after successfully registering a staff user in the authentication
-// service.
+// service. StaffID is the one carried by the staff tokens, which differs from the requested one when the restaurant was
+// linked to existing credentials with the same email.
 type RegisterStaffResponse struct {
 	ID           string
 	StaffID      string
@@ -108,6 +196,12 @@ type RegisterStaffResponse struct {
 func (c *client) RegisterStaff(ctx context.Context, req RegisterStaffRequest) (RegisterStaffResponse, error) {
 	c.logger.Info("Registering staff", log.Field{Key: "staffID", Value: req.StaffID})
 	authreq := authclient.NewRegisterStaffRequest(req.StaffID, req.Email, req.RestaurantID, req.Password)
+	authreq.SetOwner(req.Owner)
+	ctx, err := c.withServiceToken(ctx)
+	if err != nil {
+		return RegisterStaffResponse{}, err
+	}
+
 	resp, r, err := c.apicli.StaffAPI.RegisterStaff(ctx).RegisterStaffRequest(*authreq).Execute()
 	if err != nil {
 		c.logger.Warn(
@@ -115,6 +209,7 @@ func (c *client) RegisterStaff(ctx context.Context, req RegisterStaffRequest) (R
 			log.Field{Key: "error", Value: err.Error()},
 			log.Field{Key: "response", Value: r},
 		)
+		c.resetServiceToken(r)
 		return RegisterStaffResponse{}, err
 	}
 	c.logger.Info(
@@ -123,9 +218,175 @@ func (c *client) RegisterStaff(ctx context.Context, req RegisterStaffRequest) (R
 	)
 	return RegisterStaffResponse{
 		ID:           resp.GetId(),
+		StaffID:      resp.GetStaffId(),
 		Email:        resp.GetEmail(),
 		RestaurantID: resp.GetRestaurantId(),
 		CreatedAt:    resp.GetCreatedAt(),
 		UpdatedAt:    resp.GetUpdatedAt(),
 	}, nil
 }
+
+// RegisterCourierRequest represents the data required to register a new courier in the authentication service.
+// CompanyID is only provided for couriers working for a delivery company.
+type RegisterCourierRequest struct {
+	CourierID string
+	Email     string
+	CompanyID string
+	Password  string
+}
+
+// RegisterCourierResponse contains the data returned after successfully registering a courier in the authentication
+// service.
+type RegisterCourierResponse struct {
+	ID        string
+	Email     string
+	CompanyID string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c *client) RegisterCourier(ctx context.Context, req RegisterCourierRequest) (RegisterCourierResponse, error) {
+	c.logger.Info("Registering courier", log.Field{Key: "courierID", Value: req.CourierID})
+	authreq := authclient.NewRegisterCourierRequest(req.CourierID, req.Email, req.Password)
+	if req.CompanyID != "" {
+		authreq.SetCompanyId(req.CompanyID)
+	}
+	ctx, err := c.withServiceToken(ctx)
+	if err != nil {
+		return RegisterCourierResponse{}, err
+	}
+
+	resp, r, err := c.apicli.CouriersAPI.RegisterCourier(ctx).RegisterCourierRequest(*authreq).Execute()
+	if err != nil {
+		c.logger.Warn(
+			"Failed to register courier",
+			log.Field{Key: "error", Value: err.Error()},
+			log.Field{Key: "response", Value: r},
+		)
+		c.resetServiceToken(r)
+		return RegisterCourierResponse{}, err
+	}
+	c.logger.Info(
+		"Courier registered successfully at authentication service",
+		log.Field{Key: "courierID", Value: resp.GetId()},
+	)
+	return RegisterCourierResponse{
+		ID:        resp.GetId(),
+		Email:     resp.GetEmail(),
+		CompanyID: resp.GetCompanyId(),
+		CreatedAt: resp.GetCreatedAt(),
+		UpdatedAt: resp.GetUpdatedAt(),
+	}, nil
+}
+
+// UpdateCustomerEmailRequest represents the data required to update the login email of a customer in the
+// authentication service.
+type UpdateCustomerEmailRequest struct {
+	CustomerID string
+	Email      string
+}
+
+func (c *client) UpdateCustomerEmail(ctx context.Context, req UpdateCustomerEmailRequest) error {
+	c.logger.Info("Updating customer email", log.Field{Key: "customerID", Value: req.CustomerID})
+
+	authreq := authclient.NewUpdateCustomerEmailRequest(req.Email)
+	ctx, err := c.withServiceToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.apicli.CustomersAPI.UpdateCustomerEmail(ctx, req.CustomerID).
+		UpdateCustomerEmailRequest(*authreq).
+		Execute()
+	if err != nil {
+		c.logger.Warn(
+			"Failed to update customer email",
+			log.Field{Key: "error", Value: err.Error()},
+			log.Field{Key: "response", Value: r},
+		)
+		c.resetServiceToken(r)
+		return err
+	}
+	c.logger.Info(
+		"Customer email updated successfully at authentication service",
+		log.Field{Key: "customerID", Value: req.CustomerID},
+	)
+	return nil
+}
+
+// DeactivateCustomer deactivates the credentials of a customer, which can no longer log in. All its active sessions
+// are revoked too.
+func (c *client) DeactivateCustomer(ctx context.Context, customerID string) error {
+	c.logger.Info("Deactivating customer", log.Field{Key: "customerID", Value: customerID})
+
+	ctx, err := c.withServiceToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.apicli.CustomersAPI.DeactivateCustomerCredentials(ctx, customerID).Execute()
+	if err != nil {
+		c.logger.Warn(
+			"Failed to deactivate customer",
+			log.Field{Key: "error", Value: err.Error()},
+			log.Field{Key: "response", Value: r},
+		)
+		c.resetServiceToken(r)
+		return err
+	}
+	c.logger.Info(
+		"Customer deactivated successfully at authentication service",
+		log.Field{Key: "customerID", Value: customerID},
+	)
+	return nil
+}
+
+// DeleteCustomer permanently deletes the credentials of a customer who closed its account. All its active sessions
+// are revoked too.
+func (c *client) DeleteCustomer(ctx context.Context, customerID string) error {
+	c.logger.Info("Deleting customer", log.Field{Key: "customerID", Value: customerID})
+
+	ctx, err := c.withServiceToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.apicli.CustomersAPI.DeleteCustomer(ctx, customerID).Execute()
+	if err != nil {
+		c.logger.Warn(
+			"Failed to delete customer",
+			log.Field{Key: "error", Value: err.Error()},
+			log.Field{Key: "response", Value: r},
+		)
+		c.resetServiceToken(r)
+		return err
+	}
+	c.logger.Info(
+		"Customer deleted successfully at authentication service",
+		log.Field{Key: "customerID", Value: customerID},
+	)
+	return nil
+}
+
+// IntrospectAccessToken reports whether the access token is still active, so it can be rejected once revoked before its
+// expiration. It satisfies the auth.Introspector interface.
+func (c *client) IntrospectAccessToken(ctx context.Context, token string) (bool, error) {
+	authreq := authclient.NewIntrospectTokenRequest(token)
+	authreq.SetTokenTypeHint("access_token")
+	ctx, err := c.withServiceToken(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	resp, r, err := c.apicli.TokensAPI.IntrospectToken(ctx).IntrospectTokenRequest(*authreq).Execute()
+	if err != nil {
+		c.logger.Warn(
+			"Failed to introspect access token",
+			log.Field{Key: "error", Value: err.Error()},
+			log.Field{Key: "response", Value: r},
+		)
+		c.resetServiceToken(r)
+		return false, err
+	}
+	return resp.GetActive(), nil
+}
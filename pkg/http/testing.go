@@ -44,7 +44,7 @@ func ServeTestHTTPRequest(
 	var req *http.Request
 
 	switch httpMethod {
-	case http.MethodGet:
+	case http.MethodGet, http.MethodDelete:
 		baseURL, err := url.Parse(route)
 		if err != nil {
 			t.Fatalf("failed to parse route: %v", err)
@@ -59,7 +59,7 @@ func ServeTestHTTPRequest(
 			baseURL.RawQuery = q.Encode()
 		}
 
-		req = httptest.NewRequest(http.MethodGet, baseURL.String(), nil)
+		req = httptest.NewRequest(httpMethod, baseURL.String(), nil)
 
 	case http.MethodPost, http.MethodPut:
 		req = httptest.NewRequest(httpMethod, route, strings.NewReader(jsonPayload))
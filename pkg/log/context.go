@@ -14,6 +14,7 @@ const (
 	hostKey      ctxKey = "host"
 	realIPKey    ctxKey = "realIp"
 	userAgentKey ctxKey = "userAgent"
+	actorKey     ctxKey = "actor"
 )
 
 // RequestInfo represents metadata about a request, including its ID, host, and client's real IP address.
@@ -22,6 +23,9 @@ type RequestInfo struct {
 	Host      string
 	RealIP    string
 	UserAgent string
+	// Actor is the user acting on behalf of the authenticated user of an impersonated request. It is empty when the
+	// request is not impersonated.
+	Actor string
 }
 
 // MarshalLogObject serializes the RequestInfo fields into the provided zapcore.ObjectEncoder for structured logging.
@@ -30,6 +34,10 @@ func (r RequestInfo) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("host", r.Host)
 	enc.AddString("real_ip", r.RealIP)
 	enc.AddString("user_agent", r.UserAgent)
+	if r.Actor != "" {
+		enc.AddBool("impersonated", true)
+		enc.AddString("actor", r.Actor)
+	}
 	return nil
 }
 
@@ -39,9 +47,16 @@ func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
 	ctx = context.WithValue(ctx, hostKey, info.Host)
 	ctx = context.WithValue(ctx, realIPKey, info.RealIP)
 	ctx = context.WithValue(ctx, userAgentKey, info.UserAgent)
+	ctx = context.WithValue(ctx, actorKey, info.Actor)
 	return ctx
 }
 
+// WithActor flags the request of the provided context as impersonated by the actor, so every log entry of the request
+// includes it. It is meant to be called once the request is authenticated.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
 // RequestIDFromContext extracts the request ID from the provided context. Returns an empty string if not found.
 func RequestIDFromContext(ctx context.Context) string {
 	if requestID, ok := ctx.Value(requestIDKey).(string); ok {
@@ -74,6 +89,15 @@ func UserAgentFromContext(ctx context.Context) string {
 	return ""
 }
 
+// ActorFromContext extracts the actor of an impersonated request from the provided context. Returns an empty string if
+// the request is not impersonated.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok {
+		return actor
+	}
+	return ""
+}
+
 // LoggerWithRequestInfo enriches the provided logger with request metadata derived from the context.
 func LoggerWithRequestInfo(ctx context.Context, logger *zap.Logger) *zap.Logger {
 	reqInfo := RequestInfo{
@@ -81,6 +105,7 @@ func LoggerWithRequestInfo(ctx context.Context, logger *zap.Logger) *zap.Logger
 		Host:      HostFromContext(ctx),
 		RealIP:    RealIPFromContext(ctx),
 		UserAgent: UserAgentFromContext(ctx),
+		Actor:     ActorFromContext(ctx),
 	}
 
 	return logger.With(zap.Object("request", reqInfo))
@@ -0,0 +1,34 @@
+#!/bin/bash
+
+# Generate the untracked env files holding the secrets of the local docker compose deployment
+# Usage: ./generate-local-secrets.sh
+# The existing files are kept, so the secrets only change when they are removed.
+
+set -e
+
+ROOT_DIR="$(cd "$(dirname "$0")/.." && pwd)"
+AUTH_ENV="$ROOT_DIR/deployments/authentication-service/.env"
+CUSTOMER_ENV="$ROOT_DIR/deployments/customer-service/.env"
+RESTAURANT_ENV="$ROOT_DIR/deployments/restaurant-service/.env"
+
+if [ -f "$AUTH_ENV" ] && [ -f "$CUSTOMER_ENV" ] && [ -f "$RESTAURANT_ENV" ]; then
+    echo "Local secrets already generated"
+    exit 0
+fi
+
+random_secret() {
+    openssl rand -hex 32
+}
+
+CUSTOMER_SERVICE_SECRET="$(random_secret)"
+RESTAURANT_SERVICE_SECRET="$(random_secret)"
+
+mkdir -p "$(dirname "$AUTH_ENV")" "$(dirname "$CUSTOMER_ENV")" "$(dirname "$RESTAURANT_ENV")"
+cat > "$AUTH_ENV" <<ENV
+REFRESH_TOKEN_HASH_KEY=$(random_secret)
+SERVICE_CLIENT_SECRETS=customer-service=$CUSTOMER_SERVICE_SECRET,restaurant-service=$RESTAURANT_SERVICE_SECRET
+ENV
+echo "AUTH_CLIENT_SECRET=$CUSTOMER_SERVICE_SECRET" > "$CUSTOMER_ENV"
+echo "AUTH_CLIENT_SECRET=$RESTAURANT_SERVICE_SECRET" > "$RESTAURANT_ENV"
+
+echo "Local secrets generated"
@@ -5,7 +5,9 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"log"
+	"os"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -14,8 +16,23 @@ import (
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
 	customlog "github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/admins"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/couriers"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/denylist"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/introspection"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/jwks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/magiclink"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/notifier"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/servicetokens"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/sessions"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/staff"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/tenantselection"
 
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/customers"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/middleware"
@@ -54,11 +71,78 @@ func main() {
 	db := client.Database("authentication_service")
 
 	// Initialize features
-	refreshService := initRefreshFeature(logger, db)
-	authService, authMiddleware := initAuthFeature(logger)
-	authCoreService := initAuthCoreFeature(logger, authService, refreshService)
-	initCustomersFeature(logger, db, router, authCoreService, authMiddleware)
-	initStaffFeature(logger, db, router, authCoreService, authMiddleware)
+	passwordPolicy, err := initPasswordFeature(logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize passwords", err)
+		return
+	}
+	tokenDenylist, err := initDenylistFeature(logger, db)
+	if err != nil {
+		logger.Fatal("Failed to initialize token denylist", err)
+		return
+	}
+	refreshService, err := initRefreshFeature(ctx, logger, db, tokenDenylist)
+	if err != nil {
+		logger.Fatal("Failed to initialize refresh tokens", err)
+		return
+	}
+	keys, err := initKeysFeature(logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize signing keys", err)
+		return
+	}
+	authService, authMiddleware := initAuthFeature(logger, keys, tokenDenylist)
+	authEventsService, err := initAuthEventsFeature(logger, db, router, authMiddleware)
+	if err != nil {
+		logger.Fatal("Failed to initialize authentication events", err)
+		return
+	}
+	authCoreService := initAuthCoreFeature(logger, authService, refreshService, authEventsService)
+	ntf, err := initNotifierFeature(logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize notifier", err)
+		return
+	}
+	passwordResetService := initPasswordResetFeature(logger, db, ntf)
+	magicLinkService := initMagicLinkFeature(logger, db, ntf)
+	mfaService, err := initMFAFeature(logger, db)
+	if err != nil {
+		logger.Fatal("Failed to initialize MFA", err)
+		return
+	}
+	lockoutService, err := initLockoutFeature(logger, db)
+	if err != nil {
+		logger.Fatal("Failed to initialize login lockout", err)
+		return
+	}
+	initCustomersFeature(
+		logger, db, router, authCoreService, passwordResetService, magicLinkService, lockoutService, passwordPolicy,
+		authEventsService, authMiddleware,
+	)
+	tenantSelectionService := initTenantSelectionFeature(logger, db)
+	if err := initStaffFeature(
+		ctx, logger, db, router, authCoreService, passwordResetService, mfaService, lockoutService,
+		tenantSelectionService, passwordPolicy, authEventsService, authMiddleware,
+	); err != nil {
+		logger.Fatal("Failed to initialize staff", err)
+		return
+	}
+	initCouriersFeature(
+		logger, db, router, authCoreService, lockoutService, passwordPolicy, authEventsService, authMiddleware,
+	)
+	if err := initAdminsFeature(
+		ctx, logger, db, router, authCoreService, lockoutService, passwordPolicy, authEventsService,
+	); err != nil {
+		logger.Fatal("Failed to initialize platform admins", err)
+		return
+	}
+	initJWKSFeature(logger, router, keys)
+	initSessionsFeature(logger, router, refreshService, authMiddleware)
+	if err := initServiceTokensFeature(logger, router, authService); err != nil {
+		logger.Fatal("Failed to initialize service tokens", err)
+		return
+	}
+	initIntrospectionFeature(logger, router, authService, refreshService, tokenDenylist, authMiddleware)
 
 	logger.Info("Starting http server")
 	// Start the server
@@ -67,29 +151,193 @@ func main() {
 	}
 }
 
-func initRefreshFeature(logger customlog.Logger, db *mongo.Database) refresh.Service {
+func initPasswordFeature(logger customlog.Logger) (password.Policy, error) {
+	cfg, err := password.LoadConfig(logger)
+	if err != nil {
+		return password.Policy{}, err
+	}
+
+	// The hashes created with weaker parameters are upgraded on the next successful login
+	if err := password.SetParams(cfg.Params()); err != nil {
+		return password.Policy{}, err
+	}
+	return password.NewPolicy(cfg)
+}
+
+func initDenylistFeature(logger customlog.Logger, db *mongo.Database) (auth.Denylist, error) {
+	cfg, err := auth.LoadConfig(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// The most recently revoked tokens are kept in memory, in front of the MongoDB collection shared by all instances
+	repo := denylist.NewRepository(logger, db, clock.RealClock{})
+	return auth.NewCachedDenylist(repo, clock.RealClock{}, cfg.DenylistCacheSize), nil
+}
+
+func initRefreshFeature(
+	ctx context.Context,
+	logger customlog.Logger,
+	db *mongo.Database,
+	tokenDenylist auth.Denylist,
+) (refresh.Service, error) {
+	cfg, err := refresh.LoadConfig(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// An ephemeral key is only generated when explicitly allowed. Refresh tokens won't survive restarts nor scale out.
+	hashKey := []byte(cfg.HashKey)
+	if len(hashKey) == 0 {
+		if !cfg.AllowEphemeralKey {
+			return nil, refresh.ErrHashKeyMissing
+		}
+		logger.Warn("No refresh token hash key configured, generating an ephemeral one")
+		hashKey = make([]byte, 32)
+		if _, err := rand.Read(hashKey); err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize the refresh repository
 	repo := refresh.NewRepository(logger, db, clock.RealClock{})
 
 	// Initialize the refresh service
-	return refresh.NewService(logger, repo, clock.RealClock{})
+	service := refresh.NewService(logger, repo, clock.RealClock{}, refresh.NewHMACHasher(hashKey), tokenDenylist)
+
+	// The refresh tokens stored before they were hashed at rest are migrated only once, when requested
+	if cfg.MigratePlaintextTokens {
+		if _, err := service.MigratePlaintextTokens(ctx, refresh.MigratePlaintextTokensInput{}); err != nil {
+			return nil, err
+		}
+	}
+	return service, nil
+}
+
+func initKeysFeature(logger customlog.Logger) (auth.KeyProvider, error) {
+	cfg, err := auth.LoadConfig(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// A key ring allows rotating the signing key without invalidating the tokens already issued
+	if cfg.KeyRingPath != "" {
+		return auth.LoadKeyRing(cfg.KeyRingPath, cfg.KeyGracePeriod, clock.RealClock{})
+	}
+
+	// An ephemeral key is only generated when explicitly allowed. Tokens won't survive restarts nor scale out.
+	if cfg.SigningKeyPath == "" {
+		if !cfg.AllowEphemeralKey {
+			return nil, auth.ErrSigningKeyMissing
+		}
+		logger.Warn("No signing key configured, generating an ephemeral one")
+		key, err := auth.GenerateSigningKey(cfg.SigningKeyID)
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewStaticKeyProvider(key), nil
+	}
+
+	data, err := os.ReadFile(cfg.SigningKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := auth.ParseSigningKeyPEM(cfg.SigningKeyID, data)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewStaticKeyProvider(key), nil
 }
 
-func initAuthFeature(logger customlog.Logger) (auth.Service, auth.Middleware) {
+func initAuthFeature(
+	logger customlog.Logger,
+	keys auth.KeyProvider,
+	tokenDenylist auth.Denylist,
+) (auth.Service, auth.Middleware) {
 	/// Initialize the jwt service
-	//TODO configure secret by env vars
-	authService := auth.NewService(logger, []byte("a-string-secret-at-least-256-bits-long"), clock.RealClock{})
-	authMiddleware := auth.NewMiddleware(logger, authService)
+	authService := auth.NewService(logger, keys, clock.RealClock{})
+	authMiddleware := auth.NewMiddlewareWithDenylist(logger, authService, tokenDenylist)
 
 	return authService, authMiddleware
 }
 
+func initAuthEventsFeature(
+	logger customlog.Logger,
+	db *mongo.Database,
+	router *gin.Engine,
+	authMiddleware auth.Middleware,
+) (authevents.Service, error) {
+	cfg, err := authevents.LoadConfig(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := authevents.NewRepository(logger, db, clock.RealClock{})
+	service := authevents.NewService(logger, repo, cfg)
+	handler := authevents.NewHandler(logger, service, authMiddleware)
+	handler.RegisterRoutes(router)
+	return service, nil
+}
+
 func initAuthCoreFeature(
 	logger customlog.Logger,
 	authService auth.Service,
 	refreshService refresh.Service,
+	authEventsService authevents.Service,
 ) authcore.Service {
-	return authcore.NewService(logger, authService, refreshService)
+	return authcore.NewService(logger, authService, refreshService, authEventsService)
+}
+
+func initNotifierFeature(logger customlog.Logger) (notifier.Notifier, error) {
+	cfg, err := notifier.LoadConfig(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// There is no real delivery channel yet, so the notifications are kept locally
+	if cfg.FilePath != "" {
+		return notifier.NewFileNotifier(logger, cfg.FilePath), nil
+	}
+	return notifier.NewLogNotifier(logger), nil
+}
+
+func initPasswordResetFeature(
+	logger customlog.Logger,
+	db *mongo.Database,
+	ntf notifier.Notifier,
+) passwordreset.Service {
+	repo := passwordreset.NewRepository(logger, db, clock.RealClock{})
+	return passwordreset.NewService(logger, repo, ntf, clock.RealClock{})
+}
+
+func initMagicLinkFeature(logger customlog.Logger, db *mongo.Database, ntf notifier.Notifier) magiclink.Service {
+	repo := magiclink.NewRepository(logger, db, clock.RealClock{})
+	return magiclink.NewService(logger, repo, ntf, clock.RealClock{})
+}
+
+func initTenantSelectionFeature(logger customlog.Logger, db *mongo.Database) tenantselection.Service {
+	repo := tenantselection.NewRepository(logger, db, clock.RealClock{})
+	return tenantselection.NewService(logger, repo, clock.RealClock{})
+}
+
+func initMFAFeature(logger customlog.Logger, db *mongo.Database) (mfa.Service, error) {
+	cfg, err := mfa.LoadConfig(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := mfa.NewRepository(logger, db, clock.RealClock{})
+	return mfa.NewService(logger, repo, clock.RealClock{}, cfg.Issuer), nil
+}
+
+func initLockoutFeature(logger customlog.Logger, db *mongo.Database) (lockout.Service, error) {
+	cfg, err := lockout.LoadConfig(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := lockout.NewRepository(logger, db, clock.RealClock{})
+	return lockout.NewService(logger, repo, clock.RealClock{}, cfg), nil
 }
 
 func initCustomersFeature(
@@ -97,13 +345,22 @@ func initCustomersFeature(
 	db *mongo.Database,
 	router *gin.Engine,
 	authCoreService authcore.Service,
+	passwordResetService passwordreset.Service,
+	magicLinkService magiclink.Service,
+	lockoutService lockout.Service,
+	passwordPolicy password.Policy,
+	authEventsService authevents.Service,
 	authMiddleware auth.Middleware,
 ) {
 	// Initialize the customer's repository
 	repo := customers.NewRepository(logger, db, clock.RealClock{})
 
 	// Initialize the customer's service
-	service := customers.NewService(logger, repo, authCoreService)
+	authctx := auth.NewContextReader(logger)
+	service := customers.NewService(
+		logger, repo, authCoreService, passwordResetService, magicLinkService, lockoutService, passwordPolicy, authctx,
+		authEventsService,
+	)
 
 	// Initialize the customer's handler and register routes
 	handler := customers.NewHandler(logger, service, authMiddleware)
@@ -111,14 +368,131 @@ func initCustomersFeature(
 }
 
 func initStaffFeature(
+	ctx context.Context,
 	logger customlog.Logger,
 	db *mongo.Database,
 	router *gin.Engine,
 	authCoreService authcore.Service,
+	passwordResetService passwordreset.Service,
+	mfaService mfa.Service,
+	lockoutService lockout.Service,
+	tenantSelectionService tenantselection.Service,
+	passwordPolicy password.Policy,
+	authEventsService authevents.Service,
 	authMiddleware auth.Middleware,
-) {
+) error {
+	cfg, err := staff.LoadConfig(logger)
+	if err != nil {
+		return err
+	}
+
 	repo := staff.NewRepository(logger, db, clock.RealClock{})
-	service := staff.NewService(logger, repo, authCoreService)
+	authctx := auth.NewContextReader(logger)
+	service := staff.NewService(
+		logger, repo, authCoreService, passwordResetService, mfaService, lockoutService, tenantSelectionService,
+		passwordPolicy, authctx, authEventsService,
+	)
+
+	// The staff credentials stored before they could be linked to several restaurants are migrated only once, when
+	// requested
+	if cfg.MigrateLegacyStaff {
+		if _, err := service.MigrateLegacyStaff(ctx, staff.MigrateLegacyStaffInput{}); err != nil {
+			return err
+		}
+	}
+
 	handler := staff.NewHandler(logger, service, authMiddleware)
 	handler.RegisterRoutes(router)
+	return nil
+}
+
+func initCouriersFeature(
+	logger customlog.Logger,
+	db *mongo.Database,
+	router *gin.Engine,
+	authCoreService authcore.Service,
+	lockoutService lockout.Service,
+	passwordPolicy password.Policy,
+	authEventsService authevents.Service,
+	authMiddleware auth.Middleware,
+) {
+	repo := couriers.NewRepository(logger, db, clock.RealClock{})
+	service := couriers.NewService(logger, repo, authCoreService, lockoutService, passwordPolicy, authEventsService)
+	handler := couriers.NewHandler(logger, service, authMiddleware)
+	handler.RegisterRoutes(router)
+}
+
+func initAdminsFeature(
+	ctx context.Context,
+	logger customlog.Logger,
+	db *mongo.Database,
+	router *gin.Engine,
+	authCoreService authcore.Service,
+	lockoutService lockout.Service,
+	passwordPolicy password.Policy,
+	authEventsService authevents.Service,
+) error {
+	cfg, err := admins.LoadConfig(logger)
+	if err != nil {
+		return err
+	}
+
+	repo := admins.NewRepository(logger, db, clock.RealClock{})
+	service := admins.NewService(logger, repo, authCoreService, lockoutService, passwordPolicy, authEventsService)
+
+	// Admins can't be registered through the API, so the first one is created from the configuration
+	if cfg.BootstrapEmail != "" {
+		if _, err := service.BootstrapAdmin(ctx, admins.BootstrapAdminInput{
+			Email:    cfg.BootstrapEmail,
+			Password: cfg.BootstrapPassword,
+		}); err != nil {
+			return err
+		}
+	}
+
+	handler := admins.NewHandler(logger, service)
+	handler.RegisterRoutes(router)
+	return nil
+}
+
+func initJWKSFeature(logger customlog.Logger, router *gin.Engine, keys auth.KeyProvider) {
+	handler := jwks.NewHandler(logger, keys)
+	handler.RegisterRoutes(router)
+}
+
+func initSessionsFeature(
+	logger customlog.Logger,
+	router *gin.Engine,
+	refreshService refresh.Service,
+	authMiddleware auth.Middleware,
+) {
+	authctx := auth.NewContextReader(logger)
+	service := sessions.NewService(logger, refreshService, authctx)
+	handler := sessions.NewHandler(logger, service, authMiddleware)
+	handler.RegisterRoutes(router)
+}
+
+func initServiceTokensFeature(logger customlog.Logger, router *gin.Engine, authService auth.Service) error {
+	cfg, err := servicetokens.LoadConfig(logger)
+	if err != nil {
+		return err
+	}
+
+	service := servicetokens.NewService(logger, authService, cfg)
+	handler := servicetokens.NewHandler(logger, service)
+	handler.RegisterRoutes(router)
+	return nil
+}
+
+func initIntrospectionFeature(
+	logger customlog.Logger,
+	router *gin.Engine,
+	authService auth.Service,
+	refreshService refresh.Service,
+	tokenDenylist auth.Denylist,
+	authMiddleware auth.Middleware,
+) {
+	service := introspection.NewService(logger, authService, refreshService, tokenDenylist)
+	handler := introspection.NewHandler(logger, service, authMiddleware)
+	handler.RegisterRoutes(router)
 }
@@ -0,0 +1,27 @@
+package admins
+
+import (
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for the platform admins.
+type Config struct {
+	// BootstrapEmail is the email of the platform admin created at startup when it does not exist yet. No admin is
+	// created when it is empty.
+	BootstrapEmail string `env:"ADMIN_BOOTSTRAP_EMAIL"`
+	// BootstrapPassword is the password of the bootstrapped platform admin. It must meet the password policy.
+	BootstrapPassword string `env:"ADMIN_BOOTSTRAP_PASSWORD"`
+}
+
+// LoadConfig loads the platform admins configuration from environment variables and logs any errors encountered
+// during parsing. It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load admins configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
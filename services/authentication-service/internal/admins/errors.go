@@ -0,0 +1,13 @@
+// Package admins provide platform admin-related functionality and error definitions
+// for the authentication service. It defines custom errors for handling common
+// platform admin-related scenarios.
+package admins
+
+import "errors"
+
+var (
+	// ErrAdminAlreadyExists indicates that an admin with the same identifying details already exists in the system.
+	ErrAdminAlreadyExists = errors.New("admin already exists")
+	// ErrAdminNotFound indicates that an admin with the specified details could not be found in the system.
+	ErrAdminNotFound = errors.New("admin not found")
+)
@@ -0,0 +1,160 @@
+package admins
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+)
+
+// Handler manages HTTP requests for auth-platform-admin-related operations.
+type Handler struct {
+	logger  log.Logger
+	service Service
+}
+
+// NewHandler creates a new instance of Handler.
+func NewHandler(logger log.Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// RegisterRoutes registers the platform admin-related HTTP routes.
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/v1.0/admins/login", h.LoginAdmin)
+	router.POST("/v1.0/admins/refresh", h.RefreshAdmin)
+}
+
+// LoginAdminRequest represents the request payload for logging in an admin.
+type LoginAdminRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// LoginAdminResponse represents the response payload for a successful admin login.
+type LoginAdminResponse struct {
+	authcore.TokenPairResponse
+}
+
+// LoginAdmin processes the login request for an admin using credentials provided in JSON format.
+func (h *Handler) LoginAdmin(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("LoginAdmin handler called")
+
+	var req LoginAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := LoginAdminInput(req)
+	output, err := h.service.LoginAdmin(ctx, input)
+	if err != nil {
+		if errors.Is(err, authcore.ErrInvalidCredentials) {
+			logger.Warn("Invalid credentials provided", log.Field{Key: "email", Value: req.Email})
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidCredentials,
+					authcore.MsgInvalidCredentials,
+				),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrAccountLocked) {
+			logger.Warn("Login locked after too many failed attempts", log.Field{Key: "email", Value: req.Email})
+			c.JSON(
+				http.StatusTooManyRequests, customhttp.NewErrorResponse(
+					authcore.CodeAccountLocked,
+					authcore.MsgAccountLocked,
+				),
+			)
+			return
+		}
+		logger.Error("Failed to login admin", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := LoginAdminResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
+	logger.Info("Admin logged in successfully")
+	c.JSON(http.StatusOK, resp)
+}
+
+// RefreshAdminRequest represents a request to refresh the admin tokens.
+type RefreshAdminRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+	AccessToken  string `json:"access_token" binding:"required"`
+}
+
+// RefreshAdminResponse represents the response returned when refreshing an admin's token.
+type RefreshAdminResponse struct {
+	authcore.TokenPairResponse
+}
+
+// RefreshAdmin handles the refreshing of an admin's authentication token.
+func (h *Handler) RefreshAdmin(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("RefreshAdmin handler called")
+
+	var req RefreshAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := RefreshAdminInput(req)
+	output, err := h.service.RefreshAdmin(ctx, input)
+	if err != nil {
+		if errors.Is(err, authcore.ErrInvalidRefreshToken) {
+			logger.Warn("Invalid refresh token provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidRefreshToken,
+					authcore.MsgInvalidRefreshToken,
+				),
+			)
+			return
+		} else if errors.Is(err, authcore.ErrTokenMismatch) {
+			logger.Warn("Token mismatch")
+			c.JSON(
+				http.StatusForbidden, customhttp.NewErrorResponse(
+					authcore.CodeTokenMismatch,
+					authcore.MsgTokenMismatch,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to refresh admin", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := RefreshAdminResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
+	logger.Info("Admin refreshed successfully")
+	c.JSON(http.StatusOK, resp)
+}
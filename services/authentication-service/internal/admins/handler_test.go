@@ -0,0 +1,248 @@
+//go:build unit
+
+package admins_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/admins"
+	adminsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/admins/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+)
+
+type adminHandlerTestCase struct {
+	name        string
+	jsonPayload string
+	mocksSetup  func(service *adminsmocks.MockService)
+	wantJSON    string
+	wantStatus  int
+}
+
+var errUnexpected = errors.New("unexpected error")
+
+func TestHandler_LoginAdmin(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []adminHandlerTestCase{
+		{
+			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			jsonPayload: `{"name": 1.2, "email": true}`,
+			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"email is required",
+					"password is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when there are invalid credentials, then it should return a 401 with invalid credentials error",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			mocksSetup: func(service *adminsmocks.MockService) {
+				service.EXPECT().LoginAdmin(gomock.Any(), gomock.Any()).
+					Return(admins.LoginAdminOutput{}, authcore.ErrInvalidCredentials)
+			},
+			wantJSON: `{
+				"code": "INVALID_CREDENTIALS",
+				"message": "invalid credentials",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "when the admin login is locked, then it should return a 429 with account locked error",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			mocksSetup: func(service *adminsmocks.MockService) {
+				service.EXPECT().LoginAdmin(gomock.Any(), gomock.Any()).
+					Return(admins.LoginAdminOutput{}, authcore.ErrAccountLocked)
+			},
+			wantJSON: `{
+				"code": "ACCOUNT_LOCKED",
+				"message": "too many failed login attempts, try again later",
+				"details": []
+			}`,
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:        "when unexpected error when login the admin, then it should return a 500 with the internal error",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			mocksSetup: func(service *adminsmocks.MockService) {
+				service.EXPECT().LoginAdmin(gomock.Any(), gomock.Any()).
+					Return(admins.LoginAdminOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when an active admin has the same email and password, then it should return a 200 with the token",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			mocksSetup: func(service *adminsmocks.MockService) {
+				service.EXPECT().LoginAdmin(
+					gomock.Any(), admins.LoginAdminInput{
+						Email:    "test@example.com",
+						Password: "ValidPassword123",
+					},
+				).Return(
+					admins.LoginAdminOutput{
+						TokenPair: authcore.TokenPair{
+							AccessToken:  "fake-token",
+							RefreshToken: "fake-refresh-token",
+							ExpiresIn:    admins.DefaultTokenExpiration,
+							TokenType:    auth.DefaultTokenType,
+						},
+					}, nil,
+				)
+			},
+			wantJSON: `{
+			  "access_token": "fake-token",
+			  "refresh_token": "fake-refresh-token",
+			  "expires_in": 900,
+			  "token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runAdminHandlerTestCase(t, logger, http.MethodPost, "/v1.0/admins/login", tt)
+			},
+		)
+	}
+}
+
+func TestHandler_RefreshAdmin(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []adminHandlerTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"refresh_token is required",
+					"access_token is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when invalid refresh token provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			jsonPayload: `{"access_token": "valid-access-token", "refresh_token": "invalid-refresh-token"}`,
+			mocksSetup: func(service *adminsmocks.MockService) {
+				service.EXPECT().RefreshAdmin(gomock.Any(), gomock.Any()).
+					Return(admins.RefreshAdminOutput{}, authcore.ErrInvalidRefreshToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when there is a token mismatch between the access token and the refresh token, " +
+				"then it should return a 403 with the token mismatch error",
+			jsonPayload: `{"access_token": "invalid-access-token", "refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *adminsmocks.MockService) {
+				service.EXPECT().RefreshAdmin(gomock.Any(), gomock.Any()).
+					Return(admins.RefreshAdminOutput{}, authcore.ErrTokenMismatch)
+			},
+			wantJSON: `{
+				"code": "TOKEN_MISMATCH",
+				"message": "token mismatch",
+				"details": []
+			}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when unexpected error when refreshing the admin token, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"access_token": "valid-access-token", "refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *adminsmocks.MockService) {
+				service.EXPECT().RefreshAdmin(gomock.Any(), gomock.Any()).
+					Return(admins.RefreshAdminOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the admin token is refreshed, then it should return a 200 with the new token",
+			jsonPayload: `{"access_token": "valid-access-token", "refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *adminsmocks.MockService) {
+				service.EXPECT().RefreshAdmin(
+					gomock.Any(), admins.RefreshAdminInput{
+						AccessToken:  "valid-access-token",
+						RefreshToken: "valid-refresh-token",
+					},
+				).Return(
+					admins.RefreshAdminOutput{
+						TokenPair: authcore.TokenPair{
+							AccessToken:  "fake-token",
+							RefreshToken: "fake-refresh-token",
+							ExpiresIn:    admins.DefaultTokenExpiration,
+							TokenType:    auth.DefaultTokenType,
+						},
+					}, nil,
+				)
+			},
+			wantJSON: `{
+			  "access_token": "fake-token",
+			  "refresh_token": "fake-refresh-token",
+			  "expires_in": 900,
+			  "token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runAdminHandlerTestCase(t, logger, http.MethodPost, "/v1.0/admins/refresh", tt)
+			},
+		)
+	}
+}
+
+// runAdminHandlerTestCase executes a test case for the admin handler, which is common for all tests.
+func runAdminHandlerTestCase(
+	t *testing.T,
+	logger log.Logger,
+	httpMethod string,
+	route string,
+	tt adminHandlerTestCase,
+) {
+	// Create a new mock service
+	service := adminsmocks.NewMockService(gomock.NewController(t))
+	if tt.mocksSetup != nil {
+		tt.mocksSetup(service)
+	}
+
+	// Initialize the handler
+	h := admins.NewHandler(logger, service)
+
+	// Make HTTP request
+	w := customhttp.ServeTestHTTPRequest(t, h, httpMethod, route, "", nil, tt.jsonPayload)
+
+	assert.Equal(t, tt.wantStatus, w.Code)
+	if tt.wantJSON == "" {
+		assert.Empty(t, w.Body.String())
+		return
+	}
+	assert.JSONEq(t, tt.wantJSON, w.Body.String())
+}
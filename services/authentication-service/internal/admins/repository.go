@@ -0,0 +1,162 @@
+package admins
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// CollectionName defines the name of the MongoDB collection used for storing platform admin documents.
+	CollectionName = "admins"
+
+	// FieldAdminID represents the field name used to store or query the public ID of an admin in the database.
+	FieldAdminID = "admin_id"
+	// FieldEmail represents the field name used to store or query email addresses in the database.
+	FieldEmail = "email"
+	// FieldActive represents the field name used to indicate the active status of an admin in the database.
+	FieldActive = "active"
+	// FieldPassword represents the field name used to store the hashed password of an admin in the database.
+	FieldPassword = "password"
+	// FieldUpdatedAt represents the field name used to store the timestamp of the last update in the database.
+	FieldUpdatedAt = "updated_at"
+)
+
+// Admin represents a platform administrator, who manages the credentials of the other users of the platform.
+type Admin struct {
+	ID        string    `bson:"_id,omitempty"`
+	AdminID   string    `bson:"admin_id"`
+	Email     string    `bson:"email"`
+	Active    bool      `bson:"active"`
+	Password  string    `bson:"password,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// Repository defines the interface for platform admin repository operations.
+// It includes methods to create an admin, find an admin by email and update its password.
+//
+//go:generate mockgen -destination=./mocks/repository_mock.go -package=admins_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/admins Repository
+type Repository interface {
+	CreateAdmin(ctx context.Context, params CreateAdminParams) (Admin, error)
+	FindByEmail(ctx context.Context, email string) (Admin, error)
+	UpdatePassword(ctx context.Context, params UpdatePasswordParams) error
+}
+
+type repository struct {
+	logger     log.Logger
+	collection *mongo.Collection
+	clock      clock.Clock
+}
+
+// NewRepository creates a new instance of the Repository interface with MongoDB implementation.
+func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repository {
+	return &repository{
+		logger:     logger,
+		collection: db.Collection(CollectionName),
+		clock:      clk,
+	}
+}
+
+// CreateAdminParams represents the parameters needed to create a new platform admin.
+type CreateAdminParams struct {
+	AdminID  string
+	Email    string
+	Password string
+}
+
+// CreateAdmin creates a new platform admin record in the database.
+// It returns the created admin with an assigned ID or an error if the operation fails.
+// If an admin with the same email already exists, it returns ErrAdminAlreadyExists.
+func (r *repository) CreateAdmin(ctx context.Context, params CreateAdminParams) (Admin, error) {
+	logger := r.logger.WithContext(ctx)
+
+	now := r.clock.Now()
+	a := Admin{
+		AdminID:   params.AdminID,
+		Email:     params.Email,
+		Password:  params.Password,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Active:    true,
+	}
+	res, err := r.collection.InsertOne(ctx, a)
+	if err != nil {
+		if mongodb.IsDuplicateKeyError(err) {
+			logger.Warn("Admin already exists", log.Field{Key: "email", Value: params.Email})
+			return Admin{}, ErrAdminAlreadyExists
+		}
+		logger.Error("Failed to insert admin", err)
+		return Admin{}, err
+	}
+	a.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	logger.Info("Admin created successfully", log.Field{Key: "admin_id", Value: a.ID})
+	return a, nil
+}
+
+// FindByEmail searches for an active platform admin with the specified email address.
+// It returns the admin if found or ErrAdminNotFound if no matching active admin exists.
+func (r *repository) FindByEmail(ctx context.Context, email string) (Admin, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var admin Admin
+	filter := bson.M{
+		FieldEmail:  email,
+		FieldActive: true,
+	}
+
+	if err := r.collection.FindOne(ctx, filter).Decode(&admin); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn("Admin not found", log.Field{Key: "email", Value: email})
+			return Admin{}, ErrAdminNotFound
+		}
+		logger.Error("Failed to find admin", err)
+		return Admin{}, err
+	}
+	return admin, nil
+}
+
+// UpdatePasswordParams represents the parameters needed to replace the password of a platform admin.
+// Password must be already hashed.
+type UpdatePasswordParams struct {
+	AdminID  string
+	Password string
+}
+
+// UpdatePassword replaces the password of the active platform admin with the specified admin ID.
+// It returns ErrAdminNotFound if no matching active admin exists.
+func (r *repository) UpdatePassword(ctx context.Context, params UpdatePasswordParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	filter := bson.M{
+		FieldAdminID: params.AdminID,
+		FieldActive:  true,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldPassword:  params.Password,
+			FieldUpdatedAt: r.clock.Now(),
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to update admin password", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("Admin not found", log.Field{Key: "admin_id", Value: params.AdminID})
+		return ErrAdminNotFound
+	}
+
+	logger.Info("Admin password updated successfully", log.Field{Key: "admin_id", Value: params.AdminID})
+	return nil
+}
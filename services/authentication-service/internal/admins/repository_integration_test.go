@@ -0,0 +1,308 @@
+//go:build integration
+
+package admins_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/admins"
+)
+
+const testDBPrefix = "admins_test_authentication_service"
+
+type adminsRepositoryTestCase[P, W any] struct {
+	name            string
+	insertDocuments func(t *testing.T, coll *mongo.Collection)
+	params          P
+	want            W
+	wantErr         error
+}
+
+func TestRepository_CreateAdmin(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []adminsRepositoryTestCase[admins.CreateAdminParams, admins.Admin]{
+		{
+			name: "when exists an active admin with the same email, then it should return an admin already exists error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, admins.Admin{
+					AdminID:   "fake-admin-id",
+					Email:     "test@example.com",
+					Password:  "fakehashedpassword",
+					Active:    true,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params: admins.CreateAdminParams{
+				AdminID:  "fake-admin-id",
+				Email:    "test@example.com",
+				Password: "ValidPassword123",
+			},
+			want:    admins.Admin{},
+			wantErr: admins.ErrAdminAlreadyExists,
+		},
+		{
+			name: "when the admin is created successfully, then it should return the created admin",
+			params: admins.CreateAdminParams{
+				AdminID:  "fake-admin-id",
+				Email:    "test@example.com",
+				Password: "ValidPassword123",
+			},
+			want: admins.Admin{
+				AdminID:   "fake-admin-id",
+				Email:     "test@example.com",
+				Active:    true,
+				Password:  "ValidPassword123",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestAdminsCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := admins.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.CreateAdmin(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the got only if there is no error expected
+			if tt.wantErr == nil {
+				// As the ID is generated by MongoDB, we just check that it is not empty
+				assert.NotEmpty(t, got.ID, "ID should not be empty")
+
+				// Doing this as in that way, I can do a direct equal assertion between the want ant got
+				tt.want.ID = got.ID
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRepository_CreateAdmin_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := admins.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.CreateAdmin(context.Background(), admins.CreateAdminParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, admins.ErrAdminAlreadyExists)
+}
+
+func TestRepository_FindByEmail(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []adminsRepositoryTestCase[string, admins.Admin]{
+		{
+			name: "when there is not an active admin with the email, then it should return an admin not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, admins.Admin{
+					Email:     "test@example.com",
+					Password:  "fakehashedpassword",
+					Active:    false,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params:  "test@example.com",
+			want:    admins.Admin{},
+			wantErr: admins.ErrAdminNotFound,
+		},
+		{
+			name: "when there is an active admin with the email, then it should return the admin",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, admins.Admin{
+					Email:     "test2@example.com",
+					Password:  "fakehashedpassword",
+					Active:    true,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params: "test2@example.com",
+			want: admins.Admin{
+				Email:     "test2@example.com",
+				Active:    true,
+				Password:  "fakehashedpassword",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestAdminsCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := admins.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.FindByEmail(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the got only if there is no error expected
+			if tt.wantErr == nil {
+				// As the ID is generated by MongoDB, we just check that it is not empty
+				assert.NotEmpty(t, got.ID, "ID should not be empty")
+
+				tt.want.ID = got.ID
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRepository_FindByEmail_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := admins.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindByEmail(context.Background(), "")
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, admins.ErrAdminNotFound)
+}
+
+func TestRepository_UpdatePassword(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []adminsRepositoryTestCase[admins.UpdatePasswordParams, string]{
+		{
+			name: "when there is not an active admin with the id, then it should return an admin not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, admins.Admin{
+					AdminID:   "fake-admin-id",
+					Email:     "test@example.com",
+					Password:  "fakehashedpassword",
+					Active:    false,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params: admins.UpdatePasswordParams{
+				AdminID:  "fake-admin-id",
+				Password: "newfakehashedpassword",
+			},
+			want:    "fakehashedpassword",
+			wantErr: admins.ErrAdminNotFound,
+		},
+		{
+			name: "when there is an active admin with the id, then it should replace the password",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, admins.Admin{
+					AdminID:   "fake-admin-id",
+					Email:     "test@example.com",
+					Password:  "fakehashedpassword",
+					Active:    true,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params: admins.UpdatePasswordParams{
+				AdminID:  "fake-admin-id",
+				Password: "newfakehashedpassword",
+			},
+			want:    "newfakehashedpassword",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestAdminsCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := admins.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.UpdatePassword(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var got admins.Admin
+			err = coll.FindOne(context.Background(), bson.M{admins.FieldAdminID: tt.params.AdminID}).Decode(&got)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Password)
+		})
+	}
+}
+
+func TestRepository_UpdatePassword_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := admins.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.UpdatePassword(context.Background(), admins.UpdatePasswordParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, admins.ErrAdminNotFound)
+}
+
+func setupTestAdminsCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := db.Collection(admins.CollectionName)
+
+	// Create unique index on email
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: admins.FieldEmail, Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.D{{Key: admins.FieldActive, Value: true}}),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		t.Fatalf("Failed to create unique index: %v", err)
+	}
+
+	return coll
+}
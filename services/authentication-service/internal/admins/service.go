@@ -0,0 +1,271 @@
+package admins
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+)
+
+const (
+	// DefaultTokenExpiration defines the duration in seconds for which a JWT token remains valid
+	// after being issued during platform admin authentication. The default value is 900 seconds (15 minutes), shorter
+	// than for the other roles as admin tokens grant access to every account.
+	DefaultTokenExpiration = 900
+	// DefaultTokenRole represents the default role assigned to a generated JWT token for platform admins.
+	DefaultTokenRole = "platform_admin"
+)
+
+// Service defines the interface for platform admin authentication management service.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=admins_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/admins Service
+type Service interface {
+	BootstrapAdmin(ctx context.Context, input BootstrapAdminInput) (BootstrapAdminOutput, error)
+	LoginAdmin(ctx context.Context, input LoginAdminInput) (LoginAdminOutput, error)
+	RefreshAdmin(ctx context.Context, input RefreshAdminInput) (RefreshAdminOutput, error)
+}
+
+type service struct {
+	logger            log.Logger
+	repo              Repository
+	authCoreService   authcore.Service
+	lockoutService    lockout.Service
+	passwordPolicy    password.Policy
+	authEventsService authevents.Service
+}
+
+// NewService creates a new instance of Service with the provided dependencies.
+func NewService(
+	logger log.Logger,
+	repo Repository,
+	authCoreService authcore.Service,
+	lockoutService lockout.Service,
+	passwordPolicy password.Policy,
+	authEventsService authevents.Service,
+) Service {
+	return &service{
+		logger:            logger,
+		repo:              repo,
+		authCoreService:   authCoreService,
+		lockoutService:    lockoutService,
+		passwordPolicy:    passwordPolicy,
+		authEventsService: authEventsService,
+	}
+}
+
+// BootstrapAdminInput defines the input structure required for bootstrapping a platform admin.
+type BootstrapAdminInput struct {
+	Email    string
+	Password string
+}
+
+// BootstrapAdminOutput represents the result of bootstrapping a platform admin.
+type BootstrapAdminOutput struct {
+	// Created is false when an active admin with the same email already existed.
+	Created bool
+}
+
+// BootstrapAdmin creates the platform admin with the given credentials, unless an active admin with the same email
+// already exists. As there is no other way to create admins, it is meant to be called at startup.
+func (s *service) BootstrapAdmin(ctx context.Context, input BootstrapAdminInput) (BootstrapAdminOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("bootstrapping admin", log.Field{Key: "email", Value: input.Email})
+	if err := s.passwordPolicy.Validate(input.Password, input.Email); err != nil {
+		logger.Warn("password does not meet the policy", log.Field{Key: "email", Value: input.Email})
+		return BootstrapAdminOutput{}, err
+	}
+
+	hashedPassword, err := password.Hash(input.Password)
+	if err != nil {
+		logger.Error("failed to hash password", err)
+		return BootstrapAdminOutput{}, err
+	}
+
+	admin, err := s.repo.CreateAdmin(ctx, CreateAdminParams{
+		AdminID:  uuid.NewString(),
+		Email:    input.Email,
+		Password: hashedPassword,
+	})
+	if err != nil {
+		if errors.Is(err, ErrAdminAlreadyExists) {
+			logger.Info("admin already bootstrapped", log.Field{Key: "email", Value: input.Email})
+			return BootstrapAdminOutput{}, nil
+		}
+		logger.Error("failed to create admin", err)
+		return BootstrapAdminOutput{}, err
+	}
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:    authevents.EventTypeRegistration,
+		Outcome: authevents.OutcomeSuccess,
+		Subject: admin.AdminID,
+		Role:    DefaultTokenRole,
+	})
+	logger.Info("admin bootstrapped successfully", log.Field{Key: "admin_id", Value: admin.AdminID})
+	return BootstrapAdminOutput{Created: true}, nil
+}
+
+// LoginAdminInput represents the input required for the admin login process.
+type LoginAdminInput struct {
+	Email    string
+	Password string
+}
+
+// LoginAdminOutput represents the output returned upon successful login of an admin.
+type LoginAdminOutput struct {
+	authcore.TokenPair
+}
+
+func (s *service) LoginAdmin(ctx context.Context, input LoginAdminInput) (LoginAdminOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("logging in", log.Field{Key: "email", Value: input.Email})
+	if _, err := s.lockoutService.Check(ctx, lockout.CheckInput{
+		Role:       DefaultTokenRole,
+		Identifier: input.Email,
+	}); err != nil {
+		if errors.Is(err, lockout.ErrLocked) {
+			logger.Warn("admin login locked", log.Field{Key: "email", Value: input.Email})
+			s.authEventsService.Record(ctx, authevents.RecordInput{
+				Type:    authevents.EventTypeLogin,
+				Outcome: authevents.OutcomeFailure,
+				Reason:  authevents.ReasonAccountLocked,
+				Role:    DefaultTokenRole,
+			})
+			return LoginAdminOutput{}, authcore.ErrAccountLocked
+		}
+		logger.Error("failed to check the admin login lock", err)
+		return LoginAdminOutput{}, err
+	}
+
+	admin, err := s.repo.FindByEmail(ctx, input.Email)
+	if err != nil {
+		if errors.Is(err, ErrAdminNotFound) {
+			logger.Warn("admin not found", log.Field{Key: "email", Value: input.Email})
+			return LoginAdminOutput{}, s.registerLoginFailure(ctx, input.Email, "")
+		}
+		logger.Error("failed to find admin by email", err)
+		return LoginAdminOutput{}, err
+	}
+
+	// Check if the stored password matches the provided password
+	if !password.Verify(admin.Password, input.Password) {
+		logger.Warn("invalid credentials")
+		return LoginAdminOutput{}, s.registerLoginFailure(ctx, input.Email, admin.AdminID)
+	}
+	s.upgradePasswordHash(ctx, admin, input.Password)
+
+	if _, err := s.lockoutService.RegisterSuccess(ctx, lockout.RegisterSuccessInput{
+		Role:       DefaultTokenRole,
+		Identifier: input.Email,
+	}); err != nil {
+		logger.Error("failed to register the successful admin login", err)
+		return LoginAdminOutput{}, err
+	}
+
+	tokenPair, err := s.authCoreService.GenerateTokenPair(
+		ctx, authcore.GenerateTokenPairInput{
+			UserID:     admin.AdminID,
+			Expiration: DefaultTokenExpiration,
+			Role:       DefaultTokenRole,
+		},
+	)
+	if err != nil {
+		logger.Error("failed to generate token pair", err)
+		return LoginAdminOutput{}, err
+	}
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:    authevents.EventTypeLogin,
+		Outcome: authevents.OutcomeSuccess,
+		Subject: admin.AdminID,
+		Role:    DefaultTokenRole,
+	})
+	return LoginAdminOutput{TokenPair: tokenPair}, nil
+}
+
+// registerLoginFailure registers the failed login of the admin, and returns the error the login must fail with.
+// The adminID is empty when the email is not registered.
+func (s *service) registerLoginFailure(ctx context.Context, email, adminID string) error {
+	logger := s.logger.WithContext(ctx)
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:    authevents.EventTypeLogin,
+		Outcome: authevents.OutcomeFailure,
+		Reason:  authevents.ReasonInvalidCredentials,
+		Subject: adminID,
+		Role:    DefaultTokenRole,
+	})
+
+	if _, err := s.lockoutService.RegisterFailure(ctx, lockout.RegisterFailureInput{
+		Role:       DefaultTokenRole,
+		Identifier: email,
+	}); err != nil {
+		logger.Error("failed to register the failed admin login", err)
+		return err
+	}
+	return authcore.ErrInvalidCredentials
+}
+
+// upgradePasswordHash hashes the password of the admin again when the stored hash was created with weaker
+// parameters than the current ones. It is best effort, so a failure does not prevent the admin from logging in.
+func (s *service) upgradePasswordHash(ctx context.Context, admin Admin, plainPassword string) {
+	logger := s.logger.WithContext(ctx)
+
+	if !password.NeedsRehash(admin.Password) {
+		return
+	}
+
+	hashedPassword, err := password.Hash(plainPassword)
+	if err != nil {
+		logger.Error("failed to rehash the admin password", err)
+		return
+	}
+	if err := s.repo.UpdatePassword(ctx, UpdatePasswordParams{
+		AdminID:  admin.AdminID,
+		Password: hashedPassword,
+	}); err != nil {
+		logger.Error("failed to upgrade the admin password hash", err)
+		return
+	}
+	logger.Info("admin password hash upgraded", log.Field{Key: "admin_id", Value: admin.AdminID})
+}
+
+// RefreshAdminInput represents the input required to refresh an admin's authentication tokens.
+type RefreshAdminInput struct {
+	RefreshToken string
+	AccessToken  string
+}
+
+// RefreshAdminOutput wraps the response of a successful admin token refresh operation.
+type RefreshAdminOutput struct {
+	authcore.TokenPair
+}
+
+func (s *service) RefreshAdmin(ctx context.Context, input RefreshAdminInput) (RefreshAdminOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("refreshing admin token")
+
+	tokenPair, err := s.authCoreService.RefreshToken(
+		ctx, authcore.RefreshTokenInput{
+			RefreshToken: input.RefreshToken,
+			AccessToken:  input.AccessToken,
+			Expiration:   DefaultTokenExpiration,
+			Role:         DefaultTokenRole,
+		},
+	)
+	if err != nil {
+		logger.Error("failed to refresh the admin token", err)
+		return RefreshAdminOutput{}, err
+	}
+
+	return RefreshAdminOutput{TokenPair: tokenPair}, nil
+}
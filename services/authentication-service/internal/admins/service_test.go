@@ -0,0 +1,498 @@
+//go:build unit
+
+package admins_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	adminsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/admins/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	authcoremocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore/mocks"
+	autheventsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents/mocks"
+	lockoutmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout/mocks"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/admins"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+)
+
+var (
+	errRepo  = errors.New("repository error")
+	errToken = errors.New("token error")
+
+	testPasswordPolicy = password.Policy{MinLength: 8, MaxLength: 128, MinCharacterClasses: 2}
+)
+
+type adminsServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	want       W
+	mocksSetup func(
+		repo *adminsmocks.MockRepository,
+		authCoreService *authcoremocks.MockService,
+		lockoutService *lockoutmocks.MockService,
+	)
+	wantErr error
+}
+
+func TestService_BootstrapAdmin(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []adminsServiceTestCase[admins.BootstrapAdminInput, admins.BootstrapAdminOutput]{
+		{
+			name:    "when the password does not meet the policy, then it should return a policy violation error",
+			input:   admins.BootstrapAdminInput{Email: "admin@example.com", Password: "mytestpassword"},
+			want:    admins.BootstrapAdminOutput{},
+			wantErr: password.ErrPolicyViolation,
+		},
+		{
+			name:  "when there is an unexpected error when creating the admin, then it should propagate the error",
+			input: admins.BootstrapAdminInput{Email: "admin@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				repo.EXPECT().CreateAdmin(gomock.Any(), gomock.Any()).
+					Return(admins.Admin{}, errRepo)
+			},
+			want:    admins.BootstrapAdminOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an active admin with the same email, then it should not create it again",
+			input: admins.BootstrapAdminInput{Email: "admin@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				repo.EXPECT().CreateAdmin(gomock.Any(), gomock.Any()).
+					Return(admins.Admin{}, admins.ErrAdminAlreadyExists)
+			},
+			want:    admins.BootstrapAdminOutput{Created: false},
+			wantErr: nil,
+		},
+		{
+			name:  "when the admin can be created, then it should create it with a hashed password",
+			input: admins.BootstrapAdminInput{Email: "admin@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				repo.EXPECT().CreateAdmin(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, params admins.CreateAdminParams) (admins.Admin, error) {
+							// Assert that the password is hashed
+							ok := password.Verify(params.Password, "ValidPassword123")
+							require.True(t, ok, "Password should be hashed and match the input password")
+							assert.NotEmpty(t, params.AdminID)
+							assert.Equal(t, "admin@example.com", params.Email)
+
+							return admins.Admin{
+								ID:       "fake-id",
+								AdminID:  params.AdminID,
+								Email:    params.Email,
+								Password: params.Password,
+								Active:   true,
+							}, nil
+						},
+					)
+			},
+			want:    admins.BootstrapAdminOutput{Created: true},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.BootstrapAdmin(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_LoginAdmin(t *testing.T) {
+	logger, _ := log.NewTest()
+	weakParams := password.Params{Memory: 8 * 1024, Iterations: 1, Parallelism: 1}
+
+	tests := []adminsServiceTestCase[admins.LoginAdminInput, admins.LoginAdminOutput]{
+		{
+			name:  "when the login is locked, then it should return an account locked error",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				_ *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), lockout.CheckInput{
+					Role:       admins.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.CheckOutput{}, lockout.ErrLocked)
+			},
+			want:    admins.LoginAdminOutput{},
+			wantErr: authcore.ErrAccountLocked,
+		},
+		{
+			name:  "when there is an unexpected error checking the login lock, then it should propagate the error",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				_ *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, errRepo)
+			},
+			want:    admins.LoginAdminOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is an unexpected error registering the failed login, " +
+				"then it should propagate the error",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(admins.Admin{}, admins.ErrAdminNotFound)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), lockout.RegisterFailureInput{
+					Role:       admins.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterFailureOutput{}, errRepo)
+			},
+			want:    admins.LoginAdminOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is an unexpected error registering the successful login, " +
+				"then it should propagate the error",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(admins.Admin{AdminID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), lockout.RegisterSuccessInput{
+					Role:       admins.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterSuccessOutput{}, errRepo)
+			},
+			want:    admins.LoginAdminOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is not an active admin with the same email, " +
+				"then it should return an invalid credentials error",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(admins.Admin{}, admins.ErrAdminNotFound)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterFailureOutput{}, nil)
+			},
+			want:    admins.LoginAdminOutput{},
+			wantErr: authcore.ErrInvalidCredentials,
+		},
+		{
+			name: "when there is not an active admin with the same password, " +
+				"then it should return an invalid credentials error",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "InvalidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(admins.Admin{AdminID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterFailureOutput{}, nil)
+			},
+			want:    admins.LoginAdminOutput{},
+			wantErr: authcore.ErrInvalidCredentials,
+		},
+		{
+			name:  "when there is an unexpected error when fetching the admin, then it should propagate the error",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(admins.Admin{}, errRepo)
+			},
+			want:    admins.LoginAdminOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error generating the token pair, then it should propagate the error",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(admins.Admin{AdminID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{}, errToken)
+			},
+			want:    admins.LoginAdminOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when an active admin logs in with valid credentials, then it should return its token",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(admins.Admin{AdminID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), authcore.GenerateTokenPairInput{
+					UserID:     "fake-id",
+					Expiration: admins.DefaultTokenExpiration,
+					Role:       admins.DefaultTokenRole,
+				}).Return(
+					authcore.TokenPair{
+						AccessToken:  "fake-token",
+						RefreshToken: "fake-refresh-token",
+						ExpiresIn:    3600,
+						TokenType:    "Bearer",
+					}, nil,
+				)
+			},
+			want: admins.LoginAdminOutput{
+				TokenPair: authcore.TokenPair{
+					AccessToken:  "fake-token",
+					ExpiresIn:    3600, // 1 hour
+					TokenType:    "Bearer",
+					RefreshToken: "fake-refresh-token",
+				},
+			},
+		},
+		{
+			name: "when the stored password was hashed with weaker parameters, " +
+				"then it should rehash it and return the token",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.HashWithParams("ValidPassword123", weakParams)
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(admins.Admin{AdminID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params admins.UpdatePasswordParams) error {
+						assert.Equal(t, "fake-id", params.AdminID)
+						assert.False(t, password.NeedsRehash(params.Password), "The password should be rehashed")
+						assert.True(t, password.Verify(params.Password, "ValidPassword123"))
+						return nil
+					})
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: admins.LoginAdminOutput{
+				TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+			},
+		},
+		{
+			name: "when there is an error upgrading the password hash, " +
+				"then it should still return the token",
+			input: admins.LoginAdminInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *adminsmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.HashWithParams("ValidPassword123", weakParams)
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(admins.Admin{AdminID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(errRepo)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: admins.LoginAdminOutput{
+				TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.LoginAdmin(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_RefreshAdmin(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []adminsServiceTestCase[admins.RefreshAdminInput, admins.RefreshAdminOutput]{
+		{
+			name: "when there is an error refreshing the token, then it should propagate the error",
+			input: admins.RefreshAdminInput{
+				RefreshToken: "InvalidRefreshToken",
+				AccessToken:  "ValidAccessToken",
+			},
+			mocksSetup: func(
+				_ *adminsmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{}, errToken)
+			},
+			want:    admins.RefreshAdminOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the new access token is generated correctly, then it should return the new token",
+			input: admins.RefreshAdminInput{
+				RefreshToken: "ValidRefreshToken",
+				AccessToken:  "ValidAccessToken",
+			},
+			mocksSetup: func(
+				_ *adminsmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				authCoreService.EXPECT().RefreshToken(gomock.Any(), authcore.RefreshTokenInput{
+					RefreshToken: "ValidRefreshToken",
+					AccessToken:  "ValidAccessToken",
+					Expiration:   admins.DefaultTokenExpiration,
+					Role:         admins.DefaultTokenRole,
+				}).Return(
+					authcore.TokenPair{
+						AccessToken:  "fake-token",
+						RefreshToken: "fake-refresh-token",
+						ExpiresIn:    3600,
+						TokenType:    "Bearer",
+					}, nil,
+				)
+			},
+			want: admins.RefreshAdminOutput{
+				TokenPair: authcore.TokenPair{
+					AccessToken:  "fake-token",
+					RefreshToken: "fake-refresh-token",
+					ExpiresIn:    3600,
+					TokenType:    "Bearer",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.RefreshAdmin(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func serviceSetup(
+	t *testing.T, logger log.Logger, mocksSetup func(
+		repo *adminsmocks.MockRepository,
+		authCoreService *authcoremocks.MockService,
+		lockoutService *lockoutmocks.MockService,
+	),
+) (admins.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	repo := adminsmocks.NewMockRepository(ctrl)
+	authCoreService := authcoremocks.NewMockService(ctrl)
+	lockoutService := lockoutmocks.NewMockService(ctrl)
+	// The audit log is best effort and never affects the result, so the recorded events are not asserted here
+	authEventsService := autheventsmocks.NewMockService(ctrl)
+	authEventsService.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	if mocksSetup != nil {
+		mocksSetup(repo, authCoreService, lockoutService)
+	}
+
+	service := admins.NewService(
+		logger, repo, authCoreService, lockoutService, testPasswordPolicy, authEventsService,
+	)
+	return service, func() {
+		ctrl.Finish()
+	}
+}
@@ -9,6 +9,24 @@ var (
 	ErrInvalidRefreshToken = errors.New("invalid refresh token")
 	// ErrTokenMismatch indicates a mismatch between the provided access token and the refresh token.
 	ErrTokenMismatch = errors.New("token mismatch")
+	// ErrInvalidResetToken indicates that the provided password reset token is invalid, expired or already used.
+	ErrInvalidResetToken = errors.New("invalid reset token")
+	// ErrInvalidLoginToken indicates that the provided magic link login token is invalid, expired, already used or
+	// requested from another device.
+	ErrInvalidLoginToken = errors.New("invalid login token")
+	// ErrInvalidCurrentPassword indicates that the current password provided to change it does not match the stored one.
+	ErrInvalidCurrentPassword = errors.New("invalid current password")
+	// ErrAccountLocked indicates that the login is temporarily locked after too many failed attempts.
+	ErrAccountLocked = errors.New("account locked")
+	// ErrInvalidMFAChallenge indicates that the MFA challenge token is invalid, expired, already used or blocked after
+	// too many failed attempts.
+	ErrInvalidMFAChallenge = errors.New("invalid mfa challenge")
+	// ErrInvalidMFACode indicates that the provided TOTP or recovery code is not valid.
+	ErrInvalidMFACode = errors.New("invalid mfa code")
+	// ErrMFAAlreadyEnabled indicates that the user already has MFA enabled.
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+	// ErrMFAEnrollmentNotFound indicates that the user has no MFA enrollment pending confirmation.
+	ErrMFAEnrollmentNotFound = errors.New("mfa enrollment not found")
 )
 
 const (
@@ -26,4 +44,46 @@ const (
 	CodeTokenMismatch = "TOKEN_MISMATCH"
 	// MsgTokenMismatch represents the error message for a token mismatch scenario.
 	MsgTokenMismatch = "token mismatch"
+
+	// CodeInvalidResetToken represents the error code for an invalid, expired or already used password reset token.
+	CodeInvalidResetToken = "INVALID_RESET_TOKEN"
+	// MsgInvalidResetToken represents the error message indicating an invalid, expired or already used password reset
+	// token.
+	MsgInvalidResetToken = "invalid or expired reset token"
+
+	// CodeInvalidLoginToken represents the error code for an invalid, expired or already used magic link login token.
+	CodeInvalidLoginToken = "INVALID_LOGIN_TOKEN"
+	// MsgInvalidLoginToken represents the error message indicating an invalid, expired or already used magic link
+	// login token.
+	MsgInvalidLoginToken = "invalid or expired login token"
+
+	// CodeInvalidCurrentPassword represents the error code for a password change with a wrong current password.
+	CodeInvalidCurrentPassword = "INVALID_CURRENT_PASSWORD"
+	// MsgInvalidCurrentPassword represents the error message for a password change with a wrong current password.
+	MsgInvalidCurrentPassword = "current password is incorrect"
+
+	// CodeAccountLocked represents the error code for a login temporarily locked after too many failed attempts.
+	CodeAccountLocked = "ACCOUNT_LOCKED"
+	// MsgAccountLocked represents the error message for a login temporarily locked after too many failed attempts.
+	MsgAccountLocked = "too many failed login attempts, try again later"
+
+	// CodeInvalidMFAChallenge represents the error code for an invalid, expired or already used MFA challenge token.
+	CodeInvalidMFAChallenge = "INVALID_MFA_CHALLENGE"
+	// MsgInvalidMFAChallenge represents the error message for an invalid, expired or already used MFA challenge token.
+	MsgInvalidMFAChallenge = "invalid or expired mfa challenge"
+
+	// CodeInvalidMFACode represents the error code for an invalid TOTP or recovery code.
+	CodeInvalidMFACode = "INVALID_MFA_CODE"
+	// MsgInvalidMFACode represents the error message for an invalid TOTP or recovery code.
+	MsgInvalidMFACode = "invalid mfa code"
+
+	// CodeMFAAlreadyEnabled represents the error code for an MFA enrollment of a user that already has it enabled.
+	CodeMFAAlreadyEnabled = "MFA_ALREADY_ENABLED"
+	// MsgMFAAlreadyEnabled represents the error message for an MFA enrollment of a user that already has it enabled.
+	MsgMFAAlreadyEnabled = "mfa is already enabled"
+
+	// CodeMFAEnrollmentNotFound represents the error code for an MFA confirmation without a pending enrollment.
+	CodeMFAEnrollmentNotFound = "MFA_ENROLLMENT_NOT_FOUND"
+	// MsgMFAEnrollmentNotFound represents the error message for an MFA confirmation without a pending enrollment.
+	MsgMFAEnrollmentNotFound = "mfa enrollment not found"
 )
@@ -6,9 +6,14 @@ import (
 
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
 )
 
+// ImpersonationTokenExpiration defines the duration in seconds for which an impersonation token remains valid. The
+// default value is 600 seconds (10 minutes), as it can't be refreshed and grants access to the account of another user.
+const ImpersonationTokenExpiration = 600
+
 // TokenPair represents a pair of tokens typically used for authentication and session management.
 type TokenPair struct {
 	AccessToken  string
@@ -23,12 +28,19 @@ type TokenPair struct {
 type Service interface {
 	GenerateTokenPair(ctx context.Context, input GenerateTokenPairInput) (TokenPair, error)
 	RefreshToken(ctx context.Context, input RefreshTokenInput) (TokenPair, error)
+	Logout(ctx context.Context, input LogoutInput) (LogoutOutput, error)
+	RevokeSessions(ctx context.Context, input RevokeSessionsInput) (RevokeSessionsOutput, error)
+	GenerateImpersonationToken(
+		ctx context.Context,
+		input GenerateImpersonationTokenInput,
+	) (ImpersonationToken, error)
 }
 
 type service struct {
-	logger         log.Logger
-	authService    auth.Service
-	refreshService refresh.Service
+	logger            log.Logger
+	authService       auth.Service
+	refreshService    refresh.Service
+	authEventsService authevents.Service
 }
 
 // NewService creates a new instance of Service with the provided dependencies.
@@ -36,40 +48,54 @@ func NewService(
 	logger log.Logger,
 	authService auth.Service,
 	refreshService refresh.Service,
+	authEventsService authevents.Service,
 ) Service {
 	return &service{
-		logger:         logger,
-		authService:    authService,
-		refreshService: refreshService,
+		logger:            logger,
+		authService:       authService,
+		refreshService:    refreshService,
+		authEventsService: authEventsService,
 	}
 }
 
 // GenerateTokenPairInput defines the input structure required for generating a new token pair.
+// FamilyID is only set when rotating a refresh token, so the new one is linked to the previous ones.
 type GenerateTokenPairInput struct {
-	UserID     string
-	Expiration int
-	Role       string
-	TenantID   string
+	UserID      string
+	Expiration  int
+	Role        string
+	TenantID    string
+	Owner       bool
+	Permissions []string
+	FamilyID    string
 }
 
 func (s service) GenerateTokenPair(ctx context.Context, input GenerateTokenPairInput) (TokenPair, error) {
 	logger := s.logger.WithContext(ctx)
 
 	generateOutput, err := s.authService.GenerateToken(ctx, auth.GenerateTokenInput{
-		ID:         input.UserID,
-		Expiration: input.Expiration,
-		Role:       input.Role,
-		TenantID:   input.TenantID,
+		ID:          input.UserID,
+		Expiration:  input.Expiration,
+		Role:        input.Role,
+		TenantID:    input.TenantID,
+		Owner:       input.Owner,
+		Permissions: input.Permissions,
 	})
 	if err != nil {
 		logger.Error("failed to generate JWT", err)
 		return TokenPair{}, err
 	}
 
+	// The access token is linked to the refresh token, so it is denied as soon as the session is revoked
 	refreshToken, err := s.refreshService.Generate(ctx, refresh.GenerateTokenInput{
 		UserID:   input.UserID,
 		Role:     input.Role,
 		TenantID: input.TenantID,
+		FamilyID: input.FamilyID,
+		AccessToken: refresh.AccessToken{
+			ID:        generateOutput.TokenID,
+			ExpiresAt: generateOutput.ExpiresAt,
+		},
 	})
 	if err != nil {
 		logger.Error("failed to generate refresh token", err)
@@ -84,12 +110,25 @@ func (s service) GenerateTokenPair(ctx context.Context, input GenerateTokenPairI
 	}, nil
 }
 
+// TenantAccess represents the access of a user to a tenant carried by its access tokens.
+type TenantAccess struct {
+	Owner       bool
+	Permissions []string
+}
+
+// AuthorizeTenantFunc returns the current access of the user to the tenant, or an error when the user can't access it
+// anymore.
+type AuthorizeTenantFunc func(ctx context.Context, userID, tenantID string) (TenantAccess, error)
+
 // RefreshTokenInput defines the input structure required for refreshing a token pair.
 type RefreshTokenInput struct {
 	AccessToken  string
 	RefreshToken string
 	Expiration   int
 	Role         string
+	// AuthorizeTenant, when set, re-derives the owner flag and the permissions of the refreshed access token, so they
+	// never outlive a change of the access of the user to the tenant. Otherwise, they are kept from the access token.
+	AuthorizeTenant AuthorizeTenantFunc
 }
 
 func (s service) RefreshToken(ctx context.Context, input RefreshTokenInput) (TokenPair, error) {
@@ -102,6 +141,27 @@ func (s service) RefreshToken(ctx context.Context, input RefreshTokenInput) (Tok
 	if err != nil {
 		if errors.Is(err, refresh.ErrRefreshTokenNotFound) {
 			logger.Warn("refresh token not found")
+
+			// A rotated token presented again means it might have been stolen, so its family gets revoked
+			reuse, err := s.refreshService.DetectReuse(ctx, refresh.DetectReuseInput{
+				Token: input.RefreshToken,
+			})
+			if err != nil {
+				logger.Error("failed to detect refresh token reuse", err)
+				return TokenPair{}, err
+			}
+
+			reason := authevents.ReasonInvalidRefreshToken
+			if reuse.Reused {
+				reason = authevents.ReasonRefreshTokenReused
+			}
+			s.authEventsService.Record(ctx, authevents.RecordInput{
+				Type:    authevents.EventTypeRefresh,
+				Outcome: authevents.OutcomeFailure,
+				Reason:  reason,
+				Subject: reuse.UserID,
+				Role:    input.Role,
+			})
 			return TokenPair{}, ErrInvalidRefreshToken
 		}
 		logger.Error("failed to find active refresh token", err)
@@ -112,6 +172,7 @@ func (s service) RefreshToken(ctx context.Context, input RefreshTokenInput) (Tok
 	if err != nil {
 		if errors.Is(err, auth.ErrInvalidToken) {
 			logger.Warn("access token is invalid")
+			s.recordTokenMismatch(ctx, input.Role, refreshToken)
 			return TokenPair{}, ErrTokenMismatch
 		}
 		logger.Error("failed to get claims from access token", err)
@@ -124,14 +185,26 @@ func (s service) RefreshToken(ctx context.Context, input RefreshTokenInput) (Tok
 		claims.Tenant != refreshToken.TenantID {
 		
 		logger.Warn("token mismatch")
+		s.recordTokenMismatch(ctx, input.Role, refreshToken)
 		return TokenPair{}, ErrTokenMismatch
 	}
 
+	access := TenantAccess{Owner: claims.Owner, Permissions: claims.Permissions}
+	if input.AuthorizeTenant != nil {
+		access, err = input.AuthorizeTenant(ctx, refreshToken.UserID, refreshToken.TenantID)
+		if err != nil {
+			return TokenPair{}, err
+		}
+	}
+
 	tokenPair, err := s.GenerateTokenPair(ctx, GenerateTokenPairInput{
-		UserID:     refreshToken.UserID,
-		Expiration: input.Expiration,
-		Role:       input.Role,
-		TenantID:   refreshToken.TenantID,
+		UserID:      refreshToken.UserID,
+		Expiration:  input.Expiration,
+		Role:        input.Role,
+		TenantID:    refreshToken.TenantID,
+		Owner:       access.Owner,
+		Permissions: access.Permissions,
+		FamilyID:    refreshToken.FamilyID,
 	})
 	if err != nil {
 		logger.Error("failed to generate token pair", err)
@@ -145,5 +218,186 @@ func (s service) RefreshToken(ctx context.Context, input RefreshTokenInput) (Tok
 		return TokenPair{}, err
 	}
 
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeRefresh,
+		Outcome:  authevents.OutcomeSuccess,
+		Subject:  refreshToken.UserID,
+		Role:     input.Role,
+		TenantID: refreshToken.TenantID,
+	})
 	return tokenPair, nil
 }
+
+// recordTokenMismatch records the failed refresh of a valid refresh token presented with an access token that does
+// not belong to it, which might mean one of them was stolen.
+func (s service) recordTokenMismatch(ctx context.Context, role string, refreshToken refresh.FindActiveTokenOutput) {
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeRefresh,
+		Outcome:  authevents.OutcomeFailure,
+		Reason:   authevents.ReasonTokenMismatch,
+		Subject:  refreshToken.UserID,
+		Role:     role,
+		TenantID: refreshToken.TenantID,
+	})
+}
+
+// LogoutInput defines the input structure required for revoking the session of a user.
+type LogoutInput struct {
+	RefreshToken string
+	Role         string
+	// AllSessions revokes every active refresh token of the user, not only the presented one.
+	AllSessions bool
+}
+
+// LogoutOutput represents the result of a logout operation.
+type LogoutOutput struct {
+	RevokedTokens int64
+}
+
+func (s service) Logout(ctx context.Context, input LogoutInput) (LogoutOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("logging out", log.Field{Key: "all_sessions", Value: input.AllSessions})
+	refreshToken, err := s.refreshService.FindActiveToken(ctx, refresh.FindActiveTokenInput{
+		Token: input.RefreshToken,
+	})
+	if err != nil {
+		if errors.Is(err, refresh.ErrRefreshTokenNotFound) {
+			logger.Warn("refresh token not found")
+			return LogoutOutput{}, ErrInvalidRefreshToken
+		}
+		logger.Error("failed to find active refresh token", err)
+		return LogoutOutput{}, err
+	}
+
+	// A refresh token can only be revoked from the endpoints of its own role
+	if refreshToken.Role != input.Role {
+		logger.Warn("refresh token role mismatch")
+		return LogoutOutput{}, ErrInvalidRefreshToken
+	}
+
+	if !input.AllSessions {
+		if _, err := s.refreshService.Revoke(ctx, refresh.RevokeInput{Token: input.RefreshToken}); err != nil {
+			if errors.Is(err, refresh.ErrRefreshTokenNotFound) {
+				logger.Warn("refresh token not found")
+				return LogoutOutput{}, ErrInvalidRefreshToken
+			}
+			logger.Error("failed to revoke refresh token", err)
+			return LogoutOutput{}, err
+		}
+		return LogoutOutput{RevokedTokens: 1}, nil
+	}
+
+	revokeOutput, err := s.refreshService.RevokeAll(ctx, refresh.RevokeAllInput{
+		UserID:   refreshToken.UserID,
+		Role:     refreshToken.Role,
+		TenantID: refreshToken.TenantID,
+	})
+	if err != nil {
+		logger.Error("failed to revoke refresh tokens", err)
+		return LogoutOutput{}, err
+	}
+	return LogoutOutput{RevokedTokens: revokeOutput.RevokedTokens}, nil
+}
+
+// RevokeSessionsInput defines the input structure required for revoking all the sessions of a user, without needing
+// any of its tokens. TenantID is empty for the non-tenant users, such as the customers.
+type RevokeSessionsInput struct {
+	UserID   string
+	Role     string
+	TenantID string
+	// AllTenants revokes the sessions in every tenant of the user, such as all the restaurants of a staff user.
+	AllTenants bool
+	// KeepCurrentSession keeps active the session of the device performing the request.
+	KeepCurrentSession bool
+}
+
+// RevokeSessionsOutput represents the result of a sessions revocation.
+type RevokeSessionsOutput struct {
+	RevokedTokens int64
+}
+
+func (s service) RevokeSessions(ctx context.Context, input RevokeSessionsInput) (RevokeSessionsOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info(
+		"revoking sessions",
+		log.Field{Key: "user_id", Value: input.UserID},
+		log.Field{Key: "keep_current_session", Value: input.KeepCurrentSession},
+	)
+	revokeInput := refresh.RevokeAllInput{
+		UserID:     input.UserID,
+		Role:       input.Role,
+		TenantID:   input.TenantID,
+		AllTenants: input.AllTenants,
+	}
+	if input.KeepCurrentSession {
+		revokeInput.ExceptDeviceID = refresh.DeviceIDFromContext(ctx)
+	}
+
+	revokeOutput, err := s.refreshService.RevokeAll(ctx, revokeInput)
+	if err != nil {
+		logger.Error("failed to revoke refresh tokens", err)
+		return RevokeSessionsOutput{}, err
+	}
+	return RevokeSessionsOutput{RevokedTokens: revokeOutput.RevokedTokens}, nil
+}
+
+// GenerateImpersonationTokenInput defines the input structure required for generating an access token on behalf of
+// a user. ActorID is the platform admin impersonating the user.
+type GenerateImpersonationTokenInput struct {
+	UserID      string
+	Role        string
+	TenantID    string
+	Owner       bool
+	Permissions []string
+	ActorID     string
+}
+
+// ImpersonationToken represents a short-lived access token issued on behalf of a user. It never comes with a refresh
+// token, so the impersonation ends once it expires.
+type ImpersonationToken struct {
+	AccessToken string
+	ExpiresIn   int // Number of seconds until the token expires
+	TokenType   string
+}
+
+func (s service) GenerateImpersonationToken(
+	ctx context.Context,
+	input GenerateImpersonationTokenInput,
+) (ImpersonationToken, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info(
+		"generating impersonation token",
+		log.Field{Key: "user_id", Value: input.UserID},
+		log.Field{Key: "actor_id", Value: input.ActorID},
+	)
+	generateOutput, err := s.authService.GenerateToken(ctx, auth.GenerateTokenInput{
+		ID:          input.UserID,
+		Expiration:  ImpersonationTokenExpiration,
+		Role:        input.Role,
+		TenantID:    input.TenantID,
+		Owner:       input.Owner,
+		Permissions: input.Permissions,
+		Actor:       input.ActorID,
+	})
+	if err != nil {
+		logger.Error("failed to generate JWT", err)
+		return ImpersonationToken{}, err
+	}
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeImpersonation,
+		Outcome:  authevents.OutcomeSuccess,
+		Subject:  input.UserID,
+		Role:     input.Role,
+		TenantID: input.TenantID,
+		Actor:    input.ActorID,
+	})
+	return ImpersonationToken{
+		AccessToken: generateOutput.AccessToken,
+		ExpiresIn:   ImpersonationTokenExpiration,
+		TokenType:   auth.DefaultTokenType,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +16,8 @@ import (
 	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
+	autheventsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents/mocks"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
 	refreshmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh/mocks"
 )
@@ -25,6 +28,7 @@ type authCoreTestsCase[I, W any] struct {
 	mocksSetup func(
 		authService *authmocks.MockService,
 		refreshService *refreshmocks.MockService,
+		authEventsService *autheventsmocks.MockService,
 	)
 	want    W
 	wantErr error
@@ -44,7 +48,11 @@ func TestService_GenerateTokenPair(t *testing.T) {
 				Role:       "fake-role",
 				TenantID:   "fake-tenant-id",
 			},
-			mocksSetup: func(authService *authmocks.MockService, _ *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				_ *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
 				authService.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).
 					Return(auth.GenerateTokenOutput{}, errUnexpected)
 			},
@@ -59,7 +67,11 @@ func TestService_GenerateTokenPair(t *testing.T) {
 				Role:       "fake-role",
 				TenantID:   "fake-tenant-id",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
 				authService.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).
 					Return(auth.GenerateTokenOutput{
 						AccessToken: "fake-access-token",
@@ -72,14 +84,18 @@ func TestService_GenerateTokenPair(t *testing.T) {
 			wantErr: errUnexpected,
 		},
 		{
-			name: "when the token is generated correctly, then it returns the token pair",
+			name: "when the token is generated correctly, then it links the access token and returns the token pair",
 			input: authcore.GenerateTokenPairInput{
 				UserID:     "fake-id",
 				Expiration: 3600,
 				Role:       "fake-role",
 				TenantID:   "fake-tenant-id",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
 				authService.EXPECT().GenerateToken(gomock.Any(), auth.GenerateTokenInput{
 					ID:         "fake-id",
 					Expiration: 3600,
@@ -87,12 +103,18 @@ func TestService_GenerateTokenPair(t *testing.T) {
 					TenantID:   "fake-tenant-id",
 				}).Return(auth.GenerateTokenOutput{
 					AccessToken: "fake-access-token",
+					TokenID:     "fake-access-token-id",
+					ExpiresAt:   time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
 				}, nil)
 
 				refreshService.EXPECT().Generate(gomock.Any(), refresh.GenerateTokenInput{
 					UserID:   "fake-id",
 					Role:     "fake-role",
 					TenantID: "fake-tenant-id",
+					AccessToken: refresh.AccessToken{
+						ID:        "fake-access-token-id",
+						ExpiresAt: time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
+					},
 				}).Return(refresh.GenerateTokenOutput{
 					Token: "fake-refresh-token",
 				}, nil)
@@ -120,6 +142,84 @@ func TestService_GenerateTokenPair(t *testing.T) {
 	}
 }
 
+func TestService_GenerateImpersonationToken(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := authcore.GenerateImpersonationTokenInput{
+		UserID:      "fake-id",
+		Role:        "fake-role",
+		TenantID:    "fake-tenant-id",
+		Permissions: []string{auth.PermissionMenuRead},
+		ActorID:     "fake-admin-id",
+	}
+
+	tests := []authCoreTestsCase[authcore.GenerateImpersonationTokenInput, authcore.ImpersonationToken]{
+		{
+			name:  "when there is an error generating the token, then it propagates the error",
+			input: input,
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				_ *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				authService.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).
+					Return(auth.GenerateTokenOutput{}, errUnexpected)
+			},
+			want:    authcore.ImpersonationToken{},
+			wantErr: errUnexpected,
+		},
+		{
+			name: "when the token is generated correctly, " +
+				"then it returns a short-lived access token naming the actor without refresh token",
+			input: input,
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				_ *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
+				authService.EXPECT().GenerateToken(gomock.Any(), auth.GenerateTokenInput{
+					ID:          "fake-id",
+					Expiration:  authcore.ImpersonationTokenExpiration,
+					Role:        "fake-role",
+					TenantID:    "fake-tenant-id",
+					Permissions: []string{auth.PermissionMenuRead},
+					Actor:       "fake-admin-id",
+				}).Return(auth.GenerateTokenOutput{
+					AccessToken: "fake-access-token",
+					TokenID:     "fake-access-token-id",
+				}, nil)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:     authevents.EventTypeImpersonation,
+					Outcome:  authevents.OutcomeSuccess,
+					Subject:  "fake-id",
+					Role:     "fake-role",
+					TenantID: "fake-tenant-id",
+					Actor:    "fake-admin-id",
+				})
+			},
+			want: authcore.ImpersonationToken{
+				AccessToken: "fake-access-token",
+				ExpiresIn:   authcore.ImpersonationTokenExpiration,
+				TokenType:   "Bearer",
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.GenerateImpersonationToken(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestService_RefreshToken(t *testing.T) {
 	logger, _ := log.NewTest()
 
@@ -129,20 +229,87 @@ func TestService_RefreshToken(t *testing.T) {
 			input: authcore.RefreshTokenInput{
 				RefreshToken: "InvalidRefreshToken",
 			},
-			mocksSetup: func(_ *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
+					Return(refresh.FindActiveTokenOutput{}, refresh.ErrRefreshTokenNotFound)
+				refreshService.EXPECT().DetectReuse(gomock.Any(), refresh.DetectReuseInput{
+					Token: "InvalidRefreshToken",
+				}).Return(refresh.DetectReuseOutput{}, nil)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:    authevents.EventTypeRefresh,
+					Outcome: authevents.OutcomeFailure,
+					Reason:  authevents.ReasonInvalidRefreshToken,
+				})
+			},
+			want:    authcore.TokenPair{},
+			wantErr: authcore.ErrInvalidRefreshToken,
+		},
+		{
+			name: "when a rotated refresh token is reused, then it returns an invalid refresh token error",
+			input: authcore.RefreshTokenInput{
+				RefreshToken: "RotatedRefreshToken",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{}, refresh.ErrRefreshTokenNotFound)
+				refreshService.EXPECT().DetectReuse(gomock.Any(), refresh.DetectReuseInput{
+					Token: "RotatedRefreshToken",
+				}).Return(refresh.DetectReuseOutput{
+					Reused:        true,
+					UserID:        "fake-user-id",
+					FamilyID:      "fake-family-id",
+					RevokedTokens: 1,
+				}, nil)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:    authevents.EventTypeRefresh,
+					Outcome: authevents.OutcomeFailure,
+					Reason:  authevents.ReasonRefreshTokenReused,
+					Subject: "fake-user-id",
+				})
 			},
 			want:    authcore.TokenPair{},
 			wantErr: authcore.ErrInvalidRefreshToken,
 		},
+		{
+			name: "when there is an unexpected error when detecting the refresh token reuse, " +
+				"then it should propagate the error",
+			input: authcore.RefreshTokenInput{
+				RefreshToken: "RotatedRefreshToken",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
+					Return(refresh.FindActiveTokenOutput{}, refresh.ErrRefreshTokenNotFound)
+				refreshService.EXPECT().DetectReuse(gomock.Any(), gomock.Any()).
+					Return(refresh.DetectReuseOutput{}, errUnexpected)
+			},
+			want:    authcore.TokenPair{},
+			wantErr: errUnexpected,
+		},
 		{
 			name: "when there is an unexpected error when finding the refresh token, " +
 				"then it should propagate the error",
 			input: authcore.RefreshTokenInput{
 				RefreshToken: "ValidRefreshToken",
 			},
-			mocksSetup: func(_ *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{}, errUnexpected)
 			},
@@ -155,7 +322,11 @@ func TestService_RefreshToken(t *testing.T) {
 				AccessToken:  "InvalidAccessToken",
 				RefreshToken: "ValidRefreshToken",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{
 						ID:       "fake-id",
@@ -168,6 +339,14 @@ func TestService_RefreshToken(t *testing.T) {
 
 				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
 					Return(auth.GetClaimsOutput{}, auth.ErrInvalidToken)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:     authevents.EventTypeRefresh,
+					Outcome:  authevents.OutcomeFailure,
+					Reason:   authevents.ReasonTokenMismatch,
+					Subject:  "fake-user-id",
+					TenantID: "fake-tenant-id",
+				})
 			},
 			want:    authcore.TokenPair{},
 			wantErr: authcore.ErrTokenMismatch,
@@ -179,7 +358,11 @@ func TestService_RefreshToken(t *testing.T) {
 				AccessToken:  "ValidAccessToken",
 				RefreshToken: "ValidRefreshToken",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{
 						ID:       "fake-id",
@@ -203,7 +386,11 @@ func TestService_RefreshToken(t *testing.T) {
 				AccessToken:  "ValidAccessToken",
 				RefreshToken: "ValidRefreshToken",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{
 						ID:       "fake-id",
@@ -224,6 +411,14 @@ func TestService_RefreshToken(t *testing.T) {
 							Tenant: "fake-tenant-id",
 						},
 					}, nil)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:     authevents.EventTypeRefresh,
+					Outcome:  authevents.OutcomeFailure,
+					Reason:   authevents.ReasonTokenMismatch,
+					Subject:  "fake-user-id",
+					TenantID: "fake-tenant-id",
+				})
 			},
 			want:    authcore.TokenPair{},
 			wantErr: authcore.ErrTokenMismatch,
@@ -235,7 +430,11 @@ func TestService_RefreshToken(t *testing.T) {
 				AccessToken:  "ValidAccessToken",
 				RefreshToken: "ValidRefreshToken",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{
 						ID:       "fake-id",
@@ -256,6 +455,14 @@ func TestService_RefreshToken(t *testing.T) {
 							Tenant: "fake-tenant-id",
 						},
 					}, nil)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:     authevents.EventTypeRefresh,
+					Outcome:  authevents.OutcomeFailure,
+					Reason:   authevents.ReasonTokenMismatch,
+					Subject:  "fake-user-id",
+					TenantID: "fake-tenant-id",
+				})
 			},
 			want:    authcore.TokenPair{},
 			wantErr: authcore.ErrTokenMismatch,
@@ -267,7 +474,11 @@ func TestService_RefreshToken(t *testing.T) {
 				AccessToken:  "ValidAccessToken",
 				RefreshToken: "ValidRefreshToken",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{
 						ID:       "fake-id",
@@ -288,6 +499,14 @@ func TestService_RefreshToken(t *testing.T) {
 							Tenant: "",
 						},
 					}, nil)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:     authevents.EventTypeRefresh,
+					Outcome:  authevents.OutcomeFailure,
+					Reason:   authevents.ReasonTokenMismatch,
+					Subject:  "fake-user-id",
+					TenantID: "fake-tenant-id",
+				})
 			},
 			want:    authcore.TokenPair{},
 			wantErr: authcore.ErrTokenMismatch,
@@ -298,7 +517,11 @@ func TestService_RefreshToken(t *testing.T) {
 				AccessToken:  "ValidAccessToken",
 				RefreshToken: "ValidRefreshToken",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{
 						ID:       "fake-id",
@@ -332,7 +555,11 @@ func TestService_RefreshToken(t *testing.T) {
 				AccessToken:  "ValidAccessToken",
 				RefreshToken: "ValidRefreshToken",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{
 						ID:       "fake-id",
@@ -371,7 +598,11 @@ func TestService_RefreshToken(t *testing.T) {
 				AccessToken:  "ValidAccessToken",
 				RefreshToken: "ValidRefreshToken",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{
 						ID:       "fake-id",
@@ -417,7 +648,11 @@ func TestService_RefreshToken(t *testing.T) {
 				Expiration:   3600,
 				Role:         "ValidRole",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
 					Return(refresh.FindActiveTokenOutput{
 						ID:       "fake-id",
@@ -451,6 +686,14 @@ func TestService_RefreshToken(t *testing.T) {
 
 				refreshService.EXPECT().Expire(gomock.Any(), gomock.Any()).
 					Return(refresh.ExpireOutput{}, refresh.ErrRefreshTokenNotFound)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:     authevents.EventTypeRefresh,
+					Outcome:  authevents.OutcomeSuccess,
+					Subject:  "fake-user-id",
+					Role:     "ValidRole",
+					TenantID: "fake-tenant-id",
+				})
 			},
 			want: authcore.TokenPair{
 				AccessToken:  "fake-access-token",
@@ -468,7 +711,11 @@ func TestService_RefreshToken(t *testing.T) {
 				Expiration:   3600,
 				Role:         "ValidRole",
 			},
-			mocksSetup: func(authService *authmocks.MockService, refreshService *refreshmocks.MockService) {
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
 				refreshService.EXPECT().FindActiveToken(gomock.Any(), refresh.FindActiveTokenInput{
 					Token: "ValidRefreshToken",
 				}).Return(refresh.FindActiveTokenOutput{
@@ -512,6 +759,185 @@ func TestService_RefreshToken(t *testing.T) {
 				refreshService.EXPECT().Expire(gomock.Any(), refresh.ExpireInput{
 					Token: "ValidRefreshToken",
 				}).Return(refresh.ExpireOutput{}, nil)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:     authevents.EventTypeRefresh,
+					Outcome:  authevents.OutcomeSuccess,
+					Subject:  "fake-user-id",
+					Role:     "ValidRole",
+					TenantID: "fake-tenant-id",
+				})
+			},
+			want: authcore.TokenPair{
+				AccessToken:  "fake-access-token",
+				RefreshToken: "fake-refresh-token",
+				ExpiresIn:    3600,
+				TokenType:    "Bearer",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the access token belongs to an owner, then it should keep the owner flag and the permissions",
+			input: authcore.RefreshTokenInput{
+				AccessToken:  "ValidAccessToken",
+				RefreshToken: "ValidRefreshToken",
+				Expiration:   3600,
+				Role:         "ValidRole",
+			},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), refresh.FindActiveTokenInput{
+					Token: "ValidRefreshToken",
+				}).Return(refresh.FindActiveTokenOutput{
+					ID:       "fake-id",
+					Token:    "ValidRefreshToken",
+					UserID:   "fake-user-id",
+					Role:     "fake-role",
+					TenantID: "fake-tenant-id",
+					FamilyID: "fake-family-id",
+					Device:   refresh.DeviceInfo{}, // device info is irrelevant here
+				}, nil)
+
+				authService.EXPECT().GetClaims(gomock.Any(), auth.GetClaimsInput{
+					AccessToken: "ValidAccessToken",
+				}).Return(auth.GetClaimsOutput{
+					Claims: &auth.Claims{
+						RegisteredClaims: jwt.RegisteredClaims{
+							Subject: "fake-user-id",
+						},
+						Role:        "fake-role",
+						Tenant:      "fake-tenant-id",
+						Owner:       true,
+						Permissions: []string{auth.PermissionMenuWrite},
+					},
+				}, nil)
+
+				authService.EXPECT().GenerateToken(gomock.Any(), auth.GenerateTokenInput{
+					ID:          "fake-user-id",
+					Expiration:  3600,
+					Role:        "ValidRole",
+					TenantID:    "fake-tenant-id",
+					Owner:       true,
+					Permissions: []string{auth.PermissionMenuWrite},
+				}).Return(auth.GenerateTokenOutput{
+					AccessToken: "fake-access-token",
+				}, nil)
+
+				refreshService.EXPECT().Generate(gomock.Any(), refresh.GenerateTokenInput{
+					UserID:   "fake-user-id",
+					Role:     "ValidRole",
+					TenantID: "fake-tenant-id",
+					FamilyID: "fake-family-id",
+				}).Return(refresh.GenerateTokenOutput{
+					Token: "fake-refresh-token",
+				}, nil)
+
+				refreshService.EXPECT().Expire(gomock.Any(), refresh.ExpireInput{
+					Token: "ValidRefreshToken",
+				}).Return(refresh.ExpireOutput{}, nil)
+
+				authEventsService.EXPECT().Record(gomock.Any(), authevents.RecordInput{
+					Type:     authevents.EventTypeRefresh,
+					Outcome:  authevents.OutcomeSuccess,
+					Subject:  "fake-user-id",
+					Role:     "ValidRole",
+					TenantID: "fake-tenant-id",
+				})
+			},
+			want: authcore.TokenPair{
+				AccessToken:  "fake-access-token",
+				RefreshToken: "fake-refresh-token",
+				ExpiresIn:    3600,
+				TokenType:    "Bearer",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the user can't access the tenant anymore, " +
+				"then it should propagate the error of the authorization",
+			input: authcore.RefreshTokenInput{
+				AccessToken:  "ValidAccessToken",
+				RefreshToken: "ValidRefreshToken",
+				Expiration:   3600,
+				Role:         "ValidRole",
+				AuthorizeTenant: func(_ context.Context, _, _ string) (authcore.TenantAccess, error) {
+					return authcore.TenantAccess{}, authcore.ErrInvalidRefreshToken
+				},
+			},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
+					Return(refresh.FindActiveTokenOutput{
+						UserID:   "fake-user-id",
+						Role:     "fake-role",
+						TenantID: "fake-tenant-id",
+					}, nil)
+				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).Return(auth.GetClaimsOutput{
+					Claims: &auth.Claims{
+						RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-user-id"},
+						Role:             "fake-role",
+						Tenant:           "fake-tenant-id",
+					},
+				}, nil)
+			},
+			want:    authcore.TokenPair{},
+			wantErr: authcore.ErrInvalidRefreshToken,
+		},
+		{
+			name: "when the tenant access is authorized, " +
+				"then it should refresh the token with the current owner flag and permissions",
+			input: authcore.RefreshTokenInput{
+				AccessToken:  "ValidAccessToken",
+				RefreshToken: "ValidRefreshToken",
+				Expiration:   3600,
+				Role:         "ValidRole",
+				AuthorizeTenant: func(_ context.Context, userID, tenantID string) (authcore.TenantAccess, error) {
+					if userID != "fake-user-id" || tenantID != "fake-tenant-id" {
+						return authcore.TenantAccess{}, authcore.ErrInvalidRefreshToken
+					}
+					return authcore.TenantAccess{Permissions: []string{auth.PermissionMenuRead}}, nil
+				},
+			},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				authEventsService *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
+					Return(refresh.FindActiveTokenOutput{
+						UserID:   "fake-user-id",
+						Role:     "fake-role",
+						TenantID: "fake-tenant-id",
+						FamilyID: "fake-family-id",
+					}, nil)
+				// The access token still carries the owner flag and the permissions before the change
+				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).Return(auth.GetClaimsOutput{
+					Claims: &auth.Claims{
+						RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-user-id"},
+						Role:             "fake-role",
+						Tenant:           "fake-tenant-id",
+						Owner:            true,
+						Permissions:      []string{auth.PermissionMenuWrite},
+					},
+				}, nil)
+				authService.EXPECT().GenerateToken(gomock.Any(), auth.GenerateTokenInput{
+					ID:          "fake-user-id",
+					Expiration:  3600,
+					Role:        "ValidRole",
+					TenantID:    "fake-tenant-id",
+					Owner:       false,
+					Permissions: []string{auth.PermissionMenuRead},
+				}).Return(auth.GenerateTokenOutput{AccessToken: "fake-access-token"}, nil)
+				refreshService.EXPECT().Generate(gomock.Any(), gomock.Any()).
+					Return(refresh.GenerateTokenOutput{Token: "fake-refresh-token"}, nil)
+				refreshService.EXPECT().Expire(gomock.Any(), gomock.Any()).Return(refresh.ExpireOutput{}, nil)
+				authEventsService.EXPECT().Record(gomock.Any(), gomock.Any())
 			},
 			want: authcore.TokenPair{
 				AccessToken:  "fake-access-token",
@@ -536,22 +962,305 @@ func TestService_RefreshToken(t *testing.T) {
 	}
 }
 
+func TestService_Logout(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	activeToken := refresh.FindActiveTokenOutput{
+		ID:       "fake-id",
+		Token:    "ValidRefreshToken",
+		UserID:   "fake-user-id",
+		Role:     "ValidRole",
+		TenantID: "fake-tenant-id",
+	}
+
+	tests := []authCoreTestsCase[authcore.LogoutInput, authcore.LogoutOutput]{
+		{
+			name: "when the refresh token is not found, then it returns an invalid refresh token error",
+			input: authcore.LogoutInput{
+				RefreshToken: "InvalidRefreshToken",
+				Role:         "ValidRole",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
+					Return(refresh.FindActiveTokenOutput{}, refresh.ErrRefreshTokenNotFound)
+			},
+			want:    authcore.LogoutOutput{},
+			wantErr: authcore.ErrInvalidRefreshToken,
+		},
+		{
+			name: "when there is an unexpected error when finding the refresh token, " +
+				"then it should propagate the error",
+			input: authcore.LogoutInput{
+				RefreshToken: "ValidRefreshToken",
+				Role:         "ValidRole",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
+					Return(refresh.FindActiveTokenOutput{}, errUnexpected)
+			},
+			want:    authcore.LogoutOutput{},
+			wantErr: errUnexpected,
+		},
+		{
+			name: "when the refresh token belongs to another role, then it returns an invalid refresh token error",
+			input: authcore.LogoutInput{
+				RefreshToken: "ValidRefreshToken",
+				Role:         "AnotherRole",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).Return(activeToken, nil)
+			},
+			want:    authcore.LogoutOutput{},
+			wantErr: authcore.ErrInvalidRefreshToken,
+		},
+		{
+			name: "when the refresh token is revoked concurrently, then it returns an invalid refresh token error",
+			input: authcore.LogoutInput{
+				RefreshToken: "ValidRefreshToken",
+				Role:         "ValidRole",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).Return(activeToken, nil)
+				refreshService.EXPECT().Revoke(gomock.Any(), gomock.Any()).
+					Return(refresh.RevokeOutput{}, refresh.ErrRefreshTokenNotFound)
+			},
+			want:    authcore.LogoutOutput{},
+			wantErr: authcore.ErrInvalidRefreshToken,
+		},
+		{
+			name: "when there is an unexpected error when revoking the refresh token, " +
+				"then it should propagate the error",
+			input: authcore.LogoutInput{
+				RefreshToken: "ValidRefreshToken",
+				Role:         "ValidRole",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).Return(activeToken, nil)
+				refreshService.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(refresh.RevokeOutput{}, errUnexpected)
+			},
+			want:    authcore.LogoutOutput{},
+			wantErr: errUnexpected,
+		},
+		{
+			name: "when the refresh token is revoked, then it returns one revoked token",
+			input: authcore.LogoutInput{
+				RefreshToken: "ValidRefreshToken",
+				Role:         "ValidRole",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), refresh.FindActiveTokenInput{
+					Token: "ValidRefreshToken",
+				}).Return(activeToken, nil)
+				refreshService.EXPECT().Revoke(gomock.Any(), refresh.RevokeInput{
+					Token: "ValidRefreshToken",
+				}).Return(refresh.RevokeOutput{ID: "fake-id"}, nil)
+			},
+			want:    authcore.LogoutOutput{RevokedTokens: 1},
+			wantErr: nil,
+		},
+		{
+			name: "when there is an unexpected error when revoking all the sessions, " +
+				"then it should propagate the error",
+			input: authcore.LogoutInput{
+				RefreshToken: "ValidRefreshToken",
+				Role:         "ValidRole",
+				AllSessions:  true,
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).Return(activeToken, nil)
+				refreshService.EXPECT().RevokeAll(gomock.Any(), gomock.Any()).
+					Return(refresh.RevokeAllOutput{}, errUnexpected)
+			},
+			want:    authcore.LogoutOutput{},
+			wantErr: errUnexpected,
+		},
+		{
+			name: "when all the sessions are revoked, then it returns the number of revoked tokens",
+			input: authcore.LogoutInput{
+				RefreshToken: "ValidRefreshToken",
+				Role:         "ValidRole",
+				AllSessions:  true,
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).Return(activeToken, nil)
+				refreshService.EXPECT().RevokeAll(gomock.Any(), refresh.RevokeAllInput{
+					UserID:   "fake-user-id",
+					Role:     "ValidRole",
+					TenantID: "fake-tenant-id",
+				}).Return(refresh.RevokeAllOutput{RevokedTokens: 3}, nil)
+			},
+			want:    authcore.LogoutOutput{RevokedTokens: 3},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.Logout(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RevokeSessions(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []authCoreTestsCase[authcore.RevokeSessionsInput, authcore.RevokeSessionsOutput]{
+		{
+			name: "when there is an unexpected error when revoking the refresh tokens, then it should propagate the error",
+			input: authcore.RevokeSessionsInput{
+				UserID:   "fake-user-id",
+				Role:     "ValidRole",
+				TenantID: "fake-tenant-id",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().RevokeAll(gomock.Any(), gomock.Any()).
+					Return(refresh.RevokeAllOutput{}, errUnexpected)
+			},
+			want:    authcore.RevokeSessionsOutput{},
+			wantErr: errUnexpected,
+		},
+		{
+			name: "when the sessions are revoked, then it returns the number of revoked tokens",
+			input: authcore.RevokeSessionsInput{
+				UserID:   "fake-user-id",
+				Role:     "ValidRole",
+				TenantID: "fake-tenant-id",
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().RevokeAll(gomock.Any(), refresh.RevokeAllInput{
+					UserID:   "fake-user-id",
+					Role:     "ValidRole",
+					TenantID: "fake-tenant-id",
+				}).Return(refresh.RevokeAllOutput{RevokedTokens: 2}, nil)
+			},
+			want:    authcore.RevokeSessionsOutput{RevokedTokens: 2},
+			wantErr: nil,
+		},
+		{
+			name: "when the current session must be kept, then it revokes the sessions of the other devices",
+			input: authcore.RevokeSessionsInput{
+				UserID:             "fake-user-id",
+				Role:               "ValidRole",
+				TenantID:           "fake-tenant-id",
+				KeepCurrentSession: true,
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().RevokeAll(gomock.Any(), refresh.RevokeAllInput{
+					UserID:         "fake-user-id",
+					Role:           "ValidRole",
+					TenantID:       "fake-tenant-id",
+					ExceptDeviceID: refresh.DeviceIDFromContext(context.Background()),
+				}).Return(refresh.RevokeAllOutput{RevokedTokens: 1}, nil)
+			},
+			want:    authcore.RevokeSessionsOutput{RevokedTokens: 1},
+			wantErr: nil,
+		},
+		{
+			name: "when all the tenants are requested, then it revokes the sessions of the user in every tenant",
+			input: authcore.RevokeSessionsInput{
+				UserID:             "fake-user-id",
+				Role:               "ValidRole",
+				AllTenants:         true,
+				KeepCurrentSession: true,
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *autheventsmocks.MockService,
+			) {
+				refreshService.EXPECT().RevokeAll(gomock.Any(), refresh.RevokeAllInput{
+					UserID:         "fake-user-id",
+					Role:           "ValidRole",
+					AllTenants:     true,
+					ExceptDeviceID: refresh.DeviceIDFromContext(context.Background()),
+				}).Return(refresh.RevokeAllOutput{RevokedTokens: 3}, nil)
+			},
+			want:    authcore.RevokeSessionsOutput{RevokedTokens: 3},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.RevokeSessions(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func serviceSetup(
 	t *testing.T, logger log.Logger, mocksSetup func(
 		authService *authmocks.MockService,
 		refreshService *refreshmocks.MockService,
+		authEventsService *autheventsmocks.MockService,
 	),
 ) (authcore.Service, func()) {
 	ctrl := gomock.NewController(t)
 
 	authService := authmocks.NewMockService(ctrl)
 	refreshService := refreshmocks.NewMockService(ctrl)
+	authEventsService := autheventsmocks.NewMockService(ctrl)
 
 	if mocksSetup != nil {
-		mocksSetup(authService, refreshService)
+		mocksSetup(authService, refreshService, authEventsService)
 	}
 
-	service := authcore.NewService(logger, authService, refreshService)
+	service := authcore.NewService(logger, authService, refreshService, authEventsService)
 	return service, func() {
 		ctrl.Finish()
 	}
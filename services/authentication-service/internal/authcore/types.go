@@ -7,3 +7,20 @@ type TokenPairResponse struct {
 	ExpiresIn    int    `json:"expires_in"` // the number of seconds until the token expires
 	TokenType    string `json:"token_type"`
 }
+
+// ImpersonationTokenResponse represents the structure for holding an impersonation access token along with metadata.
+// It has no refresh token, as the impersonation can't be extended.
+type ImpersonationTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"` // the number of seconds until the token expires
+	TokenType   string `json:"token_type"`
+}
+
+// CookieTokenPairResponse represents the token pair returned to the browser clients in cookie mode. The refresh token
+// is set in an HttpOnly cookie instead, so it is replaced by the CSRF token the client must echo to refresh the tokens.
+type CookieTokenPairResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"` // the number of seconds until the token expires
+	TokenType   string `json:"token_type"`
+	CSRFToken   string `json:"csrf_token"`
+}
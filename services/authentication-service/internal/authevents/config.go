@@ -0,0 +1,27 @@
+package authevents
+
+import (
+	"time"
+
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for the authentication audit log.
+type Config struct {
+	// Retention is how long the events are kept before MongoDB removes them. Changing it only affects the events
+	// recorded afterward.
+	Retention time.Duration `env:"AUTH_EVENTS_RETENTION" envDefault:"2160h"`
+}
+
+// LoadConfig loads the authentication audit log configuration from environment variables and logs any errors
+// encountered during parsing. It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load authentication events configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,130 @@
+package authevents
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Handler manages HTTP requests for authentication audit log operations.
+type Handler struct {
+	logger         log.Logger
+	service        Service
+	authMiddleware auth.Middleware
+}
+
+// NewHandler creates a new instance of Handler.
+func NewHandler(logger log.Logger, service Service, authMiddleware auth.Middleware) *Handler {
+	return &Handler{
+		logger:         logger,
+		service:        service,
+		authMiddleware: authMiddleware,
+	}
+}
+
+// RegisterRoutes registers the authentication audit log HTTP routes, which are only available to platform admins.
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	adminRouter := router.Group("/v1.0/admin/auth-events", h.authMiddleware.RequirePlatformAdmin())
+	{
+		adminRouter.GET("", h.ListEvents)
+	}
+}
+
+// ListEventsRequest represents the query parameters to filter and paginate the authentication events. The time range
+// includes From but excludes To, both in RFC 3339 format.
+type ListEventsRequest struct {
+	Subject  string    `form:"subject"`
+	Type     string    `form:"type" binding:"omitempty,oneof=login refresh registration tenant_switch impersonation"`
+	From     time.Time `form:"from"`
+	To       time.Time `form:"to" binding:"omitempty,gtfield=From"`
+	Page     int       `form:"page" binding:"omitempty,gte=1"`
+	PageSize int       `form:"page_size" binding:"omitempty,gte=1,lte=100"`
+}
+
+// EventResponse represents a single authentication event in the API responses.
+type EventResponse struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Outcome   string    `json:"outcome"`
+	Reason    string    `json:"reason,omitempty"`
+	Subject   string    `json:"subject,omitempty"`
+	Role      string    `json:"role"`
+	TenantID  string    `json:"tenant_id,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PaginationResponse represents the pagination details of a listing.
+type PaginationResponse struct {
+	TotalItems  int64 `json:"total_items"`
+	TotalPages  int64 `json:"total_pages"`
+	CurrentPage int   `json:"current_page"`
+	PageSize    int   `json:"page_size"`
+}
+
+// ListEventsResponse represents the response payload containing a page of authentication events.
+type ListEventsResponse struct {
+	Events     []EventResponse    `json:"events"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// ListEvents handles the listing of the authentication events, sorted by the most recent first.
+func (h *Handler) ListEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ListEvents handler called")
+
+	var req ListEventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	output, err := h.service.ListEvents(ctx, ListEventsInput(req))
+	if err != nil {
+		logger.Error("Failed to list authentication events", err)
+		c.JSON(http.StatusInternalServerError, customhttp.NewErrorResponse(
+			customhttp.CodeInternalError,
+			customhttp.MsgInternalError,
+		))
+		return
+	}
+
+	resp := ListEventsResponse{
+		Events: make([]EventResponse, 0, len(output.Events)),
+		Pagination: PaginationResponse{
+			TotalItems:  output.TotalItems,
+			TotalPages:  (output.TotalItems + int64(output.PageSize) - 1) / int64(output.PageSize),
+			CurrentPage: output.Page,
+			PageSize:    output.PageSize,
+		},
+	}
+	for _, event := range output.Events {
+		resp.Events = append(resp.Events, EventResponse{
+			ID:        event.ID,
+			Type:      event.Type,
+			Outcome:   event.Outcome,
+			Reason:    event.Reason,
+			Subject:   event.Subject,
+			Role:      event.Role,
+			TenantID:  event.TenantID,
+			Actor:     event.Actor,
+			IP:        event.IP,
+			UserAgent: event.UserAgent,
+			RequestID: event.RequestID,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,240 @@
+//go:build unit
+
+package authevents_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
+	autheventsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents/mocks"
+)
+
+const listEventsRoute = "/v1.0/admin/auth-events"
+
+type authEventsHandlerTestCase struct {
+	name        string
+	token       string
+	queryParams map[string]string
+	mocksSetup  func(service *autheventsmocks.MockService, authService *authmocks.MockService)
+	wantJSON    string
+	wantStatus  int
+}
+
+func TestHandler_ListEvents(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []authEventsHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a platform admin, then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *autheventsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "when the type is unknown, then it should return a 400 with the validation error",
+			token:       "valid-token",
+			queryParams: map[string]string{"type": "unknown"},
+			mocksSetup: func(_ *autheventsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("type is invalid").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "when the time range ends before it starts, then it should return a 400 with the validation error",
+			token: "valid-token",
+			queryParams: map[string]string{
+				"from": "2025-01-02T00:00:00Z",
+				"to":   "2025-01-01T00:00:00Z",
+			},
+			mocksSetup: func(_ *autheventsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("to is invalid").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when the page size is too big, then it should return a 400 with the validation error",
+			token:       "valid-token",
+			queryParams: map[string]string{"page_size": "101"},
+			mocksSetup: func(_ *autheventsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("page_size is invalid").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when the time is not in RFC 3339 format, then it should return a 400 with the invalid request error",
+			token:       "valid-token",
+			queryParams: map[string]string{"from": "yesterday"},
+			mocksSetup: func(_ *autheventsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin)
+			},
+			wantJSON:   customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "when unexpected error when listing the events, then it should return a 500 with the internal error",
+			token: "valid-token",
+			mocksSetup: func(service *autheventsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin)
+				service.EXPECT().ListEvents(gomock.Any(), gomock.Any()).
+					Return(authevents.ListEventsOutput{}, errRepo)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "when no event matches the filters, then it should return a 200 with an empty list",
+			token: "valid-token",
+			mocksSetup: func(service *autheventsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin)
+				service.EXPECT().ListEvents(gomock.Any(), authevents.ListEventsInput{}).
+					Return(authevents.ListEventsOutput{Page: 1, PageSize: authevents.DefaultPageSize}, nil)
+			},
+			wantJSON: `{
+				"events": [],
+				"pagination": {
+					"total_items": 0,
+					"total_pages": 0,
+					"current_page": 1,
+					"page_size": 20
+				}
+			}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "when the events are filtered, then it should return a 200 with the page of events",
+			token: "valid-token",
+			queryParams: map[string]string{
+				"subject":   "fake-user-id",
+				"type":      "login",
+				"from":      "2024-12-31T00:00:00Z",
+				"to":        "2025-01-01T00:00:00Z",
+				"page":      "2",
+				"page_size": "1",
+			},
+			mocksSetup: func(service *autheventsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin)
+				service.EXPECT().ListEvents(gomock.Any(), authevents.ListEventsInput{
+					Subject:  "fake-user-id",
+					Type:     authevents.EventTypeLogin,
+					From:     now.Add(-24 * time.Hour),
+					To:       now,
+					Page:     2,
+					PageSize: 1,
+				}).Return(authevents.ListEventsOutput{
+					Events: []authevents.Event{
+						{
+							ID:        "fake-id",
+							Type:      authevents.EventTypeLogin,
+							Outcome:   authevents.OutcomeFailure,
+							Reason:    authevents.ReasonInvalidCredentials,
+							Subject:   "fake-user-id",
+							Role:      "staff",
+							TenantID:  "fake-tenant-id",
+							Actor:     "fake-admin-id",
+							IP:        "192.168.1.1",
+							UserAgent: "fake-user-agent",
+							RequestID: "fake-request-id",
+							CreatedAt: now.Add(-time.Hour),
+							ExpiresAt: now.Add(time.Hour),
+						},
+					},
+					TotalItems: 3,
+					Page:       2,
+					PageSize:   1,
+				}, nil)
+			},
+			wantJSON: `{
+				"events": [
+					{
+						"id": "fake-id",
+						"type": "login",
+						"outcome": "failure",
+						"reason": "invalid_credentials",
+						"subject": "fake-user-id",
+						"role": "staff",
+						"tenant_id": "fake-tenant-id",
+						"actor": "fake-admin-id",
+						"ip": "192.168.1.1",
+						"user_agent": "fake-user-agent",
+						"request_id": "fake-request-id",
+						"created_at": "2024-12-31T23:00:00Z"
+					}
+				],
+				"pagination": {
+					"total_items": 3,
+					"total_pages": 3,
+					"current_page": 2,
+					"page_size": 1
+				}
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runAuthEventsHandlerTestCase(t, logger, http.MethodGet, listEventsRoute, tt)
+		})
+	}
+}
+
+func mockClaims(authService *authmocks.MockService, role auth.Role) {
+	authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+		Return(auth.GetClaimsOutput{
+			Claims: &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-user-id"},
+				Role:             string(role),
+			},
+		}, nil)
+}
+
+// runAuthEventsHandlerTestCase executes a test case for the authentication events handler, which is common for all
+// tests.
+func runAuthEventsHandlerTestCase(
+	t *testing.T,
+	logger log.Logger,
+	httpMethod string,
+	route string,
+	tt authEventsHandlerTestCase,
+) {
+	service := autheventsmocks.NewMockService(gomock.NewController(t))
+	authService := authmocks.NewMockService(gomock.NewController(t))
+	if tt.mocksSetup != nil {
+		tt.mocksSetup(service, authService)
+	}
+
+	// Initialize the authentication middleware
+	authMiddleware := auth.NewMiddleware(logger, authService)
+
+	// Initialize the handler
+	h := authevents.NewHandler(logger, service, authMiddleware)
+
+	// Make HTTP request
+	w := customhttp.ServeTestHTTPRequest(t, h, httpMethod, route, tt.token, tt.queryParams, "")
+
+	assert.Equal(t, tt.wantStatus, w.Code)
+	assert.JSONEq(t, tt.wantJSON, w.Body.String())
+}
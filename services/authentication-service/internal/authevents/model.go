@@ -0,0 +1,21 @@
+package authevents
+
+import "time"
+
+// Event represents a security-relevant authentication event, such as a login or a token refresh.
+// The document is removed by MongoDB once ExpiresAt is reached, according to the configured retention.
+type Event struct {
+	ID        string    `bson:"_id,omitempty"`
+	Type      string    `bson:"type"`
+	Outcome   string    `bson:"outcome"`
+	Reason    string    `bson:"reason,omitempty"`
+	Subject   string    `bson:"subject,omitempty"`
+	Role      string    `bson:"role"`
+	TenantID  string    `bson:"tenant_id,omitempty"`
+	Actor     string    `bson:"actor,omitempty"`
+	IP        string    `bson:"ip,omitempty"`
+	UserAgent string    `bson:"user_agent,omitempty"`
+	RequestID string    `bson:"request_id,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
@@ -0,0 +1,171 @@
+package authevents
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// CollectionName defines the name of the database collection used to store the authentication events.
+	CollectionName = "auth_events"
+
+	// FieldType represents the database field name for storing the type of the event.
+	FieldType = "type"
+	// FieldSubject represents the database field name for storing the ID of the user the event belongs to.
+	FieldSubject = "subject"
+	// FieldCreatedAt represents the database field name for storing when the event happened.
+	FieldCreatedAt = "created_at"
+	// FieldExpiresAt represents the database field name for storing when the event is removed.
+	FieldExpiresAt = "expires_at"
+)
+
+// Repository defines a contract for storing and querying the authentication events in a persistence layer.
+//
+//go:generate mockgen -destination=./mocks/repository_mock.go -package=authevents_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents Repository
+type Repository interface {
+	Create(ctx context.Context, params CreateEventParams) (Event, error)
+	FindEvents(ctx context.Context, params FindEventsParams) ([]Event, error)
+	CountEvents(ctx context.Context, filter EventsFilter) (int64, error)
+}
+
+type repository struct {
+	logger     log.Logger
+	collection *mongo.Collection
+	clock      clock.Clock
+}
+
+// NewRepository creates a new Repository instance.
+func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repository {
+	return &repository{
+		logger:     logger,
+		collection: db.Collection(CollectionName),
+		clock:      clk,
+	}
+}
+
+// CreateEventParams defines the parameters needed to store an authentication event. Retention is how long the event
+// is kept.
+type CreateEventParams struct {
+	Type      string
+	Outcome   string
+	Reason    string
+	Subject   string
+	Role      string
+	TenantID  string
+	Actor     string
+	IP        string
+	UserAgent string
+	RequestID string
+	Retention time.Duration
+}
+
+func (r *repository) Create(ctx context.Context, params CreateEventParams) (Event, error) {
+	logger := r.logger.WithContext(ctx)
+
+	now := r.clock.Now()
+	event := Event{
+		Type:      params.Type,
+		Outcome:   params.Outcome,
+		Reason:    params.Reason,
+		Subject:   params.Subject,
+		Role:      params.Role,
+		TenantID:  params.TenantID,
+		Actor:     params.Actor,
+		IP:        params.IP,
+		UserAgent: params.UserAgent,
+		RequestID: params.RequestID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(params.Retention),
+	}
+
+	res, err := r.collection.InsertOne(ctx, event)
+	if err != nil {
+		logger.Error("Failed to store authentication event", err)
+		return Event{}, err
+	}
+
+	event.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	return event, nil
+}
+
+// EventsFilter defines the criteria the authentication events are filtered by. The empty fields are not filtered, and
+// the time range includes From but excludes To.
+type EventsFilter struct {
+	Subject string
+	Type    string
+	From    time.Time
+	To      time.Time
+}
+
+// FindEventsParams defines the parameters needed to find a page of authentication events.
+type FindEventsParams struct {
+	Filter EventsFilter
+	Skip   int64
+	Limit  int64
+}
+
+// FindEvents returns the events matching the filter, sorted by the most recent first.
+func (r *repository) FindEvents(ctx context.Context, params FindEventsParams) ([]Event, error) {
+	logger := r.logger.WithContext(ctx)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: FieldCreatedAt, Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(params.Skip).
+		SetLimit(params.Limit)
+
+	cursor, err := r.collection.Find(ctx, eventsFilter(params.Filter), opts)
+	if err != nil {
+		logger.Error("Failed to find authentication events", err)
+		return nil, err
+	}
+
+	events := make([]Event, 0)
+	if err := cursor.All(ctx, &events); err != nil {
+		logger.Error("Failed to decode authentication events", err)
+		return nil, err
+	}
+	return events, nil
+}
+
+// CountEvents returns the number of events matching the filter.
+func (r *repository) CountEvents(ctx context.Context, filter EventsFilter) (int64, error) {
+	logger := r.logger.WithContext(ctx)
+
+	count, err := r.collection.CountDocuments(ctx, eventsFilter(filter))
+	if err != nil {
+		logger.Error("Failed to count authentication events", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// eventsFilter builds the query matching the events of the given filter.
+func eventsFilter(filter EventsFilter) bson.M {
+	query := bson.M{}
+	if filter.Subject != "" {
+		query[FieldSubject] = filter.Subject
+	}
+	if filter.Type != "" {
+		query[FieldType] = filter.Type
+	}
+
+	createdAt := bson.M{}
+	if !filter.From.IsZero() {
+		createdAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		createdAt["$lt"] = filter.To
+	}
+	if len(createdAt) > 0 {
+		query[FieldCreatedAt] = createdAt
+	}
+	return query
+}
@@ -0,0 +1,236 @@
+//go:build integration
+
+package authevents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
+)
+
+const testDBPrefix = "authevents_test_authentication_service"
+
+func TestRepository_Create(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	defer tdb.Close(t)
+
+	repo := authevents.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+	got, err := repo.Create(context.Background(), authevents.CreateEventParams{
+		Type:      authevents.EventTypeLogin,
+		Outcome:   authevents.OutcomeSuccess,
+		Subject:   "fake-user-id",
+		Role:      "customer",
+		Actor:     "fake-admin-id",
+		IP:        "192.168.1.1",
+		UserAgent: "fake-user-agent",
+		RequestID: "fake-request-id",
+		Retention: time.Hour,
+	})
+	require.NoError(t, err)
+
+	want := authevents.Event{
+		ID:        got.ID,
+		Type:      authevents.EventTypeLogin,
+		Outcome:   authevents.OutcomeSuccess,
+		Subject:   "fake-user-id",
+		Role:      "customer",
+		Actor:     "fake-admin-id",
+		IP:        "192.168.1.1",
+		UserAgent: "fake-user-agent",
+		RequestID: "fake-request-id",
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	assert.NotEmpty(t, got.ID)
+	assert.Equal(t, want, got)
+
+	id, err := primitive.ObjectIDFromHex(got.ID)
+	require.NoError(t, err)
+
+	var stored authevents.Event
+	err = tdb.DB.Collection(authevents.CollectionName).FindOne(context.Background(), bson.M{"_id": id}).Decode(&stored)
+	require.NoError(t, err)
+	assert.Equal(t, want, stored)
+}
+
+func TestRepository_Create_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := authevents.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.Create(context.Background(), authevents.CreateEventParams{
+		Type:    authevents.EventTypeLogin,
+		Outcome: authevents.OutcomeSuccess,
+		Role:    "customer",
+	})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_FindEvents(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	oldLogin := newTestEvent("fake-user-id", authevents.EventTypeLogin, now.Add(-2*time.Hour))
+	refresh := newTestEvent("fake-user-id", authevents.EventTypeRefresh, now.Add(-time.Hour))
+	otherLogin := newTestEvent("fake-other-user-id", authevents.EventTypeLogin, now.Add(-30*time.Minute))
+	recentLogin := newTestEvent("fake-user-id", authevents.EventTypeLogin, now)
+
+	tests := []struct {
+		name   string
+		params authevents.FindEventsParams
+		want   []authevents.Event
+	}{
+		{
+			name:   "when there are no filters, then it should return all the events, the most recent first",
+			params: authevents.FindEventsParams{Limit: 10},
+			want:   []authevents.Event{recentLogin, otherLogin, refresh, oldLogin},
+		},
+		{
+			name: "when filtering by subject and type, then it should return only the matching events",
+			params: authevents.FindEventsParams{
+				Filter: authevents.EventsFilter{Subject: "fake-user-id", Type: authevents.EventTypeLogin},
+				Limit:  10,
+			},
+			want: []authevents.Event{recentLogin, oldLogin},
+		},
+		{
+			name: "when filtering by time range, then it should include its start and exclude its end",
+			params: authevents.FindEventsParams{
+				Filter: authevents.EventsFilter{From: now.Add(-time.Hour), To: now},
+				Limit:  10,
+			},
+			want: []authevents.Event{otherLogin, refresh},
+		},
+		{
+			name:   "when requesting a page, then it should skip the events of the previous pages",
+			params: authevents.FindEventsParams{Skip: 1, Limit: 2},
+			want:   []authevents.Event{otherLogin, refresh},
+		},
+		{
+			name: "when no event matches the filters, then it should return an empty list",
+			params: authevents.FindEventsParams{
+				Filter: authevents.EventsFilter{Subject: "fake-unknown-user-id"},
+				Limit:  10,
+			},
+			want: []authevents.Event{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := tdb.DB.Collection(authevents.CollectionName)
+			insertTestEvents(t, coll, oldLogin, refresh, otherLogin, recentLogin)
+
+			repo := authevents.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.FindEvents(context.Background(), tt.params)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRepository_FindEvents_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := authevents.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindEvents(context.Background(), authevents.FindEventsParams{Limit: 10})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_CountEvents(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	defer tdb.Close(t)
+
+	coll := tdb.DB.Collection(authevents.CollectionName)
+	insertTestEvents(
+		t, coll,
+		newTestEvent("fake-user-id", authevents.EventTypeLogin, now.Add(-time.Hour)),
+		newTestEvent("fake-user-id", authevents.EventTypeRefresh, now),
+		newTestEvent("fake-other-user-id", authevents.EventTypeLogin, now),
+	)
+
+	repo := authevents.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+	got, err := repo.CountEvents(context.Background(), authevents.EventsFilter{Subject: "fake-user-id"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+}
+
+func TestRepository_CountEvents_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := authevents.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.CountEvents(context.Background(), authevents.EventsFilter{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func newTestEvent(subject, eventType string, createdAt time.Time) authevents.Event {
+	return authevents.Event{
+		ID:        primitive.NewObjectID().Hex(),
+		Type:      eventType,
+		Outcome:   authevents.OutcomeSuccess,
+		Subject:   subject,
+		Role:      "customer",
+		CreatedAt: createdAt,
+		ExpiresAt: createdAt.Add(time.Hour),
+	}
+}
+
+// insertTestEvents stores the events with ObjectIDs, as the repository does, so they are decoded back the same.
+func insertTestEvents(t *testing.T, coll *mongo.Collection, events ...authevents.Event) {
+	t.Helper()
+
+	for _, event := range events {
+		id, err := primitive.ObjectIDFromHex(event.ID)
+		require.NoError(t, err)
+
+		_, err = coll.InsertOne(context.Background(), bson.M{
+			"_id":        id,
+			"type":       event.Type,
+			"outcome":    event.Outcome,
+			"subject":    event.Subject,
+			"role":       event.Role,
+			"created_at": event.CreatedAt,
+			"expires_at": event.ExpiresAt,
+		})
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package authevents provides the authentication audit log. It persists the security-relevant authentication events,
+// such as the logins or the token refreshes, so the platform admins can query them.
+package authevents
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// EventTypeLogin represents a login attempt of any user.
+	EventTypeLogin = "login"
+	// EventTypeRefresh represents an attempt to refresh a token pair.
+	EventTypeRefresh = "refresh"
+	// EventTypeRegistration represents the registration of the credentials of a new user.
+	EventTypeRegistration = "registration"
+	// EventTypeTenantSwitch represents an attempt of a staff user to switch its session to another of its restaurants.
+	EventTypeTenantSwitch = "tenant_switch"
+	// EventTypeImpersonation represents the issue of an impersonation token to a platform admin.
+	EventTypeImpersonation = "impersonation"
+
+	// OutcomeSuccess represents an event that succeeded.
+	OutcomeSuccess = "success"
+	// OutcomeFailure represents an event that failed. The reason of the failure is recorded with it.
+	OutcomeFailure = "failure"
+
+	// ReasonInvalidCredentials represents a login failed due to an unknown user or a wrong password.
+	ReasonInvalidCredentials = "invalid_credentials"
+	// ReasonAccountLocked represents a login rejected because of too many failed attempts.
+	ReasonAccountLocked = "account_locked"
+	// ReasonInvalidRefreshToken represents a refresh with an invalid, expired or already used refresh token.
+	ReasonInvalidRefreshToken = "invalid_refresh_token"
+	// ReasonRefreshTokenReused represents a refresh with an already rotated refresh token, which revokes its family.
+	ReasonRefreshTokenReused = "refresh_token_reused"
+	// ReasonTokenMismatch represents a refresh with an access token that does not belong to the refresh token.
+	ReasonTokenMismatch = "token_mismatch"
+	// ReasonRestaurantNotLinked represents a tenant switch to a restaurant the staff user is not active in.
+	ReasonRestaurantNotLinked = "restaurant_not_linked"
+	// ReasonInvalidLoginToken represents a magic link login with an invalid, expired or already used login token.
+	ReasonInvalidLoginToken = "invalid_login_token"
+
+	// DefaultPageSize defines the number of events returned per page when it is not specified.
+	DefaultPageSize = 20
+)
+
+// Service defines the interface for the authentication audit log service.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=authevents_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents Service
+type Service interface {
+	Record(ctx context.Context, input RecordInput)
+	ListEvents(ctx context.Context, input ListEventsInput) (ListEventsOutput, error)
+}
+
+type service struct {
+	logger log.Logger
+	repo   Repository
+	cfg    Config
+}
+
+// NewService creates a new instance of Service with the provided dependencies.
+func NewService(logger log.Logger, repo Repository, cfg Config) Service {
+	return &service{logger: logger, repo: repo, cfg: cfg}
+}
+
+// RecordInput represents an authentication event to record. Subject is empty when the user is unknown, such as on a
+// login with an unregistered email, and TenantID is empty for the non-tenant users, such as the customers. Actor is
+// the platform admin impersonating the subject, which defaults to the actor of the impersonated request, if any.
+type RecordInput struct {
+	Type     string
+	Outcome  string
+	Reason   string
+	Subject  string
+	Role     string
+	TenantID string
+	Actor    string
+}
+
+// Record stores the event, together with the IP, the user agent and the ID of the request performing it. It is best
+// effort, so a failure is logged but never fails the audited operation.
+func (s *service) Record(ctx context.Context, input RecordInput) {
+	logger := s.logger.WithContext(ctx)
+
+	actor := input.Actor
+	if actor == "" {
+		actor = log.ActorFromContext(ctx)
+	}
+	if _, err := s.repo.Create(ctx, CreateEventParams{
+		Type:      input.Type,
+		Outcome:   input.Outcome,
+		Reason:    input.Reason,
+		Subject:   input.Subject,
+		Role:      input.Role,
+		TenantID:  input.TenantID,
+		Actor:     actor,
+		IP:        log.RealIPFromContext(ctx),
+		UserAgent: log.UserAgentFromContext(ctx),
+		RequestID: log.RequestIDFromContext(ctx),
+		Retention: s.cfg.Retention,
+	}); err != nil {
+		logger.Error("failed to record the authentication event", err)
+	}
+}
+
+// ListEventsInput represents the filters and the page of the events to list. The empty filters are not applied, and
+// the time range includes From but excludes To.
+type ListEventsInput struct {
+	Subject  string
+	Type     string
+	From     time.Time
+	To       time.Time
+	Page     int
+	PageSize int
+}
+
+// ListEventsOutput represents a page of events, sorted by the most recent first, and the total of events matching
+// the filters.
+type ListEventsOutput struct {
+	Events     []Event
+	TotalItems int64
+	Page       int
+	PageSize   int
+}
+
+func (s *service) ListEvents(ctx context.Context, input ListEventsInput) (ListEventsOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	page := max(input.Page, 1)
+	pageSize := input.PageSize
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+
+	logger.Info(
+		"listing authentication events",
+		log.Field{Key: "subject", Value: input.Subject},
+		log.Field{Key: "type", Value: input.Type},
+		log.Field{Key: "page", Value: page},
+	)
+	filter := EventsFilter{
+		Subject: input.Subject,
+		Type:    input.Type,
+		From:    input.From,
+		To:      input.To,
+	}
+
+	total, err := s.repo.CountEvents(ctx, filter)
+	if err != nil {
+		logger.Error("failed to count the authentication events", err)
+		return ListEventsOutput{}, err
+	}
+
+	events, err := s.repo.FindEvents(ctx, FindEventsParams{
+		Filter: filter,
+		Skip:   int64((page - 1) * pageSize),
+		Limit:  int64(pageSize),
+	})
+	if err != nil {
+		logger.Error("failed to find the authentication events", err)
+		return ListEventsOutput{}, err
+	}
+
+	return ListEventsOutput{Events: events, TotalItems: total, Page: page, PageSize: pageSize}, nil
+}
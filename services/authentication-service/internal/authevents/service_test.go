@@ -0,0 +1,237 @@
+//go:build unit
+
+package authevents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
+	autheventsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents/mocks"
+)
+
+var (
+	errRepo = errors.New("repository error")
+
+	testConfig = authevents.Config{Retention: 90 * 24 * time.Hour}
+)
+
+type authEventsServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	mocksSetup func(repo *autheventsmocks.MockRepository)
+	want       W
+	wantErr    error
+}
+
+func TestService_Record(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := authevents.RecordInput{
+		Type:     authevents.EventTypeLogin,
+		Outcome:  authevents.OutcomeFailure,
+		Reason:   authevents.ReasonInvalidCredentials,
+		Subject:  "fake-user-id",
+		Role:     "staff",
+		TenantID: "fake-tenant-id",
+	}
+	params := authevents.CreateEventParams{
+		Type:      authevents.EventTypeLogin,
+		Outcome:   authevents.OutcomeFailure,
+		Reason:    authevents.ReasonInvalidCredentials,
+		Subject:   "fake-user-id",
+		Role:      "staff",
+		TenantID:  "fake-tenant-id",
+		IP:        "192.168.1.1",
+		UserAgent: "fake-user-agent",
+		RequestID: "fake-request-id",
+		Retention: testConfig.Retention,
+	}
+
+	tests := []authEventsServiceTestCase[authevents.RecordInput, struct{}]{
+		{
+			name:  "when the event is recorded, then it should store it with the request info",
+			input: input,
+			mocksSetup: func(repo *autheventsmocks.MockRepository) {
+				repo.EXPECT().Create(gomock.Any(), params).Return(authevents.Event{ID: "fake-id"}, nil)
+			},
+		},
+		{
+			name:  "when there is an unexpected error when storing the event, then it should not panic",
+			input: input,
+			mocksSetup: func(repo *autheventsmocks.MockRepository) {
+				repo.EXPECT().Create(gomock.Any(), params).Return(authevents.Event{}, errRepo)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			ctx := log.WithRequestInfo(context.Background(), log.RequestInfo{
+				RequestID: "fake-request-id",
+				RealIP:    "192.168.1.1",
+				UserAgent: "fake-user-agent",
+			})
+			assert.NotPanics(t, func() {
+				service.Record(ctx, tt.input)
+			})
+		})
+	}
+}
+
+func TestService_Record_ImpersonatedRequest(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	service, cleanup := serviceSetup(t, logger, func(repo *autheventsmocks.MockRepository) {
+		repo.EXPECT().Create(gomock.Any(), authevents.CreateEventParams{
+			Type:      authevents.EventTypeTenantSwitch,
+			Outcome:   authevents.OutcomeSuccess,
+			Subject:   "fake-user-id",
+			Role:      "staff",
+			TenantID:  "fake-tenant-id",
+			Actor:     "fake-admin-id",
+			RequestID: "fake-request-id",
+			Retention: testConfig.Retention,
+		}).Return(authevents.Event{ID: "fake-id"}, nil)
+	})
+	defer cleanup()
+
+	ctx := log.WithRequestInfo(context.Background(), log.RequestInfo{RequestID: "fake-request-id"})
+	ctx = log.WithActor(ctx, "fake-admin-id")
+	service.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeTenantSwitch,
+		Outcome:  authevents.OutcomeSuccess,
+		Subject:  "fake-user-id",
+		Role:     "staff",
+		TenantID: "fake-tenant-id",
+	})
+}
+
+func TestService_ListEvents(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	filter := authevents.EventsFilter{
+		Subject: "fake-user-id",
+		Type:    authevents.EventTypeLogin,
+		From:    now.Add(-time.Hour),
+		To:      now,
+	}
+	input := authevents.ListEventsInput{
+		Subject:  "fake-user-id",
+		Type:     authevents.EventTypeLogin,
+		From:     now.Add(-time.Hour),
+		To:       now,
+		Page:     2,
+		PageSize: 10,
+	}
+	events := []authevents.Event{
+		{
+			ID:        "fake-id",
+			Type:      authevents.EventTypeLogin,
+			Outcome:   authevents.OutcomeSuccess,
+			Subject:   "fake-user-id",
+			Role:      "customer",
+			CreatedAt: now.Add(-time.Minute),
+		},
+	}
+
+	tests := []authEventsServiceTestCase[authevents.ListEventsInput, authevents.ListEventsOutput]{
+		{
+			name:  "when there is an unexpected error when counting the events, then it should propagate the error",
+			input: input,
+			mocksSetup: func(repo *autheventsmocks.MockRepository) {
+				repo.EXPECT().CountEvents(gomock.Any(), filter).Return(int64(0), errRepo)
+			},
+			want:    authevents.ListEventsOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an unexpected error when finding the events, then it should propagate the error",
+			input: input,
+			mocksSetup: func(repo *autheventsmocks.MockRepository) {
+				repo.EXPECT().CountEvents(gomock.Any(), filter).Return(int64(11), nil)
+				repo.EXPECT().FindEvents(gomock.Any(), gomock.Any()).Return(nil, errRepo)
+			},
+			want:    authevents.ListEventsOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the page is requested, then it should return its events and the total of events",
+			input: input,
+			mocksSetup: func(repo *autheventsmocks.MockRepository) {
+				repo.EXPECT().CountEvents(gomock.Any(), filter).Return(int64(11), nil)
+				repo.EXPECT().FindEvents(gomock.Any(), authevents.FindEventsParams{
+					Filter: filter,
+					Skip:   10,
+					Limit:  10,
+				}).Return(events, nil)
+			},
+			want: authevents.ListEventsOutput{
+				Events:     events,
+				TotalItems: 11,
+				Page:       2,
+				PageSize:   10,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "when the page is not specified, then it should return the first page with the default size",
+			input: authevents.ListEventsInput{},
+			mocksSetup: func(repo *autheventsmocks.MockRepository) {
+				repo.EXPECT().CountEvents(gomock.Any(), authevents.EventsFilter{}).Return(int64(1), nil)
+				repo.EXPECT().FindEvents(gomock.Any(), authevents.FindEventsParams{
+					Skip:  0,
+					Limit: authevents.DefaultPageSize,
+				}).Return(events, nil)
+			},
+			want: authevents.ListEventsOutput{
+				Events:     events,
+				TotalItems: 1,
+				Page:       1,
+				PageSize:   authevents.DefaultPageSize,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.ListEvents(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func serviceSetup(
+	t *testing.T,
+	logger log.Logger,
+	mocksSetup func(repo *autheventsmocks.MockRepository),
+) (authevents.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	repo := autheventsmocks.NewMockRepository(ctrl)
+
+	if mocksSetup != nil {
+		mocksSetup(repo)
+	}
+
+	service := authevents.NewService(logger, repo, testConfig)
+	return service, func() {
+		ctrl.Finish()
+	}
+}
@@ -0,0 +1,13 @@
+// Package couriers provide courier-related functionality and error definitions
+// for the authentication service. It defines custom errors for handling common
+// courier-related scenarios.
+package couriers
+
+import "errors"
+
+var (
+	// ErrCourierAlreadyExists indicates that a courier with the same identifying details already exists in the system.
+	ErrCourierAlreadyExists = errors.New("courier already exists")
+	// ErrCourierNotFound indicates that a courier with the specified details could not be found in the system.
+	ErrCourierNotFound = errors.New("courier not found")
+)
@@ -0,0 +1,245 @@
+package couriers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+)
+
+const (
+	// CodeCourierAlreadyExists represents the error code indicating the courier already exists in the system.
+	CodeCourierAlreadyExists = "COURIER_ALREADY_EXISTS"
+	// MsgCourierAlreadyExists represents the error message indicating that the courier already exists in the system.
+	MsgCourierAlreadyExists = "courier already exists"
+)
+
+// Handler manages HTTP requests for auth-courier-related operations.
+type Handler struct {
+	logger         log.Logger
+	service        Service
+	authMiddleware auth.Middleware
+}
+
+// NewHandler creates a new instance of Handler.
+func NewHandler(logger log.Logger, service Service, authMiddleware auth.Middleware) *Handler {
+	return &Handler{
+		logger:         logger,
+		service:        service,
+		authMiddleware: authMiddleware,
+	}
+}
+
+// RegisterRoutes registers the courier-related HTTP routes.
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	authRouter := router.Group("/v1.0/auth", h.authMiddleware.RequireServiceScope(auth.ScopeAuthRegister))
+	{
+		authRouter.POST("/couriers", h.RegisterCourier)
+	}
+
+	router.POST("/v1.0/couriers/login", h.LoginCourier)
+	router.POST("/v1.0/couriers/refresh", h.RefreshCourier)
+}
+
+// RegisterCourierRequest represents the request payload for registering a new courier. CompanyID is only provided
+// for couriers working for a delivery company.
+type RegisterCourierRequest struct {
+	CourierID string `json:"courier_id" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	CompanyID string `json:"company_id"`
+	Password  string `json:"password" binding:"required,min=8"`
+}
+
+// RegisterCourierResponse represents the response returned after successfully registering a new courier.
+type RegisterCourierResponse struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	CompanyID string    `json:"company_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RegisterCourier handles the registration of a new courier.
+func (h *Handler) RegisterCourier(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("RegisterCourier handler called")
+
+	var req RegisterCourierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := RegisterCourierInput(req)
+
+	output, err := h.service.RegisterCourier(ctx, input)
+	if err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			logger.Warn("Password does not meet the policy")
+			errResp := customhttp.NewErrorResponse(customhttp.CodeValidationError, customhttp.MsgValidationError)
+			errResp.Details = policyErr.Details("password")
+			c.JSON(http.StatusBadRequest, errResp)
+			return
+		}
+		if errors.Is(err, ErrCourierAlreadyExists) {
+			logger.Warn("Courier already exists", log.Field{Key: "email", Value: req.Email})
+			c.JSON(
+				http.StatusConflict,
+				customhttp.NewErrorResponse(CodeCourierAlreadyExists, MsgCourierAlreadyExists),
+			)
+			return
+		}
+		logger.Error("Failed to register courier", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := RegisterCourierResponse(output)
+	logger.Info("Courier registered successfully", log.Field{Key: "courier", Value: resp})
+	c.JSON(http.StatusCreated, resp)
+}
+
+// LoginCourierRequest represents the request payload for logging in a courier.
+type LoginCourierRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// LoginCourierResponse represents the response payload for a successful courier login.
+type LoginCourierResponse struct {
+	authcore.TokenPairResponse
+}
+
+// LoginCourier processes the login request for a courier using credentials provided in JSON format.
+func (h *Handler) LoginCourier(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("LoginCourier handler called")
+
+	var req LoginCourierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := LoginCourierInput(req)
+	output, err := h.service.LoginCourier(ctx, input)
+	if err != nil {
+		if errors.Is(err, authcore.ErrInvalidCredentials) {
+			logger.Warn("Invalid credentials provided", log.Field{Key: "email", Value: req.Email})
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidCredentials,
+					authcore.MsgInvalidCredentials,
+				),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrAccountLocked) {
+			logger.Warn("Login locked after too many failed attempts", log.Field{Key: "email", Value: req.Email})
+			c.JSON(
+				http.StatusTooManyRequests, customhttp.NewErrorResponse(
+					authcore.CodeAccountLocked,
+					authcore.MsgAccountLocked,
+				),
+			)
+			return
+		}
+		logger.Error("Failed to login courier", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := LoginCourierResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
+	logger.Info("Courier logged in successfully")
+	c.JSON(http.StatusOK, resp)
+}
+
+// RefreshCourierRequest represents a request to refresh the courier tokens.
+type RefreshCourierRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+	AccessToken  string `json:"access_token" binding:"required"`
+}
+
+// RefreshCourierResponse represents the response returned when refreshing a courier's token.
+type RefreshCourierResponse struct {
+	authcore.TokenPairResponse
+}
+
+// RefreshCourier handles the refreshing of a courier's authentication token.
+func (h *Handler) RefreshCourier(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("RefreshCourier handler called")
+
+	var req RefreshCourierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := RefreshCourierInput(req)
+	output, err := h.service.RefreshCourier(ctx, input)
+	if err != nil {
+		if errors.Is(err, authcore.ErrInvalidRefreshToken) {
+			logger.Warn("Invalid refresh token provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidRefreshToken,
+					authcore.MsgInvalidRefreshToken,
+				),
+			)
+			return
+		} else if errors.Is(err, authcore.ErrTokenMismatch) {
+			logger.Warn("Token mismatch")
+			c.JSON(
+				http.StatusForbidden, customhttp.NewErrorResponse(
+					authcore.CodeTokenMismatch,
+					authcore.MsgTokenMismatch,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to refresh courier", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := RefreshCourierResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
+	logger.Info("Courier refreshed successfully")
+	c.JSON(http.StatusOK, resp)
+}
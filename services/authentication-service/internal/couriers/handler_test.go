@@ -0,0 +1,488 @@
+//go:build unit
+
+package couriers_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/couriers"
+	couriersmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/couriers/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+)
+
+type courierHandlerTestCase struct {
+	name        string
+	token       string
+	jsonPayload string
+	mocksSetup  func(service *couriersmocks.MockService, authService *authmocks.MockService)
+	wantJSON    string
+	wantStatus  int
+}
+
+var errUnexpected = errors.New("unexpected error")
+
+func TestHandler_RegisterCourier(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []courierHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a service, then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the service token does not have the register scope, " +
+				"then it should return a 403 with the forbidden error",
+			token: "service-token",
+			mocksSetup: func(_ *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, "fake:scope")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			token:       "service-token",
+			jsonPayload: `{"password": 1.2, "email": true}`,
+			mocksSetup: func(_ *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON:   customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "service-token",
+			jsonPayload: `{}`,
+			mocksSetup: func(_ *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"courier_id is required",
+					"email is required",
+					"password is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "when invalid email is provided, then it should return a 400 with the email validation error",
+			token: "service-token",
+			jsonPayload: `{
+				"courier_id": "fake-courier-id",
+				"email": "invalid-email",
+				"password": "ValidPassword123"
+			}`,
+			mocksSetup: func(_ *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("email must be a valid email address").
+				Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "when the password does not meet the policy, then it should return a 400 with the policy violations",
+			token: "service-token",
+			jsonPayload: `{
+				"courier_id": "fake-courier-id",
+				"email": "test@example.com",
+				"password": "password"
+			}`,
+			mocksSetup: func(service *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+				service.EXPECT().RegisterCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.RegisterCourierOutput{}, &password.PolicyError{Violations: []string{"is too common"}})
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("password is too common").
+				Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "when the courier already exists, then it should return a 409 with the courier already exists error",
+			token: "service-token",
+			jsonPayload: `{
+				"courier_id": "fake-courier-id",
+				"email": "test@example.com",
+				"password": "ValidPassword123"
+			}`,
+			mocksSetup: func(service *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+				service.EXPECT().RegisterCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.RegisterCourierOutput{}, couriers.ErrCourierAlreadyExists)
+			},
+			wantJSON: `{
+				"code": "COURIER_ALREADY_EXISTS",
+				"message": "courier already exists",
+				"details": []
+			}`,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:  "when unexpected error when registering the courier, then it should return a 500 with the internal error",
+			token: "service-token",
+			jsonPayload: `{
+				"courier_id": "fake-courier-id",
+				"email": "test@example.com",
+				"password": "ValidPassword123"
+			}`,
+			mocksSetup: func(service *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+				service.EXPECT().RegisterCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.RegisterCourierOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "when an independent courier is successfully registered, " +
+				"then it should return a 201 with the courier details",
+			token: "service-token",
+			jsonPayload: `{
+				"courier_id": "fake-courier-id",
+				"email": "test@example.com",
+				"password": "ValidPassword123"
+			}`,
+			mocksSetup: func(service *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+				service.EXPECT().RegisterCourier(
+					gomock.Any(), couriers.RegisterCourierInput{
+						CourierID: "fake-courier-id",
+						Email:     "test@example.com",
+						Password:  "ValidPassword123",
+					},
+				).Return(
+					couriers.RegisterCourierOutput{
+						ID:        "fake-id",
+						Email:     "test@example.com",
+						CreatedAt: now,
+						UpdatedAt: now,
+					}, nil,
+				)
+			},
+			wantJSON: `{
+				"created_at":"2025-01-01T00:00:00Z",
+				"email":"test@example.com",
+				"id":"fake-id",
+				"updated_at":"2025-01-01T00:00:00Z"
+			}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "when a courier of a delivery company is successfully registered, " +
+				"then it should return a 201 with the courier details",
+			token: "service-token",
+			jsonPayload: `{
+				"courier_id": "fake-courier-id",
+				"email": "test@example.com",
+				"company_id": "fake-company-id",
+				"password": "ValidPassword123"
+			}`,
+			mocksSetup: func(service *couriersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+				service.EXPECT().RegisterCourier(
+					gomock.Any(), couriers.RegisterCourierInput{
+						CourierID: "fake-courier-id",
+						Email:     "test@example.com",
+						CompanyID: "fake-company-id",
+						Password:  "ValidPassword123",
+					},
+				).Return(
+					couriers.RegisterCourierOutput{
+						ID:        "fake-id",
+						Email:     "test@example.com",
+						CompanyID: "fake-company-id",
+						CreatedAt: now,
+						UpdatedAt: now,
+					}, nil,
+				)
+			},
+			wantJSON: `{
+				"company_id":"fake-company-id",
+				"created_at":"2025-01-01T00:00:00Z",
+				"email":"test@example.com",
+				"id":"fake-id",
+				"updated_at":"2025-01-01T00:00:00Z"
+			}`,
+			wantStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCourierHandlerTestCase(t, logger, http.MethodPost, "/v1.0/auth/couriers", tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_LoginCourier(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []courierHandlerTestCase{
+		{
+			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			jsonPayload: `{"name": 1.2, "email": true}`,
+			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"email is required",
+					"password is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when there are invalid credentials, then it should return a 401 with invalid credentials error",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			mocksSetup: func(service *couriersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.LoginCourierOutput{}, authcore.ErrInvalidCredentials)
+			},
+			wantJSON: `{
+				"code": "INVALID_CREDENTIALS",
+				"message": "invalid credentials",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "when the courier login is locked, then it should return a 429 with account locked error",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			mocksSetup: func(service *couriersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.LoginCourierOutput{}, authcore.ErrAccountLocked)
+			},
+			wantJSON: `{
+				"code": "ACCOUNT_LOCKED",
+				"message": "too many failed login attempts, try again later",
+				"details": []
+			}`,
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:        "when unexpected error when login the courier, then it should return a 500 with the internal error",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			mocksSetup: func(service *couriersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.LoginCourierOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when an active courier has the same email and password, then it should return a 200 with the token",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			mocksSetup: func(service *couriersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginCourier(
+					gomock.Any(), couriers.LoginCourierInput{
+						Email:    "test@example.com",
+						Password: "ValidPassword123",
+					},
+				).Return(
+					couriers.LoginCourierOutput{
+						TokenPair: authcore.TokenPair{
+							AccessToken:  "fake-token",
+							RefreshToken: "fake-refresh-token",
+							ExpiresIn:    couriers.DefaultTokenExpiration,
+							TokenType:    auth.DefaultTokenType,
+						},
+					}, nil,
+				)
+			},
+			wantJSON: `{
+			  "access_token": "fake-token",
+			  "refresh_token": "fake-refresh-token",
+			  "expires_in": 3600,
+			  "token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCourierHandlerTestCase(t, logger, http.MethodPost, "/v1.0/couriers/login", tt, "")
+			},
+		)
+	}
+}
+
+func TestHandler_RefreshCourier(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []courierHandlerTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"refresh_token is required",
+					"access_token is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when invalid refresh token provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			jsonPayload: `{"access_token": "valid-access-token", "refresh_token": "invalid-refresh-token"}`,
+			mocksSetup: func(service *couriersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RefreshCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.RefreshCourierOutput{}, authcore.ErrInvalidRefreshToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when there is a token mismatch between the access token and the refresh token, " +
+				"then it should return a 403 with the token mismatch error",
+			jsonPayload: `{"access_token": "invalid-access-token", "refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *couriersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RefreshCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.RefreshCourierOutput{}, authcore.ErrTokenMismatch)
+			},
+			wantJSON: `{
+				"code": "TOKEN_MISMATCH",
+				"message": "token mismatch",
+				"details": []
+			}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when unexpected error when refreshing the courier token, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"access_token": "valid-access-token", "refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *couriersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RefreshCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.RefreshCourierOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the courier token is refreshed, then it should return a 200 with the new token",
+			jsonPayload: `{"access_token": "valid-access-token", "refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *couriersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RefreshCourier(
+					gomock.Any(), couriers.RefreshCourierInput{
+						AccessToken:  "valid-access-token",
+						RefreshToken: "valid-refresh-token",
+					},
+				).Return(
+					couriers.RefreshCourierOutput{
+						TokenPair: authcore.TokenPair{
+							AccessToken:  "fake-token",
+							RefreshToken: "fake-refresh-token",
+							ExpiresIn:    couriers.DefaultTokenExpiration,
+							TokenType:    auth.DefaultTokenType,
+						},
+					}, nil,
+				)
+			},
+			wantJSON: `{
+			  "access_token": "fake-token",
+			  "refresh_token": "fake-refresh-token",
+			  "expires_in": 3600,
+			  "token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCourierHandlerTestCase(t, logger, http.MethodPost, "/v1.0/couriers/refresh", tt, "")
+			},
+		)
+	}
+}
+
+func mockClaims(authService *authmocks.MockService, role auth.Role, tenant string) {
+	authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+		Return(auth.GetClaimsOutput{
+			Claims: &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-user-id"},
+				Role:             string(role),
+				Tenant:           tenant,
+			},
+		}, nil)
+}
+
+func mockServiceClaims(authService *authmocks.MockService, scope string) {
+	authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+		Return(auth.GetClaimsOutput{
+			Claims: &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-service-id"},
+				Role:             string(auth.RoleService),
+				Scope:            scope,
+			},
+		}, nil)
+}
+
+// run executes a test case for the courier handler, which is common for all tests.
+func runCourierHandlerTestCase(
+	t *testing.T,
+	logger log.Logger,
+	httpMethod string,
+	route string,
+	tt courierHandlerTestCase,
+	token string,
+) {
+	// Create a new mock service
+	service := couriersmocks.NewMockService(gomock.NewController(t))
+	authService := authmocks.NewMockService(gomock.NewController(t))
+	if tt.mocksSetup != nil {
+		tt.mocksSetup(service, authService)
+	}
+
+	// Initialize the authentication middleware
+	authMiddleware := auth.NewMiddleware(logger, authService)
+
+	// Initialize the handler
+	h := couriers.NewHandler(logger, service, authMiddleware)
+
+	// Make HTTP request
+	w := customhttp.ServeTestHTTPRequest(t, h, httpMethod, route, token, nil, tt.jsonPayload)
+
+	assert.Equal(t, tt.wantStatus, w.Code)
+	if tt.wantJSON == "" {
+		assert.Empty(t, w.Body.String())
+		return
+	}
+	assert.JSONEq(t, tt.wantJSON, w.Body.String())
+}
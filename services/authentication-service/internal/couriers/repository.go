@@ -0,0 +1,168 @@
+package couriers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// CollectionName defines the name of the MongoDB collection used for storing courier documents.
+	CollectionName = "couriers"
+
+	// FieldCourierID represents the field name used to store or query the public ID of a courier in the database.
+	FieldCourierID = "courier_id"
+	// FieldEmail represents the field name used to store or query email addresses in the database.
+	FieldEmail = "email"
+	// FieldActive represents the field name used to indicate the active status of a courier in the database.
+	FieldActive = "active"
+	// FieldCompanyID represents the field name used to store the delivery company a courier works for in the database.
+	FieldCompanyID = "company_id"
+	// FieldPassword represents the field name used to store the hashed password of a courier in the database.
+	FieldPassword = "password"
+	// FieldUpdatedAt represents the field name used to store the timestamp of the last update in the database.
+	FieldUpdatedAt = "updated_at"
+)
+
+// Courier represents a delivery person in the system. CompanyID is empty for independent couriers, which don't work
+// for any delivery company.
+type Courier struct {
+	ID        string    `bson:"_id,omitempty"`
+	CourierID string    `bson:"courier_id"`
+	Email     string    `bson:"email"`
+	CompanyID string    `bson:"company_id,omitempty"`
+	Active    bool      `bson:"active"`
+	Password  string    `bson:"password,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// Repository defines the interface for courier repository operations.
+// It includes methods to create a courier, find a courier by email and update its password.
+//
+//go:generate mockgen -destination=./mocks/repository_mock.go -package=couriers_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/couriers Repository
+type Repository interface {
+	CreateCourier(ctx context.Context, params CreateCourierParams) (Courier, error)
+	FindByEmail(ctx context.Context, email string) (Courier, error)
+	UpdatePassword(ctx context.Context, params UpdatePasswordParams) error
+}
+
+type repository struct {
+	logger     log.Logger
+	collection *mongo.Collection
+	clock      clock.Clock
+}
+
+// NewRepository creates a new instance of the Repository interface with MongoDB implementation.
+func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repository {
+	return &repository{
+		logger:     logger,
+		collection: db.Collection(CollectionName),
+		clock:      clk,
+	}
+}
+
+// CreateCourierParams represents the parameters needed to create a new courier.
+type CreateCourierParams struct {
+	CourierID string
+	Email     string
+	CompanyID string
+	Password  string
+}
+
+// CreateCourier creates a new courier record in the database.
+// It returns the created courier with an assigned ID or an error if the operation fails.
+// If a courier with the same email already exists, it returns ErrCourierAlreadyExists.
+func (r *repository) CreateCourier(ctx context.Context, params CreateCourierParams) (Courier, error) {
+	logger := r.logger.WithContext(ctx)
+
+	now := r.clock.Now()
+	c := Courier{
+		CourierID: params.CourierID,
+		Email:     params.Email,
+		CompanyID: params.CompanyID,
+		Password:  params.Password,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Active:    true,
+	}
+	res, err := r.collection.InsertOne(ctx, c)
+	if err != nil {
+		if mongodb.IsDuplicateKeyError(err) {
+			logger.Warn("Courier already exists", log.Field{Key: "email", Value: params.Email})
+			return Courier{}, ErrCourierAlreadyExists
+		}
+		logger.Error("Failed to insert courier", err)
+		return Courier{}, err
+	}
+	c.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	logger.Info("Courier created successfully", log.Field{Key: "courier_id", Value: c.ID})
+	return c, nil
+}
+
+// FindByEmail searches for an active courier with the specified email address.
+// It returns the courier if found or ErrCourierNotFound if no matching active courier exists.
+func (r *repository) FindByEmail(ctx context.Context, email string) (Courier, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var courier Courier
+	filter := bson.M{
+		FieldEmail:  email,
+		FieldActive: true,
+	}
+
+	if err := r.collection.FindOne(ctx, filter).Decode(&courier); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn("Courier not found", log.Field{Key: "email", Value: email})
+			return Courier{}, ErrCourierNotFound
+		}
+		logger.Error("Failed to find courier", err)
+		return Courier{}, err
+	}
+	return courier, nil
+}
+
+// UpdatePasswordParams represents the parameters needed to replace the password of a courier.
+// Password must be already hashed.
+type UpdatePasswordParams struct {
+	CourierID string
+	Password  string
+}
+
+// UpdatePassword replaces the password of the active courier with the specified courier ID.
+// It returns ErrCourierNotFound if no matching active courier exists.
+func (r *repository) UpdatePassword(ctx context.Context, params UpdatePasswordParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	filter := bson.M{
+		FieldCourierID: params.CourierID,
+		FieldActive:    true,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldPassword:  params.Password,
+			FieldUpdatedAt: r.clock.Now(),
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to update courier password", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("Courier not found", log.Field{Key: "courier_id", Value: params.CourierID})
+		return ErrCourierNotFound
+	}
+
+	logger.Info("Courier password updated successfully", log.Field{Key: "courier_id", Value: params.CourierID})
+	return nil
+}
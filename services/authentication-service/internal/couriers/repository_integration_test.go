@@ -0,0 +1,328 @@
+//go:build integration
+
+package couriers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/couriers"
+)
+
+const testDBPrefix = "couriers_test_authentication_service"
+
+type couriersRepositoryTestCase[P, W any] struct {
+	name            string
+	insertDocuments func(t *testing.T, coll *mongo.Collection)
+	params          P
+	want            W
+	wantErr         error
+}
+
+func TestRepository_CreateCourier(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []couriersRepositoryTestCase[couriers.CreateCourierParams, couriers.Courier]{
+		{
+			name: "when exists an active courier with the same email, then it should return a courier already exists error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, couriers.Courier{
+					CourierID: "fake-courier-id",
+					Email:     "test@example.com",
+					Password:  "fakehashedpassword",
+					Active:    true,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params: couriers.CreateCourierParams{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				Password:  "ValidPassword123",
+			},
+			want:    couriers.Courier{},
+			wantErr: couriers.ErrCourierAlreadyExists,
+		},
+		{
+			name: "when the courier is created successfully, then it should return the created courier",
+			params: couriers.CreateCourierParams{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				Password:  "ValidPassword123",
+			},
+			want: couriers.Courier{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				Active:    true,
+				Password:  "ValidPassword123",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the courier of a delivery company is created successfully, " +
+				"then it should return the created courier with its company",
+			params: couriers.CreateCourierParams{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				CompanyID: "fake-company-id",
+				Password:  "ValidPassword123",
+			},
+			want: couriers.Courier{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				CompanyID: "fake-company-id",
+				Active:    true,
+				Password:  "ValidPassword123",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestCouriersCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := couriers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.CreateCourier(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the got only if there is no error expected
+			if tt.wantErr == nil {
+				// As the ID is generated by MongoDB, we just check that it is not empty
+				assert.NotEmpty(t, got.ID, "ID should not be empty")
+
+				// Doing this as in that way, I can do a direct equal assertion between the want ant got
+				tt.want.ID = got.ID
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRepository_CreateCourier_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := couriers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.CreateCourier(context.Background(), couriers.CreateCourierParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, couriers.ErrCourierAlreadyExists)
+}
+
+func TestRepository_FindByEmail(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []couriersRepositoryTestCase[string, couriers.Courier]{
+		{
+			name: "when there is not an active courier with the email, then it should return a courier not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, couriers.Courier{
+					Email:     "test@example.com",
+					Password:  "fakehashedpassword",
+					Active:    false,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params:  "test@example.com",
+			want:    couriers.Courier{},
+			wantErr: couriers.ErrCourierNotFound,
+		},
+		{
+			name: "when there is an active courier with the email, then it should return the courier",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, couriers.Courier{
+					Email:     "test2@example.com",
+					Password:  "fakehashedpassword",
+					Active:    true,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params: "test2@example.com",
+			want: couriers.Courier{
+				Email:     "test2@example.com",
+				Active:    true,
+				Password:  "fakehashedpassword",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestCouriersCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := couriers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.FindByEmail(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the got only if there is no error expected
+			if tt.wantErr == nil {
+				// As the ID is generated by MongoDB, we just check that it is not empty
+				assert.NotEmpty(t, got.ID, "ID should not be empty")
+
+				tt.want.ID = got.ID
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRepository_FindByEmail_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := couriers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindByEmail(context.Background(), "")
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, couriers.ErrCourierNotFound)
+}
+
+func TestRepository_UpdatePassword(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []couriersRepositoryTestCase[couriers.UpdatePasswordParams, string]{
+		{
+			name: "when there is not an active courier with the id, then it should return a courier not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, couriers.Courier{
+					CourierID: "fake-courier-id",
+					Email:     "test@example.com",
+					Password:  "fakehashedpassword",
+					Active:    false,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params: couriers.UpdatePasswordParams{
+				CourierID: "fake-courier-id",
+				Password:  "newfakehashedpassword",
+			},
+			want:    "fakehashedpassword",
+			wantErr: couriers.ErrCourierNotFound,
+		},
+		{
+			name: "when there is an active courier with the id, then it should replace the password",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, couriers.Courier{
+					CourierID: "fake-courier-id",
+					Email:     "test@example.com",
+					Password:  "fakehashedpassword",
+					Active:    true,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params: couriers.UpdatePasswordParams{
+				CourierID: "fake-courier-id",
+				Password:  "newfakehashedpassword",
+			},
+			want:    "newfakehashedpassword",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestCouriersCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := couriers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.UpdatePassword(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var got couriers.Courier
+			err = coll.FindOne(context.Background(), bson.M{couriers.FieldCourierID: tt.params.CourierID}).Decode(&got)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Password)
+		})
+	}
+}
+
+func TestRepository_UpdatePassword_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := couriers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.UpdatePassword(context.Background(), couriers.UpdatePasswordParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, couriers.ErrCourierNotFound)
+}
+
+func setupTestCouriersCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := db.Collection(couriers.CollectionName)
+
+	// Create unique index on email
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: couriers.FieldEmail, Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.D{{Key: couriers.FieldActive, Value: true}}),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		t.Fatalf("Failed to create unique index: %v", err)
+	}
+
+	return coll
+}
@@ -0,0 +1,284 @@
+package couriers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+)
+
+const (
+	// DefaultTokenExpiration defines the duration in seconds for which a JWT token remains valid
+	// after being issued during courier authentication. The default value is 3600 seconds (1 hour).
+	DefaultTokenExpiration = 3600
+	// DefaultTokenRole represents the default role assigned to a generated JWT token for couriers.
+	DefaultTokenRole = "courier"
+)
+
+// Service defines the interface for courier authentication management service.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=couriers_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/couriers Service
+type Service interface {
+	RegisterCourier(ctx context.Context, input RegisterCourierInput) (RegisterCourierOutput, error)
+	LoginCourier(ctx context.Context, input LoginCourierInput) (LoginCourierOutput, error)
+	RefreshCourier(ctx context.Context, input RefreshCourierInput) (RefreshCourierOutput, error)
+}
+
+type service struct {
+	logger            log.Logger
+	repo              Repository
+	authCoreService   authcore.Service
+	lockoutService    lockout.Service
+	passwordPolicy    password.Policy
+	authEventsService authevents.Service
+}
+
+// NewService creates a new instance of Service with the provided dependencies.
+func NewService(
+	logger log.Logger,
+	repo Repository,
+	authCoreService authcore.Service,
+	lockoutService lockout.Service,
+	passwordPolicy password.Policy,
+	authEventsService authevents.Service,
+) Service {
+	return &service{
+		logger:            logger,
+		repo:              repo,
+		authCoreService:   authCoreService,
+		lockoutService:    lockoutService,
+		passwordPolicy:    passwordPolicy,
+		authEventsService: authEventsService,
+	}
+}
+
+// RegisterCourierInput defines the input structure required for registering a new courier.
+type RegisterCourierInput struct {
+	CourierID string
+	Email     string
+	CompanyID string
+	Password  string
+}
+
+// RegisterCourierOutput represents the output data returned after successfully registering a new courier.
+type RegisterCourierOutput struct {
+	ID        string
+	Email     string
+	CompanyID string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (s *service) RegisterCourier(ctx context.Context, input RegisterCourierInput) (RegisterCourierOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("registering courier", log.Field{Key: "email", Value: input.Email})
+	if err := s.passwordPolicy.Validate(input.Password, input.Email); err != nil {
+		logger.Warn("password does not meet the policy", log.Field{Key: "email", Value: input.Email})
+		return RegisterCourierOutput{}, err
+	}
+
+	hashedPassword, err := password.Hash(input.Password)
+	if err != nil {
+		logger.Error("failed to hash password", err)
+		return RegisterCourierOutput{}, err
+	}
+
+	params := CreateCourierParams{
+		CourierID: input.CourierID,
+		Email:     input.Email,
+		CompanyID: input.CompanyID,
+		Password:  hashedPassword,
+	}
+
+	courier, err := s.repo.CreateCourier(ctx, params)
+	if err != nil {
+		logger.Error("failed to create courier", err)
+		return RegisterCourierOutput{}, err
+	}
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeRegistration,
+		Outcome:  authevents.OutcomeSuccess,
+		Subject:  courier.CourierID,
+		Role:     DefaultTokenRole,
+		TenantID: courier.CompanyID,
+	})
+
+	output := RegisterCourierOutput{
+		ID:        courier.ID,
+		Email:     courier.Email,
+		CompanyID: courier.CompanyID,
+		CreatedAt: courier.CreatedAt,
+		UpdatedAt: courier.UpdatedAt,
+	}
+	logger.Info("courier registered successfully", log.Field{Key: "courier_id", Value: courier.ID})
+	return output, nil
+}
+
+// LoginCourierInput represents the input required for the courier login process.
+type LoginCourierInput struct {
+	Email    string
+	Password string
+}
+
+// LoginCourierOutput represents the output returned upon successful login of a courier.
+type LoginCourierOutput struct {
+	authcore.TokenPair
+}
+
+func (s *service) LoginCourier(ctx context.Context, input LoginCourierInput) (LoginCourierOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("logging in", log.Field{Key: "email", Value: input.Email})
+	if _, err := s.lockoutService.Check(ctx, lockout.CheckInput{
+		Role:       DefaultTokenRole,
+		Identifier: input.Email,
+	}); err != nil {
+		if errors.Is(err, lockout.ErrLocked) {
+			logger.Warn("courier login locked", log.Field{Key: "email", Value: input.Email})
+			s.authEventsService.Record(ctx, authevents.RecordInput{
+				Type:    authevents.EventTypeLogin,
+				Outcome: authevents.OutcomeFailure,
+				Reason:  authevents.ReasonAccountLocked,
+				Role:    DefaultTokenRole,
+			})
+			return LoginCourierOutput{}, authcore.ErrAccountLocked
+		}
+		logger.Error("failed to check the courier login lock", err)
+		return LoginCourierOutput{}, err
+	}
+
+	courier, err := s.repo.FindByEmail(ctx, input.Email)
+	if err != nil {
+		if errors.Is(err, ErrCourierNotFound) {
+			logger.Warn("courier not found", log.Field{Key: "email", Value: input.Email})
+			return LoginCourierOutput{}, s.registerLoginFailure(ctx, input.Email, Courier{})
+		}
+		logger.Error("failed to find courier by email", err)
+		return LoginCourierOutput{}, err
+	}
+
+	// Check if the stored password matches the provided password
+	if !password.Verify(courier.Password, input.Password) {
+		logger.Warn("invalid credentials")
+		return LoginCourierOutput{}, s.registerLoginFailure(ctx, input.Email, courier)
+	}
+	s.upgradePasswordHash(ctx, courier, input.Password)
+
+	if _, err := s.lockoutService.RegisterSuccess(ctx, lockout.RegisterSuccessInput{
+		Role:       DefaultTokenRole,
+		Identifier: input.Email,
+	}); err != nil {
+		logger.Error("failed to register the successful courier login", err)
+		return LoginCourierOutput{}, err
+	}
+
+	// The tenant of a courier is the delivery company it works for, and it is omitted for independent couriers
+	tokenPair, err := s.authCoreService.GenerateTokenPair(
+		ctx, authcore.GenerateTokenPairInput{
+			UserID:     courier.CourierID,
+			Expiration: DefaultTokenExpiration,
+			Role:       DefaultTokenRole,
+			TenantID:   courier.CompanyID,
+		},
+	)
+	if err != nil {
+		logger.Error("failed to generate token pair", err)
+		return LoginCourierOutput{}, err
+	}
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeLogin,
+		Outcome:  authevents.OutcomeSuccess,
+		Subject:  courier.CourierID,
+		Role:     DefaultTokenRole,
+		TenantID: courier.CompanyID,
+	})
+	return LoginCourierOutput{TokenPair: tokenPair}, nil
+}
+
+// registerLoginFailure registers the failed login of the courier, and returns the error the login must fail with.
+// The courier is empty when the email is not registered.
+func (s *service) registerLoginFailure(ctx context.Context, email string, courier Courier) error {
+	logger := s.logger.WithContext(ctx)
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeLogin,
+		Outcome:  authevents.OutcomeFailure,
+		Reason:   authevents.ReasonInvalidCredentials,
+		Subject:  courier.CourierID,
+		Role:     DefaultTokenRole,
+		TenantID: courier.CompanyID,
+	})
+
+	if _, err := s.lockoutService.RegisterFailure(ctx, lockout.RegisterFailureInput{
+		Role:       DefaultTokenRole,
+		Identifier: email,
+	}); err != nil {
+		logger.Error("failed to register the failed courier login", err)
+		return err
+	}
+	return authcore.ErrInvalidCredentials
+}
+
+// upgradePasswordHash hashes the password of the courier again when the stored hash was created with weaker
+// parameters than the current ones. It is best effort, so a failure does not prevent the courier from logging in.
+func (s *service) upgradePasswordHash(ctx context.Context, courier Courier, plainPassword string) {
+	logger := s.logger.WithContext(ctx)
+
+	if !password.NeedsRehash(courier.Password) {
+		return
+	}
+
+	hashedPassword, err := password.Hash(plainPassword)
+	if err != nil {
+		logger.Error("failed to rehash the courier password", err)
+		return
+	}
+	if err := s.repo.UpdatePassword(ctx, UpdatePasswordParams{
+		CourierID: courier.CourierID,
+		Password:  hashedPassword,
+	}); err != nil {
+		logger.Error("failed to upgrade the courier password hash", err)
+		return
+	}
+	logger.Info("courier password hash upgraded", log.Field{Key: "courier_id", Value: courier.CourierID})
+}
+
+// RefreshCourierInput represents the input required to refresh a courier's authentication tokens.
+type RefreshCourierInput struct {
+	RefreshToken string
+	AccessToken  string
+}
+
+// RefreshCourierOutput wraps the response of a successful courier token refresh operation.
+type RefreshCourierOutput struct {
+	authcore.TokenPair
+}
+
+func (s *service) RefreshCourier(ctx context.Context, input RefreshCourierInput) (RefreshCourierOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("refreshing courier token")
+
+	tokenPair, err := s.authCoreService.RefreshToken(
+		ctx, authcore.RefreshTokenInput{
+			RefreshToken: input.RefreshToken,
+			AccessToken:  input.AccessToken,
+			Expiration:   DefaultTokenExpiration,
+			Role:         DefaultTokenRole,
+		},
+	)
+	if err != nil {
+		logger.Error("failed to refresh the courier token", err)
+		return RefreshCourierOutput{}, err
+	}
+
+	return RefreshCourierOutput{TokenPair: tokenPair}, nil
+}
@@ -0,0 +1,595 @@
+//go:build unit
+
+package couriers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	authcoremocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore/mocks"
+	autheventsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents/mocks"
+	couriersmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/couriers/mocks"
+	lockoutmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout/mocks"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/couriers"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+)
+
+var (
+	errRepo  = errors.New("repository error")
+	errToken = errors.New("token error")
+
+	testPasswordPolicy = password.Policy{MinLength: 8, MaxLength: 128, MinCharacterClasses: 2}
+)
+
+type couriersServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	want       W
+	mocksSetup func(
+		repo *couriersmocks.MockRepository,
+		authCoreService *authcoremocks.MockService,
+		lockoutService *lockoutmocks.MockService,
+	)
+	wantErr error
+}
+
+func TestService_RegisterCourier(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []couriersServiceTestCase[couriers.RegisterCourierInput, couriers.RegisterCourierOutput]{
+		{
+			name: "when the password does not meet the policy, then it should return a policy violation error",
+			input: couriers.RegisterCourierInput{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				Password:  "mytestpassword",
+			},
+			want:    couriers.RegisterCourierOutput{},
+			wantErr: password.ErrPolicyViolation,
+		},
+		{
+			name: "when there is an active courier with the same email, then it should return a courier already exists error",
+			input: couriers.RegisterCourierInput{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				Password:  "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				repo.EXPECT().CreateCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.Courier{}, couriers.ErrCourierAlreadyExists)
+			},
+			want:    couriers.RegisterCourierOutput{},
+			wantErr: couriers.ErrCourierAlreadyExists,
+		},
+		{
+			name: "when there is an unexpected error when creating the courier, then it should propagate the error",
+			input: couriers.RegisterCourierInput{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				Password:  "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				repo.EXPECT().CreateCourier(gomock.Any(), gomock.Any()).
+					Return(couriers.Courier{}, errRepo)
+			},
+			want:    couriers.RegisterCourierOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when the independent courier can be created, then it should return the created courier",
+			input: couriers.RegisterCourierInput{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				Password:  "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				repo.EXPECT().CreateCourier(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, params couriers.CreateCourierParams) (couriers.Courier, error) {
+							// Assert that the password is hashed
+							ok := password.Verify(params.Password, "ValidPassword123")
+							require.True(t, ok, "Password should be hashed and match the input password")
+							assert.Empty(t, params.CompanyID)
+
+							return couriers.Courier{
+								ID:        "fake-id",
+								CourierID: params.CourierID,
+								Email:     params.Email,
+								Password:  params.Password,
+								CreatedAt: now,
+								UpdatedAt: now,
+								Active:    true,
+							}, nil
+						},
+					)
+			},
+			want: couriers.RegisterCourierOutput{
+				ID:        "fake-id",
+				Email:     "test@example.com",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the courier of a delivery company can be created, then it should return the created courier",
+			input: couriers.RegisterCourierInput{
+				CourierID: "fake-courier-id",
+				Email:     "test@example.com",
+				CompanyID: "fake-company-id",
+				Password:  "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				repo.EXPECT().CreateCourier(gomock.Any(), gomock.Any()).
+					DoAndReturn(
+						func(_ context.Context, params couriers.CreateCourierParams) (couriers.Courier, error) {
+							return couriers.Courier{
+								ID:        "fake-id",
+								CourierID: params.CourierID,
+								Email:     params.Email,
+								CompanyID: params.CompanyID,
+								Password:  params.Password,
+								CreatedAt: now,
+								UpdatedAt: now,
+								Active:    true,
+							}, nil
+						},
+					)
+			},
+			want: couriers.RegisterCourierOutput{
+				ID:        "fake-id",
+				Email:     "test@example.com",
+				CompanyID: "fake-company-id",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.RegisterCourier(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_LoginCourier(t *testing.T) {
+	logger, _ := log.NewTest()
+	weakParams := password.Params{Memory: 8 * 1024, Iterations: 1, Parallelism: 1}
+
+	tests := []couriersServiceTestCase[couriers.LoginCourierInput, couriers.LoginCourierOutput]{
+		{
+			name:  "when the login is locked, then it should return an account locked error",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				_ *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), lockout.CheckInput{
+					Role:       couriers.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.CheckOutput{}, lockout.ErrLocked)
+			},
+			want:    couriers.LoginCourierOutput{},
+			wantErr: authcore.ErrAccountLocked,
+		},
+		{
+			name:  "when there is an unexpected error checking the login lock, then it should propagate the error",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				_ *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, errRepo)
+			},
+			want:    couriers.LoginCourierOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is an unexpected error registering the failed login, " +
+				"then it should propagate the error",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(couriers.Courier{}, couriers.ErrCourierNotFound)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), lockout.RegisterFailureInput{
+					Role:       couriers.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterFailureOutput{}, errRepo)
+			},
+			want:    couriers.LoginCourierOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is an unexpected error registering the successful login, " +
+				"then it should propagate the error",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(couriers.Courier{CourierID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), lockout.RegisterSuccessInput{
+					Role:       couriers.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterSuccessOutput{}, errRepo)
+			},
+			want:    couriers.LoginCourierOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is not an active courier with the same email, " +
+				"then it should return an invalid credentials error",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(couriers.Courier{}, couriers.ErrCourierNotFound)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterFailureOutput{}, nil)
+			},
+			want:    couriers.LoginCourierOutput{},
+			wantErr: authcore.ErrInvalidCredentials,
+		},
+		{
+			name: "when there is not an active courier with the same password, " +
+				"then it should return an invalid credentials error",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "InvalidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(couriers.Courier{CourierID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterFailureOutput{}, nil)
+			},
+			want:    couriers.LoginCourierOutput{},
+			wantErr: authcore.ErrInvalidCredentials,
+		},
+		{
+			name:  "when there is an unexpected error when fetching the courier, then it should propagate the error",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(couriers.Courier{}, errRepo)
+			},
+			want:    couriers.LoginCourierOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error generating the token pair, then it should propagate the error",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(couriers.Courier{CourierID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{}, errToken)
+			},
+			want:    couriers.LoginCourierOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when an independent courier logs in with valid credentials, " +
+				"then it should return its token without tenant",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(couriers.Courier{CourierID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), authcore.GenerateTokenPairInput{
+					UserID:     "fake-id",
+					Expiration: couriers.DefaultTokenExpiration,
+					Role:       couriers.DefaultTokenRole,
+				}).Return(
+					authcore.TokenPair{
+						AccessToken:  "fake-token",
+						RefreshToken: "fake-refresh-token",
+						ExpiresIn:    3600,
+						TokenType:    "Bearer",
+					}, nil,
+				)
+			},
+			want: couriers.LoginCourierOutput{
+				TokenPair: authcore.TokenPair{
+					AccessToken:  "fake-token",
+					ExpiresIn:    3600, // 1 hour
+					TokenType:    "Bearer",
+					RefreshToken: "fake-refresh-token",
+				},
+			},
+		},
+		{
+			name: "when a courier of a delivery company logs in with valid credentials, " +
+				"then it should return its token scoped to the company",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(couriers.Courier{
+						CourierID: "fake-id",
+						CompanyID: "fake-company-id",
+						Password:  hashedPassword,
+						Active:    true,
+					}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), authcore.GenerateTokenPairInput{
+					UserID:     "fake-id",
+					Expiration: couriers.DefaultTokenExpiration,
+					Role:       couriers.DefaultTokenRole,
+					TenantID:   "fake-company-id",
+				}).Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: couriers.LoginCourierOutput{
+				TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+			},
+		},
+		{
+			name: "when the stored password was hashed with weaker parameters, " +
+				"then it should rehash it and return the token",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.HashWithParams("ValidPassword123", weakParams)
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(couriers.Courier{CourierID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params couriers.UpdatePasswordParams) error {
+						assert.Equal(t, "fake-id", params.CourierID)
+						assert.False(t, password.NeedsRehash(params.Password), "The password should be rehashed")
+						assert.True(t, password.Verify(params.Password, "ValidPassword123"))
+						return nil
+					})
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: couriers.LoginCourierOutput{
+				TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+			},
+		},
+		{
+			name: "when there is an error upgrading the password hash, " +
+				"then it should still return the token",
+			input: couriers.LoginCourierInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *couriersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.HashWithParams("ValidPassword123", weakParams)
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(couriers.Courier{CourierID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(errRepo)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: couriers.LoginCourierOutput{
+				TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.LoginCourier(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_RefreshCourier(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []couriersServiceTestCase[couriers.RefreshCourierInput, couriers.RefreshCourierOutput]{
+		{
+			name: "when there is an error refreshing the token, then it should propagate the error",
+			input: couriers.RefreshCourierInput{
+				RefreshToken: "InvalidRefreshToken",
+				AccessToken:  "ValidAccessToken",
+			},
+			mocksSetup: func(
+				_ *couriersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{}, errToken)
+			},
+			want:    couriers.RefreshCourierOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the new access token is generated correctly, then it should return the new token",
+			input: couriers.RefreshCourierInput{
+				RefreshToken: "ValidRefreshToken",
+				AccessToken:  "ValidAccessToken",
+			},
+			mocksSetup: func(
+				_ *couriersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *lockoutmocks.MockService,
+			) {
+				authCoreService.EXPECT().RefreshToken(gomock.Any(), authcore.RefreshTokenInput{
+					RefreshToken: "ValidRefreshToken",
+					AccessToken:  "ValidAccessToken",
+					Expiration:   couriers.DefaultTokenExpiration,
+					Role:         couriers.DefaultTokenRole,
+				}).Return(
+					authcore.TokenPair{
+						AccessToken:  "fake-token",
+						RefreshToken: "fake-refresh-token",
+						ExpiresIn:    3600,
+						TokenType:    "Bearer",
+					}, nil,
+				)
+			},
+			want: couriers.RefreshCourierOutput{
+				TokenPair: authcore.TokenPair{
+					AccessToken:  "fake-token",
+					RefreshToken: "fake-refresh-token",
+					ExpiresIn:    3600,
+					TokenType:    "Bearer",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.RefreshCourier(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func serviceSetup(
+	t *testing.T, logger log.Logger, mocksSetup func(
+		repo *couriersmocks.MockRepository,
+		authCoreService *authcoremocks.MockService,
+		lockoutService *lockoutmocks.MockService,
+	),
+) (couriers.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	repo := couriersmocks.NewMockRepository(ctrl)
+	authCoreService := authcoremocks.NewMockService(ctrl)
+	lockoutService := lockoutmocks.NewMockService(ctrl)
+	// The audit log is best effort and never affects the result, so the recorded events are not asserted here
+	authEventsService := autheventsmocks.NewMockService(ctrl)
+	authEventsService.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	if mocksSetup != nil {
+		mocksSetup(repo, authCoreService, lockoutService)
+	}
+
+	service := couriers.NewService(
+		logger, repo, authCoreService, lockoutService, testPasswordPolicy, authEventsService,
+	)
+	return service, func() {
+		ctrl.Finish()
+	}
+}
@@ -11,6 +11,8 @@ import (
 	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refreshcookie"
 )
 
 const (
@@ -18,6 +20,21 @@ const (
 	CodeCustomerAlreadyExists = "CUSTOMER_ALREADY_EXISTS"
 	// MsgCustomerAlreadyExists represents the error message indicating that the customer already exists in the system.
 	MsgCustomerAlreadyExists = "customer already exists"
+	// CodeCustomerNotFound represents the error code indicating the customer does not exist in the system.
+	CodeCustomerNotFound = "CUSTOMER_NOT_FOUND"
+	// MsgCustomerNotFound represents the error message indicating that the customer does not exist in the system.
+	MsgCustomerNotFound = "customer not found"
+
+	// RefreshRoute is the route to refresh the customer tokens, which the refresh token cookie is scoped to.
+	RefreshRoute = "/v1.0/customers/refresh"
+	// CookieLogoutRoute is the logout route for the clients in cookie mode. It is nested in RefreshRoute, as the
+	// browser only sends the refresh token cookie to it.
+	CookieLogoutRoute = RefreshRoute + "/logout"
+	// CookieLogoutAllRoute is the route to log out from all the sessions for the clients in cookie mode.
+	CookieLogoutAllRoute = CookieLogoutRoute + "/all"
+	// CSRFCookiePath is the path the CSRF token cookie is scoped to. It is not sent to the other services behind the
+	// gateway.
+	CSRFCookiePath = "/v1.0/customers"
 )
 
 // Handler manages HTTP requests for auth-customer-related operations.
@@ -38,13 +55,39 @@ func NewHandler(logger log.Logger, service Service, authMiddleware auth.Middlewa
 
 // RegisterRoutes registers the customer-related HTTP routes.
 func (h *Handler) RegisterRoutes(router *gin.Engine) {
-	authRouter := router.Group("/v1.0/auth")
+	authRouter := router.Group("/v1.0/auth", h.authMiddleware.RequireServiceScope(auth.ScopeAuthRegister))
 	{
 		authRouter.POST("/customers", h.RegisterCustomer)
 	}
 
+	manageRouter := router.Group(
+		"/v1.0/auth/customers/:customerID",
+		h.authMiddleware.RequireServiceScope(auth.ScopeAuthManage),
+	)
+	{
+		manageRouter.PUT("/email", h.UpdateCustomerEmail)
+		manageRouter.POST("/deactivate", h.DeactivateCustomer)
+		manageRouter.DELETE("", h.DeleteCustomer)
+	}
+
 	router.POST("/v1.0/customers/login", h.LoginCustomer)
-	router.POST("v1.0/customers/refresh", h.RefreshCustomer)
+	router.POST("/v1.0/customers/login/magic-link", h.RequestCustomerMagicLink)
+	router.POST("/v1.0/customers/login/magic-link/verify", h.VerifyCustomerMagicLink)
+	router.POST(RefreshRoute, h.RefreshCustomer)
+	router.POST("/v1.0/customers/logout", h.LogoutCustomer)
+	router.POST("/v1.0/customers/logout/all", h.LogoutCustomerAllSessions)
+	router.POST(CookieLogoutRoute, h.LogoutCustomer)
+	router.POST(CookieLogoutAllRoute, h.LogoutCustomerAllSessions)
+	router.POST("/v1.0/customers/password/forgot", h.ForgotCustomerPassword)
+	router.POST("/v1.0/customers/password/reset", h.ResetCustomerPassword)
+	router.PUT("/v1.0/customers/password", h.authMiddleware.RequireCustomer(), h.ChangeCustomerPassword)
+
+	adminRouter := router.Group("/v1.0/admin/customers", h.authMiddleware.RequirePlatformAdmin())
+	{
+		adminRouter.POST("/:customerID/deactivate", h.DeactivateCustomer)
+		adminRouter.POST("/:customerID/reactivate", h.ReactivateCustomer)
+		adminRouter.POST("/:customerID/impersonate", h.ImpersonateCustomer)
+	}
 }
 
 // RegisterCustomerRequest represents the request payload for registering a new customer.
@@ -81,6 +124,14 @@ func (h *Handler) RegisterCustomer(c *gin.Context) {
 
 	output, err := h.service.RegisterCustomer(ctx, input)
 	if err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			logger.Warn("Password does not meet the policy")
+			errResp := customhttp.NewErrorResponse(customhttp.CodeValidationError, customhttp.MsgValidationError)
+			errResp.Details = policyErr.Details("password")
+			c.JSON(http.StatusBadRequest, errResp)
+			return
+		}
 		if errors.Is(err, ErrCustomerAlreadyExists) {
 			logger.Warn("Customer already exists", log.Field{Key: "email", Value: req.Email})
 			c.JSON(
@@ -143,6 +194,16 @@ func (h *Handler) LoginCustomer(c *gin.Context) {
 			)
 			return
 		}
+		if errors.Is(err, authcore.ErrAccountLocked) {
+			logger.Warn("Login locked after too many failed attempts", log.Field{Key: "email", Value: req.Email})
+			c.JSON(
+				http.StatusTooManyRequests, customhttp.NewErrorResponse(
+					authcore.CodeAccountLocked,
+					authcore.MsgAccountLocked,
+				),
+			)
+			return
+		}
 		logger.Error("Failed to login customer", err)
 		c.JSON(
 			http.StatusInternalServerError, customhttp.NewErrorResponse(
@@ -153,8 +214,110 @@ func (h *Handler) LoginCustomer(c *gin.Context) {
 		return
 	}
 
-	resp := LoginCustomerResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
 	logger.Info("Customer logged in successfully")
+	if refreshcookie.Enabled(c) {
+		h.writeCookieTokenPair(c, output.TokenPair)
+		return
+	}
+
+	resp := LoginCustomerResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RequestCustomerMagicLinkRequest represents the request payload for requesting a magic login link for a customer.
+type RequestCustomerMagicLinkRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// RequestCustomerMagicLink handles the request of a magic link login token for a customer. The response is the same
+// whether the email is registered or not.
+func (h *Handler) RequestCustomerMagicLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("RequestCustomerMagicLink handler called")
+
+	var req RequestCustomerMagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := RequestCustomerMagicLinkInput(req)
+	if _, err := h.service.RequestCustomerMagicLink(ctx, input); err != nil {
+		logger.Error("Failed to request customer magic link", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Customer magic link requested successfully")
+	c.Status(http.StatusAccepted)
+}
+
+// VerifyCustomerMagicLinkRequest represents the request payload for logging in a customer with a magic link login
+// token.
+type VerifyCustomerMagicLinkRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// VerifyCustomerMagicLinkResponse represents the response payload for a successful customer magic link login.
+type VerifyCustomerMagicLinkResponse struct {
+	authcore.TokenPairResponse
+}
+
+// VerifyCustomerMagicLink exchanges a magic link login token for a token pair of the customer. It must be called from
+// the same device that requested the link.
+func (h *Handler) VerifyCustomerMagicLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("VerifyCustomerMagicLink handler called")
+
+	var req VerifyCustomerMagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := LoginCustomerWithMagicLinkInput(req)
+	output, err := h.service.LoginCustomerWithMagicLink(ctx, input)
+	if err != nil {
+		if errors.Is(err, authcore.ErrInvalidLoginToken) {
+			logger.Warn("Invalid login token provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidLoginToken,
+					authcore.MsgInvalidLoginToken,
+				),
+			)
+			return
+		}
+		logger.Error("Failed to login customer with magic link", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Customer logged in with magic link successfully")
+	if refreshcookie.Enabled(c) {
+		h.writeCookieTokenPair(c, output.TokenPair)
+		return
+	}
+
+	resp := VerifyCustomerMagicLinkResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -164,6 +327,12 @@ type RefreshCustomerRequest struct {
 	AccessToken  string `json:"access_token" binding:"required"`
 }
 
+// RefreshCustomerCookieRequest represents a request to refresh the customer tokens in cookie mode, where the refresh
+// token is read from its cookie instead of the payload.
+type RefreshCustomerCookieRequest struct {
+	AccessToken string `json:"access_token" binding:"required"`
+}
+
 // RefreshCustomerResponse represents the response returned when refreshing a customer's token.
 type RefreshCustomerResponse struct {
 	authcore.TokenPairResponse
@@ -176,15 +345,11 @@ func (h *Handler) RefreshCustomer(c *gin.Context) {
 
 	logger.Info("RefreshCustomer handler called")
 
-	var req RefreshCustomerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
-		errResp := customhttp.GetErrorResponseFromValidationErr(err)
-		c.JSON(http.StatusBadRequest, errResp)
+	input, ok := h.bindRefreshCustomerInput(c)
+	if !ok {
 		return
 	}
 
-	input := RefreshCustomerInput(req)
 	output, err := h.service.RefreshCustomer(c.Request.Context(), input)
 	if err != nil {
 		if errors.Is(err, authcore.ErrInvalidRefreshToken) {
@@ -217,7 +382,561 @@ func (h *Handler) RefreshCustomer(c *gin.Context) {
 		return
 	}
 
-	resp := RefreshCustomerResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
 	logger.Info("Customer refreshed successfully")
+	if refreshcookie.Enabled(c) {
+		h.writeCookieTokenPair(c, output.TokenPair)
+		return
+	}
+
+	resp := RefreshCustomerResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
+	c.JSON(http.StatusOK, resp)
+}
+
+// bindRefreshCustomerInput binds the refresh request. In cookie mode, the refresh token is read from its cookie, and
+// the CSRF token of the request must match the cookie one. It writes the error response and returns false when the
+// request is not valid.
+func (h *Handler) bindRefreshCustomerInput(c *gin.Context) (RefreshCustomerInput, bool) {
+	logger := h.logger.WithContext(c.Request.Context())
+
+	if !refreshcookie.Enabled(c) {
+		var req RefreshCustomerRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+			errResp := customhttp.GetErrorResponseFromValidationErr(err)
+			c.JSON(http.StatusBadRequest, errResp)
+			return RefreshCustomerInput{}, false
+		}
+		return RefreshCustomerInput(req), true
+	}
+
+	var req RefreshCustomerCookieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return RefreshCustomerInput{}, false
+	}
+
+	refreshToken, ok := h.cookieRefreshToken(c)
+	if !ok {
+		return RefreshCustomerInput{}, false
+	}
+	return RefreshCustomerInput{RefreshToken: refreshToken, AccessToken: req.AccessToken}, true
+}
+
+// cookieRefreshToken reads the refresh token from its cookie, once the CSRF token of the request is checked against
+// the cookie one. It writes the error response and returns false when they are not valid.
+func (h *Handler) cookieRefreshToken(c *gin.Context) (string, bool) {
+	logger := h.logger.WithContext(c.Request.Context())
+
+	refreshToken, err := refreshcookie.RefreshToken(c)
+	if err != nil {
+		if errors.Is(err, refreshcookie.ErrInvalidCSRFToken) {
+			logger.Warn("Invalid CSRF token provided")
+			c.JSON(
+				http.StatusForbidden, customhttp.NewErrorResponse(
+					refreshcookie.CodeInvalidCSRFToken,
+					refreshcookie.MsgInvalidCSRFToken,
+				),
+			)
+			return "", false
+		}
+		logger.Warn("Refresh token cookie not provided")
+		c.JSON(
+			http.StatusUnauthorized, customhttp.NewErrorResponse(
+				authcore.CodeInvalidRefreshToken,
+				authcore.MsgInvalidRefreshToken,
+			),
+		)
+		return "", false
+	}
+	return refreshToken, true
+}
+
+// writeCookieTokenPair writes the token pair for the clients in cookie mode. The refresh token is set in a cookie
+// scoped to the refresh route, and it is replaced in the response by the CSRF token the client must echo to refresh.
+func (h *Handler) writeCookieTokenPair(c *gin.Context, tokenPair authcore.TokenPair) {
+	logger := h.logger.WithContext(c.Request.Context())
+
+	csrfToken, err := refreshcookie.Set(c, RefreshRoute, CSRFCookiePath, tokenPair.RefreshToken)
+	if err != nil {
+		logger.Error("Failed to set the refresh token cookie", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	c.JSON(http.StatusOK, authcore.CookieTokenPairResponse{
+		AccessToken: tokenPair.AccessToken,
+		ExpiresIn:   tokenPair.ExpiresIn,
+		TokenType:   tokenPair.TokenType,
+		CSRFToken:   csrfToken,
+	})
+}
+
+// LogoutCustomerRequest represents the request payload for revoking the sessions of a customer.
+type LogoutCustomerRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutCustomer handles the revocation of the customer session linked to the presented refresh token.
+func (h *Handler) LogoutCustomer(c *gin.Context) {
+	h.logoutCustomer(c, "LogoutCustomer", false)
+}
+
+// LogoutCustomerAllSessions handles the revocation of all the active sessions of the customer who owns the presented refresh
+// token.
+func (h *Handler) LogoutCustomerAllSessions(c *gin.Context) {
+	h.logoutCustomer(c, "LogoutCustomerAllSessions", true)
+}
+
+func (h *Handler) logoutCustomer(c *gin.Context, handlerName string, allSessions bool) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info(handlerName + " handler called")
+
+	refreshToken, ok := h.bindLogoutRefreshToken(c)
+	if !ok {
+		return
+	}
+
+	input := LogoutCustomerInput{
+		RefreshToken: refreshToken,
+		AllSessions:  allSessions,
+	}
+	output, err := h.service.LogoutCustomer(ctx, input)
+	if err != nil {
+		if errors.Is(err, auth.ErrImpersonationNotAllowed) {
+			logger.Warn("Logout from all sessions not allowed while impersonating")
+			c.JSON(
+				http.StatusForbidden,
+				customhttp.NewErrorResponse(auth.CodeForbiddenError, auth.MessageForbiddenError),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrInvalidRefreshToken) {
+			logger.Warn("Invalid refresh token provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidRefreshToken,
+					authcore.MsgInvalidRefreshToken,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to logout customer", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Customer logged out successfully", log.Field{Key: "revoked_tokens", Value: output.RevokedTokens})
+	if refreshcookie.Enabled(c) {
+		refreshcookie.Clear(c, RefreshRoute, CSRFCookiePath)
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// bindLogoutRefreshToken returns the refresh token of the logout request. In cookie mode, it is read from its cookie
+// and no payload is expected. It writes the error response and returns false when the request is not valid.
+func (h *Handler) bindLogoutRefreshToken(c *gin.Context) (string, bool) {
+	if refreshcookie.Enabled(c) {
+		return h.cookieRefreshToken(c)
+	}
+
+	var req LogoutCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.WithContext(c.Request.Context()).Warn(
+			"Failed to bind request", log.Field{Key: "error", Value: err.Error()},
+		)
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return "", false
+	}
+	return req.RefreshToken, true
+}
+
+// ForgotCustomerPasswordRequest represents the request payload for requesting a customer password reset.
+type ForgotCustomerPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ForgotCustomerPassword handles the request of a password reset token for a customer. The response is the same
+// whether the email is registered or not.
+func (h *Handler) ForgotCustomerPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ForgotCustomerPassword handler called")
+
+	var req ForgotCustomerPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := RequestCustomerPasswordResetInput(req)
+	if _, err := h.service.RequestCustomerPasswordReset(ctx, input); err != nil {
+		logger.Error("Failed to request customer password reset", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Customer password reset requested successfully")
+	c.Status(http.StatusAccepted)
+}
+
+// ResetCustomerPasswordRequest represents the request payload for setting a new customer password with a reset token.
+type ResetCustomerPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// ResetCustomerPassword handles the reset of a customer password with a password reset token.
+func (h *Handler) ResetCustomerPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ResetCustomerPassword handler called")
+
+	var req ResetCustomerPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := ResetCustomerPasswordInput(req)
+	output, err := h.service.ResetCustomerPassword(ctx, input)
+	if err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			logger.Warn("Password does not meet the policy")
+			errResp := customhttp.NewErrorResponse(customhttp.CodeValidationError, customhttp.MsgValidationError)
+			errResp.Details = policyErr.Details("password")
+			c.JSON(http.StatusBadRequest, errResp)
+			return
+		}
+		if errors.Is(err, authcore.ErrInvalidResetToken) {
+			logger.Warn("Invalid reset token provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidResetToken,
+					authcore.MsgInvalidResetToken,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to reset customer password", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Customer password reset successfully", log.Field{Key: "revoked_tokens", Value: output.RevokedTokens})
+	c.Status(http.StatusNoContent)
+}
+
+// ChangeCustomerPasswordRequest represents the request payload for the authenticated customer to change its password.
+type ChangeCustomerPasswordRequest struct {
+	CurrentPassword     string `json:"current_password" binding:"required"`
+	NewPassword         string `json:"new_password" binding:"required,min=8"`
+	RevokeOtherSessions bool   `json:"revoke_other_sessions"`
+}
+
+// ChangeCustomerPassword handles the password change of the authenticated customer.
+func (h *Handler) ChangeCustomerPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ChangeCustomerPassword handler called")
+
+	var req ChangeCustomerPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := ChangeCustomerPasswordInput(req)
+	output, err := h.service.ChangeCustomerPassword(ctx, input)
+	if err != nil {
+		if errors.Is(err, auth.ErrImpersonationNotAllowed) {
+			logger.Warn("Password change not allowed while impersonating")
+			c.JSON(
+				http.StatusForbidden,
+				customhttp.NewErrorResponse(auth.CodeForbiddenError, auth.MessageForbiddenError),
+			)
+			return
+		}
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			logger.Warn("Password does not meet the policy")
+			errResp := customhttp.NewErrorResponse(customhttp.CodeValidationError, customhttp.MsgValidationError)
+			errResp.Details = policyErr.Details("new_password")
+			c.JSON(http.StatusBadRequest, errResp)
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidToken) {
+			logger.Warn("Authentication context not found")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					auth.CodeUnauthorizedError,
+					auth.MessageUnauthorizedError,
+				),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrInvalidCurrentPassword) {
+			logger.Warn("Invalid current password provided")
+			c.JSON(
+				http.StatusForbidden, customhttp.NewErrorResponse(
+					authcore.CodeInvalidCurrentPassword,
+					authcore.MsgInvalidCurrentPassword,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to change customer password", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Customer password changed successfully", log.Field{Key: "revoked_tokens", Value: output.RevokedTokens})
+	c.Status(http.StatusNoContent)
+}
+
+// DeactivateCustomer handles the deactivation of a customer by a platform admin or by the service owning its profile,
+// revoking all its sessions.
+func (h *Handler) DeactivateCustomer(c *gin.Context) {
+	h.setCustomerActive(c, "DeactivateCustomer", false)
+}
+
+// ReactivateCustomer handles the reactivation of a deactivated customer by a platform admin.
+func (h *Handler) ReactivateCustomer(c *gin.Context) {
+	h.setCustomerActive(c, "ReactivateCustomer", true)
+}
+
+func (h *Handler) setCustomerActive(c *gin.Context, handlerName string, active bool) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info(handlerName + " handler called")
+
+	input := SetCustomerActiveInput{
+		CustomerID: c.Param("customerID"),
+		Active:     active,
+	}
+	output, err := h.service.SetCustomerActive(ctx, input)
+	if err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("Customer not found", log.Field{Key: "customer_id", Value: input.CustomerID})
+			c.JSON(
+				http.StatusNotFound,
+				customhttp.NewErrorResponse(CodeCustomerNotFound, MsgCustomerNotFound),
+			)
+			return
+		}
+		if errors.Is(err, ErrCustomerAlreadyExists) {
+			logger.Warn("Customer already exists", log.Field{Key: "customer_id", Value: input.CustomerID})
+			c.JSON(
+				http.StatusConflict,
+				customhttp.NewErrorResponse(CodeCustomerAlreadyExists, MsgCustomerAlreadyExists),
+			)
+			return
+		}
+
+		logger.Error("Failed to set customer active status", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info(
+		"Customer active status set successfully",
+		log.Field{Key: "customer_id", Value: input.CustomerID},
+		log.Field{Key: "active", Value: active},
+		log.Field{Key: "revoked_tokens", Value: output.RevokedTokens},
+	)
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateCustomerEmailRequest represents the request payload for updating the login email of a customer.
+type UpdateCustomerEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// UpdateCustomerEmail handles the update of the login email of a customer by the service owning its profile.
+func (h *Handler) UpdateCustomerEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("UpdateCustomerEmail handler called")
+
+	var req UpdateCustomerEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := UpdateCustomerEmailInput{
+		CustomerID: c.Param("customerID"),
+		Email:      req.Email,
+	}
+	if _, err := h.service.UpdateCustomerEmail(ctx, input); err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("Customer not found", log.Field{Key: "customer_id", Value: input.CustomerID})
+			c.JSON(
+				http.StatusNotFound,
+				customhttp.NewErrorResponse(CodeCustomerNotFound, MsgCustomerNotFound),
+			)
+			return
+		}
+		if errors.Is(err, ErrCustomerAlreadyExists) {
+			logger.Warn("Customer already exists", log.Field{Key: "email", Value: req.Email})
+			c.JSON(
+				http.StatusConflict,
+				customhttp.NewErrorResponse(CodeCustomerAlreadyExists, MsgCustomerAlreadyExists),
+			)
+			return
+		}
+
+		logger.Error("Failed to update customer email", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Customer email updated successfully", log.Field{Key: "customer_id", Value: input.CustomerID})
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteCustomer handles the deletion of the credentials of a customer who closed its account, revoking all its
+// sessions.
+func (h *Handler) DeleteCustomer(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("DeleteCustomer handler called")
+
+	input := DeleteCustomerInput{CustomerID: c.Param("customerID")}
+	output, err := h.service.DeleteCustomer(ctx, input)
+	if err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("Customer not found", log.Field{Key: "customer_id", Value: input.CustomerID})
+			c.JSON(
+				http.StatusNotFound,
+				customhttp.NewErrorResponse(CodeCustomerNotFound, MsgCustomerNotFound),
+			)
+			return
+		}
+
+		logger.Error("Failed to delete customer", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info(
+		"Customer deleted successfully",
+		log.Field{Key: "customer_id", Value: input.CustomerID},
+		log.Field{Key: "revoked_tokens", Value: output.RevokedTokens},
+	)
+	c.Status(http.StatusNoContent)
+}
+
+// ImpersonateCustomerResponse represents the response returned when a platform admin impersonates a customer.
+type ImpersonateCustomerResponse struct {
+	authcore.ImpersonationTokenResponse
+}
+
+// ImpersonateCustomer handles the issuing of a short-lived access token on behalf of a customer, so a platform admin
+// can reproduce its problems.
+func (h *Handler) ImpersonateCustomer(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ImpersonateCustomer handler called")
+
+	input := ImpersonateCustomerInput{CustomerID: c.Param("customerID")}
+	output, err := h.service.ImpersonateCustomer(ctx, input)
+	if err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("Customer not found", log.Field{Key: "customer_id", Value: input.CustomerID})
+			c.JSON(
+				http.StatusNotFound,
+				customhttp.NewErrorResponse(CodeCustomerNotFound, MsgCustomerNotFound),
+			)
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidToken) {
+			logger.Warn("Authentication context not found")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					auth.CodeUnauthorizedError,
+					auth.MessageUnauthorizedError,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to impersonate customer", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := ImpersonateCustomerResponse{
+		ImpersonationTokenResponse: authcore.ImpersonationTokenResponse(output.ImpersonationToken),
+	}
+	logger.Info("Customer impersonated successfully", log.Field{Key: "customer_id", Value: input.CustomerID})
 	c.JSON(http.StatusOK, resp)
 }
@@ -3,12 +3,18 @@
 package customers_test
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
@@ -19,6 +25,8 @@ import (
 
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/customers"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refreshcookie"
 )
 
 type customerHandlerTestCase struct {
@@ -32,22 +40,64 @@ type customerHandlerTestCase struct {
 	wantStatus  int
 }
 
-var errUnexpected = errors.New("unexpected error")
+var (
+	errUnexpected = errors.New("unexpected error")
+
+	testTokenPair = authcore.TokenPair{
+		AccessToken:  "fake-token",
+		RefreshToken: "fake-refresh-token",
+		ExpiresIn:    customers.DefaultTokenExpiration,
+		TokenType:    auth.DefaultTokenType,
+	}
+)
 
 func TestHandler_RegisterCustomer(t *testing.T) {
 	logger := customhttp.SetupTestEnv()
 	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	tests := []customerHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a service, then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the service token does not have the register scope, " +
+				"then it should return a 403 with the forbidden error",
+			token: "service-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, "fake:scope")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
 		{
 			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			token:       "service-token",
 			jsonPayload: `{"password": 1.2, "email": true}`,
-			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
-			wantStatus:  http.StatusBadRequest,
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON:   customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus: http.StatusBadRequest,
 		},
 		{
 			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "service-token",
 			jsonPayload: `{}`,
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
 			wantJSON: customhttp.NewValidationErrorRespBuilder().
 				WithDetails(
 					"customer_id is required",
@@ -57,37 +107,65 @@ func TestHandler_RegisterCustomer(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 		},
 		{
-			name: "when invalid email is provided, then it should return a 400 with the email validation error",
+			name:  "when invalid email is provided, then it should return a 400 with the email validation error",
+			token: "service-token",
 			jsonPayload: `{
 				"customer_id": "fake-customer-id",
 				"email": "invalid-email",
 				"password": "ValidPassword123"
 			}`,
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
 			wantJSON: customhttp.NewValidationErrorRespBuilder().
 				WithDetails("email must be a valid email address").
 				Build(),
 			wantStatus: http.StatusBadRequest,
 		},
 		{
-			name: "when invalid password is provided, then it should return a 400 with the pwd validation error",
+			name:  "when invalid password is provided, then it should return a 400 with the pwd validation error",
+			token: "service-token",
 			jsonPayload: `{
 				"customer_id": "fake-customer-id",
 				"email": "test@example.com",
 				"password": "short"
 			}`,
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
 			wantJSON: customhttp.NewValidationErrorRespBuilder().
 				WithDetails("password must be a valid password with at least 8 characters long").
 				Build(),
 			wantStatus: http.StatusBadRequest,
 		},
 		{
-			name: "when the customer already exists, then it should return a 409 with the customer already exists error",
+			name:  "when the password does not meet the policy, then it should return a 400 with the policy violations",
+			token: "service-token",
+			jsonPayload: `{
+				"customer_id": "fake-customer-id",
+				"email": "test@example.com",
+				"password": "password"
+			}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+				service.EXPECT().RegisterCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.RegisterCustomerOutput{}, &password.PolicyError{Violations: []string{"must be at least 10 characters long", "is too common"}})
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("password must be at least 10 characters long", "password is too common").
+				Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "when the customer already exists, then it should return a 409 with the customer already exists error",
+			token: "service-token",
 			jsonPayload: `{
 				"customer_id": "fake-customer-id",
 				"email": "test@example.com",
 				"password": "ValidPassword123"
 			}`,
-			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
 				service.EXPECT().RegisterCustomer(gomock.Any(), gomock.Any()).
 					Return(customers.RegisterCustomerOutput{}, customers.ErrCustomerAlreadyExists)
 			},
@@ -99,13 +177,15 @@ func TestHandler_RegisterCustomer(t *testing.T) {
 			wantStatus: http.StatusConflict,
 		},
 		{
-			name: "when unexpected error when registering the customer, then it should return a 500 with the internal error",
+			name:  "when unexpected error when registering the customer, then it should return a 500 with the internal error",
+			token: "service-token",
 			jsonPayload: `{
 				"customer_id": "fake-customer-id",
 				"email": "test@example.com",
 				"password": "ValidPassword123"
 			}`,
-			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
 				service.EXPECT().RegisterCustomer(gomock.Any(), gomock.Any()).
 					Return(customers.RegisterCustomerOutput{}, errUnexpected)
 			},
@@ -113,13 +193,15 @@ func TestHandler_RegisterCustomer(t *testing.T) {
 			wantStatus: http.StatusInternalServerError,
 		},
 		{
-			name: "when the customer is successfully registered, then it should return a 201 with the customer details",
+			name:  "when the customer is successfully registered, then it should return a 201 with the customer details",
+			token: "service-token",
 			jsonPayload: `{
 				"customer_id": "fake-customer-id",
 				"email": "test@example.com",
 				"password": "ValidPassword123"
 			}`,
-			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
 				service.EXPECT().RegisterCustomer(
 					gomock.Any(), customers.RegisterCustomerInput{
 						CustomerID: "fake-customer-id",
@@ -148,7 +230,7 @@ func TestHandler_RegisterCustomer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(
 			tt.name, func(t *testing.T) {
-				runCustomerHandlerTestCase(t, logger, http.MethodPost, "/v1.0/auth/customers", tt, "")
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, "/v1.0/auth/customers", tt, tt.token)
 			},
 		)
 	}
@@ -205,6 +287,20 @@ func TestHandler_LoginCustomer(t *testing.T) {
 			}`,
 			wantStatus: http.StatusUnauthorized,
 		},
+		{
+			name:        "when the customer login is locked, then it should return a 429 with account locked error",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.LoginCustomerOutput{}, authcore.ErrAccountLocked)
+			},
+			wantJSON: `{
+				"code": "ACCOUNT_LOCKED",
+				"message": "too many failed login attempts, try again later",
+				"details": []
+			}`,
+			wantStatus: http.StatusTooManyRequests,
+		},
 		{
 			name:        "when unexpected error when login the customer, then it should return a 500 with the internal error",
 			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
@@ -254,6 +350,129 @@ func TestHandler_LoginCustomer(t *testing.T) {
 	}
 }
 
+func TestHandler_RequestCustomerMagicLink(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			jsonPayload: `{"email": true}`,
+			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "when invalid email is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{"email": "invalid-email"}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("email must be a valid email address").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when unexpected error when requesting the magic link, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"email": "test@example.com"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RequestCustomerMagicLink(gomock.Any(), gomock.Any()).
+					Return(customers.RequestCustomerMagicLinkOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the magic link is requested, then it should return a 202 without content",
+			jsonPayload: `{"email": "test@example.com"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RequestCustomerMagicLink(gomock.Any(), customers.RequestCustomerMagicLinkInput{
+					Email: "test@example.com",
+				}).Return(customers.RequestCustomerMagicLinkOutput{}, nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+	}
+
+	route := "/v1.0/customers/login/magic-link"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+			},
+		)
+	}
+}
+
+func TestHandler_VerifyCustomerMagicLink(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON:    customhttp.NewValidationErrorRespBuilder().WithDetails("token is required").Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name: "when invalid login token provided, " +
+				"then it should return a 401 with the invalid login token error",
+			jsonPayload: `{"token": "invalid-login-token"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginCustomerWithMagicLink(gomock.Any(), gomock.Any()).
+					Return(customers.LoginCustomerWithMagicLinkOutput{}, authcore.ErrInvalidLoginToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_LOGIN_TOKEN",
+				"message": "invalid or expired login token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when unexpected error when login the customer with the magic link, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"token": "fake-login-token"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginCustomerWithMagicLink(gomock.Any(), gomock.Any()).
+					Return(customers.LoginCustomerWithMagicLinkOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the login token is valid, then it should return a 200 with the token",
+			jsonPayload: `{"token": "fake-login-token"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginCustomerWithMagicLink(gomock.Any(), customers.LoginCustomerWithMagicLinkInput{
+					Token: "fake-login-token",
+				}).Return(
+					customers.LoginCustomerWithMagicLinkOutput{
+						TokenPair: authcore.TokenPair{
+							AccessToken:  "fake-token",
+							RefreshToken: "fake-refresh-token",
+							ExpiresIn:    customers.DefaultTokenExpiration,
+							TokenType:    auth.DefaultTokenType,
+						},
+					}, nil,
+				)
+			},
+			wantJSON: `{
+			  "access_token": "fake-token",
+			  "refresh_token": "fake-refresh-token",
+			  "expires_in": 3600,
+			  "token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	route := "/v1.0/customers/login/magic-link/verify"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+			},
+		)
+	}
+}
+
 func TestHandler_RefreshCustomer(t *testing.T) {
 	logger := customhttp.SetupTestEnv()
 
@@ -354,31 +573,1321 @@ func TestHandler_RefreshCustomer(t *testing.T) {
 	}
 }
 
-// runCustomerHandlerTestCase executes a test case for the customer handler, which is common for all tests.
-func runCustomerHandlerTestCase(
-	t *testing.T,
-	logger log.Logger,
-	httpMethod string,
-	route string,
-	tt customerHandlerTestCase,
-	token string,
-) {
-	// Create a new mock service
+type customerCookieModeTestCase struct {
+	name        string
+	jsonPayload string
+	headers     map[string]string
+	cookies     []*http.Cookie
+	mocksSetup  func(service *customersmocks.MockService)
+	wantJSON    string
+	// wantTokens is the token pair expected along with the refresh token cookie, whose CSRF token is the one set in its
+	// cookie.
+	wantTokens        authcore.CookieTokenPairResponse
+	wantRefreshCookie string
+	// wantClearedCookies reports whether the refresh token and CSRF cookies are expected to be expired.
+	wantClearedCookies bool
+	wantStatus         int
+}
+
+func TestHandler_LoginCustomer_CookieMode(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerCookieModeTestCase{
+		{
+			name: "when the customer logs in with the cookie mode, " +
+				"then it should return a 200 with the refresh token in a cookie",
+			jsonPayload: `{"email": "test@example.com", "password": "ValidPassword123"}`,
+			headers:     map[string]string{refreshcookie.ModeHeader: refreshcookie.ModeCookie},
+			mocksSetup: func(service *customersmocks.MockService) {
+				service.EXPECT().LoginCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.LoginCustomerOutput{TokenPair: testTokenPair}, nil)
+			},
+			wantTokens: authcore.CookieTokenPairResponse{
+				AccessToken: "fake-token",
+				ExpiresIn:   customers.DefaultTokenExpiration,
+				TokenType:   auth.DefaultTokenType,
+			},
+			wantRefreshCookie: "fake-refresh-token",
+			wantStatus:        http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCustomerCookieModeTestCase(t, logger, "/v1.0/customers/login", tt)
+		})
+	}
+}
+
+func TestHandler_RefreshCustomer_CookieMode(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	cookieMode := map[string]string{
+		refreshcookie.ModeHeader: refreshcookie.ModeCookie,
+		refreshcookie.CSRFHeader: "fake-csrf-token",
+	}
+	cookies := []*http.Cookie{
+		{Name: refreshcookie.RefreshTokenCookieName, Value: "valid-refresh-token"},
+		{Name: refreshcookie.CSRFCookieName, Value: "fake-csrf-token"},
+	}
+
+	tests := []customerCookieModeTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			headers:     cookieMode,
+			cookies:     cookies,
+			wantJSON:    customhttp.NewValidationErrorRespBuilder().WithDetails("access_token is required").Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name: "when the refresh token cookie is not provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			jsonPayload: `{"access_token": "valid-access-token"}`,
+			headers:     cookieMode,
+			cookies:     []*http.Cookie{{Name: refreshcookie.CSRFCookieName, Value: "fake-csrf-token"}},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the CSRF token does not match its cookie, " +
+				"then it should return a 403 with the invalid CSRF token error",
+			jsonPayload: `{"access_token": "valid-access-token"}`,
+			headers: map[string]string{
+				refreshcookie.ModeHeader: refreshcookie.ModeCookie,
+				refreshcookie.CSRFHeader: "fake-other-csrf-token",
+			},
+			cookies: cookies,
+			wantJSON: `{
+				"code": "INVALID_CSRF_TOKEN",
+				"message": "invalid or missing csrf token",
+				"details": []
+			}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the refresh token cookie is valid, " +
+				"then it should return a 200 with the new refresh token in a cookie",
+			jsonPayload: `{"access_token": "valid-access-token"}`,
+			headers:     cookieMode,
+			cookies:     cookies,
+			mocksSetup: func(service *customersmocks.MockService) {
+				service.EXPECT().RefreshCustomer(gomock.Any(), customers.RefreshCustomerInput{
+					RefreshToken: "valid-refresh-token",
+					AccessToken:  "valid-access-token",
+				}).Return(customers.RefreshCustomerOutput{TokenPair: testTokenPair}, nil)
+			},
+			wantTokens: authcore.CookieTokenPairResponse{
+				AccessToken: "fake-token",
+				ExpiresIn:   customers.DefaultTokenExpiration,
+				TokenType:   auth.DefaultTokenType,
+			},
+			wantRefreshCookie: "fake-refresh-token",
+			wantStatus:        http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCustomerCookieModeTestCase(t, logger, customers.RefreshRoute, tt)
+		})
+	}
+}
+
+func TestHandler_RefreshCustomer_CookieModeAfterReload(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
 	service := customersmocks.NewMockService(gomock.NewController(t))
+	service.EXPECT().LoginCustomer(gomock.Any(), gomock.Any()).
+		Return(customers.LoginCustomerOutput{TokenPair: testTokenPair}, nil)
+	service.EXPECT().RefreshCustomer(gomock.Any(), customers.RefreshCustomerInput{
+		RefreshToken: "fake-refresh-token",
+		AccessToken:  "fake-token",
+	}).Return(customers.RefreshCustomerOutput{TokenPair: testTokenPair}, nil)
+
 	authService := authmocks.NewMockService(gomock.NewController(t))
-	if tt.mocksSetup != nil {
-		tt.mocksSetup(service, authService)
+	h := customers.NewHandler(logger, service, auth.NewMiddleware(logger, authService))
+	router := gin.New()
+	h.RegisterRoutes(router)
+
+	loginReq := httptest.NewRequest(
+		http.MethodPost,
+		"/v1.0/customers/login",
+		strings.NewReader(`{"email": "test@example.com", "password": "ValidPassword123"}`),
+	)
+	loginReq.Header.Set("Content-Type", "application/json")
+	loginReq.Header.Set(refreshcookie.ModeHeader, refreshcookie.ModeCookie)
+	loginW := httptest.NewRecorder()
+	router.ServeHTTP(loginW, loginReq)
+	require.Equal(t, http.StatusOK, loginW.Code)
+
+	// After a reload, the in-memory CSRF token of the login response is lost, so the client reads it back from its
+	// cookie, which must be readable by the pages of the client.
+	var csrfToken string
+	for _, cookie := range loginW.Result().Cookies() {
+		if cookie.Name == refreshcookie.CSRFCookieName {
+			assert.False(t, cookie.HttpOnly)
+			assert.Equal(t, customers.CSRFCookiePath, cookie.Path)
+			csrfToken = cookie.Value
+		}
 	}
+	require.NotEmpty(t, csrfToken)
 
-	// Initialize the authentication middleware
-	authMiddleware := auth.NewMiddleware(logger, authService)
+	refreshReq := httptest.NewRequest(
+		http.MethodPost, customers.RefreshRoute, strings.NewReader(`{"access_token": "fake-token"}`),
+	)
+	refreshReq.Header.Set("Content-Type", "application/json")
+	refreshReq.Header.Set(refreshcookie.ModeHeader, refreshcookie.ModeCookie)
+	refreshReq.Header.Set(refreshcookie.CSRFHeader, csrfToken)
+	addBrowserCookies(refreshReq, loginW.Result().Cookies())
+	refreshW := httptest.NewRecorder()
+	router.ServeHTTP(refreshW, refreshReq)
 
-	// Initialize the handler
-	h := customers.NewHandler(logger, service, authMiddleware)
+	assert.Equal(t, http.StatusOK, refreshW.Code)
+}
 
-	// Make HTTP request
-	w := customhttp.ServeTestHTTPRequest(t, h, httpMethod, route, token, tt.queryParams, tt.jsonPayload)
+func TestHandler_LogoutCustomer_CookieModeAfterLogin(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
 
-	assert.Equal(t, tt.wantStatus, w.Code)
-	assert.JSONEq(t, tt.wantJSON, w.Body.String())
+	service := customersmocks.NewMockService(gomock.NewController(t))
+	service.EXPECT().LoginCustomer(gomock.Any(), gomock.Any()).
+		Return(customers.LoginCustomerOutput{TokenPair: testTokenPair}, nil)
+	service.EXPECT().LogoutCustomer(gomock.Any(), customers.LogoutCustomerInput{
+		RefreshToken: "fake-refresh-token",
+		AllSessions:  false,
+	}).Return(customers.LogoutCustomerOutput{RevokedTokens: 1}, nil)
+
+	authService := authmocks.NewMockService(gomock.NewController(t))
+	h := customers.NewHandler(logger, service, auth.NewMiddleware(logger, authService))
+	router := gin.New()
+	h.RegisterRoutes(router)
+
+	loginReq := httptest.NewRequest(
+		http.MethodPost,
+		"/v1.0/customers/login",
+		strings.NewReader(`{"email": "test@example.com", "password": "ValidPassword123"}`),
+	)
+	loginReq.Header.Set("Content-Type", "application/json")
+	loginReq.Header.Set(refreshcookie.ModeHeader, refreshcookie.ModeCookie)
+	loginW := httptest.NewRecorder()
+	router.ServeHTTP(loginW, loginReq)
+	require.Equal(t, http.StatusOK, loginW.Code)
+
+	var got authcore.CookieTokenPairResponse
+	require.NoError(t, json.Unmarshal(loginW.Body.Bytes(), &got))
+
+	// The browser only sends the cookies whose path covers the logout route
+	logoutReq := httptest.NewRequest(http.MethodPost, customers.CookieLogoutRoute, nil)
+	logoutReq.Header.Set(refreshcookie.ModeHeader, refreshcookie.ModeCookie)
+	logoutReq.Header.Set(refreshcookie.CSRFHeader, got.CSRFToken)
+	addBrowserCookies(logoutReq, loginW.Result().Cookies())
+	logoutW := httptest.NewRecorder()
+	router.ServeHTTP(logoutW, logoutReq)
+
+	assert.Equal(t, http.StatusNoContent, logoutW.Code)
+}
+
+func TestHandler_LogoutCustomer_CookieMode(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	cookieMode := map[string]string{
+		refreshcookie.ModeHeader: refreshcookie.ModeCookie,
+		refreshcookie.CSRFHeader: "fake-csrf-token",
+	}
+	cookies := []*http.Cookie{
+		{Name: refreshcookie.RefreshTokenCookieName, Value: "valid-refresh-token"},
+		{Name: refreshcookie.CSRFCookieName, Value: "fake-csrf-token"},
+	}
+
+	tests := []customerCookieModeTestCase{
+		{
+			name: "when the refresh token cookie is not provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			headers: cookieMode,
+			cookies: []*http.Cookie{{Name: refreshcookie.CSRFCookieName, Value: "fake-csrf-token"}},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the CSRF token does not match its cookie, " +
+				"then it should return a 403 with the invalid CSRF token error",
+			headers: map[string]string{
+				refreshcookie.ModeHeader: refreshcookie.ModeCookie,
+				refreshcookie.CSRFHeader: "fake-other-csrf-token",
+			},
+			cookies: cookies,
+			wantJSON: `{
+				"code": "INVALID_CSRF_TOKEN",
+				"message": "invalid or missing csrf token",
+				"details": []
+			}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when invalid refresh token cookie provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			headers: cookieMode,
+			cookies: cookies,
+			mocksSetup: func(service *customersmocks.MockService) {
+				service.EXPECT().LogoutCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.LogoutCustomerOutput{}, authcore.ErrInvalidRefreshToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the customer is logged out with the refresh token cookie, " +
+				"then it should return a 204 and expire the cookies",
+			headers: cookieMode,
+			cookies: cookies,
+			mocksSetup: func(service *customersmocks.MockService) {
+				service.EXPECT().LogoutCustomer(gomock.Any(), customers.LogoutCustomerInput{
+					RefreshToken: "valid-refresh-token",
+					AllSessions:  false,
+				}).Return(customers.LogoutCustomerOutput{RevokedTokens: 1}, nil)
+			},
+			wantClearedCookies: true,
+			wantStatus:         http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCustomerCookieModeTestCase(t, logger, customers.CookieLogoutRoute, tt)
+		})
+	}
+}
+
+func TestHandler_LogoutCustomerAllSessions_CookieMode(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	cookieMode := map[string]string{
+		refreshcookie.ModeHeader: refreshcookie.ModeCookie,
+		refreshcookie.CSRFHeader: "fake-csrf-token",
+	}
+
+	tests := []customerCookieModeTestCase{
+		{
+			name: "when the refresh token cookie is not provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			headers: cookieMode,
+			cookies: []*http.Cookie{{Name: refreshcookie.CSRFCookieName, Value: "fake-csrf-token"}},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when all the customer sessions are revoked with the refresh token cookie, " +
+				"then it should return a 204 and expire the cookies",
+			headers: cookieMode,
+			cookies: []*http.Cookie{
+				{Name: refreshcookie.RefreshTokenCookieName, Value: "valid-refresh-token"},
+				{Name: refreshcookie.CSRFCookieName, Value: "fake-csrf-token"},
+			},
+			mocksSetup: func(service *customersmocks.MockService) {
+				service.EXPECT().LogoutCustomer(gomock.Any(), customers.LogoutCustomerInput{
+					RefreshToken: "valid-refresh-token",
+					AllSessions:  true,
+				}).Return(customers.LogoutCustomerOutput{RevokedTokens: 3}, nil)
+			},
+			wantClearedCookies: true,
+			wantStatus:         http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCustomerCookieModeTestCase(t, logger, customers.CookieLogoutAllRoute, tt)
+		})
+	}
+}
+
+func TestHandler_LogoutCustomer(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			jsonPayload: `{"refresh_token": true}`,
+			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("refresh_token is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when invalid refresh token provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			jsonPayload: `{"refresh_token": "invalid-refresh-token"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.LogoutCustomerOutput{}, authcore.ErrInvalidRefreshToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when unexpected error when logging out the customer, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.LogoutCustomerOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the customer is logged out, then it should return a 204 without content",
+			jsonPayload: `{"refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutCustomer(gomock.Any(), customers.LogoutCustomerInput{
+					RefreshToken: "valid-refresh-token",
+					AllSessions:  false,
+				}).Return(customers.LogoutCustomerOutput{RevokedTokens: 1}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/customers/logout"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+			},
+		)
+	}
+}
+
+func TestHandler_LogoutCustomerAllSessions(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("refresh_token is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when all the sessions are revoked while impersonating, " +
+				"then it should return a 403 with the forbidden error",
+			jsonPayload: `{"refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.LogoutCustomerOutput{}, auth.ErrImpersonationNotAllowed)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when invalid refresh token provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			jsonPayload: `{"refresh_token": "invalid-refresh-token"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.LogoutCustomerOutput{}, authcore.ErrInvalidRefreshToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "when all the customer sessions are revoked, then it should return a 204 without content",
+			jsonPayload: `{"refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutCustomer(gomock.Any(), customers.LogoutCustomerInput{
+					RefreshToken: "valid-refresh-token",
+					AllSessions:  true,
+				}).Return(customers.LogoutCustomerOutput{RevokedTokens: 3}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/customers/logout/all"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+			},
+		)
+	}
+}
+
+func TestHandler_ForgotCustomerPassword(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			jsonPayload: `{"email": true}`,
+			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "when invalid email is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{"email": "invalid-email"}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("email must be a valid email address").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when unexpected error when requesting the password reset, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"email": "test@example.com"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RequestCustomerPasswordReset(gomock.Any(), gomock.Any()).
+					Return(customers.RequestCustomerPasswordResetOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the password reset is requested, then it should return a 202 without content",
+			jsonPayload: `{"email": "test@example.com"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RequestCustomerPasswordReset(gomock.Any(), customers.RequestCustomerPasswordResetInput{
+					Email: "test@example.com",
+				}).Return(customers.RequestCustomerPasswordResetOutput{}, nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+	}
+
+	route := "/v1.0/customers/password/forgot"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+			},
+		)
+	}
+}
+
+func TestHandler_ResetCustomerPassword(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"token is required",
+					"password is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when invalid password is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{"token": "fake-reset-token", "password": "short"}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("password must be a valid password with at least 8 characters long").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when invalid reset token provided, " +
+				"then it should return a 401 with the invalid reset token error",
+			jsonPayload: `{"token": "invalid-reset-token", "password": "NewPassword123"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().ResetCustomerPassword(gomock.Any(), gomock.Any()).
+					Return(customers.ResetCustomerPasswordOutput{}, authcore.ErrInvalidResetToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_RESET_TOKEN",
+				"message": "invalid or expired reset token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when unexpected error when resetting the password, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"token": "fake-reset-token", "password": "NewPassword123"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().ResetCustomerPassword(gomock.Any(), gomock.Any()).
+					Return(customers.ResetCustomerPasswordOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the password is reset, then it should return a 204 without content",
+			jsonPayload: `{"token": "fake-reset-token", "password": "NewPassword123"}`,
+			mocksSetup: func(service *customersmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().ResetCustomerPassword(gomock.Any(), customers.ResetCustomerPasswordInput{
+					Token:    "fake-reset-token",
+					Password: "NewPassword123",
+				}).Return(customers.ResetCustomerPasswordOutput{RevokedTokens: 2}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/customers/password/reset"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+			},
+		)
+	}
+}
+
+func TestHandler_ChangeCustomerPassword(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a customer, then it should return a 403 with the forbidden error",
+			token: "staff-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-tenant-id")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "valid-token",
+			jsonPayload: `{}`,
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"current_password is required",
+					"new_password is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when invalid new password is provided, then it should return a 400 with the validation error",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "short"}`,
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("new_password must be at least 8 characters long").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the new password does not meet the policy, " +
+				"then it should return a 400 with the policy violations",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "password"}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+				service.EXPECT().ChangeCustomerPassword(gomock.Any(), gomock.Any()).
+					Return(customers.ChangeCustomerPasswordOutput{}, &password.PolicyError{Violations: []string{"must be at least 10 characters long", "is too common"}})
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("new_password must be at least 10 characters long", "new_password is too common").
+				Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the current password is not valid, " +
+				"then it should return a 403 with the invalid current password error",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "WrongPassword123", "new_password": "NewPassword123"}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+				service.EXPECT().ChangeCustomerPassword(gomock.Any(), gomock.Any()).
+					Return(customers.ChangeCustomerPasswordOutput{}, authcore.ErrInvalidCurrentPassword)
+			},
+			wantJSON: `{
+				"code": "INVALID_CURRENT_PASSWORD",
+				"message": "current password is incorrect",
+				"details": []
+			}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the customer is impersonated, " +
+				"then it should return a 403 with the forbidden error",
+			token:       "impersonation-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "NewPassword123"}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+				service.EXPECT().ChangeCustomerPassword(gomock.Any(), gomock.Any()).
+					Return(customers.ChangeCustomerPasswordOutput{}, auth.ErrImpersonationNotAllowed)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the authenticated customer no longer exists, " +
+				"then it should return a 401 with the unauthorized error",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "NewPassword123"}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+				service.EXPECT().ChangeCustomerPassword(gomock.Any(), gomock.Any()).
+					Return(customers.ChangeCustomerPasswordOutput{}, auth.ErrInvalidToken)
+			},
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when unexpected error when changing the password, " +
+				"then it should return a 500 with the internal error",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "NewPassword123"}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+				service.EXPECT().ChangeCustomerPassword(gomock.Any(), gomock.Any()).
+					Return(customers.ChangeCustomerPasswordOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "when the password is changed, then it should return a 204 without content",
+			token: "valid-token",
+			jsonPayload: `{
+				"current_password": "CurrentPassword123",
+				"new_password": "NewPassword123",
+				"revoke_other_sessions": true
+			}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+				service.EXPECT().ChangeCustomerPassword(gomock.Any(), customers.ChangeCustomerPasswordInput{
+					CurrentPassword:     "CurrentPassword123",
+					NewPassword:         "NewPassword123",
+					RevokeOtherSessions: true,
+				}).Return(customers.ChangeCustomerPasswordOutput{RevokedTokens: 2}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/customers/password"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPut, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_DeactivateCustomer(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when authenticated user is not a platform admin, " +
+				"then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:  "when the customer does not exist, then it should return a 404 with the customer not found error",
+			token: "admin-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetCustomerActive(gomock.Any(), gomock.Any()).
+					Return(customers.SetCustomerActiveOutput{}, customers.ErrCustomerNotFound)
+			},
+			wantJSON: `{
+				"code": "CUSTOMER_NOT_FOUND",
+				"message": "customer not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "when unexpected error when deactivating the customer, " +
+				"then it should return a 500 with the internal error",
+			token: "admin-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetCustomerActive(gomock.Any(), gomock.Any()).
+					Return(customers.SetCustomerActiveOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "when the customer is deactivated, then it should return a 204 without content",
+			token: "admin-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetCustomerActive(gomock.Any(), customers.SetCustomerActiveInput{
+					CustomerID: "fake-customer-id",
+					Active:     false,
+				}).Return(customers.SetCustomerActiveOutput{RevokedTokens: 2}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/admin/customers/fake-customer-id/deactivate"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_ReactivateCustomer(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name: "when authenticated user is not a platform admin, " +
+				"then it should return a 403 with the forbidden error",
+			token: "staff-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-tenant-id")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:  "when the customer does not exist, then it should return a 404 with the customer not found error",
+			token: "admin-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetCustomerActive(gomock.Any(), gomock.Any()).
+					Return(customers.SetCustomerActiveOutput{}, customers.ErrCustomerNotFound)
+			},
+			wantJSON: `{
+				"code": "CUSTOMER_NOT_FOUND",
+				"message": "customer not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "when another active customer has the same email, " +
+				"then it should return a 409 with the customer already exists error",
+			token: "admin-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetCustomerActive(gomock.Any(), gomock.Any()).
+					Return(customers.SetCustomerActiveOutput{}, customers.ErrCustomerAlreadyExists)
+			},
+			wantJSON: `{
+				"code": "CUSTOMER_ALREADY_EXISTS",
+				"message": "customer already exists",
+				"details": []
+			}`,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:  "when the customer is reactivated, then it should return a 204 without content",
+			token: "admin-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetCustomerActive(gomock.Any(), customers.SetCustomerActiveInput{
+					CustomerID: "fake-customer-id",
+					Active:     true,
+				}).Return(customers.SetCustomerActiveOutput{}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/admin/customers/fake-customer-id/reactivate"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_DeactivateCustomerFromService(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name: "when the service token does not have the manage scope, " +
+				"then it should return a 403 with the forbidden error",
+			token: "service-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:  "when the customer is deactivated, then it should return a 204 without content",
+			token: "service-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().SetCustomerActive(gomock.Any(), customers.SetCustomerActiveInput{
+					CustomerID: "fake-customer-id",
+					Active:     false,
+				}).Return(customers.SetCustomerActiveOutput{RevokedTokens: 2}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/auth/customers/fake-customer-id/deactivate"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_UpdateCustomerEmail(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a service, then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the service token does not have the manage scope, " +
+				"then it should return a 403 with the forbidden error",
+			token: "service-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "service-token",
+			jsonPayload: `{}`,
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("email is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when invalid email is provided, then it should return a 400 with the email validation error",
+			token:       "service-token",
+			jsonPayload: `{"email": "invalid-email"}`,
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("email must be a valid email address").
+				Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the customer does not exist, " +
+				"then it should return a 404 with the customer not found error",
+			token:       "service-token",
+			jsonPayload: `{"email": "new@example.com"}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().UpdateCustomerEmail(gomock.Any(), gomock.Any()).
+					Return(customers.UpdateCustomerEmailOutput{}, customers.ErrCustomerNotFound)
+			},
+			wantJSON: `{
+				"code": "CUSTOMER_NOT_FOUND",
+				"message": "customer not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "when another active customer has the same email, " +
+				"then it should return a 409 with the customer already exists error",
+			token:       "service-token",
+			jsonPayload: `{"email": "new@example.com"}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().UpdateCustomerEmail(gomock.Any(), gomock.Any()).
+					Return(customers.UpdateCustomerEmailOutput{}, customers.ErrCustomerAlreadyExists)
+			},
+			wantJSON: `{
+				"code": "CUSTOMER_ALREADY_EXISTS",
+				"message": "customer already exists",
+				"details": []
+			}`,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "when unexpected error when updating the email, " +
+				"then it should return a 500 with the internal error",
+			token:       "service-token",
+			jsonPayload: `{"email": "new@example.com"}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().UpdateCustomerEmail(gomock.Any(), gomock.Any()).
+					Return(customers.UpdateCustomerEmailOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the email is updated, then it should return a 204 without content",
+			token:       "service-token",
+			jsonPayload: `{"email": "new@example.com"}`,
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().UpdateCustomerEmail(gomock.Any(), customers.UpdateCustomerEmailInput{
+					CustomerID: "fake-customer-id",
+					Email:      "new@example.com",
+				}).Return(customers.UpdateCustomerEmailOutput{}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/auth/customers/fake-customer-id/email"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPut, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_DeleteCustomer(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the service token does not have the manage scope, " +
+				"then it should return a 403 with the forbidden error",
+			token: "service-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:  "when the customer does not exist, then it should return a 404 with the customer not found error",
+			token: "service-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().DeleteCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.DeleteCustomerOutput{}, customers.ErrCustomerNotFound)
+			},
+			wantJSON: `{
+				"code": "CUSTOMER_NOT_FOUND",
+				"message": "customer not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "when unexpected error when deleting the customer, " +
+				"then it should return a 500 with the internal error",
+			token: "service-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().DeleteCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.DeleteCustomerOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "when the customer is deleted, then it should return a 204 without content",
+			token: "service-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().DeleteCustomer(gomock.Any(), customers.DeleteCustomerInput{
+					CustomerID: "fake-customer-id",
+				}).Return(customers.DeleteCustomerOutput{RevokedTokens: 2}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/auth/customers/fake-customer-id"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodDelete, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_ImpersonateCustomer(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []customerHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when authenticated user is not a platform admin, " +
+				"then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:  "when the customer does not exist, then it should return a 404 with the customer not found error",
+			token: "admin-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().ImpersonateCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.ImpersonateCustomerOutput{}, customers.ErrCustomerNotFound)
+			},
+			wantJSON: `{
+				"code": "CUSTOMER_NOT_FOUND",
+				"message": "customer not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "when unexpected error when impersonating the customer, " +
+				"then it should return a 500 with the internal error",
+			token: "admin-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().ImpersonateCustomer(gomock.Any(), gomock.Any()).
+					Return(customers.ImpersonateCustomerOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "when the customer is impersonated, " +
+				"then it should return a 200 with the access token and without refresh token",
+			token: "admin-token",
+			mocksSetup: func(service *customersmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().ImpersonateCustomer(gomock.Any(), customers.ImpersonateCustomerInput{
+					CustomerID: "fake-customer-id",
+				}).Return(customers.ImpersonateCustomerOutput{
+					ImpersonationToken: authcore.ImpersonationToken{
+						AccessToken: "fake-token",
+						ExpiresIn:   authcore.ImpersonationTokenExpiration,
+						TokenType:   "Bearer",
+					},
+				}, nil)
+			},
+			wantJSON: `{
+				"access_token": "fake-token",
+				"expires_in": 600,
+				"token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	route := "/v1.0/admin/customers/fake-customer-id/impersonate"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runCustomerHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func mockClaims(authService *authmocks.MockService, role auth.Role, tenant string) {
+	authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+		Return(auth.GetClaimsOutput{
+			Claims: &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-user-id"},
+				Role:             string(role),
+				Tenant:           tenant,
+			},
+		}, nil)
+}
+
+func mockServiceClaims(authService *authmocks.MockService, scope string) {
+	authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+		Return(auth.GetClaimsOutput{
+			Claims: &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-service-id"},
+				Role:             string(auth.RoleService),
+				Scope:            scope,
+			},
+		}, nil)
+}
+
+// runCustomerHandlerTestCase executes a test case for the customer handler, which is common for all tests.
+func runCustomerHandlerTestCase(
+	t *testing.T,
+	logger log.Logger,
+	httpMethod string,
+	route string,
+	tt customerHandlerTestCase,
+	token string,
+) {
+	// Create a new mock service
+	service := customersmocks.NewMockService(gomock.NewController(t))
+	authService := authmocks.NewMockService(gomock.NewController(t))
+	if tt.mocksSetup != nil {
+		tt.mocksSetup(service, authService)
+	}
+
+	// Initialize the authentication middleware
+	authMiddleware := auth.NewMiddleware(logger, authService)
+
+	// Initialize the handler
+	h := customers.NewHandler(logger, service, authMiddleware)
+
+	// Make HTTP request
+	w := customhttp.ServeTestHTTPRequest(t, h, httpMethod, route, token, tt.queryParams, tt.jsonPayload)
+
+	assert.Equal(t, tt.wantStatus, w.Code)
+	if tt.wantJSON == "" {
+		assert.Empty(t, w.Body.String())
+		return
+	}
+	assert.JSONEq(t, tt.wantJSON, w.Body.String())
+}
+
+// runCustomerCookieModeTestCase executes a test case for the customer handler routes that support the cookie mode,
+// whose requests carry their own headers and cookies.
+func runCustomerCookieModeTestCase(t *testing.T, logger log.Logger, route string, tt customerCookieModeTestCase) {
+	service := customersmocks.NewMockService(gomock.NewController(t))
+	if tt.mocksSetup != nil {
+		tt.mocksSetup(service)
+	}
+
+	authService := authmocks.NewMockService(gomock.NewController(t))
+	h := customers.NewHandler(logger, service, auth.NewMiddleware(logger, authService))
+	router := gin.New()
+	h.RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodPost, route, strings.NewReader(tt.jsonPayload))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range tt.headers {
+		req.Header.Set(k, v)
+	}
+	for _, cookie := range tt.cookies {
+		req.AddCookie(cookie)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, tt.wantStatus, w.Code)
+	if tt.wantClearedCookies {
+		assert.Empty(t, w.Body.String())
+		require.Len(t, w.Result().Cookies(), 2)
+		for _, cookie := range w.Result().Cookies() {
+			assert.Equal(t, customerCookiePath(cookie.Name), cookie.Path)
+			assert.Empty(t, cookie.Value)
+			assert.Negative(t, cookie.MaxAge)
+		}
+		return
+	}
+	if tt.wantRefreshCookie == "" {
+		assert.JSONEq(t, tt.wantJSON, w.Body.String())
+		assert.Empty(t, w.Result().Cookies())
+		return
+	}
+
+	cookies := map[string]string{}
+	for _, cookie := range w.Result().Cookies() {
+		assert.Equal(t, customerCookiePath(cookie.Name), cookie.Path)
+		assert.Equal(t, cookie.Name == refreshcookie.RefreshTokenCookieName, cookie.HttpOnly)
+		cookies[cookie.Name] = cookie.Value
+	}
+	assert.Equal(t, tt.wantRefreshCookie, cookies[refreshcookie.RefreshTokenCookieName])
+	assert.NotEmpty(t, cookies[refreshcookie.CSRFCookieName])
+
+	var got authcore.CookieTokenPairResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	tt.wantTokens.CSRFToken = cookies[refreshcookie.CSRFCookieName]
+	assert.Equal(t, tt.wantTokens, got)
+	assert.NotContains(t, w.Body.String(), "refresh_token")
+}
+
+// customerCookiePath returns the path the given cookie of the cookie mode is expected to be scoped to.
+func customerCookiePath(name string) string {
+	if name == refreshcookie.RefreshTokenCookieName {
+		return customers.RefreshRoute
+	}
+	return customers.CSRFCookiePath
+}
+
+// addBrowserCookies adds the given response cookies to the request as a browser would, only when their path covers
+// the request path.
+func addBrowserCookies(req *http.Request, cookies []*http.Cookie) {
+	for _, cookie := range cookies {
+		if cookie.Path == req.URL.Path || strings.HasPrefix(req.URL.Path, strings.TrimSuffix(cookie.Path, "/")+"/") {
+			req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+		}
+	}
 }
@@ -18,10 +18,16 @@ const (
 	// CollectionName defines the name of the MongoDB collection used for storing customer documents.
 	CollectionName = "customers"
 
+	// FieldCustomerID represents the field name used to store or query the public ID of a customer in the database.
+	FieldCustomerID = "customer_id"
 	// FieldEmail represents the field name used to store or query email addresses in the database.
 	FieldEmail = "email"
 	// FieldActive represents the field name used to indicate the active status of a customer in the database.
 	FieldActive = "active"
+	// FieldPassword represents the field name used to store the hashed password of a customer in the database.
+	FieldPassword = "password"
+	// FieldUpdatedAt represents the field name used to store the timestamp of the last update in the database.
+	FieldUpdatedAt = "updated_at"
 )
 
 // Customer represents a user in the system with associated details such as email, name, and account activation status.
@@ -36,12 +42,18 @@ type Customer struct {
 }
 
 // Repository defines the interface for customer repository operations.
-// It includes methods to create a customer and find a customer by email.
+// It includes methods to create a customer, find a customer by email or ID, update its email, password or active
+// status, and delete it.
 //
 //go:generate mockgen -destination=./mocks/repository_mock.go -package=customers_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/customers Repository
 type Repository interface {
 	CreateCustomer(ctx context.Context, params CreateCustomerParams) (Customer, error)
 	FindByEmail(ctx context.Context, email string) (Customer, error)
+	FindByCustomerID(ctx context.Context, customerID string) (Customer, error)
+	UpdatePassword(ctx context.Context, params UpdatePasswordParams) error
+	UpdateActive(ctx context.Context, params UpdateActiveParams) error
+	UpdateEmail(ctx context.Context, params UpdateEmailParams) error
+	DeleteCustomer(ctx context.Context, customerID string) error
 }
 
 type repository struct {
@@ -116,3 +128,161 @@ func (r *repository) FindByEmail(ctx context.Context, email string) (Customer, e
 	}
 	return customer, nil
 }
+
+// FindByCustomerID searches for an active customer with the specified customer ID.
+// It returns the customer if found or ErrCustomerNotFound if no matching active customer exists.
+func (r *repository) FindByCustomerID(ctx context.Context, customerID string) (Customer, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var customer Customer
+	filter := bson.M{
+		FieldCustomerID: customerID,
+		FieldActive:     true,
+	}
+
+	if err := r.collection.FindOne(ctx, filter).Decode(&customer); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn("Customer not found", log.Field{Key: "customer_id", Value: customerID})
+			return Customer{}, ErrCustomerNotFound
+		}
+		logger.Error("Failed to find customer", err)
+		return Customer{}, err
+	}
+	return customer, nil
+}
+
+// UpdatePasswordParams represents the parameters needed to replace the password of a customer.
+// Password must be already hashed.
+type UpdatePasswordParams struct {
+	CustomerID string
+	Password   string
+}
+
+// UpdatePassword replaces the password of the active customer with the specified customer ID.
+// It returns ErrCustomerNotFound if no matching active customer exists.
+func (r *repository) UpdatePassword(ctx context.Context, params UpdatePasswordParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	filter := bson.M{
+		FieldCustomerID: params.CustomerID,
+		FieldActive:     true,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldPassword:  params.Password,
+			FieldUpdatedAt: r.clock.Now(),
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to update customer password", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("Customer not found", log.Field{Key: "customer_id", Value: params.CustomerID})
+		return ErrCustomerNotFound
+	}
+
+	logger.Info("Customer password updated successfully", log.Field{Key: "customer_id", Value: params.CustomerID})
+	return nil
+}
+
+// UpdateActiveParams represents the parameters needed to activate or deactivate a customer.
+type UpdateActiveParams struct {
+	CustomerID string
+	Active     bool
+}
+
+// UpdateActive sets the active status of the customer with the specified customer ID, whether it is active or not.
+// It returns ErrCustomerNotFound if no customer with the ID exists, and ErrCustomerAlreadyExists if the customer is
+// activated while another active customer has the same email.
+func (r *repository) UpdateActive(ctx context.Context, params UpdateActiveParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	filter := bson.M{FieldCustomerID: params.CustomerID}
+	update := bson.M{
+		"$set": bson.M{
+			FieldActive:    params.Active,
+			FieldUpdatedAt: r.clock.Now(),
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		if mongodb.IsDuplicateKeyError(err) {
+			logger.Warn("Customer already exists", log.Field{Key: "customer_id", Value: params.CustomerID})
+			return ErrCustomerAlreadyExists
+		}
+		logger.Error("Failed to update customer active status", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("Customer not found", log.Field{Key: "customer_id", Value: params.CustomerID})
+		return ErrCustomerNotFound
+	}
+
+	logger.Info(
+		"Customer active status updated successfully",
+		log.Field{Key: "customer_id", Value: params.CustomerID},
+		log.Field{Key: "active", Value: params.Active},
+	)
+	return nil
+}
+
+// UpdateEmailParams represents the parameters needed to replace the login email of a customer.
+type UpdateEmailParams struct {
+	CustomerID string
+	Email      string
+}
+
+// UpdateEmail replaces the login email of the customer with the specified customer ID, whether it is active or not.
+// It returns ErrCustomerNotFound if no customer with the ID exists, and ErrCustomerAlreadyExists if another active
+// customer has the same email.
+func (r *repository) UpdateEmail(ctx context.Context, params UpdateEmailParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	filter := bson.M{FieldCustomerID: params.CustomerID}
+	update := bson.M{
+		"$set": bson.M{
+			FieldEmail:     params.Email,
+			FieldUpdatedAt: r.clock.Now(),
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		if mongodb.IsDuplicateKeyError(err) {
+			logger.Warn("Customer already exists", log.Field{Key: "email", Value: params.Email})
+			return ErrCustomerAlreadyExists
+		}
+		logger.Error("Failed to update customer email", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("Customer not found", log.Field{Key: "customer_id", Value: params.CustomerID})
+		return ErrCustomerNotFound
+	}
+
+	logger.Info("Customer email updated successfully", log.Field{Key: "customer_id", Value: params.CustomerID})
+	return nil
+}
+
+// DeleteCustomer permanently removes the credentials of the customer with the specified customer ID, whether it is
+// active or not. It returns ErrCustomerNotFound if no customer with the ID exists.
+func (r *repository) DeleteCustomer(ctx context.Context, customerID string) error {
+	logger := r.logger.WithContext(ctx)
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{FieldCustomerID: customerID})
+	if err != nil {
+		logger.Error("Failed to delete customer", err)
+		return err
+	}
+	if res.DeletedCount == 0 {
+		logger.Warn("Customer not found", log.Field{Key: "customer_id", Value: customerID})
+		return ErrCustomerNotFound
+	}
+
+	logger.Info("Customer deleted successfully", log.Field{Key: "customer_id", Value: customerID})
+	return nil
+}
@@ -4,10 +4,12 @@ package customers_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -202,6 +204,475 @@ func TestRepository_FindByEmail_UnexpectedFailure(t *testing.T) {
 	assert.NotErrorIs(t, err, customers.ErrCustomerNotFound)
 }
 
+func TestRepository_FindByCustomerID(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []customersRepositoryTestCase[string, customers.Customer]{
+		{
+			name: "when there is not an active customer with the id, then it should return a customer not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     false,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+			},
+			params:  "fake-customer-id",
+			want:    customers.Customer{},
+			wantErr: customers.ErrCustomerNotFound,
+		},
+		{
+			name: "when there is an active customer with the id, then it should return the customer",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     true,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+			},
+			params: "fake-customer-id",
+			want: customers.Customer{
+				CustomerID: "fake-customer-id",
+				Email:      "test@example.com",
+				Active:     true,
+				Password:   "fakehashedpassword",
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestCustomersCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.FindByCustomerID(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the got only if there is no error expected
+			if tt.wantErr == nil {
+				// As the ID is generated by MongoDB, we just check that it is not empty
+				assert.NotEmpty(t, got.ID, "ID should not be empty")
+
+				tt.want.ID = got.ID
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRepository_FindByCustomerID_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindByCustomerID(context.Background(), "")
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, customers.ErrCustomerNotFound)
+}
+
+func TestRepository_UpdatePassword(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []customersRepositoryTestCase[customers.UpdatePasswordParams, string]{
+		{
+			name: "when there is not an active customer with the id, then it should return a customer not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     false,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+			},
+			params: customers.UpdatePasswordParams{
+				CustomerID: "fake-customer-id",
+				Password:   "newfakehashedpassword",
+			},
+			want:    "fakehashedpassword",
+			wantErr: customers.ErrCustomerNotFound,
+		},
+		{
+			name: "when there is an active customer with the id, then it should replace the password",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     true,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+			},
+			params: customers.UpdatePasswordParams{
+				CustomerID: "fake-customer-id",
+				Password:   "newfakehashedpassword",
+			},
+			want:    "newfakehashedpassword",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestCustomersCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.UpdatePassword(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var got customers.Customer
+			err = coll.FindOne(context.Background(), bson.M{customers.FieldCustomerID: tt.params.CustomerID}).Decode(&got)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Password)
+		})
+	}
+}
+
+func TestRepository_UpdatePassword_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.UpdatePassword(context.Background(), customers.UpdatePasswordParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, customers.ErrCustomerNotFound)
+}
+
+func TestRepository_UpdateActive(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []customersRepositoryTestCase[customers.UpdateActiveParams, bool]{
+		{
+			name: "when there is not a customer with the id, then it should return a customer not found error",
+			params: customers.UpdateActiveParams{
+				CustomerID: "fake-customer-id",
+				Active:     false,
+			},
+			wantErr: customers.ErrCustomerNotFound,
+		},
+		{
+			name: "when there is an active customer with the id, then it should deactivate it",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     true,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+			},
+			params: customers.UpdateActiveParams{
+				CustomerID: "fake-customer-id",
+				Active:     false,
+			},
+			want:    false,
+			wantErr: nil,
+		},
+		{
+			name: "when there is an inactive customer with the id, then it should reactivate it",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     false,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+			},
+			params: customers.UpdateActiveParams{
+				CustomerID: "fake-customer-id",
+				Active:     true,
+			},
+			want:    true,
+			wantErr: nil,
+		},
+		{
+			name: "when another active customer has the same email, " +
+				"then it should return a customer already exists error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     false,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "another-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     true,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+			},
+			params: customers.UpdateActiveParams{
+				CustomerID: "fake-customer-id",
+				Active:     true,
+			},
+			want:    false,
+			wantErr: customers.ErrCustomerAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestCustomersCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.UpdateActive(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the stored status only if the customer exists
+			if !errors.Is(tt.wantErr, customers.ErrCustomerNotFound) {
+				var got customers.Customer
+				err = coll.FindOne(context.Background(), bson.M{customers.FieldCustomerID: tt.params.CustomerID}).Decode(&got)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got.Active)
+			}
+		})
+	}
+}
+
+func TestRepository_UpdateActive_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.UpdateActive(context.Background(), customers.UpdateActiveParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, customers.ErrCustomerNotFound)
+}
+
+func TestRepository_UpdateEmail(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []customersRepositoryTestCase[customers.UpdateEmailParams, string]{
+		{
+			name: "when there is not a customer with the id, then it should return a customer not found error",
+			params: customers.UpdateEmailParams{
+				CustomerID: "fake-customer-id",
+				Email:      "new@example.com",
+			},
+			wantErr: customers.ErrCustomerNotFound,
+		},
+		{
+			name: "when there is a customer with the id, then it should replace its email",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     true,
+					CreatedAt:  now.Add(-time.Hour),
+					UpdatedAt:  now.Add(-time.Hour),
+				})
+			},
+			params: customers.UpdateEmailParams{
+				CustomerID: "fake-customer-id",
+				Email:      "new@example.com",
+			},
+			want:    "new@example.com",
+			wantErr: nil,
+		},
+		{
+			name: "when another active customer has the same email, " +
+				"then it should return a customer already exists error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     true,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "another-customer-id",
+					Email:      "new@example.com",
+					Password:   "fakehashedpassword",
+					Active:     true,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+			},
+			params: customers.UpdateEmailParams{
+				CustomerID: "fake-customer-id",
+				Email:      "new@example.com",
+			},
+			want:    "test@example.com",
+			wantErr: customers.ErrCustomerAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestCustomersCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.UpdateEmail(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the stored email only if the customer exists
+			if !errors.Is(tt.wantErr, customers.ErrCustomerNotFound) {
+				var got customers.Customer
+				err = coll.FindOne(context.Background(), bson.M{customers.FieldCustomerID: tt.params.CustomerID}).Decode(&got)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got.Email)
+				if tt.wantErr == nil {
+					assert.Equal(t, now, got.UpdatedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestRepository_UpdateEmail_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.UpdateEmail(context.Background(), customers.UpdateEmailParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, customers.ErrCustomerNotFound)
+}
+
+func TestRepository_DeleteCustomer(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []customersRepositoryTestCase[string, struct{}]{
+		{
+			name:    "when there is not a customer with the id, then it should return a customer not found error",
+			params:  "fake-customer-id",
+			wantErr: customers.ErrCustomerNotFound,
+		},
+		{
+			name: "when there is an inactive customer with the id, then it should delete it",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, customers.Customer{
+					CustomerID: "fake-customer-id",
+					Email:      "test@example.com",
+					Password:   "fakehashedpassword",
+					Active:     false,
+					CreatedAt:  now,
+					UpdatedAt:  now,
+				})
+			},
+			params:  "fake-customer-id",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestCustomersCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.DeleteCustomer(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// The customer must not be stored anymore
+			count, err := coll.CountDocuments(context.Background(), bson.M{customers.FieldCustomerID: tt.params})
+			require.NoError(t, err)
+			assert.Zero(t, count)
+		})
+	}
+}
+
+func TestRepository_DeleteCustomer_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := customers.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.DeleteCustomer(context.Background(), "fake-customer-id")
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, customers.ErrCustomerNotFound)
+}
+
 func setupTestCustomersCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -5,9 +5,14 @@ import (
 	"errors"
 	"time"
 
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/magiclink"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset"
 )
 
 const (
@@ -25,12 +30,37 @@ type Service interface {
 	RegisterCustomer(ctx context.Context, input RegisterCustomerInput) (RegisterCustomerOutput, error)
 	LoginCustomer(ctx context.Context, input LoginCustomerInput) (LoginCustomerOutput, error)
 	RefreshCustomer(ctx context.Context, input RefreshCustomerInput) (RefreshCustomerOutput, error)
+	LogoutCustomer(ctx context.Context, input LogoutCustomerInput) (LogoutCustomerOutput, error)
+	RequestCustomerPasswordReset(
+		ctx context.Context,
+		input RequestCustomerPasswordResetInput,
+	) (RequestCustomerPasswordResetOutput, error)
+	ResetCustomerPassword(ctx context.Context, input ResetCustomerPasswordInput) (ResetCustomerPasswordOutput, error)
+	RequestCustomerMagicLink(
+		ctx context.Context,
+		input RequestCustomerMagicLinkInput,
+	) (RequestCustomerMagicLinkOutput, error)
+	LoginCustomerWithMagicLink(
+		ctx context.Context,
+		input LoginCustomerWithMagicLinkInput,
+	) (LoginCustomerWithMagicLinkOutput, error)
+	ChangeCustomerPassword(ctx context.Context, input ChangeCustomerPasswordInput) (ChangeCustomerPasswordOutput, error)
+	SetCustomerActive(ctx context.Context, input SetCustomerActiveInput) (SetCustomerActiveOutput, error)
+	UpdateCustomerEmail(ctx context.Context, input UpdateCustomerEmailInput) (UpdateCustomerEmailOutput, error)
+	DeleteCustomer(ctx context.Context, input DeleteCustomerInput) (DeleteCustomerOutput, error)
+	ImpersonateCustomer(ctx context.Context, input ImpersonateCustomerInput) (ImpersonateCustomerOutput, error)
 }
 
 type service struct {
-	logger          log.Logger
-	repo            Repository
-	authCoreService authcore.Service
+	logger               log.Logger
+	repo                 Repository
+	authCoreService      authcore.Service
+	passwordResetService passwordreset.Service
+	magicLinkService     magiclink.Service
+	lockoutService       lockout.Service
+	passwordPolicy       password.Policy
+	authctx              auth.ContextReader
+	authEventsService    authevents.Service
 }
 
 // NewService creates a new instance of Service with the provided dependencies.
@@ -38,11 +68,23 @@ func NewService(
 	logger log.Logger,
 	repo Repository,
 	authCoreService authcore.Service,
+	passwordResetService passwordreset.Service,
+	magicLinkService magiclink.Service,
+	lockoutService lockout.Service,
+	passwordPolicy password.Policy,
+	authctx auth.ContextReader,
+	authEventsService authevents.Service,
 ) Service {
 	return &service{
-		logger:          logger,
-		repo:            repo,
-		authCoreService: authCoreService,
+		logger:               logger,
+		repo:                 repo,
+		authCoreService:      authCoreService,
+		passwordResetService: passwordResetService,
+		magicLinkService:     magicLinkService,
+		lockoutService:       lockoutService,
+		passwordPolicy:       passwordPolicy,
+		authctx:              authctx,
+		authEventsService:    authEventsService,
 	}
 }
 
@@ -65,6 +107,11 @@ func (s *service) RegisterCustomer(ctx context.Context, input RegisterCustomerIn
 	logger := s.logger.WithContext(ctx)
 
 	logger.Info("registering customer", log.Field{Key: "email", Value: input.Email})
+	if err := s.passwordPolicy.Validate(input.Password, input.Email); err != nil {
+		logger.Warn("password does not meet the policy", log.Field{Key: "email", Value: input.Email})
+		return RegisterCustomerOutput{}, err
+	}
+
 	hashedPassword, err := password.Hash(input.Password)
 	if err != nil {
 		logger.Error("failed to hash password", err)
@@ -83,6 +130,13 @@ func (s *service) RegisterCustomer(ctx context.Context, input RegisterCustomerIn
 		return RegisterCustomerOutput{}, err
 	}
 
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:    authevents.EventTypeRegistration,
+		Outcome: authevents.OutcomeSuccess,
+		Subject: customer.CustomerID,
+		Role:    DefaultTokenRole,
+	})
+
 	output := RegisterCustomerOutput{
 		ID:        customer.ID,
 		Email:     customer.Email,
@@ -108,11 +162,29 @@ func (s *service) LoginCustomer(ctx context.Context, input LoginCustomerInput) (
 	logger := s.logger.WithContext(ctx)
 
 	logger.Info("logging in", log.Field{Key: "email", Value: input.Email})
+	if _, err := s.lockoutService.Check(ctx, lockout.CheckInput{
+		Role:       DefaultTokenRole,
+		Identifier: input.Email,
+	}); err != nil {
+		if errors.Is(err, lockout.ErrLocked) {
+			logger.Warn("customer login locked", log.Field{Key: "email", Value: input.Email})
+			s.authEventsService.Record(ctx, authevents.RecordInput{
+				Type:    authevents.EventTypeLogin,
+				Outcome: authevents.OutcomeFailure,
+				Reason:  authevents.ReasonAccountLocked,
+				Role:    DefaultTokenRole,
+			})
+			return LoginCustomerOutput{}, authcore.ErrAccountLocked
+		}
+		logger.Error("failed to check the customer login lock", err)
+		return LoginCustomerOutput{}, err
+	}
+
 	customer, err := s.repo.FindByEmail(ctx, input.Email)
 	if err != nil {
 		if errors.Is(err, ErrCustomerNotFound) {
 			logger.Warn("customer not found", log.Field{Key: "email", Value: input.Email})
-			return LoginCustomerOutput{}, authcore.ErrInvalidCredentials
+			return LoginCustomerOutput{}, s.registerLoginFailure(ctx, input.Email, "")
 		}
 		logger.Error("failed to find customer by email", err)
 		return LoginCustomerOutput{}, err
@@ -121,7 +193,16 @@ func (s *service) LoginCustomer(ctx context.Context, input LoginCustomerInput) (
 	// Check if the stored password matches the provided password
 	if !password.Verify(customer.Password, input.Password) {
 		logger.Warn("invalid credentials")
-		return LoginCustomerOutput{}, authcore.ErrInvalidCredentials
+		return LoginCustomerOutput{}, s.registerLoginFailure(ctx, input.Email, customer.CustomerID)
+	}
+	s.upgradePasswordHash(ctx, customer, input.Password)
+
+	if _, err := s.lockoutService.RegisterSuccess(ctx, lockout.RegisterSuccessInput{
+		Role:       DefaultTokenRole,
+		Identifier: input.Email,
+	}); err != nil {
+		logger.Error("failed to register the successful customer login", err)
+		return LoginCustomerOutput{}, err
 	}
 
 	tokenPair, err := s.authCoreService.GenerateTokenPair(
@@ -136,9 +217,62 @@ func (s *service) LoginCustomer(ctx context.Context, input LoginCustomerInput) (
 		return LoginCustomerOutput{}, err
 	}
 
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:    authevents.EventTypeLogin,
+		Outcome: authevents.OutcomeSuccess,
+		Subject: customer.CustomerID,
+		Role:    DefaultTokenRole,
+	})
 	return LoginCustomerOutput{TokenPair: tokenPair}, nil
 }
 
+// registerLoginFailure registers the failed login of the customer, and returns the error the login must fail with.
+// The customerID is empty when the email is not registered.
+func (s *service) registerLoginFailure(ctx context.Context, email, customerID string) error {
+	logger := s.logger.WithContext(ctx)
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:    authevents.EventTypeLogin,
+		Outcome: authevents.OutcomeFailure,
+		Reason:  authevents.ReasonInvalidCredentials,
+		Subject: customerID,
+		Role:    DefaultTokenRole,
+	})
+
+	if _, err := s.lockoutService.RegisterFailure(ctx, lockout.RegisterFailureInput{
+		Role:       DefaultTokenRole,
+		Identifier: email,
+	}); err != nil {
+		logger.Error("failed to register the failed customer login", err)
+		return err
+	}
+	return authcore.ErrInvalidCredentials
+}
+
+// upgradePasswordHash hashes the password of the customer again when the stored hash was created with weaker
+// parameters than the current ones. It is best effort, so a failure does not prevent the customer from logging in.
+func (s *service) upgradePasswordHash(ctx context.Context, customer Customer, plainPassword string) {
+	logger := s.logger.WithContext(ctx)
+
+	if !password.NeedsRehash(customer.Password) {
+		return
+	}
+
+	hashedPassword, err := password.Hash(plainPassword)
+	if err != nil {
+		logger.Error("failed to rehash the customer password", err)
+		return
+	}
+	if err := s.repo.UpdatePassword(ctx, UpdatePasswordParams{
+		CustomerID: customer.CustomerID,
+		Password:   hashedPassword,
+	}); err != nil {
+		logger.Error("failed to upgrade the customer password hash", err)
+		return
+	}
+	logger.Info("customer password hash upgraded", log.Field{Key: "customer_id", Value: customer.CustomerID})
+}
+
 // RefreshCustomerInput represents the input required to refresh a customer's authentication tokens.
 type RefreshCustomerInput struct {
 	RefreshToken string
@@ -170,3 +304,531 @@ func (s *service) RefreshCustomer(ctx context.Context, input RefreshCustomerInpu
 
 	return RefreshCustomerOutput{TokenPair: tokenPair}, nil
 }
+
+// LogoutCustomerInput represents the input required to revoke the sessions of a customer.
+type LogoutCustomerInput struct {
+	RefreshToken string
+	// AllSessions revokes every active session of the customer, not only the one of the presented refresh token.
+	AllSessions bool
+}
+
+// LogoutCustomerOutput represents the result of a customer logout operation.
+type LogoutCustomerOutput struct {
+	RevokedTokens int64
+}
+
+func (s *service) LogoutCustomer(ctx context.Context, input LogoutCustomerInput) (LogoutCustomerOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// Revoking all the sessions locks the user out of its other devices, so it is only allowed to the user itself
+	if input.AllSessions {
+		if err := s.authctx.RequireNotImpersonated(ctx); err != nil {
+			return LogoutCustomerOutput{}, err
+		}
+	}
+
+	logger.Info("logging out customer")
+
+	output, err := s.authCoreService.Logout(ctx, authcore.LogoutInput{
+		RefreshToken: input.RefreshToken,
+		Role:         DefaultTokenRole,
+		AllSessions:  input.AllSessions,
+	})
+	if err != nil {
+		logger.Error("failed to logout the customer", err)
+		return LogoutCustomerOutput{}, err
+	}
+
+	return LogoutCustomerOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// RequestCustomerPasswordResetInput represents the input required to request a password reset for a customer.
+type RequestCustomerPasswordResetInput struct {
+	Email string
+}
+
+// RequestCustomerPasswordResetOutput represents the result of a customer password reset request.
+type RequestCustomerPasswordResetOutput struct{}
+
+// RequestCustomerPasswordReset sends a password reset token to the customer with the given email. To avoid disclosing
+// which emails are registered, requesting it for an unknown email is not an error.
+func (s *service) RequestCustomerPasswordReset(
+	ctx context.Context,
+	input RequestCustomerPasswordResetInput,
+) (RequestCustomerPasswordResetOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("requesting customer password reset", log.Field{Key: "email", Value: input.Email})
+	customer, err := s.repo.FindByEmail(ctx, input.Email)
+	if err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "email", Value: input.Email})
+			return RequestCustomerPasswordResetOutput{}, nil
+		}
+		logger.Error("failed to find customer by email", err)
+		return RequestCustomerPasswordResetOutput{}, err
+	}
+
+	if _, err := s.passwordResetService.RequestReset(ctx, passwordreset.RequestResetInput{
+		UserID: customer.CustomerID,
+		Role:   DefaultTokenRole,
+		Email:  customer.Email,
+	}); err != nil {
+		logger.Error("failed to request the password reset", err)
+		return RequestCustomerPasswordResetOutput{}, err
+	}
+
+	return RequestCustomerPasswordResetOutput{}, nil
+}
+
+// ResetCustomerPasswordInput represents the input required to set a new customer password with a reset token.
+type ResetCustomerPasswordInput struct {
+	Token    string
+	Password string
+}
+
+// ResetCustomerPasswordOutput represents the result of a customer password reset.
+type ResetCustomerPasswordOutput struct {
+	RevokedTokens int64
+}
+
+// ResetCustomerPassword consumes the reset token and sets the new password of its owner. All the sessions of the
+// customer are revoked afterward, as they could have been opened by someone knowing the previous password.
+func (s *service) ResetCustomerPassword(
+	ctx context.Context,
+	input ResetCustomerPasswordInput,
+) (ResetCustomerPasswordOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("resetting customer password")
+	// The email of the token owner is unknown yet, and the token must not be consumed by a rejected password
+	if err := s.passwordPolicy.Validate(input.Password, ""); err != nil {
+		logger.Warn("password does not meet the policy")
+		return ResetCustomerPasswordOutput{}, err
+	}
+
+	owner, err := s.passwordResetService.Consume(ctx, passwordreset.ConsumeInput{
+		Token: input.Token,
+		Role:  DefaultTokenRole,
+	})
+	if err != nil {
+		if errors.Is(err, passwordreset.ErrResetTokenNotFound) {
+			logger.Warn("password reset token not found")
+			return ResetCustomerPasswordOutput{}, authcore.ErrInvalidResetToken
+		}
+		logger.Error("failed to consume the password reset token", err)
+		return ResetCustomerPasswordOutput{}, err
+	}
+
+	hashedPassword, err := password.Hash(input.Password)
+	if err != nil {
+		logger.Error("failed to hash password", err)
+		return ResetCustomerPasswordOutput{}, err
+	}
+
+	if err := s.repo.UpdatePassword(ctx, UpdatePasswordParams{
+		CustomerID: owner.UserID,
+		Password:   hashedPassword,
+	}); err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "customer_id", Value: owner.UserID})
+			return ResetCustomerPasswordOutput{}, authcore.ErrInvalidResetToken
+		}
+		logger.Error("failed to update the customer password", err)
+		return ResetCustomerPasswordOutput{}, err
+	}
+
+	output, err := s.authCoreService.RevokeSessions(ctx, authcore.RevokeSessionsInput{
+		UserID: owner.UserID,
+		Role:   DefaultTokenRole,
+	})
+	if err != nil {
+		logger.Error("failed to revoke the customer sessions", err)
+		return ResetCustomerPasswordOutput{}, err
+	}
+
+	logger.Info("customer password reset successfully", log.Field{Key: "customer_id", Value: owner.UserID})
+	return ResetCustomerPasswordOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// RequestCustomerMagicLinkInput represents the input required to request a magic login link for a customer.
+type RequestCustomerMagicLinkInput struct {
+	Email string
+}
+
+// RequestCustomerMagicLinkOutput represents the result of a customer magic link request.
+type RequestCustomerMagicLinkOutput struct{}
+
+// RequestCustomerMagicLink sends a login token to the customer with the given email, bound to the requesting device.
+// To avoid disclosing which emails are registered, requesting it for an unknown email is not an error.
+func (s *service) RequestCustomerMagicLink(
+	ctx context.Context,
+	input RequestCustomerMagicLinkInput,
+) (RequestCustomerMagicLinkOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("requesting customer magic link", log.Field{Key: "email", Value: input.Email})
+	customer, err := s.repo.FindByEmail(ctx, input.Email)
+	if err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "email", Value: input.Email})
+			return RequestCustomerMagicLinkOutput{}, nil
+		}
+		logger.Error("failed to find customer by email", err)
+		return RequestCustomerMagicLinkOutput{}, err
+	}
+
+	if _, err := s.magicLinkService.RequestLink(ctx, magiclink.RequestLinkInput{
+		UserID: customer.CustomerID,
+		Role:   DefaultTokenRole,
+		Email:  customer.Email,
+	}); err != nil {
+		logger.Error("failed to request the magic link", err)
+		return RequestCustomerMagicLinkOutput{}, err
+	}
+
+	return RequestCustomerMagicLinkOutput{}, nil
+}
+
+// LoginCustomerWithMagicLinkInput represents the input required to log in a customer with a magic link login token.
+type LoginCustomerWithMagicLinkInput struct {
+	Token string
+}
+
+// LoginCustomerWithMagicLinkOutput represents the output returned upon successful magic link login of a customer.
+type LoginCustomerWithMagicLinkOutput struct {
+	authcore.TokenPair
+}
+
+// LoginCustomerWithMagicLink consumes the login token, which must be presented from the same device that requested it,
+// and exchanges it for a token pair of its owner.
+func (s *service) LoginCustomerWithMagicLink(
+	ctx context.Context,
+	input LoginCustomerWithMagicLinkInput,
+) (LoginCustomerWithMagicLinkOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("logging in customer with magic link")
+	owner, err := s.magicLinkService.Consume(ctx, magiclink.ConsumeInput{
+		Token: input.Token,
+		Role:  DefaultTokenRole,
+	})
+	if err != nil {
+		if errors.Is(err, magiclink.ErrLoginTokenNotFound) {
+			logger.Warn("login token not found")
+			s.recordMagicLinkLoginFailure(ctx, "")
+			return LoginCustomerWithMagicLinkOutput{}, authcore.ErrInvalidLoginToken
+		}
+		logger.Error("failed to consume the login token", err)
+		return LoginCustomerWithMagicLinkOutput{}, err
+	}
+
+	// The customer could have been deactivated or deleted after requesting the link
+	customer, err := s.repo.FindByCustomerID(ctx, owner.UserID)
+	if err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "customer_id", Value: owner.UserID})
+			s.recordMagicLinkLoginFailure(ctx, owner.UserID)
+			return LoginCustomerWithMagicLinkOutput{}, authcore.ErrInvalidLoginToken
+		}
+		logger.Error("failed to find customer by id", err)
+		return LoginCustomerWithMagicLinkOutput{}, err
+	}
+
+	tokenPair, err := s.authCoreService.GenerateTokenPair(
+		ctx, authcore.GenerateTokenPairInput{
+			UserID:     customer.CustomerID,
+			Expiration: DefaultTokenExpiration,
+			Role:       DefaultTokenRole,
+		},
+	)
+	if err != nil {
+		logger.Error("failed to generate token pair", err)
+		return LoginCustomerWithMagicLinkOutput{}, err
+	}
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:    authevents.EventTypeLogin,
+		Outcome: authevents.OutcomeSuccess,
+		Subject: customer.CustomerID,
+		Role:    DefaultTokenRole,
+	})
+	return LoginCustomerWithMagicLinkOutput{TokenPair: tokenPair}, nil
+}
+
+// recordMagicLinkLoginFailure records the failed magic link login of the customer. The customerID is empty when the
+// login token is unknown.
+func (s *service) recordMagicLinkLoginFailure(ctx context.Context, customerID string) {
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:    authevents.EventTypeLogin,
+		Outcome: authevents.OutcomeFailure,
+		Reason:  authevents.ReasonInvalidLoginToken,
+		Subject: customerID,
+		Role:    DefaultTokenRole,
+	})
+}
+
+// ChangeCustomerPasswordInput represents the input required for the authenticated customer to change its password.
+type ChangeCustomerPasswordInput struct {
+	CurrentPassword string
+	NewPassword     string
+	// RevokeOtherSessions revokes every active session of the customer except the one performing the change.
+	RevokeOtherSessions bool
+}
+
+// ChangeCustomerPasswordOutput represents the result of a customer password change.
+type ChangeCustomerPasswordOutput struct {
+	RevokedTokens int64
+}
+
+// ChangeCustomerPassword replaces the password of the authenticated customer, once its current password is verified.
+func (s *service) ChangeCustomerPassword(
+	ctx context.Context,
+	input ChangeCustomerPasswordInput,
+) (ChangeCustomerPasswordOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	if err := s.authctx.RequireNotImpersonated(ctx); err != nil {
+		return ChangeCustomerPasswordOutput{}, err
+	}
+
+	customerID, ok := s.authctx.GetSubject(ctx)
+	if !ok || customerID == "" {
+		logger.Warn("authentication context not found")
+		return ChangeCustomerPasswordOutput{}, auth.ErrInvalidToken
+	}
+
+	logger.Info("changing customer password", log.Field{Key: "customer_id", Value: customerID})
+	customer, err := s.repo.FindByCustomerID(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "customer_id", Value: customerID})
+			return ChangeCustomerPasswordOutput{}, auth.ErrInvalidToken
+		}
+		logger.Error("failed to find customer by id", err)
+		return ChangeCustomerPasswordOutput{}, err
+	}
+
+	if !password.Verify(customer.Password, input.CurrentPassword) {
+		logger.Warn("invalid current password")
+		return ChangeCustomerPasswordOutput{}, authcore.ErrInvalidCurrentPassword
+	}
+
+	if err := s.passwordPolicy.Validate(input.NewPassword, customer.Email); err != nil {
+		logger.Warn("password does not meet the policy")
+		return ChangeCustomerPasswordOutput{}, err
+	}
+
+	hashedPassword, err := password.Hash(input.NewPassword)
+	if err != nil {
+		logger.Error("failed to hash password", err)
+		return ChangeCustomerPasswordOutput{}, err
+	}
+
+	if err := s.repo.UpdatePassword(ctx, UpdatePasswordParams{
+		CustomerID: customerID,
+		Password:   hashedPassword,
+	}); err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "customer_id", Value: customerID})
+			return ChangeCustomerPasswordOutput{}, auth.ErrInvalidToken
+		}
+		logger.Error("failed to update the customer password", err)
+		return ChangeCustomerPasswordOutput{}, err
+	}
+
+	if !input.RevokeOtherSessions {
+		logger.Info("customer password changed successfully", log.Field{Key: "customer_id", Value: customerID})
+		return ChangeCustomerPasswordOutput{}, nil
+	}
+
+	output, err := s.authCoreService.RevokeSessions(ctx, authcore.RevokeSessionsInput{
+		UserID:             customerID,
+		Role:               DefaultTokenRole,
+		KeepCurrentSession: true,
+	})
+	if err != nil {
+		logger.Error("failed to revoke the customer sessions", err)
+		return ChangeCustomerPasswordOutput{}, err
+	}
+
+	logger.Info("customer password changed successfully", log.Field{Key: "customer_id", Value: customerID})
+	return ChangeCustomerPasswordOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// SetCustomerActiveInput represents the input required for a platform admin to activate or deactivate a customer.
+type SetCustomerActiveInput struct {
+	CustomerID string
+	Active     bool
+}
+
+// SetCustomerActiveOutput represents the result of activating or deactivating a customer.
+type SetCustomerActiveOutput struct {
+	RevokedTokens int64
+}
+
+// SetCustomerActive activates or deactivates the credentials of a customer. Deactivated customers can no longer log
+// in, and all their active sessions are revoked.
+func (s *service) SetCustomerActive(ctx context.Context, input SetCustomerActiveInput) (SetCustomerActiveOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info(
+		"setting customer active status",
+		log.Field{Key: "customer_id", Value: input.CustomerID},
+		log.Field{Key: "active", Value: input.Active},
+	)
+	if err := s.repo.UpdateActive(ctx, UpdateActiveParams(input)); err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "customer_id", Value: input.CustomerID})
+			return SetCustomerActiveOutput{}, err
+		}
+		logger.Error("failed to update the customer active status", err)
+		return SetCustomerActiveOutput{}, err
+	}
+
+	if input.Active {
+		logger.Info("customer activated successfully", log.Field{Key: "customer_id", Value: input.CustomerID})
+		return SetCustomerActiveOutput{}, nil
+	}
+
+	output, err := s.authCoreService.RevokeSessions(ctx, authcore.RevokeSessionsInput{
+		UserID: input.CustomerID,
+		Role:   DefaultTokenRole,
+	})
+	if err != nil {
+		logger.Error("failed to revoke the customer sessions", err)
+		return SetCustomerActiveOutput{}, err
+	}
+
+	logger.Info("customer deactivated successfully", log.Field{Key: "customer_id", Value: input.CustomerID})
+	return SetCustomerActiveOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// UpdateCustomerEmailInput represents the input required to update the login email of a customer, once it is changed
+// in its profile.
+type UpdateCustomerEmailInput struct {
+	CustomerID string
+	Email      string
+}
+
+// UpdateCustomerEmailOutput represents the result of updating the login email of a customer.
+type UpdateCustomerEmailOutput struct{}
+
+// UpdateCustomerEmail replaces the login email of a customer, so it keeps matching the email of its profile.
+func (s *service) UpdateCustomerEmail(
+	ctx context.Context,
+	input UpdateCustomerEmailInput,
+) (UpdateCustomerEmailOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info(
+		"updating customer email",
+		log.Field{Key: "customer_id", Value: input.CustomerID},
+		log.Field{Key: "email", Value: input.Email},
+	)
+	if err := s.repo.UpdateEmail(ctx, UpdateEmailParams(input)); err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "customer_id", Value: input.CustomerID})
+			return UpdateCustomerEmailOutput{}, err
+		}
+		if errors.Is(err, ErrCustomerAlreadyExists) {
+			logger.Warn("customer already exists", log.Field{Key: "email", Value: input.Email})
+			return UpdateCustomerEmailOutput{}, err
+		}
+		logger.Error("failed to update the customer email", err)
+		return UpdateCustomerEmailOutput{}, err
+	}
+
+	logger.Info("customer email updated successfully", log.Field{Key: "customer_id", Value: input.CustomerID})
+	return UpdateCustomerEmailOutput{}, nil
+}
+
+// DeleteCustomerInput represents the input required to delete the credentials of a customer who closed its account.
+type DeleteCustomerInput struct {
+	CustomerID string
+}
+
+// DeleteCustomerOutput represents the result of deleting the credentials of a customer.
+type DeleteCustomerOutput struct {
+	RevokedTokens int64
+}
+
+// DeleteCustomer permanently removes the credentials of a customer. Its sessions are revoked before, so a failed
+// deletion can be retried without leaving any session of a deleted customer active.
+func (s *service) DeleteCustomer(ctx context.Context, input DeleteCustomerInput) (DeleteCustomerOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("deleting customer", log.Field{Key: "customer_id", Value: input.CustomerID})
+	output, err := s.authCoreService.RevokeSessions(ctx, authcore.RevokeSessionsInput{
+		UserID: input.CustomerID,
+		Role:   DefaultTokenRole,
+	})
+	if err != nil {
+		logger.Error("failed to revoke the customer sessions", err)
+		return DeleteCustomerOutput{}, err
+	}
+
+	if err := s.repo.DeleteCustomer(ctx, input.CustomerID); err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "customer_id", Value: input.CustomerID})
+			return DeleteCustomerOutput{}, err
+		}
+		logger.Error("failed to delete the customer", err)
+		return DeleteCustomerOutput{}, err
+	}
+
+	logger.Info("customer deleted successfully", log.Field{Key: "customer_id", Value: input.CustomerID})
+	return DeleteCustomerOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// ImpersonateCustomerInput represents the input required for a platform admin to impersonate a customer.
+type ImpersonateCustomerInput struct {
+	CustomerID string
+}
+
+// ImpersonateCustomerOutput represents the short-lived access token issued to impersonate a customer.
+type ImpersonateCustomerOutput struct {
+	authcore.ImpersonationToken
+}
+
+// ImpersonateCustomer issues a short-lived access token on behalf of an active customer, naming the authenticated
+// platform admin as its actor. No refresh token is issued, so the impersonation can't be extended.
+func (s *service) ImpersonateCustomer(
+	ctx context.Context,
+	input ImpersonateCustomerInput,
+) (ImpersonateCustomerOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	adminID, ok := s.authctx.GetSubject(ctx)
+	if !ok || adminID == "" {
+		logger.Warn("authentication context not found")
+		return ImpersonateCustomerOutput{}, auth.ErrInvalidToken
+	}
+
+	logger.Info(
+		"impersonating customer",
+		log.Field{Key: "customer_id", Value: input.CustomerID},
+		log.Field{Key: "admin_id", Value: adminID},
+	)
+	if _, err := s.repo.FindByCustomerID(ctx, input.CustomerID); err != nil {
+		if errors.Is(err, ErrCustomerNotFound) {
+			logger.Warn("customer not found", log.Field{Key: "customer_id", Value: input.CustomerID})
+			return ImpersonateCustomerOutput{}, err
+		}
+		logger.Error("failed to find customer by id", err)
+		return ImpersonateCustomerOutput{}, err
+	}
+
+	token, err := s.authCoreService.GenerateImpersonationToken(ctx, authcore.GenerateImpersonationTokenInput{
+		UserID:  input.CustomerID,
+		Role:    DefaultTokenRole,
+		ActorID: adminID,
+	})
+	if err != nil {
+		logger.Error("failed to generate impersonation token", err)
+		return ImpersonateCustomerOutput{}, err
+	}
+
+	logger.Info("customer impersonation token generated", log.Field{Key: "customer_id", Value: input.CustomerID})
+	return ImpersonateCustomerOutput{ImpersonationToken: token}, nil
+}
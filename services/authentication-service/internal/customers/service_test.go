@@ -12,18 +12,29 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
 	authcoremocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore/mocks"
+	autheventsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents/mocks"
 	customersmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/customers/mocks"
+	lockoutmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout/mocks"
+	magiclinkmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/magiclink/mocks"
+	passwordresetmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset/mocks"
 
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/customers"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/magiclink"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset"
 )
 
 var (
 	errRepo  = errors.New("repository error")
 	errToken = errors.New("token error")
+
+	testPasswordPolicy = password.Policy{MinLength: 8, MaxLength: 128, MinCharacterClasses: 2}
 )
 
 type customersServiceTestCase[I, W any] struct {
@@ -33,6 +44,10 @@ type customersServiceTestCase[I, W any] struct {
 	mocksSetup func(
 		repo *customersmocks.MockRepository,
 		authCoreService *authcoremocks.MockService,
+		passwordResetService *passwordresetmocks.MockService,
+		magicLinkService *magiclinkmocks.MockService,
+		lockoutService *lockoutmocks.MockService,
+		authctx *authmocks.MockContextReader,
 	)
 	wantErr error
 }
@@ -42,6 +57,16 @@ func TestService_RegisterCustomer(t *testing.T) {
 	logger, _ := log.NewTest()
 
 	tests := []customersServiceTestCase[customers.RegisterCustomerInput, customers.RegisterCustomerOutput]{
+		{
+			name: "when the password does not meet the policy, then it should return a policy violation error",
+			input: customers.RegisterCustomerInput{
+				CustomerID: "fake-customer-id",
+				Email:      "test@example.com",
+				Password:   "mytestpassword",
+			},
+			want:    customers.RegisterCustomerOutput{},
+			wantErr: password.ErrPolicyViolation,
+		},
 		{
 			name: "when there is an active customer with the same email, then it should return a customer already exists error",
 			input: customers.RegisterCustomerInput{
@@ -52,6 +77,10 @@ func TestService_RegisterCustomer(t *testing.T) {
 			mocksSetup: func(
 				repo *customersmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
 				repo.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).
 					Return(customers.Customer{}, customers.ErrCustomerAlreadyExists)
@@ -69,6 +98,10 @@ func TestService_RegisterCustomer(t *testing.T) {
 			mocksSetup: func(
 				repo *customersmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
 				repo.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).
 					Return(customers.Customer{}, errRepo)
@@ -86,6 +119,10 @@ func TestService_RegisterCustomer(t *testing.T) {
 			mocksSetup: func(
 				repo *customersmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
 				repo.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).
 					DoAndReturn(
@@ -134,8 +171,94 @@ func TestService_RegisterCustomer(t *testing.T) {
 func TestService_LoginCustomer(t *testing.T) {
 	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	logger, _ := log.NewTest()
+	weakParams := password.Params{Memory: 8 * 1024, Iterations: 1, Parallelism: 1}
 
 	tests := []customersServiceTestCase[customers.LoginCustomerInput, customers.LoginCustomerOutput]{
+		{
+			name:  "when the login is locked, then it should return an account locked error",
+			input: customers.LoginCustomerInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), lockout.CheckInput{
+					Role:       customers.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.CheckOutput{}, lockout.ErrLocked)
+			},
+			want:    customers.LoginCustomerOutput{},
+			wantErr: authcore.ErrAccountLocked,
+		},
+		{
+			name:  "when there is an unexpected error checking the login lock, then it should propagate the error",
+			input: customers.LoginCustomerInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, errRepo)
+			},
+			want:    customers.LoginCustomerOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is an unexpected error registering the failed login, " +
+				"then it should propagate the error",
+			input: customers.LoginCustomerInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(customers.Customer{}, customers.ErrCustomerNotFound)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), lockout.RegisterFailureInput{
+					Role:       customers.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterFailureOutput{}, errRepo)
+			},
+			want:    customers.LoginCustomerOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is an unexpected error registering the successful login, " +
+				"then it should propagate the error",
+			input: customers.LoginCustomerInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(customers.Customer{CustomerID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), lockout.RegisterSuccessInput{
+					Role:       customers.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterSuccessOutput{}, errRepo)
+			},
+			want:    customers.LoginCustomerOutput{},
+			wantErr: errRepo,
+		},
 		{
 			name: "when there is not an active customer with the same email, " +
 				"then it should return an invalid credentials error",
@@ -146,9 +269,16 @@ func TestService_LoginCustomer(t *testing.T) {
 			mocksSetup: func(
 				repo *customersmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
 				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
 					Return(customers.Customer{}, customers.ErrCustomerNotFound)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterFailureOutput{}, nil)
 			},
 			want:    customers.LoginCustomerOutput{},
 			wantErr: authcore.ErrInvalidCredentials,
@@ -163,7 +293,13 @@ func TestService_LoginCustomer(t *testing.T) {
 			mocksSetup: func(
 				repo *customersmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
 				hashedPassword, err := password.Hash("ValidPassword123")
 				require.NoError(t, err)
 
@@ -178,6 +314,8 @@ func TestService_LoginCustomer(t *testing.T) {
 							Active:    true,
 						}, nil,
 					)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterFailureOutput{}, nil)
 			},
 			want:    customers.LoginCustomerOutput{},
 			wantErr: authcore.ErrInvalidCredentials,
@@ -191,7 +329,12 @@ func TestService_LoginCustomer(t *testing.T) {
 			mocksSetup: func(
 				repo *customersmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
 				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
 					Return(customers.Customer{}, errRepo)
 			},
@@ -207,7 +350,13 @@ func TestService_LoginCustomer(t *testing.T) {
 			mocksSetup: func(
 				repo *customersmocks.MockRepository,
 				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
 				hashedPassword, err := password.Hash("ValidPassword123")
 				require.NoError(t, err)
 
@@ -225,6 +374,8 @@ func TestService_LoginCustomer(t *testing.T) {
 
 				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
 					Return(authcore.TokenPair{}, errToken)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
 			},
 			want:    customers.LoginCustomerOutput{},
 			wantErr: errToken,
@@ -238,7 +389,13 @@ func TestService_LoginCustomer(t *testing.T) {
 			mocksSetup: func(
 				repo *customersmocks.MockRepository,
 				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
 				hashedPassword, err := password.Hash("ValidPassword123")
 				require.NoError(t, err)
 
@@ -264,6 +421,8 @@ func TestService_LoginCustomer(t *testing.T) {
 							TokenType:    "Bearer",
 						}, nil,
 					)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
 			},
 			want: customers.LoginCustomerOutput{
 				TokenPair: authcore.TokenPair{
@@ -274,6 +433,76 @@ func TestService_LoginCustomer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "when the stored password was hashed with weaker parameters, " +
+				"then it should rehash it and return the token",
+			input: customers.LoginCustomerInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.HashWithParams("ValidPassword123", weakParams)
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(customers.Customer{CustomerID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params customers.UpdatePasswordParams) error {
+						assert.Equal(t, "fake-id", params.CustomerID)
+						assert.False(t, password.NeedsRehash(params.Password), "The password should be rehashed")
+						assert.True(t, password.Verify(params.Password, "ValidPassword123"))
+						return nil
+					})
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: customers.LoginCustomerOutput{
+				TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+			},
+		},
+		{
+			name: "when there is an error upgrading the password hash, " +
+				"then it should still return the token",
+			input: customers.LoginCustomerInput{Email: "test@example.com", Password: "ValidPassword123"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.HashWithParams("ValidPassword123", weakParams)
+				require.NoError(t, err)
+
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").
+					Return(customers.Customer{CustomerID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params customers.UpdatePasswordParams) error {
+						assert.Equal(t, "fake-id", params.CustomerID)
+						assert.False(t, password.NeedsRehash(params.Password), "The password should be rehashed")
+						assert.True(t, password.Verify(params.Password, "ValidPassword123"))
+						return errRepo
+					})
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: customers.LoginCustomerOutput{
+				TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -304,6 +533,10 @@ func TestService_RefreshCustomer(t *testing.T) {
 			mocksSetup: func(
 				_ *customersmocks.MockRepository,
 				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
 				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
 					Return(authcore.TokenPair{}, errToken)
@@ -320,6 +553,10 @@ func TestService_RefreshCustomer(t *testing.T) {
 			mocksSetup: func(
 				_ *customersmocks.MockRepository,
 				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
 				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
 					Return(
@@ -357,22 +594,1276 @@ func TestService_RefreshCustomer(t *testing.T) {
 	}
 }
 
-func serviceSetup(
-	t *testing.T, logger log.Logger, mocksSetup func(
-		repo *customersmocks.MockRepository,
-		authCoreService *authcoremocks.MockService,
-	),
-) (customers.Service, func()) {
-	ctrl := gomock.NewController(t)
+func TestService_LogoutCustomer(t *testing.T) {
+	logger, _ := log.NewTest()
 
-	repo := customersmocks.NewMockRepository(ctrl)
-	authCoreService := authcoremocks.NewMockService(ctrl)
+	tests := []customersServiceTestCase[customers.LogoutCustomerInput, customers.LogoutCustomerOutput]{
+		{
+			name: "when there is an error logging out, then it should propagate the error",
+			input: customers.LogoutCustomerInput{
+				RefreshToken: "InvalidRefreshToken",
+			},
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				authCoreService.EXPECT().Logout(gomock.Any(), gomock.Any()).
+					Return(authcore.LogoutOutput{}, authcore.ErrInvalidRefreshToken)
+			},
+			want:    customers.LogoutCustomerOutput{},
+			wantErr: authcore.ErrInvalidRefreshToken,
+		},
+		{
+			name: "when all the sessions are revoked while impersonating, " +
+				"then it should return an impersonation not allowed error",
+			input: customers.LogoutCustomerInput{
+				RefreshToken: "ValidRefreshToken",
+				AllSessions:  true,
+			},
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(auth.ErrImpersonationNotAllowed)
+			},
+			want:    customers.LogoutCustomerOutput{},
+			wantErr: auth.ErrImpersonationNotAllowed,
+		},
+		{
+			name: "when the customer is logged out, then it should return the number of revoked tokens",
+			input: customers.LogoutCustomerInput{
+				RefreshToken: "ValidRefreshToken",
+				AllSessions:  true,
+			},
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authCoreService.EXPECT().Logout(gomock.Any(), authcore.LogoutInput{
+					RefreshToken: "ValidRefreshToken",
+					Role:         customers.DefaultTokenRole,
+					AllSessions:  true,
+				}).Return(authcore.LogoutOutput{RevokedTokens: 2}, nil)
+			},
+			want: customers.LogoutCustomerOutput{RevokedTokens: 2},
+		},
+	}
 
-	if mocksSetup != nil {
-		mocksSetup(repo, authCoreService)
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.LogoutCustomer(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_RequestCustomerPasswordReset(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []customersServiceTestCase[
+		customers.RequestCustomerPasswordResetInput,
+		customers.RequestCustomerPasswordResetOutput,
+	]{
+		{
+			name:  "when the customer is not found, then it should not return any error",
+			input: customers.RequestCustomerPasswordResetInput{Email: "unknown@example.com"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByEmail(gomock.Any(), "unknown@example.com").
+					Return(customers.Customer{}, customers.ErrCustomerNotFound)
+			},
+			want:    customers.RequestCustomerPasswordResetOutput{},
+			wantErr: nil,
+		},
+		{
+			name:  "when there is an unexpected error finding the customer, then it should propagate the error",
+			input: customers.RequestCustomerPasswordResetInput{Email: "test@example.com"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(customers.Customer{}, errRepo)
+			},
+			want:    customers.RequestCustomerPasswordResetOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error requesting the password reset, then it should propagate the error",
+			input: customers.RequestCustomerPasswordResetInput{Email: "test@example.com"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(customers.Customer{CustomerID: "fake-customer-id", Email: "test@example.com"}, nil)
+				passwordResetService.EXPECT().RequestReset(gomock.Any(), gomock.Any()).
+					Return(passwordreset.RequestResetOutput{}, errToken)
+			},
+			want:    customers.RequestCustomerPasswordResetOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the password reset is requested, then it should not return any error",
+			input: customers.RequestCustomerPasswordResetInput{Email: "test@example.com"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(customers.Customer{CustomerID: "fake-customer-id", Email: "test@example.com"}, nil)
+				passwordResetService.EXPECT().RequestReset(gomock.Any(), passwordreset.RequestResetInput{
+					UserID: "fake-customer-id",
+					Role:   customers.DefaultTokenRole,
+					Email:  "test@example.com",
+				}).Return(passwordreset.RequestResetOutput{}, nil)
+			},
+			want:    customers.RequestCustomerPasswordResetOutput{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.RequestCustomerPasswordReset(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_ResetCustomerPassword(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := customers.ResetCustomerPasswordInput{
+		Token:    "fake-reset-token",
+		Password: "NewPassword123",
+	}
+	owner := passwordreset.ConsumeOutput{
+		UserID: "fake-customer-id",
+		Role:   customers.DefaultTokenRole,
 	}
 
-	service := customers.NewService(logger, repo, authCoreService)
+	tests := []customersServiceTestCase[customers.ResetCustomerPasswordInput, customers.ResetCustomerPasswordOutput]{
+		{
+			name: "when the new password does not meet the policy, " +
+				"then it should return a policy violation error without consuming the reset token",
+			input: customers.ResetCustomerPasswordInput{
+				Token:    "fake-reset-token",
+				Password: "newpassword",
+			},
+			want:    customers.ResetCustomerPasswordOutput{},
+			wantErr: password.ErrPolicyViolation,
+		},
+		{
+			name:  "when the reset token is not valid, then it should return an invalid reset token error",
+			input: input,
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), passwordreset.ConsumeInput{
+					Token: "fake-reset-token",
+					Role:  customers.DefaultTokenRole,
+				}).Return(passwordreset.ConsumeOutput{}, passwordreset.ErrResetTokenNotFound)
+			},
+			want:    customers.ResetCustomerPasswordOutput{},
+			wantErr: authcore.ErrInvalidResetToken,
+		},
+		{
+			name:  "when there is an unexpected error consuming the reset token, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).
+					Return(passwordreset.ConsumeOutput{}, errToken)
+			},
+			want:    customers.ResetCustomerPasswordOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the customer no longer exists, then it should return an invalid reset token error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(customers.ErrCustomerNotFound)
+			},
+			want:    customers.ResetCustomerPasswordOutput{},
+			wantErr: authcore.ErrInvalidResetToken,
+		},
+		{
+			name:  "when there is an unexpected error updating the password, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    customers.ResetCustomerPasswordOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error revoking the sessions, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), gomock.Any()).
+					Return(authcore.RevokeSessionsOutput{}, errToken)
+			},
+			want:    customers.ResetCustomerPasswordOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the password is reset, then it should revoke all the customer sessions",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params customers.UpdatePasswordParams) error {
+						require.Equal(t, "fake-customer-id", params.CustomerID)
+						require.True(t, password.Verify(params.Password, "NewPassword123"))
+						return nil
+					})
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), authcore.RevokeSessionsInput{
+					UserID: "fake-customer-id",
+					Role:   customers.DefaultTokenRole,
+				}).Return(authcore.RevokeSessionsOutput{RevokedTokens: 2}, nil)
+			},
+			want:    customers.ResetCustomerPasswordOutput{RevokedTokens: 2},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.ResetCustomerPassword(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_RequestCustomerMagicLink(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []customersServiceTestCase[
+		customers.RequestCustomerMagicLinkInput,
+		customers.RequestCustomerMagicLinkOutput,
+	]{
+		{
+			name:  "when the customer is not found, then it should not return any error",
+			input: customers.RequestCustomerMagicLinkInput{Email: "unknown@example.com"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByEmail(gomock.Any(), "unknown@example.com").
+					Return(customers.Customer{}, customers.ErrCustomerNotFound)
+			},
+			want:    customers.RequestCustomerMagicLinkOutput{},
+			wantErr: nil,
+		},
+		{
+			name:  "when there is an unexpected error finding the customer, then it should propagate the error",
+			input: customers.RequestCustomerMagicLinkInput{Email: "test@example.com"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(customers.Customer{}, errRepo)
+			},
+			want:    customers.RequestCustomerMagicLinkOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error requesting the magic link, then it should propagate the error",
+			input: customers.RequestCustomerMagicLinkInput{Email: "test@example.com"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				magicLinkService *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(customers.Customer{CustomerID: "fake-customer-id", Email: "test@example.com"}, nil)
+				magicLinkService.EXPECT().RequestLink(gomock.Any(), gomock.Any()).
+					Return(magiclink.RequestLinkOutput{}, errToken)
+			},
+			want:    customers.RequestCustomerMagicLinkOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the magic link is requested, then it should not return any error",
+			input: customers.RequestCustomerMagicLinkInput{Email: "test@example.com"},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				magicLinkService *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).
+					Return(customers.Customer{CustomerID: "fake-customer-id", Email: "test@example.com"}, nil)
+				magicLinkService.EXPECT().RequestLink(gomock.Any(), magiclink.RequestLinkInput{
+					UserID: "fake-customer-id",
+					Role:   customers.DefaultTokenRole,
+					Email:  "test@example.com",
+				}).Return(magiclink.RequestLinkOutput{}, nil)
+			},
+			want:    customers.RequestCustomerMagicLinkOutput{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.RequestCustomerMagicLink(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_LoginCustomerWithMagicLink(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := customers.LoginCustomerWithMagicLinkInput{Token: "fake-login-token"}
+	owner := magiclink.ConsumeOutput{UserID: "fake-customer-id", Role: customers.DefaultTokenRole}
+
+	tests := []customersServiceTestCase[
+		customers.LoginCustomerWithMagicLinkInput,
+		customers.LoginCustomerWithMagicLinkOutput,
+	]{
+		{
+			name:  "when the login token is not valid, then it should return an invalid login token error",
+			input: input,
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				magicLinkService *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				magicLinkService.EXPECT().Consume(gomock.Any(), gomock.Any()).
+					Return(magiclink.ConsumeOutput{}, magiclink.ErrLoginTokenNotFound)
+			},
+			want:    customers.LoginCustomerWithMagicLinkOutput{},
+			wantErr: authcore.ErrInvalidLoginToken,
+		},
+		{
+			name:  "when there is an unexpected error consuming the login token, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				magicLinkService *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				magicLinkService.EXPECT().Consume(gomock.Any(), gomock.Any()).
+					Return(magiclink.ConsumeOutput{}, errToken)
+			},
+			want:    customers.LoginCustomerWithMagicLinkOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the customer is no longer active, then it should return an invalid login token error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				magicLinkService *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				magicLinkService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), "fake-customer-id").
+					Return(customers.Customer{}, customers.ErrCustomerNotFound)
+			},
+			want:    customers.LoginCustomerWithMagicLinkOutput{},
+			wantErr: authcore.ErrInvalidLoginToken,
+		},
+		{
+			name:  "when there is an unexpected error finding the customer, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				magicLinkService *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				magicLinkService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customers.Customer{}, errRepo)
+			},
+			want:    customers.LoginCustomerWithMagicLinkOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error generating the token pair, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				magicLinkService *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				magicLinkService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).
+					Return(customers.Customer{CustomerID: "fake-customer-id"}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{}, errToken)
+			},
+			want:    customers.LoginCustomerWithMagicLinkOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the login token is valid, then it should return a token pair of its owner",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				magicLinkService *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				magicLinkService.EXPECT().Consume(gomock.Any(), magiclink.ConsumeInput{
+					Token: "fake-login-token",
+					Role:  customers.DefaultTokenRole,
+				}).Return(owner, nil)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), "fake-customer-id").
+					Return(customers.Customer{CustomerID: "fake-customer-id"}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), authcore.GenerateTokenPairInput{
+					UserID:     "fake-customer-id",
+					Expiration: customers.DefaultTokenExpiration,
+					Role:       customers.DefaultTokenRole,
+				}).Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: customers.LoginCustomerWithMagicLinkOutput{
+				TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.LoginCustomerWithMagicLink(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_ChangeCustomerPassword(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	hashedPassword, err := password.Hash("CurrentPassword123")
+	require.NoError(t, err)
+	customer := customers.Customer{
+		CustomerID: "fake-customer-id",
+		Email:      "test@example.com",
+		Password:   hashedPassword,
+		Active:     true,
+	}
+	input := customers.ChangeCustomerPasswordInput{
+		CurrentPassword: "CurrentPassword123",
+		NewPassword:     "NewPassword123",
+	}
+
+	tests := []customersServiceTestCase[customers.ChangeCustomerPasswordInput, customers.ChangeCustomerPasswordOutput]{
+		{
+			name: "when the new password does not meet the policy, then it should return a policy violation error",
+			input: customers.ChangeCustomerPasswordInput{
+				CurrentPassword: "CurrentPassword123",
+				NewPassword:     "Test@Example.com",
+			},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-customer-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customer, nil)
+			},
+			want:    customers.ChangeCustomerPasswordOutput{},
+			wantErr: password.ErrPolicyViolation,
+		},
+		{
+			name:  "when the customer is impersonated, then it should return an impersonation not allowed error",
+			input: input,
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(auth.ErrImpersonationNotAllowed)
+			},
+			want:    customers.ChangeCustomerPasswordOutput{},
+			wantErr: auth.ErrImpersonationNotAllowed,
+		},
+		{
+			name:  "when there is no authentication context, then it should return an invalid token error",
+			input: input,
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("", false)
+			},
+			want:    customers.ChangeCustomerPasswordOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when the customer no longer exists, then it should return an invalid token error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-customer-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), "fake-customer-id").
+					Return(customers.Customer{}, customers.ErrCustomerNotFound)
+			},
+			want:    customers.ChangeCustomerPasswordOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when there is an unexpected error finding the customer, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-customer-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customers.Customer{}, errRepo)
+			},
+			want:    customers.ChangeCustomerPasswordOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when the current password is not valid, then it should return an invalid current password error",
+			input: customers.ChangeCustomerPasswordInput{
+				CurrentPassword: "WrongPassword123",
+				NewPassword:     "NewPassword123",
+			},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-customer-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customer, nil)
+			},
+			want:    customers.ChangeCustomerPasswordOutput{},
+			wantErr: authcore.ErrInvalidCurrentPassword,
+		},
+		{
+			name:  "when there is an unexpected error updating the password, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-customer-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customer, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    customers.ChangeCustomerPasswordOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the password is changed without revoking the other sessions, then it should keep them",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-customer-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customer, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params customers.UpdatePasswordParams) error {
+						require.Equal(t, "fake-customer-id", params.CustomerID)
+						require.True(t, password.Verify(params.Password, "NewPassword123"))
+						return nil
+					})
+			},
+			want:    customers.ChangeCustomerPasswordOutput{},
+			wantErr: nil,
+		},
+		{
+			name: "when there is an error revoking the other sessions, then it should propagate the error",
+			input: customers.ChangeCustomerPasswordInput{
+				CurrentPassword:     "CurrentPassword123",
+				NewPassword:         "NewPassword123",
+				RevokeOtherSessions: true,
+			},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-customer-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customer, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), gomock.Any()).
+					Return(authcore.RevokeSessionsOutput{}, errToken)
+			},
+			want:    customers.ChangeCustomerPasswordOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the password is changed revoking the other sessions, " +
+				"then it should keep only the current session",
+			input: customers.ChangeCustomerPasswordInput{
+				CurrentPassword:     "CurrentPassword123",
+				NewPassword:         "NewPassword123",
+				RevokeOtherSessions: true,
+			},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-customer-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customer, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), authcore.RevokeSessionsInput{
+					UserID:             "fake-customer-id",
+					Role:               customers.DefaultTokenRole,
+					KeepCurrentSession: true,
+				}).Return(authcore.RevokeSessionsOutput{RevokedTokens: 2}, nil)
+			},
+			want:    customers.ChangeCustomerPasswordOutput{RevokedTokens: 2},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.ChangeCustomerPassword(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_SetCustomerActive(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []customersServiceTestCase[customers.SetCustomerActiveInput, customers.SetCustomerActiveOutput]{
+		{
+			name:  "when the customer does not exist, then it should return a customer not found error",
+			input: customers.SetCustomerActiveInput{CustomerID: "fake-customer-id", Active: false},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateActive(gomock.Any(), gomock.Any()).Return(customers.ErrCustomerNotFound)
+			},
+			want:    customers.SetCustomerActiveOutput{},
+			wantErr: customers.ErrCustomerNotFound,
+		},
+		{
+			name: "when another active customer has the same email, " +
+				"then it should return a customer already exists error",
+			input: customers.SetCustomerActiveInput{CustomerID: "fake-customer-id", Active: true},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateActive(gomock.Any(), gomock.Any()).Return(customers.ErrCustomerAlreadyExists)
+			},
+			want:    customers.SetCustomerActiveOutput{},
+			wantErr: customers.ErrCustomerAlreadyExists,
+		},
+		{
+			name:  "when there is an error revoking the customer sessions, then it should propagate the error",
+			input: customers.SetCustomerActiveInput{CustomerID: "fake-customer-id", Active: false},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateActive(gomock.Any(), gomock.Any()).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), gomock.Any()).
+					Return(authcore.RevokeSessionsOutput{}, errToken)
+			},
+			want:    customers.SetCustomerActiveOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the customer is deactivated, " +
+				"then it should revoke its sessions and return the number of revoked tokens",
+			input: customers.SetCustomerActiveInput{CustomerID: "fake-customer-id", Active: false},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateActive(gomock.Any(), customers.UpdateActiveParams{
+					CustomerID: "fake-customer-id",
+					Active:     false,
+				}).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), authcore.RevokeSessionsInput{
+					UserID: "fake-customer-id",
+					Role:   customers.DefaultTokenRole,
+				}).Return(authcore.RevokeSessionsOutput{RevokedTokens: 2}, nil)
+			},
+			want: customers.SetCustomerActiveOutput{RevokedTokens: 2},
+		},
+		{
+			name:  "when the customer is reactivated, then it should not revoke any session",
+			input: customers.SetCustomerActiveInput{CustomerID: "fake-customer-id", Active: true},
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateActive(gomock.Any(), customers.UpdateActiveParams{
+					CustomerID: "fake-customer-id",
+					Active:     true,
+				}).Return(nil)
+			},
+			want: customers.SetCustomerActiveOutput{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.SetCustomerActive(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_UpdateCustomerEmail(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := customers.UpdateCustomerEmailInput{CustomerID: "fake-customer-id", Email: "new@example.com"}
+
+	tests := []customersServiceTestCase[customers.UpdateCustomerEmailInput, customers.UpdateCustomerEmailOutput]{
+		{
+			name:  "when the customer does not exist, then it should return a customer not found error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateEmail(gomock.Any(), gomock.Any()).Return(customers.ErrCustomerNotFound)
+			},
+			want:    customers.UpdateCustomerEmailOutput{},
+			wantErr: customers.ErrCustomerNotFound,
+		},
+		{
+			name: "when another active customer has the same email, " +
+				"then it should return a customer already exists error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateEmail(gomock.Any(), gomock.Any()).Return(customers.ErrCustomerAlreadyExists)
+			},
+			want:    customers.UpdateCustomerEmailOutput{},
+			wantErr: customers.ErrCustomerAlreadyExists,
+		},
+		{
+			name:  "when there is an unexpected error when updating the email, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateEmail(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    customers.UpdateCustomerEmailOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the email is updated, then it should return no error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateEmail(gomock.Any(), customers.UpdateEmailParams{
+					CustomerID: "fake-customer-id",
+					Email:      "new@example.com",
+				}).Return(nil)
+			},
+			want: customers.UpdateCustomerEmailOutput{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.UpdateCustomerEmail(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_DeleteCustomer(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := customers.DeleteCustomerInput{CustomerID: "fake-customer-id"}
+
+	tests := []customersServiceTestCase[customers.DeleteCustomerInput, customers.DeleteCustomerOutput]{
+		{
+			name:  "when there is an error revoking the customer sessions, then it should not delete the customer",
+			input: input,
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), gomock.Any()).
+					Return(authcore.RevokeSessionsOutput{}, errToken)
+			},
+			want:    customers.DeleteCustomerOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the customer does not exist, then it should return a customer not found error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), gomock.Any()).
+					Return(authcore.RevokeSessionsOutput{}, nil)
+				repo.EXPECT().DeleteCustomer(gomock.Any(), gomock.Any()).Return(customers.ErrCustomerNotFound)
+			},
+			want:    customers.DeleteCustomerOutput{},
+			wantErr: customers.ErrCustomerNotFound,
+		},
+		{
+			name:  "when there is an unexpected error when deleting the customer, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), gomock.Any()).
+					Return(authcore.RevokeSessionsOutput{}, nil)
+				repo.EXPECT().DeleteCustomer(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    customers.DeleteCustomerOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when the customer is deleted, " +
+				"then it should revoke its sessions and return the number of revoked tokens",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), authcore.RevokeSessionsInput{
+					UserID: "fake-customer-id",
+					Role:   customers.DefaultTokenRole,
+				}).Return(authcore.RevokeSessionsOutput{RevokedTokens: 2}, nil)
+				repo.EXPECT().DeleteCustomer(gomock.Any(), "fake-customer-id").Return(nil)
+			},
+			want: customers.DeleteCustomerOutput{RevokedTokens: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.DeleteCustomer(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ImpersonateCustomer(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := customers.ImpersonateCustomerInput{CustomerID: "fake-customer-id"}
+	customer := customers.Customer{CustomerID: "fake-customer-id", Email: "test@example.com", Active: true}
+
+	tests := []customersServiceTestCase[customers.ImpersonateCustomerInput, customers.ImpersonateCustomerOutput]{
+		{
+			name:  "when there is no authentication context, then it should return an invalid token error",
+			input: input,
+			mocksSetup: func(
+				_ *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("", false)
+			},
+			want:    customers.ImpersonateCustomerOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when the customer does not exist, then it should return a customer not found error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-admin-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), "fake-customer-id").
+					Return(customers.Customer{}, customers.ErrCustomerNotFound)
+			},
+			want:    customers.ImpersonateCustomerOutput{},
+			wantErr: customers.ErrCustomerNotFound,
+		},
+		{
+			name:  "when there is an unexpected error finding the customer, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-admin-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customers.Customer{}, errRepo)
+			},
+			want:    customers.ImpersonateCustomerOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error generating the token, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-admin-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), gomock.Any()).Return(customer, nil)
+				authCoreService.EXPECT().GenerateImpersonationToken(gomock.Any(), gomock.Any()).
+					Return(authcore.ImpersonationToken{}, errToken)
+			},
+			want:    customers.ImpersonateCustomerOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the customer is impersonated, then it should return a token naming the admin as actor",
+			input: input,
+			mocksSetup: func(
+				repo *customersmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *magiclinkmocks.MockService,
+				_ *lockoutmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-admin-id", true)
+				repo.EXPECT().FindByCustomerID(gomock.Any(), "fake-customer-id").Return(customer, nil)
+				authCoreService.EXPECT().GenerateImpersonationToken(
+					gomock.Any(), authcore.GenerateImpersonationTokenInput{
+						UserID:  "fake-customer-id",
+						Role:    customers.DefaultTokenRole,
+						ActorID: "fake-admin-id",
+					},
+				).Return(authcore.ImpersonationToken{
+					AccessToken: "fake-token",
+					ExpiresIn:   authcore.ImpersonationTokenExpiration,
+					TokenType:   "Bearer",
+				}, nil)
+			},
+			want: customers.ImpersonateCustomerOutput{
+				ImpersonationToken: authcore.ImpersonationToken{
+					AccessToken: "fake-token",
+					ExpiresIn:   authcore.ImpersonationTokenExpiration,
+					TokenType:   "Bearer",
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.ImpersonateCustomer(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func serviceSetup(
+	t *testing.T, logger log.Logger, mocksSetup func(
+		repo *customersmocks.MockRepository,
+		authCoreService *authcoremocks.MockService,
+		passwordResetService *passwordresetmocks.MockService,
+		magicLinkService *magiclinkmocks.MockService,
+		lockoutService *lockoutmocks.MockService,
+		authctx *authmocks.MockContextReader,
+	),
+) (customers.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	repo := customersmocks.NewMockRepository(ctrl)
+	authCoreService := authcoremocks.NewMockService(ctrl)
+	passwordResetService := passwordresetmocks.NewMockService(ctrl)
+	magicLinkService := magiclinkmocks.NewMockService(ctrl)
+	lockoutService := lockoutmocks.NewMockService(ctrl)
+	authctx := authmocks.NewMockContextReader(ctrl)
+	// The audit log is best effort and never affects the result, so the recorded events are not asserted here
+	authEventsService := autheventsmocks.NewMockService(ctrl)
+	authEventsService.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	if mocksSetup != nil {
+		mocksSetup(repo, authCoreService, passwordResetService, magicLinkService, lockoutService, authctx)
+	}
+
+	service := customers.NewService(
+		logger, repo, authCoreService, passwordResetService, magicLinkService, lockoutService, testPasswordPolicy,
+		authctx, authEventsService,
+	)
 	return service, func() {
 		ctrl.Finish()
 	}
@@ -0,0 +1,11 @@
+package denylist
+
+import "time"
+
+// DeniedToken represents an access token revoked before its expiration, identified by its jti claim.
+// The document is removed by MongoDB once ExpiresAt is reached, as the token can't be used anymore anyway.
+type DeniedToken struct {
+	TokenID   string    `bson:"_id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+	CreatedAt time.Time `bson:"created_at"`
+}
@@ -0,0 +1,93 @@
+// Package denylist provides the persistent store of the access tokens revoked before their expiration, which backs
+// the auth.Denylist checked by the authentication middleware.
+package denylist
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// CollectionName defines the name of the database collection used to store the revoked access tokens.
+	CollectionName = "token_denylist"
+
+	// FieldTokenID represents the database field name for storing the jti of the revoked access token.
+	FieldTokenID = "_id"
+	// FieldExpiresAt represents the database field name for storing when the revoked access token expires.
+	FieldExpiresAt = "expires_at"
+	// FieldCreatedAt represents the database field name for storing when the access token was revoked.
+	FieldCreatedAt = "created_at"
+)
+
+// Repository defines a contract for storing the revoked access tokens in a persistence layer. It satisfies
+// auth.Denylist, and it is implemented on top of MongoDB, but it can be backed by any store with expiring keys, such
+// as Redis.
+//
+//go:generate mockgen -destination=./mocks/repository_mock.go -package=denylist_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/denylist Repository
+type Repository interface {
+	Add(ctx context.Context, tokenID string, expiresAt time.Time) error
+	Contains(ctx context.Context, tokenID string) (bool, error)
+}
+
+type repository struct {
+	logger     log.Logger
+	collection *mongo.Collection
+	clock      clock.Clock
+}
+
+// NewRepository creates a new Repository instance.
+func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repository {
+	return &repository{
+		logger:     logger,
+		collection: db.Collection(CollectionName),
+		clock:      clk,
+	}
+}
+
+// Add stores the revoked access token until it expires. Revoking the same token twice is not an error.
+func (r *repository) Add(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	logger := r.logger.WithContext(ctx)
+
+	update := bson.M{
+		"$set": bson.M{
+			FieldExpiresAt: expiresAt,
+		},
+		"$setOnInsert": bson.M{
+			FieldCreatedAt: r.clock.Now(),
+		},
+	}
+
+	opts := options.Update().SetUpsert(true)
+	if _, err := r.collection.UpdateOne(ctx, bson.M{FieldTokenID: tokenID}, update, opts); err != nil {
+		logger.Error("Failed to add access token to the denylist", err)
+		return err
+	}
+	return nil
+}
+
+// Contains reports whether the access token is revoked. The tokens that already expired are not reported, even if
+// MongoDB did not remove them yet.
+func (r *repository) Contains(ctx context.Context, tokenID string) (bool, error) {
+	logger := r.logger.WithContext(ctx)
+
+	filter := bson.M{
+		FieldTokenID: tokenID,
+		FieldExpiresAt: bson.M{
+			"$gt": r.clock.Now(),
+		},
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		logger.Error("Failed to find access token in the denylist", err)
+		return false, err
+	}
+	return count > 0, nil
+}
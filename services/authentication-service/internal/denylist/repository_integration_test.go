@@ -0,0 +1,166 @@
+//go:build integration
+
+package denylist_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/denylist"
+)
+
+const testDBPrefix = "denylist_test_authentication_service"
+
+func TestRepository_Add(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []struct {
+		name            string
+		insertDocuments func(t *testing.T, coll *mongo.Collection)
+		want            denylist.DeniedToken
+	}{
+		{
+			name: "when the token is not revoked yet, then it should be stored",
+			want: denylist.DeniedToken{
+				TokenID:   "fake-token-id",
+				ExpiresAt: now.Add(time.Hour),
+				CreatedAt: now,
+			},
+		},
+		{
+			name: "when the token was already revoked, then it should keep the original revocation time",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, denylist.DeniedToken{
+					TokenID:   "fake-token-id",
+					ExpiresAt: now.Add(time.Hour),
+					CreatedAt: now.Add(-time.Minute),
+				})
+			},
+			want: denylist.DeniedToken{
+				TokenID:   "fake-token-id",
+				ExpiresAt: now.Add(time.Hour),
+				CreatedAt: now.Add(-time.Minute),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := tdb.DB.Collection(denylist.CollectionName)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := denylist.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.Add(context.Background(), "fake-token-id", now.Add(time.Hour))
+			require.NoError(t, err)
+
+			var got denylist.DeniedToken
+			err = coll.FindOne(context.Background(), bson.M{denylist.FieldTokenID: "fake-token-id"}).Decode(&got)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRepository_Add_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := denylist.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.Add(context.Background(), "fake-token-id", now.Add(time.Hour))
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_Contains(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []struct {
+		name            string
+		insertDocuments func(t *testing.T, coll *mongo.Collection)
+		want            bool
+	}{
+		{
+			name: "when the token is not revoked, then it should return false",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, denylist.DeniedToken{
+					TokenID:   "fake-other-token-id",
+					ExpiresAt: now.Add(time.Hour),
+					CreatedAt: now,
+				})
+			},
+			want: false,
+		},
+		{
+			name: "when the revoked token already expired, then it should return false",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, denylist.DeniedToken{
+					TokenID:   "fake-token-id",
+					ExpiresAt: now,
+					CreatedAt: now.Add(-time.Hour),
+				})
+			},
+			want: false,
+		},
+		{
+			name: "when the token is revoked, then it should return true",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, denylist.DeniedToken{
+					TokenID:   "fake-token-id",
+					ExpiresAt: now.Add(time.Hour),
+					CreatedAt: now,
+				})
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := tdb.DB.Collection(denylist.CollectionName)
+			tt.insertDocuments(t, coll)
+
+			repo := denylist.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.Contains(context.Background(), "fake-token-id")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRepository_Contains_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := denylist.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.Contains(context.Background(), "fake-token-id")
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
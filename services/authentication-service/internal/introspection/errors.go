@@ -0,0 +1,9 @@
+// Package introspection provides the token introspection (RFC 7662) and revocation (RFC 7009) operations, which allow
+// the API gateway and the internal services to check whether a token is still active, and to revoke it.
+package introspection
+
+import "errors"
+
+// ErrUnsupportedTokenType indicates that the token can not be revoked, as it happens with the access tokens issued
+// without a jti claim.
+var ErrUnsupportedTokenType = errors.New("unsupported token type")
@@ -0,0 +1,156 @@
+package introspection
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// CodeUnsupportedTokenType represents the error code for the token types that can not be revoked.
+	CodeUnsupportedTokenType = "UNSUPPORTED_TOKEN_TYPE"
+	// MsgUnsupportedTokenType represents the error message for the token types that can not be revoked.
+	MsgUnsupportedTokenType = "the token type can not be revoked"
+)
+
+// Handler manages HTTP requests for token introspection and revocation operations.
+type Handler struct {
+	logger         log.Logger
+	service        Service
+	authMiddleware auth.Middleware
+}
+
+// NewHandler creates a new instance of Handler.
+func NewHandler(logger log.Logger, service Service, authMiddleware auth.Middleware) *Handler {
+	return &Handler{
+		logger:         logger,
+		service:        service,
+		authMiddleware: authMiddleware,
+	}
+}
+
+// RegisterRoutes registers the token introspection and revocation HTTP routes, which are reserved to the services
+// holding a service token with the required scope.
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	authRouter := router.Group("/v1.0/auth")
+	{
+		authRouter.POST("/introspect", h.authMiddleware.RequireServiceScope(auth.ScopeAuthIntrospect), h.IntrospectToken)
+		authRouter.POST("/revoke", h.authMiddleware.RequireServiceScope(auth.ScopeAuthRevoke), h.RevokeToken)
+	}
+}
+
+// IntrospectTokenRequest represents the request payload for introspecting a token.
+type IntrospectTokenRequest struct {
+	Token         string `json:"token" binding:"required"`
+	TokenTypeHint string `json:"token_type_hint" binding:"omitempty,oneof=access_token refresh_token"`
+}
+
+// IntrospectTokenResponse represents the response payload of a token introspection. Only Active is set for the
+// tokens that are not active.
+type IntrospectTokenResponse struct {
+	Active    bool   `json:"active"`
+	TokenType string `json:"token_type,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Role      string `json:"role,omitempty"`
+	Tenant    string `json:"tenant,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+}
+
+// IntrospectToken handles the introspection of an access or refresh token.
+func (h *Handler) IntrospectToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("IntrospectToken handler called")
+
+	var req IntrospectTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	output, err := h.service.IntrospectToken(ctx, IntrospectTokenInput(req))
+	if err != nil {
+		logger.Error("Failed to introspect token", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	if !output.Active {
+		c.JSON(http.StatusOK, IntrospectTokenResponse{Active: false})
+		return
+	}
+
+	c.JSON(http.StatusOK, IntrospectTokenResponse{
+		Active:    true,
+		TokenType: output.TokenType,
+		Subject:   output.Subject,
+		Role:      output.Role,
+		Tenant:    output.Tenant,
+		Scope:     output.Scope,
+		ExpiresAt: output.ExpiresAt.Unix(),
+		IssuedAt:  output.IssuedAt.Unix(),
+	})
+}
+
+// RevokeTokenRequest represents the request payload for revoking a token.
+type RevokeTokenRequest struct {
+	Token         string `json:"token" binding:"required"`
+	TokenTypeHint string `json:"token_type_hint" binding:"omitempty,oneof=access_token refresh_token"`
+}
+
+// RevokeToken handles the revocation of a refresh token. As stated by RFC 7009, revoking a token which is not active
+// is not an error.
+func (h *Handler) RevokeToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("RevokeToken handler called")
+
+	var req RevokeTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	output, err := h.service.RevokeToken(ctx, RevokeTokenInput(req))
+	if err != nil {
+		if errors.Is(err, ErrUnsupportedTokenType) {
+			logger.Warn("Unsupported token type", log.Field{Key: "token_type_hint", Value: req.TokenTypeHint})
+			c.JSON(
+				http.StatusBadRequest, customhttp.NewErrorResponse(
+					CodeUnsupportedTokenType,
+					MsgUnsupportedTokenType,
+				),
+			)
+			return
+		}
+		logger.Error("Failed to revoke token", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Token revocation handled", log.Field{Key: "revoked", Value: output.Revoked})
+	c.Status(http.StatusOK)
+}
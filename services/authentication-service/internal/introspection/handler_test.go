@@ -0,0 +1,267 @@
+//go:build unit
+
+package introspection_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/introspection"
+	introspectionmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/introspection/mocks"
+)
+
+type introspectionHandlerTestCase struct {
+	name        string
+	token       string
+	jsonPayload string
+	mocksSetup  func(service *introspectionmocks.MockService, authService *authmocks.MockService)
+	wantJSON    string
+	wantStatus  int
+}
+
+func TestHandler_IntrospectToken(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []introspectionHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the service token does not have the introspect scope, " +
+				"then it should return a 403 with the forbidden error",
+			token: "service-token",
+			mocksSetup: func(_ *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRevoke)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "service-token",
+			jsonPayload: `{}`,
+			mocksSetup: func(_ *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthIntrospect)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("token is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when an invalid token type hint is provided, then it should return a 400 with the validation error",
+			token:       "service-token",
+			jsonPayload: `{"token": "fake-token", "token_type_hint": "id_token"}`,
+			mocksSetup: func(_ *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthIntrospect)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("token_type_hint is invalid").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when unexpected error when introspecting the token, then it should return a 500 with the internal error",
+			token:       "service-token",
+			jsonPayload: `{"token": "fake-token"}`,
+			mocksSetup: func(service *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthIntrospect)
+				service.EXPECT().IntrospectToken(gomock.Any(), gomock.Any()).
+					Return(introspection.IntrospectTokenOutput{}, errRefresh)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the token is not active, then it should return a 200 with only the active flag",
+			token:       "service-token",
+			jsonPayload: `{"token": "fake-token"}`,
+			mocksSetup: func(service *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthIntrospect)
+				service.EXPECT().IntrospectToken(gomock.Any(), gomock.Any()).
+					Return(introspection.IntrospectTokenOutput{Active: false}, nil)
+			},
+			wantJSON:   `{"active": false}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "when the token is active, then it should return a 200 with the token details",
+			token:       "service-token",
+			jsonPayload: `{"token": "fake-token", "token_type_hint": "access_token"}`,
+			mocksSetup: func(service *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthIntrospect)
+				service.EXPECT().IntrospectToken(gomock.Any(), introspection.IntrospectTokenInput{
+					Token:         "fake-token",
+					TokenTypeHint: introspection.TokenTypeAccessToken,
+				}).Return(introspection.IntrospectTokenOutput{
+					Active:    true,
+					TokenType: introspection.TokenTypeAccessToken,
+					Subject:   "fake-user-id",
+					Role:      "staff",
+					Tenant:    "fake-tenant-id",
+					ExpiresAt: now.Add(time.Hour),
+					IssuedAt:  now,
+				}, nil)
+			},
+			wantJSON: `{
+				"active": true,
+				"token_type": "access_token",
+				"sub": "fake-user-id",
+				"role": "staff",
+				"tenant": "fake-tenant-id",
+				"exp": 1735693200,
+				"iat": 1735689600
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runIntrospectionHandlerTestCase(t, logger, http.MethodPost, "/v1.0/auth/introspect", tt)
+		})
+	}
+}
+
+func TestHandler_RevokeToken(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []introspectionHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the service token does not have the revoke scope, " +
+				"then it should return a 403 with the forbidden error",
+			token: "service-token",
+			mocksSetup: func(_ *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthIntrospect)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "service-token",
+			jsonPayload: `{}`,
+			mocksSetup: func(_ *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRevoke)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("token is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the token type can not be revoked, " +
+				"then it should return a 400 with the unsupported token type error",
+			token:       "service-token",
+			jsonPayload: `{"token": "fake-token", "token_type_hint": "access_token"}`,
+			mocksSetup: func(service *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRevoke)
+				service.EXPECT().RevokeToken(gomock.Any(), gomock.Any()).
+					Return(introspection.RevokeTokenOutput{}, introspection.ErrUnsupportedTokenType)
+			},
+			wantJSON: `{
+				"code": "UNSUPPORTED_TOKEN_TYPE",
+				"message": "the token type can not be revoked",
+				"details": []
+			}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when unexpected error when revoking the token, then it should return a 500 with the internal error",
+			token:       "service-token",
+			jsonPayload: `{"token": "fake-token"}`,
+			mocksSetup: func(service *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRevoke)
+				service.EXPECT().RevokeToken(gomock.Any(), gomock.Any()).
+					Return(introspection.RevokeTokenOutput{}, errRefresh)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the token is not active, then it should return a 200 anyway",
+			token:       "service-token",
+			jsonPayload: `{"token": "fake-token"}`,
+			mocksSetup: func(service *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRevoke)
+				service.EXPECT().RevokeToken(gomock.Any(), gomock.Any()).
+					Return(introspection.RevokeTokenOutput{Revoked: false}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "when the token is revoked, then it should return a 200",
+			token:       "service-token",
+			jsonPayload: `{"token": "fake-token", "token_type_hint": "refresh_token"}`,
+			mocksSetup: func(service *introspectionmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRevoke)
+				service.EXPECT().RevokeToken(gomock.Any(), introspection.RevokeTokenInput{
+					Token:         "fake-token",
+					TokenTypeHint: introspection.TokenTypeRefreshToken,
+				}).Return(introspection.RevokeTokenOutput{Revoked: true}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runIntrospectionHandlerTestCase(t, logger, http.MethodPost, "/v1.0/auth/revoke", tt)
+		})
+	}
+}
+
+func mockServiceClaims(authService *authmocks.MockService, scope string) {
+	authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+		Return(auth.GetClaimsOutput{
+			Claims: &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-service-id"},
+				Role:             string(auth.RoleService),
+				Scope:            scope,
+			},
+		}, nil)
+}
+
+// runIntrospectionHandlerTestCase executes a test case for the introspection handler, which is common for all tests.
+func runIntrospectionHandlerTestCase(
+	t *testing.T,
+	logger log.Logger,
+	httpMethod string,
+	route string,
+	tt introspectionHandlerTestCase,
+) {
+	service := introspectionmocks.NewMockService(gomock.NewController(t))
+	authService := authmocks.NewMockService(gomock.NewController(t))
+	if tt.mocksSetup != nil {
+		tt.mocksSetup(service, authService)
+	}
+
+	// Initialize the authentication middleware
+	authMiddleware := auth.NewMiddleware(logger, authService)
+
+	// Initialize the handler
+	h := introspection.NewHandler(logger, service, authMiddleware)
+
+	// Make HTTP request
+	w := customhttp.ServeTestHTTPRequest(t, h, httpMethod, route, tt.token, nil, tt.jsonPayload)
+
+	assert.Equal(t, tt.wantStatus, w.Code)
+	if tt.wantJSON == "" {
+		assert.Empty(t, w.Body.String())
+		return
+	}
+	assert.JSONEq(t, tt.wantJSON, w.Body.String())
+}
@@ -0,0 +1,228 @@
+package introspection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
+)
+
+const (
+	// TokenTypeAccessToken identifies the access tokens, used both as token type hint and as introspected token type.
+	TokenTypeAccessToken = "access_token"
+	// TokenTypeRefreshToken identifies the refresh tokens, used both as token type hint and as introspected token type.
+	TokenTypeRefreshToken = "refresh_token"
+)
+
+// Service defines the interface for the token introspection and revocation service.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=introspection_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/introspection Service
+type Service interface {
+	IntrospectToken(ctx context.Context, input IntrospectTokenInput) (IntrospectTokenOutput, error)
+	RevokeToken(ctx context.Context, input RevokeTokenInput) (RevokeTokenOutput, error)
+}
+
+type service struct {
+	logger         log.Logger
+	authService    auth.Service
+	refreshService refresh.Service
+	denylist       auth.Denylist
+}
+
+// NewService creates a new instance of Service with the provided dependencies.
+func NewService(
+	logger log.Logger,
+	authService auth.Service,
+	refreshService refresh.Service,
+	denylist auth.Denylist,
+) Service {
+	return &service{
+		logger:         logger,
+		authService:    authService,
+		refreshService: refreshService,
+		denylist:       denylist,
+	}
+}
+
+// IntrospectTokenInput represents the token to introspect. TokenTypeHint is optional, and only decides which token
+// type is looked up first.
+type IntrospectTokenInput struct {
+	Token         string
+	TokenTypeHint string
+}
+
+// IntrospectTokenOutput represents the state of the introspected token. When the token is not active, the rest of
+// the fields are empty, so nothing is disclosed about it.
+type IntrospectTokenOutput struct {
+	Active    bool
+	TokenType string
+	Subject   string
+	Role      string
+	Tenant    string
+	Scope     string
+	ExpiresAt time.Time
+	IssuedAt  time.Time
+}
+
+// IntrospectToken reports whether the token is an active access or refresh token. Access tokens are active while
+// their signature and expiration are valid and they are not denied, and refresh tokens while they are neither expired
+// nor revoked.
+func (s *service) IntrospectToken(ctx context.Context, input IntrospectTokenInput) (IntrospectTokenOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("introspecting token", log.Field{Key: "token_type_hint", Value: input.TokenTypeHint})
+	lookups := []func(context.Context, string) (IntrospectTokenOutput, error){
+		s.introspectAccessToken,
+		s.introspectRefreshToken,
+	}
+	if input.TokenTypeHint == TokenTypeRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, lookup := range lookups {
+		output, err := lookup(ctx, input.Token)
+		if err != nil {
+			logger.Error("failed to introspect token", err)
+			return IntrospectTokenOutput{}, err
+		}
+		if output.Active {
+			logger.Info("active token introspected", log.Field{Key: "token_type", Value: output.TokenType})
+			return output, nil
+		}
+	}
+
+	logger.Info("inactive token introspected")
+	return IntrospectTokenOutput{Active: false}, nil
+}
+
+func (s *service) introspectAccessToken(ctx context.Context, token string) (IntrospectTokenOutput, error) {
+	output, err := s.authService.GetClaims(ctx, auth.GetClaimsInput{AccessToken: token})
+	if err != nil {
+		// Any token which can not be verified is just not an active access token
+		return IntrospectTokenOutput{}, nil
+	}
+
+	claims := output.Claims
+	if claims.ID != "" {
+		denied, err := s.denylist.Contains(ctx, claims.ID)
+		if err != nil {
+			return IntrospectTokenOutput{}, err
+		}
+		if denied {
+			return IntrospectTokenOutput{}, nil
+		}
+	}
+
+	result := IntrospectTokenOutput{
+		Active:    true,
+		TokenType: TokenTypeAccessToken,
+		Subject:   claims.Subject,
+		Role:      claims.Role,
+		Tenant:    claims.Tenant,
+		Scope:     claims.Scope,
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Time
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
+	}
+	return result, nil
+}
+
+func (s *service) introspectRefreshToken(ctx context.Context, token string) (IntrospectTokenOutput, error) {
+	output, err := s.refreshService.FindActiveToken(ctx, refresh.FindActiveTokenInput{Token: token})
+	if err != nil {
+		if errors.Is(err, refresh.ErrRefreshTokenNotFound) {
+			return IntrospectTokenOutput{}, nil
+		}
+		return IntrospectTokenOutput{}, err
+	}
+
+	return IntrospectTokenOutput{
+		Active:    true,
+		TokenType: TokenTypeRefreshToken,
+		Subject:   output.UserID,
+		Role:      output.Role,
+		Tenant:    output.TenantID,
+		ExpiresAt: output.ExpiresAt,
+		IssuedAt:  output.CreatedAt,
+	}, nil
+}
+
+// RevokeTokenInput represents the token to revoke. TokenTypeHint is optional.
+type RevokeTokenInput struct {
+	Token         string
+	TokenTypeHint string
+}
+
+// RevokeTokenOutput represents the result of a token revocation. Revoked is false when the token was neither a valid
+// access token nor an active refresh token, which is not an error, as the token can no longer be used either way.
+type RevokeTokenOutput struct {
+	Revoked bool
+}
+
+// RevokeToken revokes the access or refresh token. Access tokens are added to the denylist until they expire, and
+// refresh tokens are revoked along with the access token issued with them. The access tokens issued without a jti
+// claim can not be revoked.
+func (s *service) RevokeToken(ctx context.Context, input RevokeTokenInput) (RevokeTokenOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("revoking token", log.Field{Key: "token_type_hint", Value: input.TokenTypeHint})
+	revocations := []func(context.Context, string) (bool, error){
+		s.revokeAccessToken,
+		s.revokeRefreshToken,
+	}
+	if input.TokenTypeHint == TokenTypeRefreshToken {
+		revocations[0], revocations[1] = revocations[1], revocations[0]
+	}
+
+	for _, revoke := range revocations {
+		revoked, err := revoke(ctx, input.Token)
+		if err != nil {
+			if errors.Is(err, ErrUnsupportedTokenType) {
+				logger.Warn("access token without jti can not be revoked")
+				return RevokeTokenOutput{}, err
+			}
+			logger.Error("failed to revoke token", err)
+			return RevokeTokenOutput{}, err
+		}
+		if revoked {
+			logger.Info("token revoked successfully")
+			return RevokeTokenOutput{Revoked: true}, nil
+		}
+	}
+
+	logger.Info("token not revoked, as it is neither a valid access token nor an active refresh token")
+	return RevokeTokenOutput{Revoked: false}, nil
+}
+
+func (s *service) revokeAccessToken(ctx context.Context, token string) (bool, error) {
+	output, err := s.authService.GetClaims(ctx, auth.GetClaimsInput{AccessToken: token})
+	if err != nil {
+		// Any token which can not be verified is just not a valid access token
+		return false, nil
+	}
+
+	claims := output.Claims
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return false, ErrUnsupportedTokenType
+	}
+	if err := s.denylist.Add(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *service) revokeRefreshToken(ctx context.Context, token string) (bool, error) {
+	if _, err := s.refreshService.Revoke(ctx, refresh.RevokeInput{Token: token}); err != nil {
+		if errors.Is(err, refresh.ErrRefreshTokenNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
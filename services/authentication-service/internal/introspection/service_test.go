@@ -0,0 +1,361 @@
+//go:build unit
+
+package introspection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/introspection"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
+	refreshmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh/mocks"
+)
+
+var (
+	errRefresh  = errors.New("refresh error")
+	errDenylist = errors.New("denylist error")
+)
+
+type introspectionServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	mocksSetup func(
+		authService *authmocks.MockService,
+		refreshService *refreshmocks.MockService,
+		denylist *authmocks.MockDenylist,
+	)
+	want    W
+	wantErr error
+}
+
+func TestService_IntrospectToken(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt = now.Add(time.Hour)
+	)
+	logger, _ := log.NewTest()
+
+	accessClaims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "fake-access-token-id",
+			Subject:   "fake-user-id",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+		Role:   "staff",
+		Tenant: "fake-tenant-id",
+	}
+
+	tests := []introspectionServiceTestCase[introspection.IntrospectTokenInput, introspection.IntrospectTokenOutput]{
+		{
+			name:  "when the token is neither an access nor a refresh token, then it returns an inactive token",
+			input: introspection.IntrospectTokenInput{Token: "unknown-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), auth.GetClaimsInput{AccessToken: "unknown-token"}).
+					Return(auth.GetClaimsOutput{}, auth.ErrInvalidToken)
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), refresh.FindActiveTokenInput{Token: "unknown-token"}).
+					Return(refresh.FindActiveTokenOutput{}, refresh.ErrRefreshTokenNotFound)
+			},
+			want:    introspection.IntrospectTokenOutput{Active: false},
+			wantErr: nil,
+		},
+		{
+			name:  "when there is an unexpected error finding the refresh token, then it propagates the error",
+			input: introspection.IntrospectTokenInput{Token: "unknown-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+					Return(auth.GetClaimsOutput{}, auth.ErrInvalidToken)
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
+					Return(refresh.FindActiveTokenOutput{}, errRefresh)
+			},
+			want:    introspection.IntrospectTokenOutput{},
+			wantErr: errRefresh,
+		},
+		{
+			name:  "when the token is a valid access token, then it returns its claims",
+			input: introspection.IntrospectTokenInput{Token: "access-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				_ *refreshmocks.MockService,
+				denylist *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), auth.GetClaimsInput{AccessToken: "access-token"}).
+					Return(auth.GetClaimsOutput{Claims: accessClaims}, nil)
+				denylist.EXPECT().Contains(gomock.Any(), "fake-access-token-id").Return(false, nil)
+			},
+			want: introspection.IntrospectTokenOutput{
+				Active:    true,
+				TokenType: introspection.TokenTypeAccessToken,
+				Subject:   "fake-user-id",
+				Role:      "staff",
+				Tenant:    "fake-tenant-id",
+				ExpiresAt: expiresAt,
+				IssuedAt:  now,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "when the access token has been denied, then it returns an inactive token",
+			input: introspection.IntrospectTokenInput{Token: "access-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				denylist *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+					Return(auth.GetClaimsOutput{Claims: accessClaims}, nil)
+				denylist.EXPECT().Contains(gomock.Any(), "fake-access-token-id").Return(true, nil)
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), gomock.Any()).
+					Return(refresh.FindActiveTokenOutput{}, refresh.ErrRefreshTokenNotFound)
+			},
+			want:    introspection.IntrospectTokenOutput{Active: false},
+			wantErr: nil,
+		},
+		{
+			name:  "when there is an unexpected error checking the denylist, then it propagates the error",
+			input: introspection.IntrospectTokenInput{Token: "access-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				_ *refreshmocks.MockService,
+				denylist *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+					Return(auth.GetClaimsOutput{Claims: accessClaims}, nil)
+				denylist.EXPECT().Contains(gomock.Any(), gomock.Any()).Return(false, errDenylist)
+			},
+			want:    introspection.IntrospectTokenOutput{},
+			wantErr: errDenylist,
+		},
+		{
+			name:  "when the token is an active refresh token, then it returns its owner",
+			input: introspection.IntrospectTokenInput{Token: "refresh-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+					Return(auth.GetClaimsOutput{}, auth.ErrInvalidToken)
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), refresh.FindActiveTokenInput{Token: "refresh-token"}).
+					Return(refresh.FindActiveTokenOutput{
+						UserID:    "fake-user-id",
+						Role:      "customer",
+						ExpiresAt: expiresAt,
+						CreatedAt: now,
+					}, nil)
+			},
+			want: introspection.IntrospectTokenOutput{
+				Active:    true,
+				TokenType: introspection.TokenTypeRefreshToken,
+				Subject:   "fake-user-id",
+				Role:      "customer",
+				ExpiresAt: expiresAt,
+				IssuedAt:  now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the refresh token hint is provided, then it looks up the refresh token first",
+			input: introspection.IntrospectTokenInput{
+				Token:         "refresh-token",
+				TokenTypeHint: introspection.TokenTypeRefreshToken,
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *authmocks.MockDenylist,
+			) {
+				refreshService.EXPECT().FindActiveToken(gomock.Any(), refresh.FindActiveTokenInput{Token: "refresh-token"}).
+					Return(refresh.FindActiveTokenOutput{
+						UserID:    "fake-user-id",
+						Role:      "customer",
+						ExpiresAt: expiresAt,
+						CreatedAt: now,
+					}, nil)
+			},
+			want: introspection.IntrospectTokenOutput{
+				Active:    true,
+				TokenType: introspection.TokenTypeRefreshToken,
+				Subject:   "fake-user-id",
+				Role:      "customer",
+				ExpiresAt: expiresAt,
+				IssuedAt:  now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := serviceSetup(t, logger, tt.mocksSetup)
+
+			got, err := service.IntrospectToken(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RevokeToken(t *testing.T) {
+	expiresAt := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	accessClaims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "fake-access-token-id",
+			Subject:   "fake-user-id",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: "customer",
+	}
+
+	tests := []introspectionServiceTestCase[introspection.RevokeTokenInput, introspection.RevokeTokenOutput]{
+		{
+			name:  "when the token is neither an access nor a refresh token, then it does not revoke anything",
+			input: introspection.RevokeTokenInput{Token: "unknown-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), auth.GetClaimsInput{AccessToken: "unknown-token"}).
+					Return(auth.GetClaimsOutput{}, auth.ErrInvalidToken)
+				refreshService.EXPECT().Revoke(gomock.Any(), refresh.RevokeInput{Token: "unknown-token"}).
+					Return(refresh.RevokeOutput{}, refresh.ErrRefreshTokenNotFound)
+			},
+			want:    introspection.RevokeTokenOutput{Revoked: false},
+			wantErr: nil,
+		},
+		{
+			name:  "when the access token has no jti, then it returns an unsupported token type error",
+			input: introspection.RevokeTokenInput{Token: "access-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				_ *refreshmocks.MockService,
+				_ *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+					Return(auth.GetClaimsOutput{Claims: &auth.Claims{
+						RegisteredClaims: jwt.RegisteredClaims{
+							Subject:   "fake-user-id",
+							ExpiresAt: jwt.NewNumericDate(expiresAt),
+						},
+					}}, nil)
+			},
+			want:    introspection.RevokeTokenOutput{},
+			wantErr: introspection.ErrUnsupportedTokenType,
+		},
+		{
+			name:  "when there is an unexpected error denying the access token, then it propagates the error",
+			input: introspection.RevokeTokenInput{Token: "access-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				_ *refreshmocks.MockService,
+				denylist *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+					Return(auth.GetClaimsOutput{Claims: accessClaims}, nil)
+				denylist.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).Return(errDenylist)
+			},
+			want:    introspection.RevokeTokenOutput{},
+			wantErr: errDenylist,
+		},
+		{
+			name:  "when the token is a valid access token, then it denies it until it expires",
+			input: introspection.RevokeTokenInput{Token: "access-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				_ *refreshmocks.MockService,
+				denylist *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), auth.GetClaimsInput{AccessToken: "access-token"}).
+					Return(auth.GetClaimsOutput{Claims: accessClaims}, nil)
+				denylist.EXPECT().Add(gomock.Any(), "fake-access-token-id", expiresAt).Return(nil)
+			},
+			want:    introspection.RevokeTokenOutput{Revoked: true},
+			wantErr: nil,
+		},
+		{
+			name:  "when there is an unexpected error revoking the refresh token, then it propagates the error",
+			input: introspection.RevokeTokenInput{Token: "refresh-token"},
+			mocksSetup: func(
+				authService *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *authmocks.MockDenylist,
+			) {
+				authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+					Return(auth.GetClaimsOutput{}, auth.ErrInvalidToken)
+				refreshService.EXPECT().Revoke(gomock.Any(), gomock.Any()).
+					Return(refresh.RevokeOutput{}, errRefresh)
+			},
+			want:    introspection.RevokeTokenOutput{},
+			wantErr: errRefresh,
+		},
+		{
+			name: "when the refresh token hint is provided, then it revokes the refresh token first",
+			input: introspection.RevokeTokenInput{
+				Token:         "refresh-token",
+				TokenTypeHint: introspection.TokenTypeRefreshToken,
+			},
+			mocksSetup: func(
+				_ *authmocks.MockService,
+				refreshService *refreshmocks.MockService,
+				_ *authmocks.MockDenylist,
+			) {
+				refreshService.EXPECT().Revoke(gomock.Any(), refresh.RevokeInput{Token: "refresh-token"}).
+					Return(refresh.RevokeOutput{ID: "fake-id", UserID: "fake-user-id", Role: "customer"}, nil)
+			},
+			want:    introspection.RevokeTokenOutput{Revoked: true},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := serviceSetup(t, logger, tt.mocksSetup)
+
+			got, err := service.RevokeToken(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func serviceSetup(
+	t *testing.T,
+	logger log.Logger,
+	mocksSetup func(
+		authService *authmocks.MockService,
+		refreshService *refreshmocks.MockService,
+		denylist *authmocks.MockDenylist,
+	),
+) introspection.Service {
+	ctrl := gomock.NewController(t)
+	authService := authmocks.NewMockService(ctrl)
+	refreshService := refreshmocks.NewMockService(ctrl)
+	denylist := authmocks.NewMockDenylist(ctrl)
+	if mocksSetup != nil {
+		mocksSetup(authService, refreshService, denylist)
+	}
+
+	return introspection.NewService(logger, authService, refreshService, denylist)
+}
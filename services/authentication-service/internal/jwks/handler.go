@@ -0,0 +1,65 @@
+// Package jwks exposes the public keys used to verify the access tokens issued by the authentication service.
+package jwks
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// cacheControl allows the consumers to cache the key set, so it is not fetched on every token validation.
+const cacheControl = "public, max-age=300"
+
+// Handler manages HTTP requests for the JSON Web Key Set.
+type Handler struct {
+	logger log.Logger
+	keys   auth.KeyProvider
+}
+
+// NewHandler creates a new instance of Handler.
+func NewHandler(logger log.Logger, keys auth.KeyProvider) *Handler {
+	return &Handler{
+		logger: logger,
+		keys:   keys,
+	}
+}
+
+// RegisterRoutes registers the JWKS HTTP routes.
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	router.GET(auth.JWKSPath, h.GetJWKS)
+}
+
+// GetJWKS handles the retrieval of the public keys currently accepted to verify access tokens.
+func (h *Handler) GetJWKS(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("GetJWKS handler called")
+
+	keys, err := h.keys.VerificationKeys(ctx)
+	if err != nil {
+		logger.Error("Failed to get verification keys", err)
+		c.JSON(http.StatusInternalServerError, customhttp.NewErrorResponse(
+			customhttp.CodeInternalError,
+			customhttp.MsgInternalError,
+		))
+		return
+	}
+
+	jwks, err := auth.NewJWKS(keys)
+	if err != nil {
+		logger.Error("Failed to build JWKS", err)
+		c.JSON(http.StatusInternalServerError, customhttp.NewErrorResponse(
+			customhttp.CodeInternalError,
+			customhttp.MsgInternalError,
+		))
+		return
+	}
+
+	c.Header("Cache-Control", cacheControl)
+	c.JSON(http.StatusOK, jwks)
+}
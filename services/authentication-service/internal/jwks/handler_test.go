@@ -0,0 +1,92 @@
+//go:build unit
+
+package jwks_test
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/jwks"
+)
+
+type jwksHandlerTestCase struct {
+	name       string
+	mocksSetup func(keys *authmocks.MockKeyProvider)
+	wantJSON   string
+	wantStatus int
+}
+
+var errUnexpected = errors.New("unexpected error")
+
+func TestHandler_GetJWKS(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	publicKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+	encodedKey := base64.RawURLEncoding.EncodeToString(publicKey)
+
+	tests := []jwksHandlerTestCase{
+		{
+			name: "when the verification keys cannot be retrieved, then it should return a 500 with internal error",
+			mocksSetup: func(keys *authmocks.MockKeyProvider) {
+				keys.EXPECT().VerificationKeys(gomock.Any()).Return(nil, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "when a verification key is not supported, then it should return a 500 with internal error",
+			mocksSetup: func(keys *authmocks.MockKeyProvider) {
+				keys.EXPECT().VerificationKeys(gomock.Any()).Return([]auth.VerificationKey{
+					{ID: "fake-kid", Algorithm: "HS256", PublicKey: []byte("secret")},
+				}, nil)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "when the verification keys are retrieved, then it should return a 200 with the key set",
+			mocksSetup: func(keys *authmocks.MockKeyProvider) {
+				keys.EXPECT().VerificationKeys(gomock.Any()).Return([]auth.VerificationKey{
+					{ID: "fake-kid", Algorithm: auth.AlgorithmEdDSA, PublicKey: publicKey},
+				}, nil)
+			},
+			wantJSON: fmt.Sprintf(`{
+				"keys": [{
+					"kty": "OKP",
+					"kid": "fake-kid",
+					"use": "sig",
+					"alg": "EdDSA",
+					"crv": "Ed25519",
+					"x": "%s"
+				}]
+			}`, encodedKey),
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := authmocks.NewMockKeyProvider(gomock.NewController(t))
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(keys)
+			}
+
+			h := jwks.NewHandler(logger, keys)
+
+			w := customhttp.ServeTestHTTPRequest(t, h, http.MethodGet, auth.JWKSPath, "", nil, "")
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.JSONEq(t, tt.wantJSON, w.Body.String())
+		})
+	}
+}
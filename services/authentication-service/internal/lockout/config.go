@@ -0,0 +1,34 @@
+package lockout
+
+import (
+	"time"
+
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for the login brute-force protection.
+type Config struct {
+	// MaxAccountAttempts is the number of consecutive failed logins of an account before it is locked.
+	MaxAccountAttempts int `env:"LOCKOUT_MAX_ACCOUNT_ATTEMPTS" envDefault:"5"`
+	// MaxIPAttempts is the number of failed logins from the same IP, whatever the account, before it is locked.
+	MaxIPAttempts int `env:"LOCKOUT_MAX_IP_ATTEMPTS" envDefault:"50"`
+	// BaseDuration is the duration of the first lock. Each failed login after it doubles the duration of the next one.
+	BaseDuration time.Duration `env:"LOCKOUT_BASE_DURATION" envDefault:"1m"`
+	// MaxDuration caps the duration of the locks.
+	MaxDuration time.Duration `env:"LOCKOUT_MAX_DURATION" envDefault:"1h"`
+	// Window is how long the failed logins are remembered since the last one, or since the end of the lock.
+	Window time.Duration `env:"LOCKOUT_WINDOW" envDefault:"15m"`
+}
+
+// LoadConfig loads the lockout configuration from environment variables and logs any errors encountered during
+// parsing. It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load lockout configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
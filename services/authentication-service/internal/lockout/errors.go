@@ -0,0 +1,10 @@
+package lockout
+
+import "errors"
+
+var (
+	// ErrLocked indicates that the account, or the IP the login comes from, is temporarily locked.
+	ErrLocked = errors.New("login locked")
+	// ErrLockNotFound indicates that none of the given keys is currently locked.
+	ErrLockNotFound = errors.New("lock not found")
+)
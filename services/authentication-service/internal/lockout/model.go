@@ -0,0 +1,14 @@
+package lockout
+
+import "time"
+
+// Attempts represents the failed logins tracked for a key, which identifies either an account or an IP.
+// The document is removed by MongoDB once ExpiresAt is reached, which resets the tracking.
+type Attempts struct {
+	ID             string     `bson:"_id,omitempty"`
+	Key            string     `bson:"key"`
+	FailedAttempts int        `bson:"failed_attempts"`
+	LockedUntil    *time.Time `bson:"locked_until,omitempty"`
+	LastFailedAt   time.Time  `bson:"last_failed_at"`
+	ExpiresAt      time.Time  `bson:"expires_at"`
+}
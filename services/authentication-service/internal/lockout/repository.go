@@ -0,0 +1,167 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// CollectionName defines the name of the database collection used to store the failed login attempts.
+	CollectionName = "login_attempts"
+
+	// FieldKey represents the database field name for storing the account or IP key the attempts belong to.
+	FieldKey = "key"
+	// FieldFailedAttempts represents the database field name for storing the number of failed logins.
+	FieldFailedAttempts = "failed_attempts"
+	// FieldLockedUntil represents the database field name for storing until when the key is locked.
+	FieldLockedUntil = "locked_until"
+	// FieldLastFailedAt represents the database field name for storing the time of the last failed login.
+	FieldLastFailedAt = "last_failed_at"
+	// FieldExpiresAt represents the database field name for storing when the tracked attempts are forgotten.
+	FieldExpiresAt = "expires_at"
+)
+
+// Repository defines a contract for tracking the failed login attempts in a persistence layer.
+// It is implemented on top of MongoDB, but it can be backed by any store with atomic counters, such as Redis.
+//
+//go:generate mockgen -destination=./mocks/repository_mock.go -package=lockout_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout Repository
+type Repository interface {
+	FindActiveLock(ctx context.Context, params FindActiveLockParams) (Attempts, error)
+	RegisterFailure(ctx context.Context, params RegisterFailureParams) (Attempts, error)
+	Lock(ctx context.Context, params LockParams) error
+	Reset(ctx context.Context, key string) error
+}
+
+type repository struct {
+	logger     log.Logger
+	collection *mongo.Collection
+	clock      clock.Clock
+}
+
+// NewRepository creates a new Repository instance.
+func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repository {
+	return &repository{
+		logger:     logger,
+		collection: db.Collection(CollectionName),
+		clock:      clk,
+	}
+}
+
+// FindActiveLockParams defines the parameters needed to find an active lock among the given keys.
+type FindActiveLockParams struct {
+	Keys []string
+}
+
+// FindActiveLock returns the attempts of the key with the longest active lock among the given ones.
+// It returns ErrLockNotFound if none of them is currently locked.
+func (r *repository) FindActiveLock(ctx context.Context, params FindActiveLockParams) (Attempts, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var attempts Attempts
+	filter := bson.M{
+		FieldKey:         bson.M{"$in": params.Keys},
+		FieldLockedUntil: bson.M{"$gt": r.clock.Now()},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: FieldLockedUntil, Value: -1}})
+
+	if err := r.collection.FindOne(ctx, filter, opts).Decode(&attempts); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return Attempts{}, ErrLockNotFound
+		}
+		logger.Error("Failed to find active login lock", err)
+		return Attempts{}, err
+	}
+	return attempts, nil
+}
+
+// RegisterFailureParams defines the parameters needed to register a failed login for a key. Window is how long the
+// failed login is remembered.
+type RegisterFailureParams struct {
+	Key    string
+	Window time.Duration
+}
+
+// RegisterFailure increases the failed logins of the key, and returns the updated attempts. The attempts that
+// already expired are discarded first, so the count restarts even if MongoDB did not remove them yet.
+func (r *repository) RegisterFailure(ctx context.Context, params RegisterFailureParams) (Attempts, error) {
+	logger := r.logger.WithContext(ctx)
+
+	now := r.clock.Now()
+	if _, err := r.collection.DeleteOne(ctx, bson.M{
+		FieldKey:       params.Key,
+		FieldExpiresAt: bson.M{"$lte": now},
+	}); err != nil {
+		logger.Error("Failed to discard expired login attempts", err)
+		return Attempts{}, err
+	}
+
+	var attempts Attempts
+	update := bson.M{
+		"$inc": bson.M{
+			FieldFailedAttempts: 1,
+		},
+		"$set": bson.M{
+			FieldLastFailedAt: now,
+		},
+		// A lock extends the expiration beyond the window, so it must not be shortened by later failures
+		"$max": bson.M{
+			FieldExpiresAt: now.Add(params.Window),
+		},
+	}
+
+	// Returning the updated document
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{FieldKey: params.Key}, update, opts).Decode(&attempts)
+	if err != nil {
+		logger.Error("Failed to register failed login attempt", err)
+		return Attempts{}, err
+	}
+	return attempts, nil
+}
+
+// LockParams defines the parameters needed to lock a key. ExpiresAt must be after LockedUntil, so the failed logins
+// are still remembered once the lock ends.
+type LockParams struct {
+	Key         string
+	LockedUntil time.Time
+	ExpiresAt   time.Time
+}
+
+// Lock locks the key until the given time.
+func (r *repository) Lock(ctx context.Context, params LockParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	update := bson.M{
+		"$set": bson.M{
+			FieldLockedUntil: params.LockedUntil,
+		},
+		"$max": bson.M{
+			FieldExpiresAt: params.ExpiresAt,
+		},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{FieldKey: params.Key}, update); err != nil {
+		logger.Error("Failed to lock login attempts", err)
+		return err
+	}
+	return nil
+}
+
+// Reset forgets the failed logins of the key.
+func (r *repository) Reset(ctx context.Context, key string) error {
+	logger := r.logger.WithContext(ctx)
+
+	if _, err := r.collection.DeleteOne(ctx, bson.M{FieldKey: key}); err != nil {
+		logger.Error("Failed to reset login attempts", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+//go:build integration
+
+package lockout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+)
+
+const testDBPrefix = "customers_test_authentication_service"
+
+type lockoutRepositoryTestCase[P, W any] struct {
+	name            string
+	insertDocuments func(t *testing.T, coll *mongo.Collection)
+	params          P
+	want            W
+	wantErr         error
+}
+
+func TestRepository_FindActiveLock(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	params := lockout.FindActiveLockParams{Keys: []string{"fake-account-key", "fake-ip-key"}}
+
+	tests := []lockoutRepositoryTestCase[lockout.FindActiveLockParams, lockout.Attempts]{
+		{
+			name:    "when none of the keys have attempts, then it should return a lock not found error",
+			params:  params,
+			want:    lockout.Attempts{},
+			wantErr: lockout.ErrLockNotFound,
+		},
+		{
+			name: "when the lock of the keys already ended, then it should return a lock not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				lockedUntil := now.Add(-time.Second)
+				mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+					Key:            "fake-account-key",
+					FailedAttempts: 5,
+					LockedUntil:    &lockedUntil,
+					LastFailedAt:   now.Add(-time.Minute),
+					ExpiresAt:      now.Add(time.Hour),
+				})
+			},
+			params:  params,
+			want:    lockout.Attempts{},
+			wantErr: lockout.ErrLockNotFound,
+		},
+		{
+			name: "when another key is locked, then it should return a lock not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				lockedUntil := now.Add(time.Minute)
+				mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+					Key:            "fake-other-key",
+					FailedAttempts: 5,
+					LockedUntil:    &lockedUntil,
+					LastFailedAt:   now,
+					ExpiresAt:      now.Add(time.Hour),
+				})
+			},
+			params:  params,
+			want:    lockout.Attempts{},
+			wantErr: lockout.ErrLockNotFound,
+		},
+		{
+			name: "when several keys are locked, then it should return the longest lock",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				accountLockedUntil := now.Add(time.Minute)
+				mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+					Key:            "fake-account-key",
+					FailedAttempts: 5,
+					LockedUntil:    &accountLockedUntil,
+					LastFailedAt:   now,
+					ExpiresAt:      now.Add(time.Hour),
+				})
+				ipLockedUntil := now.Add(time.Hour)
+				mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+					Key:            "fake-ip-key",
+					FailedAttempts: 60,
+					LockedUntil:    &ipLockedUntil,
+					LastFailedAt:   now,
+					ExpiresAt:      now.Add(2 * time.Hour),
+				})
+			},
+			params: params,
+			want: lockout.Attempts{
+				Key:            "fake-ip-key",
+				FailedAttempts: 60,
+				LockedUntil:    func() *time.Time { t := now.Add(time.Hour); return &t }(),
+				LastFailedAt:   now,
+				ExpiresAt:      now.Add(2 * time.Hour),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestLoginAttemptsCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := lockout.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			attempts, err := repo.FindActiveLock(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				tt.want.ID = attempts.ID
+				assert.Equal(t, tt.want, attempts)
+			}
+		})
+	}
+}
+
+func TestRepository_FindActiveLock_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestLoginAttemptsCollection(t, tdb.DB)
+
+	repo := lockout.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindActiveLock(context.Background(), lockout.FindActiveLockParams{Keys: []string{"fake-key"}})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_RegisterFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	params := lockout.RegisterFailureParams{Key: "fake-key", Window: 15 * time.Minute}
+
+	tests := []lockoutRepositoryTestCase[lockout.RegisterFailureParams, lockout.Attempts]{
+		{
+			name:   "when the key has no attempts, then it should track the first failure",
+			params: params,
+			want: lockout.Attempts{
+				Key:            "fake-key",
+				FailedAttempts: 1,
+				LastFailedAt:   now,
+				ExpiresAt:      now.Add(15 * time.Minute),
+			},
+		},
+		{
+			name: "when the key has attempts, then it should increase the failures and extend the expiration",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+					Key:            "fake-key",
+					FailedAttempts: 2,
+					LastFailedAt:   now.Add(-time.Minute),
+					ExpiresAt:      now.Add(14 * time.Minute),
+				})
+			},
+			params: params,
+			want: lockout.Attempts{
+				Key:            "fake-key",
+				FailedAttempts: 3,
+				LastFailedAt:   now,
+				ExpiresAt:      now.Add(15 * time.Minute),
+			},
+		},
+		{
+			name: "when the attempts of the key expired, then it should restart the tracking",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+					Key:            "fake-key",
+					FailedAttempts: 4,
+					LastFailedAt:   now.Add(-time.Hour),
+					ExpiresAt:      now.Add(-45 * time.Minute),
+				})
+			},
+			params: params,
+			want: lockout.Attempts{
+				Key:            "fake-key",
+				FailedAttempts: 1,
+				LastFailedAt:   now,
+				ExpiresAt:      now.Add(15 * time.Minute),
+			},
+		},
+		{
+			name: "when the key is locked, then it should not shorten its expiration",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				lockedUntil := now.Add(time.Hour)
+				mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+					Key:            "fake-key",
+					FailedAttempts: 5,
+					LockedUntil:    &lockedUntil,
+					LastFailedAt:   now.Add(-time.Minute),
+					ExpiresAt:      now.Add(time.Hour + 15*time.Minute),
+				})
+			},
+			params: params,
+			want: lockout.Attempts{
+				Key:            "fake-key",
+				FailedAttempts: 6,
+				LockedUntil:    func() *time.Time { t := now.Add(time.Hour); return &t }(),
+				LastFailedAt:   now,
+				ExpiresAt:      now.Add(time.Hour + 15*time.Minute),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestLoginAttemptsCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := lockout.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			attempts, err := repo.RegisterFailure(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, attempts.ID, "ID should not be empty")
+
+				tt.want.ID = attempts.ID
+				assert.Equal(t, tt.want, attempts)
+			}
+		})
+	}
+}
+
+func TestRepository_RegisterFailure_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestLoginAttemptsCollection(t, tdb.DB)
+
+	repo := lockout.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.RegisterFailure(context.Background(), lockout.RegisterFailureParams{Key: "fake-key"})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_Lock(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	defer tdb.Close(t)
+
+	coll := setupTestLoginAttemptsCollection(t, tdb.DB)
+	mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+		Key:            "fake-key",
+		FailedAttempts: 5,
+		LastFailedAt:   now,
+		ExpiresAt:      now.Add(15 * time.Minute),
+	})
+
+	repo := lockout.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+	err := repo.Lock(context.Background(), lockout.LockParams{
+		Key:         "fake-key",
+		LockedUntil: now.Add(time.Minute),
+		ExpiresAt:   now.Add(16 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	var attempts lockout.Attempts
+	err = coll.FindOne(context.Background(), bson.M{lockout.FieldKey: "fake-key"}).Decode(&attempts)
+	require.NoError(t, err)
+
+	require.NotNil(t, attempts.LockedUntil)
+	assert.Equal(t, now.Add(time.Minute), *attempts.LockedUntil)
+	assert.Equal(t, now.Add(16*time.Minute), attempts.ExpiresAt)
+	assert.Equal(t, 5, attempts.FailedAttempts)
+}
+
+func TestRepository_Lock_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestLoginAttemptsCollection(t, tdb.DB)
+
+	repo := lockout.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.Lock(context.Background(), lockout.LockParams{Key: "fake-key"})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_Reset(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	defer tdb.Close(t)
+
+	coll := setupTestLoginAttemptsCollection(t, tdb.DB)
+	mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+		Key:            "fake-key",
+		FailedAttempts: 3,
+		LastFailedAt:   now,
+		ExpiresAt:      now.Add(15 * time.Minute),
+	})
+	mongodb.InsertTestDocument(t, coll, lockout.Attempts{
+		Key:            "fake-other-key",
+		FailedAttempts: 3,
+		LastFailedAt:   now,
+		ExpiresAt:      now.Add(15 * time.Minute),
+	})
+
+	repo := lockout.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+	err := repo.Reset(context.Background(), "fake-key")
+	require.NoError(t, err)
+
+	count, err := coll.CountDocuments(context.Background(), bson.M{lockout.FieldKey: "fake-key"})
+	require.NoError(t, err)
+	assert.Zero(t, count, "The attempts of the key should be removed")
+
+	count, err = coll.CountDocuments(context.Background(), bson.M{lockout.FieldKey: "fake-other-key"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "The attempts of other keys should be kept")
+}
+
+func TestRepository_Reset_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestLoginAttemptsCollection(t, tdb.DB)
+
+	repo := lockout.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.Reset(context.Background(), "fake-key")
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func setupTestLoginAttemptsCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := db.Collection(lockout.CollectionName)
+
+	// Create unique index on the key
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: lockout.FieldKey, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		t.Fatalf("Failed to create unique index: %v", err)
+	}
+	return coll
+}
@@ -0,0 +1,178 @@
+// Package lockout provides the brute-force protection of the login endpoints. It tracks the failed logins per account
+// and per IP, and locks them temporarily, with an exponential backoff, once they reach the configured thresholds.
+package lockout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	accountKeyPrefix = "account:"
+	ipKeyPrefix      = "ip:"
+)
+
+// Service represents the core interface for the login brute-force protection.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=lockout_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout Service
+type Service interface {
+	Check(ctx context.Context, input CheckInput) (CheckOutput, error)
+	RegisterFailure(ctx context.Context, input RegisterFailureInput) (RegisterFailureOutput, error)
+	RegisterSuccess(ctx context.Context, input RegisterSuccessInput) (RegisterSuccessOutput, error)
+}
+
+type service struct {
+	logger log.Logger
+	repo   Repository
+	clock  clock.Clock
+	cfg    Config
+}
+
+// NewService initializes and returns a new Service implementation.
+func NewService(logger log.Logger, repo Repository, clk clock.Clock, cfg Config) Service {
+	return &service{logger: logger, repo: repo, clock: clk, cfg: cfg}
+}
+
+// CheckInput represents the account a login is attempted for. Identifier is the login name of the account, such as
+// its email, and TenantID is empty for the non-tenant users, such as the customers.
+type CheckInput struct {
+	Role       string
+	TenantID   string
+	Identifier string
+}
+
+// CheckOutput represents the result of a successful lock check.
+type CheckOutput struct{}
+
+// Check returns ErrLocked if the account, or the IP performing the request, is locked. It must be called before
+// verifying the credentials, so the locked logins don't cost a password hash.
+func (s *service) Check(ctx context.Context, input CheckInput) (CheckOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	keys := []string{accountKey(input.Role, input.TenantID, input.Identifier)}
+	if ip := log.RealIPFromContext(ctx); ip != "" {
+		keys = append(keys, ipKeyPrefix+ip)
+	}
+
+	attempts, err := s.repo.FindActiveLock(ctx, FindActiveLockParams{Keys: keys})
+	if err != nil {
+		if errors.Is(err, ErrLockNotFound) {
+			return CheckOutput{}, nil
+		}
+		logger.Error("failed to check the login locks", err)
+		return CheckOutput{}, err
+	}
+
+	logger.Warn(
+		"login locked",
+		log.Field{Key: "key", Value: attempts.Key},
+		log.Field{Key: "locked_until", Value: attempts.LockedUntil},
+	)
+	return CheckOutput{}, ErrLocked
+}
+
+// RegisterFailureInput represents the account a failed login was attempted for.
+type RegisterFailureInput struct {
+	Role       string
+	TenantID   string
+	Identifier string
+}
+
+// RegisterFailureOutput represents the result of registering a failed login. Locked reports whether the failure
+// locked the account or the IP performing the request.
+type RegisterFailureOutput struct {
+	Locked bool
+}
+
+// RegisterFailure registers the failed login for the account and the IP performing the request, and locks any of
+// them that reached its threshold.
+func (s *service) RegisterFailure(ctx context.Context, input RegisterFailureInput) (RegisterFailureOutput, error) {
+	locked, err := s.registerFailure(
+		ctx,
+		accountKey(input.Role, input.TenantID, input.Identifier),
+		s.cfg.MaxAccountAttempts,
+	)
+	if err != nil {
+		return RegisterFailureOutput{}, err
+	}
+
+	if ip := log.RealIPFromContext(ctx); ip != "" {
+		ipLocked, err := s.registerFailure(ctx, ipKeyPrefix+ip, s.cfg.MaxIPAttempts)
+		if err != nil {
+			return RegisterFailureOutput{}, err
+		}
+		locked = locked || ipLocked
+	}
+	return RegisterFailureOutput{Locked: locked}, nil
+}
+
+func (s *service) registerFailure(ctx context.Context, key string, maxAttempts int) (bool, error) {
+	logger := s.logger.WithContext(ctx)
+
+	attempts, err := s.repo.RegisterFailure(ctx, RegisterFailureParams{Key: key, Window: s.cfg.Window})
+	if err != nil {
+		logger.Error("failed to register the failed login", err)
+		return false, err
+	}
+	if attempts.FailedAttempts < maxAttempts {
+		return false, nil
+	}
+
+	lockedUntil := s.clock.Now().Add(s.lockDuration(attempts.FailedAttempts - maxAttempts))
+	if err := s.repo.Lock(ctx, LockParams{
+		Key:         key,
+		LockedUntil: lockedUntil,
+		ExpiresAt:   lockedUntil.Add(s.cfg.Window),
+	}); err != nil {
+		logger.Error("failed to lock the login", err)
+		return false, err
+	}
+
+	logger.Warn(
+		"login locked after too many failed attempts",
+		log.Field{Key: "key", Value: key},
+		log.Field{Key: "failed_attempts", Value: attempts.FailedAttempts},
+		log.Field{Key: "locked_until", Value: lockedUntil},
+	)
+	return true, nil
+}
+
+// lockDuration returns the duration of the lock once the threshold was exceeded by the given number of attempts. It
+// doubles with each attempt, up to the configured maximum.
+func (s *service) lockDuration(exceeded int) time.Duration {
+	duration := s.cfg.BaseDuration
+	for i := 0; i < exceeded && duration < s.cfg.MaxDuration; i++ {
+		duration *= 2
+	}
+	return min(duration, s.cfg.MaxDuration)
+}
+
+// RegisterSuccessInput represents the account a successful login was performed for.
+type RegisterSuccessInput struct {
+	Role       string
+	TenantID   string
+	Identifier string
+}
+
+// RegisterSuccessOutput represents the result of registering a successful login.
+type RegisterSuccessOutput struct{}
+
+// RegisterSuccess forgets the failed logins of the account. The failed logins of the IP are kept, as a successful
+// login does not prove the other attempts from the same IP were legit.
+func (s *service) RegisterSuccess(ctx context.Context, input RegisterSuccessInput) (RegisterSuccessOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	if err := s.repo.Reset(ctx, accountKey(input.Role, input.TenantID, input.Identifier)); err != nil {
+		logger.Error("failed to reset the failed logins", err)
+		return RegisterSuccessOutput{}, err
+	}
+	return RegisterSuccessOutput{}, nil
+}
+
+func accountKey(role, tenantID, identifier string) string {
+	return accountKeyPrefix + role + ":" + tenantID + ":" + identifier
+}
@@ -0,0 +1,325 @@
+//go:build unit
+
+package lockout_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	lockoutmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout/mocks"
+)
+
+const (
+	testIP         = "192.168.1.1"
+	testAccountKey = "account:customer::test@example.com"
+	testIPKey      = "ip:" + testIP
+)
+
+var (
+	errRepo = errors.New("repository error")
+
+	testConfig = lockout.Config{
+		MaxAccountAttempts: 5,
+		MaxIPAttempts:      50,
+		BaseDuration:       time.Minute,
+		MaxDuration:        time.Hour,
+		Window:             15 * time.Minute,
+	}
+)
+
+type lockoutServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	realIP     string
+	mocksSetup func(repo *lockoutmocks.MockRepository)
+	want       W
+	wantErr    error
+}
+
+func TestService_Check(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+	lockedUntil := now.Add(time.Minute)
+
+	input := lockout.CheckInput{Role: "customer", Identifier: "test@example.com"}
+
+	tests := []lockoutServiceTestCase[lockout.CheckInput, lockout.CheckOutput]{
+		{
+			name:   "when neither the account nor the IP are locked, then it returns no error",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().FindActiveLock(gomock.Any(), lockout.FindActiveLockParams{
+					Keys: []string{testAccountKey, testIPKey},
+				}).Return(lockout.Attempts{}, lockout.ErrLockNotFound)
+			},
+			want:    lockout.CheckOutput{},
+			wantErr: nil,
+		},
+		{
+			name:  "when the IP is unknown, then it only checks the account",
+			input: input,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().FindActiveLock(gomock.Any(), lockout.FindActiveLockParams{
+					Keys: []string{testAccountKey},
+				}).Return(lockout.Attempts{}, lockout.ErrLockNotFound)
+			},
+			want:    lockout.CheckOutput{},
+			wantErr: nil,
+		},
+		{
+			name:   "when the account or the IP are locked, then it returns a locked error",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().FindActiveLock(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testIPKey, LockedUntil: &lockedUntil}, nil)
+			},
+			want:    lockout.CheckOutput{},
+			wantErr: lockout.ErrLocked,
+		},
+		{
+			name:   "when there is an error finding the locks, then it propagates the error",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().FindActiveLock(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{}, errRepo)
+			},
+			want:    lockout.CheckOutput{},
+			wantErr: errRepo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.Check(contextWithRealIP(tt.realIP), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RegisterFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	input := lockout.RegisterFailureInput{Role: "customer", Identifier: "test@example.com"}
+
+	tests := []lockoutServiceTestCase[lockout.RegisterFailureInput, lockout.RegisterFailureOutput]{
+		{
+			name:   "when the failures are below the thresholds, then it does not lock the login",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().RegisterFailure(gomock.Any(), lockout.RegisterFailureParams{
+					Key:    testAccountKey,
+					Window: testConfig.Window,
+				}).Return(lockout.Attempts{Key: testAccountKey, FailedAttempts: 4}, nil)
+				repo.EXPECT().RegisterFailure(gomock.Any(), lockout.RegisterFailureParams{
+					Key:    testIPKey,
+					Window: testConfig.Window,
+				}).Return(lockout.Attempts{Key: testIPKey, FailedAttempts: 49}, nil)
+			},
+			want:    lockout.RegisterFailureOutput{Locked: false},
+			wantErr: nil,
+		},
+		{
+			name:  "when the IP is unknown, then it only registers the failure of the account",
+			input: input,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().RegisterFailure(gomock.Any(), lockout.RegisterFailureParams{
+					Key:    testAccountKey,
+					Window: testConfig.Window,
+				}).Return(lockout.Attempts{Key: testAccountKey, FailedAttempts: 1}, nil)
+			},
+			want:    lockout.RegisterFailureOutput{Locked: false},
+			wantErr: nil,
+		},
+		{
+			name:   "when the account reaches its threshold, then it locks the account for the base duration",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testAccountKey, FailedAttempts: 5}, nil)
+				repo.EXPECT().Lock(gomock.Any(), lockout.LockParams{
+					Key:         testAccountKey,
+					LockedUntil: now.Add(time.Minute),
+					ExpiresAt:   now.Add(time.Minute + testConfig.Window),
+				}).Return(nil)
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testIPKey, FailedAttempts: 5}, nil)
+			},
+			want:    lockout.RegisterFailureOutput{Locked: true},
+			wantErr: nil,
+		},
+		{
+			name:   "when the account exceeds its threshold, then it doubles the lock duration for each exceeded failure",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testAccountKey, FailedAttempts: 8}, nil)
+				repo.EXPECT().Lock(gomock.Any(), lockout.LockParams{
+					Key:         testAccountKey,
+					LockedUntil: now.Add(8 * time.Minute),
+					ExpiresAt:   now.Add(8*time.Minute + testConfig.Window),
+				}).Return(nil)
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testIPKey, FailedAttempts: 8}, nil)
+			},
+			want:    lockout.RegisterFailureOutput{Locked: true},
+			wantErr: nil,
+		},
+		{
+			name:   "when the lock duration exceeds the maximum, then it caps the lock duration",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testAccountKey, FailedAttempts: 100}, nil)
+				repo.EXPECT().Lock(gomock.Any(), lockout.LockParams{
+					Key:         testAccountKey,
+					LockedUntil: now.Add(time.Hour),
+					ExpiresAt:   now.Add(time.Hour + testConfig.Window),
+				}).Return(nil)
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testIPKey, FailedAttempts: 8}, nil)
+			},
+			want:    lockout.RegisterFailureOutput{Locked: true},
+			wantErr: nil,
+		},
+		{
+			name:   "when the IP reaches its threshold, then it locks the IP",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testAccountKey, FailedAttempts: 1}, nil)
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testIPKey, FailedAttempts: 50}, nil)
+				repo.EXPECT().Lock(gomock.Any(), lockout.LockParams{
+					Key:         testIPKey,
+					LockedUntil: now.Add(time.Minute),
+					ExpiresAt:   now.Add(time.Minute + testConfig.Window),
+				}).Return(nil)
+			},
+			want:    lockout.RegisterFailureOutput{Locked: true},
+			wantErr: nil,
+		},
+		{
+			name:   "when there is an error registering the failure, then it propagates the error",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{}, errRepo)
+			},
+			want:    lockout.RegisterFailureOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:   "when there is an error locking the login, then it propagates the error",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.Attempts{Key: testAccountKey, FailedAttempts: 5}, nil)
+				repo.EXPECT().Lock(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    lockout.RegisterFailureOutput{},
+			wantErr: errRepo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.RegisterFailure(contextWithRealIP(tt.realIP), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RegisterSuccess(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	input := lockout.RegisterSuccessInput{Role: "staff", TenantID: "fake-restaurant-id", Identifier: "test@example.com"}
+
+	tests := []lockoutServiceTestCase[lockout.RegisterSuccessInput, lockout.RegisterSuccessOutput]{
+		{
+			name:   "when the login succeeds, then it resets the failed logins of the account only",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().Reset(gomock.Any(), "account:staff:fake-restaurant-id:test@example.com").Return(nil)
+			},
+			want:    lockout.RegisterSuccessOutput{},
+			wantErr: nil,
+		},
+		{
+			name:   "when there is an error resetting the failed logins, then it propagates the error",
+			input:  input,
+			realIP: testIP,
+			mocksSetup: func(repo *lockoutmocks.MockRepository) {
+				repo.EXPECT().Reset(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    lockout.RegisterSuccessOutput{},
+			wantErr: errRepo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.RegisterSuccess(contextWithRealIP(tt.realIP), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func contextWithRealIP(ip string) context.Context {
+	return log.WithRequestInfo(context.Background(), log.RequestInfo{RealIP: ip})
+}
+
+func serviceSetup(
+	t *testing.T,
+	logger log.Logger,
+	now time.Time,
+	mocksSetup func(repo *lockoutmocks.MockRepository),
+) (lockout.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	repo := lockoutmocks.NewMockRepository(ctrl)
+
+	if mocksSetup != nil {
+		mocksSetup(repo)
+	}
+
+	service := lockout.NewService(logger, repo, clock.FixedClock{FixedTime: now}, testConfig)
+	return service, func() {
+		ctrl.Finish()
+	}
+}
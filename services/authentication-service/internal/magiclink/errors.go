@@ -0,0 +1,9 @@
+package magiclink
+
+import "errors"
+
+var (
+	// ErrLoginTokenNotFound indicates that the specified login token could not be found, or it can no longer be used,
+	// such as when it is presented from another device than the one it was requested from.
+	ErrLoginTokenNotFound = errors.New("login token not found")
+)
@@ -0,0 +1,17 @@
+package magiclink
+
+import "time"
+
+// Token represents a single-use token that allows a user to log in without their password. Only the TokenHash is
+// stored, DeviceID binds it to the device it was requested from, and UsedAt is set once the token has been consumed.
+type Token struct {
+	ID        string     `bson:"_id,omitempty"`
+	UserID    string     `bson:"user_id"`
+	Role      string     `bson:"role"`
+	TenantID  string     `bson:"tenant_id"`
+	TokenHash string     `bson:"token_hash"`
+	DeviceID  string     `bson:"device_id"`
+	ExpiresAt time.Time  `bson:"expires_at"`
+	UsedAt    *time.Time `bson:"used_at,omitempty"`
+	CreatedAt time.Time  `bson:"created_at"`
+}
@@ -0,0 +1,412 @@
+//go:build integration
+
+package magiclink_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/magiclink"
+)
+
+const testDBPrefix = "magiclink_test_authentication_service"
+
+type magicLinkRepositoryTestCase[P, W any] struct {
+	name            string
+	insertDocuments func(t *testing.T, coll *mongo.Collection)
+	params          P
+	want            W
+	wantErr         error
+}
+
+func TestRepository_Create(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt = now.Add(15 * time.Minute)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []magicLinkRepositoryTestCase[magiclink.CreateTokenParams, magiclink.Token]{
+		{
+			name: "when the token is stored successfully, then it should return the stored token",
+			params: magiclink.CreateTokenParams{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TenantID:  "fake-tenant-id",
+				TokenHash: "fake-token-hash",
+				DeviceID:  "fake-device-id",
+				ExpiresAt: expiresAt,
+			},
+			want: magiclink.Token{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TenantID:  "fake-tenant-id",
+				TokenHash: "fake-token-hash",
+				DeviceID:  "fake-device-id",
+				ExpiresAt: expiresAt,
+				CreatedAt: now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestMagicLinkTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := magiclink.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			token, err := repo.Create(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				// As the ID is generated by MongoDB, we just check that it is not empty
+				assert.NotEmpty(t, token.ID, "ID should not be empty")
+
+				tt.want.ID = token.ID
+				assert.Equal(t, tt.want, token)
+			}
+		})
+	}
+}
+
+func TestRepository_Create_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestMagicLinkTokenCollection(t, tdb.DB)
+
+	repo := magiclink.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.Create(context.Background(), magiclink.CreateTokenParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_Consume(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt = now.Add(15 * time.Minute)
+		expiredAt = now.Add(-time.Minute)
+		usedAt    = now.Add(-5 * time.Minute)
+	)
+	logger, _ := log.NewTest()
+
+	consumeParams := magiclink.ConsumeTokenParams{
+		TokenHash: "fake-token-hash",
+		Role:      "fake-role",
+		DeviceID:  "fake-device-id",
+	}
+
+	tests := []magicLinkRepositoryTestCase[magiclink.ConsumeTokenParams, magiclink.Token]{
+		{
+			name: "when the token does not exist, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-other-token-hash",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params:  consumeParams,
+			want:    magiclink.Token{},
+			wantErr: magiclink.ErrLoginTokenNotFound,
+		},
+		{
+			name: "when the token was issued for another role, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-other-role",
+					TokenHash: "fake-token-hash",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params:  consumeParams,
+			want:    magiclink.Token{},
+			wantErr: magiclink.ErrLoginTokenNotFound,
+		},
+		{
+			name: "when the token was requested from another device, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-token-hash",
+					DeviceID:  "fake-other-device-id",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params:  consumeParams,
+			want:    magiclink.Token{},
+			wantErr: magiclink.ErrLoginTokenNotFound,
+		},
+		{
+			name: "when the token is expired, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-token-hash",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiredAt,
+					CreatedAt: now,
+				})
+			},
+			params:  consumeParams,
+			want:    magiclink.Token{},
+			wantErr: magiclink.ErrLoginTokenNotFound,
+		},
+		{
+			name: "when the token was already used, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-token-hash",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiresAt,
+					UsedAt:    &usedAt,
+					CreatedAt: now,
+				})
+			},
+			params:  consumeParams,
+			want:    magiclink.Token{},
+			wantErr: magiclink.ErrLoginTokenNotFound,
+		},
+		{
+			name: "when the token is valid, then it should mark it as used and return it",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-token-hash",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params: consumeParams,
+			want: magiclink.Token{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TenantID:  "fake-tenant-id",
+				TokenHash: "fake-token-hash",
+				DeviceID:  "fake-device-id",
+				ExpiresAt: expiresAt,
+				UsedAt:    &now,
+				CreatedAt: now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestMagicLinkTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := magiclink.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			token, err := repo.Consume(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, token.ID, "ID should not be empty")
+
+				tt.want.ID = token.ID
+				assert.Equal(t, tt.want, token)
+
+				// A consumed token can't be consumed again
+				_, err = repo.Consume(context.Background(), tt.params)
+				assert.ErrorIs(t, err, magiclink.ErrLoginTokenNotFound)
+			}
+		})
+	}
+}
+
+func TestRepository_Consume_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestMagicLinkTokenCollection(t, tdb.DB)
+
+	repo := magiclink.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.Consume(context.Background(), magiclink.ConsumeTokenParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_InvalidateAll(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt = now.Add(15 * time.Minute)
+		expiredAt = now.Add(-time.Minute)
+		usedAt    = now.Add(-5 * time.Minute)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []magicLinkRepositoryTestCase[magiclink.InvalidateAllParams, int64]{
+		{
+			name: "when the user has no pending tokens, then it should not invalidate any token",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-expired-token-hash",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiredAt,
+					CreatedAt: now,
+				})
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-used-token-hash",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiresAt,
+					UsedAt:    &usedAt,
+					CreatedAt: now,
+				})
+			},
+			params: magiclink.InvalidateAllParams{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			want: 0,
+		},
+		{
+			name: "when the user has pending tokens, then it should invalidate only the ones of the user",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-token-hash-1",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-token-hash-2",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+				mongodb.InsertTestDocument(t, coll, magiclink.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-other-tenant-id",
+					TokenHash: "fake-token-hash-3",
+					DeviceID:  "fake-device-id",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params: magiclink.InvalidateAllParams{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestMagicLinkTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := magiclink.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			invalidated, err := repo.InvalidateAll(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, invalidated)
+
+			if tt.want > 0 {
+				count, err := coll.CountDocuments(context.Background(), bson.M{
+					magiclink.FieldUserID:   tt.params.UserID,
+					magiclink.FieldTenantID: tt.params.TenantID,
+					magiclink.FieldUsedAt:   now,
+				})
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, count)
+			}
+		})
+	}
+}
+
+func TestRepository_InvalidateAll_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestMagicLinkTokenCollection(t, tdb.DB)
+
+	repo := magiclink.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.InvalidateAll(context.Background(), magiclink.InvalidateAllParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func setupTestMagicLinkTokenCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := db.Collection(magiclink.CollectionName)
+
+	// Create unique index on the token digest
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: magiclink.FieldTokenHash, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		t.Fatalf("Failed to create unique index: %v", err)
+	}
+
+	return coll
+}
@@ -0,0 +1,158 @@
+// Package magiclink provides the functionality for issuing and consuming the single-use, short-lived login tokens that
+// allow the users to log in without their password, through a link sent to their email.
+package magiclink
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/notifier"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
+)
+
+const (
+	// DefaultLoginTokenLength defines the default length, in bytes, of a generated login token.
+	DefaultLoginTokenLength = 32
+	// DefaultTokenExpiration specifies the default duration for which a login token remains valid. It is shorter than
+	// the password reset one, as the token grants a session by itself.
+	DefaultTokenExpiration = 15 * time.Minute
+)
+
+// Service represents the core interface for magic link login tokens.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=magiclink_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/magiclink Service
+type Service interface {
+	RequestLink(ctx context.Context, input RequestLinkInput) (RequestLinkOutput, error)
+	Consume(ctx context.Context, input ConsumeInput) (ConsumeOutput, error)
+}
+
+type service struct {
+	logger   log.Logger
+	repo     Repository
+	notifier notifier.Notifier
+	clock    clock.Clock
+}
+
+// NewService initializes and returns a new Service implementation.
+func NewService(logger log.Logger, repo Repository, notifier notifier.Notifier, clk clock.Clock) Service {
+	return &service{logger: logger, repo: repo, notifier: notifier, clock: clk}
+}
+
+// RequestLinkInput represents the input required to issue a login token and deliver it to the user.
+// TenantID is empty for the non-tenant users, such as the customers.
+type RequestLinkInput struct {
+	UserID   string
+	Role     string
+	TenantID string
+	Email    string
+}
+
+// RequestLinkOutput represents the result of a magic link request.
+type RequestLinkOutput struct {
+	ExpiresAt time.Time
+}
+
+// RequestLink issues a new login token bound to the requesting device and sends it to the user. Only the latest issued
+// token can be used, so the pending ones are invalidated first.
+func (s *service) RequestLink(ctx context.Context, input RequestLinkInput) (RequestLinkOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	token, err := generateToken()
+	if err != nil {
+		logger.Error("failed to generate login token", err)
+		return RequestLinkOutput{}, err
+	}
+
+	if _, err := s.repo.InvalidateAll(ctx, InvalidateAllParams{
+		UserID:   input.UserID,
+		Role:     input.Role,
+		TenantID: input.TenantID,
+	}); err != nil {
+		logger.Error("failed to invalidate pending login tokens", err)
+		return RequestLinkOutput{}, err
+	}
+
+	expiresAt := s.clock.Now().Add(DefaultTokenExpiration)
+	if _, err := s.repo.Create(ctx, CreateTokenParams{
+		UserID:    input.UserID,
+		Role:      input.Role,
+		TenantID:  input.TenantID,
+		TokenHash: hashToken(token),
+		DeviceID:  refresh.DeviceIDFromContext(ctx),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		logger.Error("failed to store login token", err)
+		return RequestLinkOutput{}, err
+	}
+
+	if err := s.notifier.SendMagicLink(ctx, notifier.MagicLinkNotification{
+		Email:     input.Email,
+		Role:      input.Role,
+		TenantID:  input.TenantID,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		logger.Error("failed to send magic link notification", err)
+		return RequestLinkOutput{}, err
+	}
+
+	logger.Info("magic link requested", log.Field{Key: "user_id", Value: input.UserID})
+	return RequestLinkOutput{ExpiresAt: expiresAt}, nil
+}
+
+// ConsumeInput represents the input required to consume a login token.
+// Role must match the role the token was issued for.
+type ConsumeInput struct {
+	Token string
+	Role  string
+}
+
+// ConsumeOutput represents the owner of a consumed login token.
+type ConsumeOutput struct {
+	UserID   string
+	Role     string
+	TenantID string
+}
+
+// Consume marks the login token as used and returns its owner. The token is only accepted from the same device it was
+// requested from, so a leaked link can't be used from another one.
+func (s *service) Consume(ctx context.Context, input ConsumeInput) (ConsumeOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	token, err := s.repo.Consume(ctx, ConsumeTokenParams{
+		TokenHash: hashToken(input.Token),
+		Role:      input.Role,
+		DeviceID:  refresh.DeviceIDFromContext(ctx),
+	})
+	if err != nil {
+		logger.Error("failed to consume login token", err)
+		return ConsumeOutput{}, err
+	}
+
+	return ConsumeOutput{
+		UserID:   token.UserID,
+		Role:     token.Role,
+		TenantID: token.TenantID,
+	}, nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, DefaultLoginTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+// hashToken computes the digest the tokens are stored with. The tokens are long random values, so a plain SHA-256 is
+// enough to prevent them from being used if the database is leaked.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,229 @@
+//go:build unit
+
+package magiclink_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/magiclink"
+	magiclinkmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/magiclink/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/notifier"
+	notifiermocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/notifier/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
+)
+
+var (
+	errRepo     = errors.New("repository error")
+	errNotifier = errors.New("notifier error")
+)
+
+type magicLinkServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	mocksSetup func(repo *magiclinkmocks.MockRepository, ntf *notifiermocks.MockNotifier)
+	want       W
+	wantErr    error
+}
+
+func TestService_RequestLink(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(magiclink.DefaultTokenExpiration)
+	logger, _ := log.NewTest()
+	ctx := deviceContext("192.168.1.1", "fake-user-agent")
+
+	input := magiclink.RequestLinkInput{
+		UserID: "fake-user-id",
+		Role:   "fake-role",
+		Email:  "test@example.com",
+	}
+
+	tests := []magicLinkServiceTestCase[magiclink.RequestLinkInput, magiclink.RequestLinkOutput]{
+		{
+			name:  "when there is an error invalidating the pending tokens, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *magiclinkmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().InvalidateAll(gomock.Any(), gomock.Any()).Return(int64(0), errRepo)
+			},
+			want:    magiclink.RequestLinkOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error storing the token, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *magiclinkmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().InvalidateAll(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(magiclink.Token{}, errRepo)
+			},
+			want:    magiclink.RequestLinkOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error sending the notification, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *magiclinkmocks.MockRepository, ntf *notifiermocks.MockNotifier) {
+				repo.EXPECT().InvalidateAll(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(magiclink.Token{}, nil)
+				ntf.EXPECT().SendMagicLink(gomock.Any(), gomock.Any()).Return(errNotifier)
+			},
+			want:    magiclink.RequestLinkOutput{},
+			wantErr: errNotifier,
+		},
+		{
+			name: "when the link is requested, " +
+				"then it stores the token digest bound to the device and sends the token to the user",
+			input: input,
+			mocksSetup: func(repo *magiclinkmocks.MockRepository, ntf *notifiermocks.MockNotifier) {
+				var storedHash string
+				repo.EXPECT().InvalidateAll(gomock.Any(), magiclink.InvalidateAllParams{
+					UserID: "fake-user-id",
+					Role:   "fake-role",
+				}).Return(int64(1), nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params magiclink.CreateTokenParams) (magiclink.Token, error) {
+						require.Equal(t, "fake-user-id", params.UserID)
+						require.Equal(t, "fake-role", params.Role)
+						require.Equal(t, refresh.DeviceIDFromContext(ctx), params.DeviceID)
+						require.Equal(t, expiresAt, params.ExpiresAt)
+						require.NotEmpty(t, params.TokenHash)
+
+						storedHash = params.TokenHash
+						return magiclink.Token{TokenHash: params.TokenHash}, nil
+					})
+				ntf.EXPECT().SendMagicLink(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, notification notifier.MagicLinkNotification) error {
+						require.Equal(t, "test@example.com", notification.Email)
+						require.Equal(t, "fake-role", notification.Role)
+						require.Equal(t, expiresAt, notification.ExpiresAt)
+						// Only the digest of the sent token must be stored
+						require.Equal(t, hashToken(notification.Token), storedHash)
+						return nil
+					})
+			},
+			want:    magiclink.RequestLinkOutput{ExpiresAt: expiresAt},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.RequestLink(ctx, tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Consume(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+	ctx := deviceContext("192.168.1.1", "fake-user-agent")
+
+	input := magiclink.ConsumeInput{
+		Token: "fake-token",
+		Role:  "fake-role",
+	}
+
+	tests := []magicLinkServiceTestCase[magiclink.ConsumeInput, magiclink.ConsumeOutput]{
+		{
+			name:  "when the token can't be consumed, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *magiclinkmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().Consume(gomock.Any(), gomock.Any()).
+					Return(magiclink.Token{}, magiclink.ErrLoginTokenNotFound)
+			},
+			want:    magiclink.ConsumeOutput{},
+			wantErr: magiclink.ErrLoginTokenNotFound,
+		},
+		{
+			name:  "when there is an unexpected error consuming the token, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *magiclinkmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(magiclink.Token{}, errRepo)
+			},
+			want:    magiclink.ConsumeOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the token is consumed from the requesting device, then it returns the token owner",
+			input: input,
+			mocksSetup: func(repo *magiclinkmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().Consume(gomock.Any(), magiclink.ConsumeTokenParams{
+					TokenHash: hashToken("fake-token"),
+					Role:      "fake-role",
+					DeviceID:  refresh.DeviceIDFromContext(ctx),
+				}).Return(magiclink.Token{
+					ID:        "fake-id",
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: hashToken("fake-token"),
+					DeviceID:  refresh.DeviceIDFromContext(ctx),
+					ExpiresAt: now.Add(time.Minute),
+					UsedAt:    &now,
+				}, nil)
+			},
+			want: magiclink.ConsumeOutput{
+				UserID: "fake-user-id",
+				Role:   "fake-role",
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.Consume(ctx, tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// deviceContext returns a context of a request performed from the device identified by the IP and user agent.
+func deviceContext(ip, userAgent string) context.Context {
+	return log.WithRequestInfo(context.Background(), log.RequestInfo{RealIP: ip, UserAgent: userAgent})
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func serviceSetup(
+	t *testing.T,
+	logger log.Logger,
+	now time.Time,
+	mocksSetup func(repo *magiclinkmocks.MockRepository, ntf *notifiermocks.MockNotifier),
+) (magiclink.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	repo := magiclinkmocks.NewMockRepository(ctrl)
+	ntf := notifiermocks.NewMockNotifier(ctrl)
+
+	if mocksSetup != nil {
+		mocksSetup(repo, ntf)
+	}
+
+	service := magiclink.NewService(logger, repo, ntf, clock.FixedClock{FixedTime: now})
+	return service, func() {
+		ctrl.Finish()
+	}
+}
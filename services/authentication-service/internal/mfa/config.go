@@ -0,0 +1,24 @@
+package mfa
+
+import (
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for the multi-factor authentication.
+type Config struct {
+	// Issuer is the name shown by the authenticator apps next to the account.
+	Issuer string `env:"MFA_ISSUER" envDefault:"Food Delivery Platform"`
+}
+
+// LoadConfig loads the MFA configuration from environment variables and logs any errors encountered during parsing.
+// It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load MFA configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,18 @@
+package mfa
+
+import "errors"
+
+var (
+	// ErrEnrollmentNotFound indicates that the user has not started any MFA enrollment.
+	ErrEnrollmentNotFound = errors.New("mfa enrollment not found")
+	// ErrAlreadyEnabled indicates that the user has already confirmed its MFA enrollment.
+	ErrAlreadyEnabled = errors.New("mfa already enabled")
+	// ErrChallengeNotFound indicates that the specified MFA challenge could not be found, or it can no longer be used.
+	ErrChallengeNotFound = errors.New("mfa challenge not found")
+	// ErrInvalidCode indicates that the provided TOTP or recovery code is not valid.
+	ErrInvalidCode = errors.New("invalid mfa code")
+	// ErrTimeStepAlreadyUsed indicates that a TOTP code of the same, or a later, period was already accepted.
+	ErrTimeStepAlreadyUsed = errors.New("mfa time step already used")
+	// ErrRecoveryCodeNotFound indicates that the recovery code does not exist, or it was already used.
+	ErrRecoveryCodeNotFound = errors.New("mfa recovery code not found")
+)
@@ -0,0 +1,33 @@
+package mfa
+
+import "time"
+
+// Enrollment represents the TOTP configuration of a user. MFA is only enforced once the enrollment is confirmed with a
+// first valid code. RecoveryCodes only holds the digests of the codes that have not been used yet. The enrollment is
+// not scoped to any tenant, so it applies to all the tenants of the user.
+type Enrollment struct {
+	ID            string     `bson:"_id,omitempty"`
+	UserID        string     `bson:"user_id"`
+	Role          string     `bson:"role"`
+	Secret        string     `bson:"secret"`
+	Confirmed     bool       `bson:"confirmed"`
+	RecoveryCodes []string   `bson:"recovery_codes,omitempty"`
+	LastUsedStep  int64      `bson:"last_used_step"`
+	ConfirmedAt   *time.Time `bson:"confirmed_at,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at"`
+	UpdatedAt     time.Time  `bson:"updated_at"`
+}
+
+// Challenge represents the pending second step of a login. It is issued once the password has been verified, and it
+// must be exchanged together with a valid code before any token pair is issued. Only the TokenHash is stored.
+type Challenge struct {
+	ID             string     `bson:"_id,omitempty"`
+	UserID         string     `bson:"user_id"`
+	Role           string     `bson:"role"`
+	TenantID       string     `bson:"tenant_id"`
+	TokenHash      string     `bson:"token_hash"`
+	FailedAttempts int        `bson:"failed_attempts"`
+	ExpiresAt      time.Time  `bson:"expires_at"`
+	UsedAt         *time.Time `bson:"used_at,omitempty"`
+	CreatedAt      time.Time  `bson:"created_at"`
+}
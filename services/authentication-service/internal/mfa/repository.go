@@ -0,0 +1,424 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// EnrollmentsCollectionName defines the name of the database collection used to store the MFA enrollments.
+	EnrollmentsCollectionName = "mfa_enrollments"
+	// ChallengesCollectionName defines the name of the database collection used to store the MFA login challenges.
+	ChallengesCollectionName = "mfa_challenges"
+
+	// FieldUserID represents the database field name for storing the ID of the user.
+	FieldUserID = "user_id"
+	// FieldRole represents the database field name for storing the role of the user.
+	FieldRole = "role"
+	// FieldTenantID represents the database field name for storing the tenant a challenge was issued for.
+	FieldTenantID = "tenant_id"
+	// FieldSecret represents the database field name for storing the TOTP secret of an enrollment.
+	FieldSecret = "secret"
+	// FieldConfirmed represents the database field name for storing whether an enrollment is confirmed.
+	FieldConfirmed = "confirmed"
+	// FieldRecoveryCodes represents the database field name for storing the digests of the unused recovery codes.
+	FieldRecoveryCodes = "recovery_codes"
+	// FieldLastUsedStep represents the database field name for storing the last accepted TOTP time step.
+	FieldLastUsedStep = "last_used_step"
+	// FieldConfirmedAt represents the database field name for storing when an enrollment was confirmed.
+	FieldConfirmedAt = "confirmed_at"
+	// FieldTokenHash represents the database field name for storing the challenge token digests.
+	FieldTokenHash = "token_hash"
+	// FieldFailedAttempts represents the database field name for storing the failed attempts of a challenge.
+	FieldFailedAttempts = "failed_attempts"
+	// FieldExpiresAt represents the database field name for storing the expiration time of a challenge.
+	FieldExpiresAt = "expires_at"
+	// FieldUsedAt represents the database field name for storing when a challenge was consumed.
+	FieldUsedAt = "used_at"
+	// FieldCreatedAt represents the database field name for storing the creation time of a document.
+	FieldCreatedAt = "created_at"
+	// FieldUpdatedAt represents the database field name for storing the last update time of a document.
+	FieldUpdatedAt = "updated_at"
+)
+
+// Repository defines a contract for storing the MFA enrollments and login challenges in a persistence layer.
+//
+//go:generate mockgen -destination=./mocks/repository_mock.go -package=mfa_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa Repository
+type Repository interface {
+	UpsertPendingEnrollment(ctx context.Context, params UpsertPendingEnrollmentParams) (Enrollment, error)
+	FindEnrollment(ctx context.Context, params FindEnrollmentParams) (Enrollment, error)
+	ConfirmEnrollment(ctx context.Context, params ConfirmEnrollmentParams) error
+	UseTimeStep(ctx context.Context, params UseTimeStepParams) error
+	ConsumeRecoveryCode(ctx context.Context, params ConsumeRecoveryCodeParams) error
+	CreateChallenge(ctx context.Context, params CreateChallengeParams) (Challenge, error)
+	FindActiveChallenge(ctx context.Context, params FindActiveChallengeParams) (Challenge, error)
+	RegisterFailedAttempt(ctx context.Context, challengeID string) error
+	ConsumeChallenge(ctx context.Context, challengeID string) error
+}
+
+type repository struct {
+	logger      log.Logger
+	enrollments *mongo.Collection
+	challenges  *mongo.Collection
+	clock       clock.Clock
+}
+
+// NewRepository creates a new Repository instance.
+func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repository {
+	return &repository{
+		logger:      logger,
+		enrollments: db.Collection(EnrollmentsCollectionName),
+		challenges:  db.Collection(ChallengesCollectionName),
+		clock:       clk,
+	}
+}
+
+// UpsertPendingEnrollmentParams defines the parameters needed to start, or restart, the MFA enrollment of a user.
+type UpsertPendingEnrollmentParams struct {
+	UserID string
+	Role   string
+	Secret string
+}
+
+// UpsertPendingEnrollment stores the secret of a not yet confirmed enrollment, replacing the previous one if any.
+// It returns ErrAlreadyEnabled if the user already has a confirmed enrollment.
+func (r *repository) UpsertPendingEnrollment(
+	ctx context.Context,
+	params UpsertPendingEnrollmentParams,
+) (Enrollment, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var enrollment Enrollment
+	now := r.clock.Now()
+	filter := bson.M{
+		FieldUserID:    params.UserID,
+		FieldRole:      params.Role,
+		FieldConfirmed: false,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldSecret:       params.Secret,
+			FieldLastUsedStep: int64(0),
+			FieldUpdatedAt:    now,
+		},
+		"$setOnInsert": bson.M{
+			FieldCreatedAt: now,
+		},
+	}
+
+	// Returning the updated document
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := r.enrollments.FindOneAndUpdate(ctx, filter, update, opts).Decode(&enrollment)
+	if err != nil {
+		// The unique index on the user prevents a second enrollment when the confirmed one exists
+		if mongodb.IsDuplicateKeyError(err) {
+			logger.Warn("MFA already enabled", log.Field{Key: "user_id", Value: params.UserID})
+			return Enrollment{}, ErrAlreadyEnabled
+		}
+		logger.Error("Failed to store MFA enrollment", err)
+		return Enrollment{}, err
+	}
+	return enrollment, nil
+}
+
+// FindEnrollmentParams defines the parameters needed to find the MFA enrollment of a user. The enrollment is not
+// scoped to any tenant, so a user linked to several tenants is challenged in all of them.
+type FindEnrollmentParams struct {
+	UserID string
+	Role   string
+}
+
+// FindEnrollment returns the enrollment of the user, whether it is confirmed or not.
+// It returns ErrEnrollmentNotFound if the user never started an enrollment.
+func (r *repository) FindEnrollment(ctx context.Context, params FindEnrollmentParams) (Enrollment, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var enrollment Enrollment
+	filter := bson.M{
+		FieldUserID: params.UserID,
+		FieldRole:   params.Role,
+	}
+
+	if err := r.enrollments.FindOne(ctx, filter).Decode(&enrollment); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn("MFA enrollment not found", log.Field{Key: "user_id", Value: params.UserID})
+			return Enrollment{}, ErrEnrollmentNotFound
+		}
+		logger.Error("Failed to find MFA enrollment", err)
+		return Enrollment{}, err
+	}
+	return enrollment, nil
+}
+
+// ConfirmEnrollmentParams defines the parameters needed to confirm an enrollment. RecoveryCodes must be already
+// hashed, and Step is the time step of the code used to confirm it, so it can't be used again.
+type ConfirmEnrollmentParams struct {
+	ID            string
+	RecoveryCodes []string
+	Step          int64
+}
+
+// ConfirmEnrollment enables MFA for the pending enrollment with the given ID.
+// It returns ErrEnrollmentNotFound if there is no pending enrollment with that ID.
+func (r *repository) ConfirmEnrollment(ctx context.Context, params ConfirmEnrollmentParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	id, err := primitive.ObjectIDFromHex(params.ID)
+	if err != nil {
+		logger.Warn("Invalid MFA enrollment ID", log.Field{Key: "enrollment_id", Value: params.ID})
+		return ErrEnrollmentNotFound
+	}
+
+	now := r.clock.Now()
+	filter := bson.M{
+		"_id":          id,
+		FieldConfirmed: false,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldConfirmed:     true,
+			FieldConfirmedAt:   now,
+			FieldRecoveryCodes: params.RecoveryCodes,
+			FieldLastUsedStep:  params.Step,
+			FieldUpdatedAt:     now,
+		},
+	}
+
+	res, err := r.enrollments.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to confirm MFA enrollment", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("Pending MFA enrollment not found", log.Field{Key: "enrollment_id", Value: params.ID})
+		return ErrEnrollmentNotFound
+	}
+	return nil
+}
+
+// UseTimeStepParams defines the parameters needed to mark the time step of an accepted TOTP code as used.
+type UseTimeStepParams struct {
+	ID   string
+	Step int64
+}
+
+// UseTimeStep marks the time step as used for the confirmed enrollment with the given ID, so the same code can't be
+// replayed. It returns ErrTimeStepAlreadyUsed if that time step, or a later one, was already used.
+func (r *repository) UseTimeStep(ctx context.Context, params UseTimeStepParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	id, err := primitive.ObjectIDFromHex(params.ID)
+	if err != nil {
+		logger.Warn("Invalid MFA enrollment ID", log.Field{Key: "enrollment_id", Value: params.ID})
+		return ErrEnrollmentNotFound
+	}
+
+	filter := bson.M{
+		"_id":             id,
+		FieldConfirmed:    true,
+		FieldLastUsedStep: bson.M{"$lt": params.Step},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldLastUsedStep: params.Step,
+			FieldUpdatedAt:    r.clock.Now(),
+		},
+	}
+
+	res, err := r.enrollments.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to use MFA time step", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("MFA time step already used", log.Field{Key: "enrollment_id", Value: params.ID})
+		return ErrTimeStepAlreadyUsed
+	}
+	return nil
+}
+
+// ConsumeRecoveryCodeParams defines the parameters needed to consume a recovery code. CodeHash is the digest of the
+// recovery code.
+type ConsumeRecoveryCodeParams struct {
+	ID       string
+	CodeHash string
+}
+
+// ConsumeRecoveryCode removes the recovery code from the confirmed enrollment with the given ID, so it can only be
+// used once. It returns ErrRecoveryCodeNotFound if the enrollment does not have that code.
+func (r *repository) ConsumeRecoveryCode(ctx context.Context, params ConsumeRecoveryCodeParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	id, err := primitive.ObjectIDFromHex(params.ID)
+	if err != nil {
+		logger.Warn("Invalid MFA enrollment ID", log.Field{Key: "enrollment_id", Value: params.ID})
+		return ErrEnrollmentNotFound
+	}
+
+	filter := bson.M{
+		"_id":              id,
+		FieldConfirmed:     true,
+		FieldRecoveryCodes: params.CodeHash,
+	}
+	update := bson.M{
+		"$pull": bson.M{
+			FieldRecoveryCodes: params.CodeHash,
+		},
+		"$set": bson.M{
+			FieldUpdatedAt: r.clock.Now(),
+		},
+	}
+
+	res, err := r.enrollments.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to consume MFA recovery code", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("MFA recovery code not found", log.Field{Key: "enrollment_id", Value: params.ID})
+		return ErrRecoveryCodeNotFound
+	}
+	return nil
+}
+
+// CreateChallengeParams defines the parameters required to create a new MFA login challenge.
+type CreateChallengeParams struct {
+	UserID    string
+	Role      string
+	TenantID  string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+func (r *repository) CreateChallenge(ctx context.Context, params CreateChallengeParams) (Challenge, error) {
+	logger := r.logger.WithContext(ctx)
+
+	challenge := Challenge{
+		UserID:    params.UserID,
+		Role:      params.Role,
+		TenantID:  params.TenantID,
+		TokenHash: params.TokenHash,
+		ExpiresAt: params.ExpiresAt,
+		CreatedAt: r.clock.Now(),
+	}
+
+	res, err := r.challenges.InsertOne(ctx, challenge)
+	if err != nil {
+		logger.Error("Failed to store MFA challenge", err)
+		return Challenge{}, err
+	}
+
+	challenge.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	return challenge, nil
+}
+
+// FindActiveChallengeParams defines the parameters needed to find an MFA challenge that can still be used.
+// Role restricts the search to the challenges issued for that role.
+type FindActiveChallengeParams struct {
+	TokenHash   string
+	Role        string
+	MaxAttempts int
+}
+
+// FindActiveChallenge returns the challenge if it is not used nor expired, and it has not reached the maximum number
+// of failed attempts. Otherwise, it returns ErrChallengeNotFound.
+func (r *repository) FindActiveChallenge(ctx context.Context, params FindActiveChallengeParams) (Challenge, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var challenge Challenge
+	filter := bson.M{
+		FieldTokenHash:      params.TokenHash,
+		FieldRole:           params.Role,
+		FieldUsedAt:         bson.M{"$exists": false},
+		FieldFailedAttempts: bson.M{"$lt": params.MaxAttempts},
+		FieldExpiresAt: bson.M{
+			"$gt": r.clock.Now(),
+		},
+	}
+
+	if err := r.challenges.FindOne(ctx, filter).Decode(&challenge); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn("MFA challenge not found")
+			return Challenge{}, ErrChallengeNotFound
+		}
+		logger.Error("Failed to find MFA challenge", err)
+		return Challenge{}, err
+	}
+	return challenge, nil
+}
+
+// RegisterFailedAttempt increases the failed attempts of the challenge with the given ID.
+// It returns ErrChallengeNotFound if there is no challenge with that ID.
+func (r *repository) RegisterFailedAttempt(ctx context.Context, challengeID string) error {
+	logger := r.logger.WithContext(ctx)
+
+	id, err := primitive.ObjectIDFromHex(challengeID)
+	if err != nil {
+		logger.Warn("Invalid MFA challenge ID", log.Field{Key: "challenge_id", Value: challengeID})
+		return ErrChallengeNotFound
+	}
+
+	update := bson.M{
+		"$inc": bson.M{
+			FieldFailedAttempts: 1,
+		},
+	}
+
+	res, err := r.challenges.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		logger.Error("Failed to register MFA challenge failed attempt", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("MFA challenge not found", log.Field{Key: "challenge_id", Value: challengeID})
+		return ErrChallengeNotFound
+	}
+	return nil
+}
+
+// ConsumeChallenge marks the challenge with the given ID as used, so it can't be exchanged twice.
+// It returns ErrChallengeNotFound if the challenge does not exist, or it was already used or expired.
+func (r *repository) ConsumeChallenge(ctx context.Context, challengeID string) error {
+	logger := r.logger.WithContext(ctx)
+
+	id, err := primitive.ObjectIDFromHex(challengeID)
+	if err != nil {
+		logger.Warn("Invalid MFA challenge ID", log.Field{Key: "challenge_id", Value: challengeID})
+		return ErrChallengeNotFound
+	}
+
+	now := r.clock.Now()
+	filter := bson.M{
+		"_id":       id,
+		FieldUsedAt: bson.M{"$exists": false},
+		FieldExpiresAt: bson.M{
+			"$gt": now,
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldUsedAt: now,
+		},
+	}
+
+	res, err := r.challenges.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to consume MFA challenge", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("MFA challenge not found", log.Field{Key: "challenge_id", Value: challengeID})
+		return ErrChallengeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,405 @@
+//go:build integration
+
+package mfa_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa"
+)
+
+const testDBPrefix = "customers_test_authentication_service"
+
+type mfaRepositoryTestCase[P, W any] struct {
+	name            string
+	insertDocuments func(t *testing.T, enrollments, challenges *mongo.Collection)
+	params          P
+	want            W
+	wantErr         error
+}
+
+func TestRepository_UpsertPendingEnrollment(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	params := mfa.UpsertPendingEnrollmentParams{
+		UserID: "fake-user-id",
+		Role:   "fake-role",
+		Secret: "fake-secret",
+	}
+
+	tests := []mfaRepositoryTestCase[mfa.UpsertPendingEnrollmentParams, mfa.Enrollment]{
+		{
+			name:   "when the user has no enrollment, then it should create a pending one",
+			params: params,
+			want: mfa.Enrollment{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				Secret:    "fake-secret",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+		},
+		{
+			name: "when the user has a pending enrollment, then it should replace its secret",
+			insertDocuments: func(t *testing.T, enrollments, _ *mongo.Collection) {
+				mongodb.InsertTestDocument(t, enrollments, mfa.Enrollment{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					Secret:    "fake-previous-secret",
+					CreatedAt: now.Add(-time.Hour),
+					UpdatedAt: now.Add(-time.Hour),
+				})
+			},
+			params: params,
+			want: mfa.Enrollment{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				Secret:    "fake-secret",
+				CreatedAt: now.Add(-time.Hour),
+				UpdatedAt: now,
+			},
+		},
+		{
+			name: "when the user has a confirmed enrollment, then it should return an already enabled error",
+			insertDocuments: func(t *testing.T, enrollments, _ *mongo.Collection) {
+				mongodb.InsertTestDocument(t, enrollments, mfa.Enrollment{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					Secret:    "fake-previous-secret",
+					Confirmed: true,
+					CreatedAt: now.Add(-time.Hour),
+					UpdatedAt: now.Add(-time.Hour),
+				})
+			},
+			params:  params,
+			want:    mfa.Enrollment{},
+			wantErr: mfa.ErrAlreadyEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			enrollments, challenges := setupTestMFACollections(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, enrollments, challenges)
+			}
+
+			repo := mfa.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			enrollment, err := repo.UpsertPendingEnrollment(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, enrollment.ID, "ID should not be empty")
+
+				tt.want.ID = enrollment.ID
+				assert.Equal(t, tt.want, enrollment)
+			}
+		})
+	}
+}
+
+func TestRepository_UpsertPendingEnrollment_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestMFACollections(t, tdb.DB)
+
+	repo := mfa.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.UpsertPendingEnrollment(context.Background(), mfa.UpsertPendingEnrollmentParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_FindEnrollment(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	params := mfa.FindEnrollmentParams{
+		UserID: "fake-user-id",
+		Role:   "fake-role",
+	}
+
+	tests := []mfaRepositoryTestCase[mfa.FindEnrollmentParams, mfa.Enrollment]{
+		{
+			name: "when the enrollment belongs to another role, then it should return an enrollment not found error",
+			insertDocuments: func(t *testing.T, enrollments, _ *mongo.Collection) {
+				mongodb.InsertTestDocument(t, enrollments, mfa.Enrollment{
+					UserID:    "fake-user-id",
+					Role:      "fake-other-role",
+					Secret:    "fake-secret",
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params:  params,
+			want:    mfa.Enrollment{},
+			wantErr: mfa.ErrEnrollmentNotFound,
+		},
+		{
+			name: "when the user has an enrollment, then it should return it",
+			insertDocuments: func(t *testing.T, enrollments, _ *mongo.Collection) {
+				mongodb.InsertTestDocument(t, enrollments, mfa.Enrollment{
+					UserID:        "fake-user-id",
+					Role:          "fake-role",
+					Secret:        "fake-secret",
+					Confirmed:     true,
+					RecoveryCodes: []string{"fake-code-hash"},
+					LastUsedStep:  10,
+					ConfirmedAt:   &now,
+					CreatedAt:     now,
+					UpdatedAt:     now,
+				})
+			},
+			params: params,
+			want: mfa.Enrollment{
+				UserID:        "fake-user-id",
+				Role:          "fake-role",
+				Secret:        "fake-secret",
+				Confirmed:     true,
+				RecoveryCodes: []string{"fake-code-hash"},
+				LastUsedStep:  10,
+				ConfirmedAt:   &now,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			enrollments, challenges := setupTestMFACollections(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, enrollments, challenges)
+			}
+
+			repo := mfa.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			enrollment, err := repo.FindEnrollment(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, enrollment.ID, "ID should not be empty")
+
+				tt.want.ID = enrollment.ID
+				assert.Equal(t, tt.want, enrollment)
+			}
+		})
+	}
+}
+
+func TestRepository_FindEnrollment_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestMFACollections(t, tdb.DB)
+
+	repo := mfa.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindEnrollment(context.Background(), mfa.FindEnrollmentParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_EnrollmentLifecycle(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+	ctx := context.Background()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	defer tdb.Close(t)
+	setupTestMFACollections(t, tdb.DB)
+
+	repo := mfa.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+	enrollment, err := repo.UpsertPendingEnrollment(ctx, mfa.UpsertPendingEnrollmentParams{
+		UserID: "fake-user-id",
+		Role:   "fake-role",
+		Secret: "fake-secret",
+	})
+	require.NoError(t, err)
+
+	// The time steps and recovery codes can't be used until the enrollment is confirmed
+	err = repo.UseTimeStep(ctx, mfa.UseTimeStepParams{ID: enrollment.ID, Step: 11})
+	assert.ErrorIs(t, err, mfa.ErrTimeStepAlreadyUsed)
+
+	err = repo.ConfirmEnrollment(ctx, mfa.ConfirmEnrollmentParams{
+		ID:            enrollment.ID,
+		RecoveryCodes: []string{"fake-code-hash", "fake-other-code-hash"},
+		Step:          10,
+	})
+	require.NoError(t, err)
+
+	// A confirmed enrollment can't be confirmed again
+	err = repo.ConfirmEnrollment(ctx, mfa.ConfirmEnrollmentParams{ID: enrollment.ID, Step: 10})
+	assert.ErrorIs(t, err, mfa.ErrEnrollmentNotFound)
+
+	// The time step used to confirm the enrollment, and the previous ones, can't be replayed
+	err = repo.UseTimeStep(ctx, mfa.UseTimeStepParams{ID: enrollment.ID, Step: 10})
+	assert.ErrorIs(t, err, mfa.ErrTimeStepAlreadyUsed)
+	err = repo.UseTimeStep(ctx, mfa.UseTimeStepParams{ID: enrollment.ID, Step: 11})
+	assert.NoError(t, err)
+	err = repo.UseTimeStep(ctx, mfa.UseTimeStepParams{ID: enrollment.ID, Step: 11})
+	assert.ErrorIs(t, err, mfa.ErrTimeStepAlreadyUsed)
+
+	// Each recovery code can only be used once
+	err = repo.ConsumeRecoveryCode(ctx, mfa.ConsumeRecoveryCodeParams{ID: enrollment.ID, CodeHash: "fake-code-hash"})
+	assert.NoError(t, err)
+	err = repo.ConsumeRecoveryCode(ctx, mfa.ConsumeRecoveryCodeParams{ID: enrollment.ID, CodeHash: "fake-code-hash"})
+	assert.ErrorIs(t, err, mfa.ErrRecoveryCodeNotFound)
+
+	got, err := repo.FindEnrollment(ctx, mfa.FindEnrollmentParams{
+		UserID: "fake-user-id",
+		Role:   "fake-role",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, mfa.Enrollment{
+		ID:            enrollment.ID,
+		UserID:        "fake-user-id",
+		Role:          "fake-role",
+		Secret:        "fake-secret",
+		Confirmed:     true,
+		RecoveryCodes: []string{"fake-other-code-hash"},
+		LastUsedStep:  11,
+		ConfirmedAt:   &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, got)
+}
+
+func TestRepository_ChallengeLifecycle(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(5 * time.Minute)
+	logger, _ := log.NewTest()
+	ctx := context.Background()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	defer tdb.Close(t)
+	setupTestMFACollections(t, tdb.DB)
+
+	repo := mfa.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+	challenge, err := repo.CreateChallenge(ctx, mfa.CreateChallengeParams{
+		UserID:    "fake-user-id",
+		Role:      "fake-role",
+		TenantID:  "fake-tenant-id",
+		TokenHash: "fake-token-hash",
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, challenge.ID, "ID should not be empty")
+
+	findParams := mfa.FindActiveChallengeParams{TokenHash: "fake-token-hash", Role: "fake-role", MaxAttempts: 2}
+
+	// The challenge is restricted to the role it was issued for
+	_, err = repo.FindActiveChallenge(ctx, mfa.FindActiveChallengeParams{
+		TokenHash:   "fake-token-hash",
+		Role:        "fake-other-role",
+		MaxAttempts: 2,
+	})
+	assert.ErrorIs(t, err, mfa.ErrChallengeNotFound)
+
+	got, err := repo.FindActiveChallenge(ctx, findParams)
+	require.NoError(t, err)
+	assert.Equal(t, challenge, got)
+
+	// The challenge can't be used once it reaches the maximum number of failed attempts
+	require.NoError(t, repo.RegisterFailedAttempt(ctx, challenge.ID))
+	_, err = repo.FindActiveChallenge(ctx, findParams)
+	assert.NoError(t, err)
+	require.NoError(t, repo.RegisterFailedAttempt(ctx, challenge.ID))
+	_, err = repo.FindActiveChallenge(ctx, findParams)
+	assert.ErrorIs(t, err, mfa.ErrChallengeNotFound)
+
+	// A consumed challenge can't be consumed nor found again
+	findParams.MaxAttempts = 5
+	require.NoError(t, repo.ConsumeChallenge(ctx, challenge.ID))
+	assert.ErrorIs(t, repo.ConsumeChallenge(ctx, challenge.ID), mfa.ErrChallengeNotFound)
+	_, err = repo.FindActiveChallenge(ctx, findParams)
+	assert.ErrorIs(t, err, mfa.ErrChallengeNotFound)
+
+	// An expired challenge can't be found
+	expiredRepo := mfa.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: expiresAt})
+	_, err = repo.CreateChallenge(ctx, mfa.CreateChallengeParams{
+		UserID:    "fake-user-id",
+		Role:      "fake-role",
+		TenantID:  "fake-tenant-id",
+		TokenHash: "fake-other-token-hash",
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	_, err = expiredRepo.FindActiveChallenge(ctx, mfa.FindActiveChallengeParams{
+		TokenHash:   "fake-other-token-hash",
+		Role:        "fake-role",
+		MaxAttempts: 5,
+	})
+	assert.ErrorIs(t, err, mfa.ErrChallengeNotFound)
+}
+
+func TestRepository_FindActiveChallenge_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestMFACollections(t, tdb.DB)
+
+	repo := mfa.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindActiveChallenge(context.Background(), mfa.FindActiveChallengeParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func setupTestMFACollections(t *testing.T, db *mongo.Database) (*mongo.Collection, *mongo.Collection) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	enrollments := db.Collection(mfa.EnrollmentsCollectionName)
+	challenges := db.Collection(mfa.ChallengesCollectionName)
+
+	// Create unique index on the enrollment owner
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: mfa.FieldUserID, Value: 1},
+			{Key: mfa.FieldRole, Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := enrollments.Indexes().CreateOne(ctx, indexModel); err != nil {
+		t.Fatalf("Failed to create unique index: %v", err)
+	}
+
+	// Create unique index on the challenge token digest
+	indexModel = mongo.IndexModel{
+		Keys:    bson.D{{Key: mfa.FieldTokenHash, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := challenges.Indexes().CreateOne(ctx, indexModel); err != nil {
+		t.Fatalf("Failed to create unique index: %v", err)
+	}
+
+	return enrollments, challenges
+}
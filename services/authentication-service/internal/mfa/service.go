@@ -0,0 +1,356 @@
+// Package mfa provides the TOTP based multi-factor authentication: the enrollment of the users, their recovery codes
+// and the login challenges that must be completed before any token pair is issued.
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// DefaultChallengeTokenLength defines the default length, in bytes, of a generated challenge token.
+	DefaultChallengeTokenLength = 32
+	// DefaultChallengeExpiration specifies the default duration for which a login challenge remains valid.
+	DefaultChallengeExpiration = 5 * time.Minute
+	// MaxChallengeAttempts defines how many wrong codes are accepted before the challenge can no longer be used.
+	MaxChallengeAttempts = 5
+	// RecoveryCodesCount defines how many recovery codes are generated when the enrollment is confirmed.
+	RecoveryCodesCount = 10
+	// recoveryCodeLength defines the number of random bytes of each recovery code.
+	recoveryCodeLength = 5
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Service represents the core interface for the multi-factor authentication.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=mfa_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa Service
+type Service interface {
+	Enroll(ctx context.Context, input EnrollInput) (EnrollOutput, error)
+	ConfirmEnrollment(ctx context.Context, input ConfirmEnrollmentInput) (ConfirmEnrollmentOutput, error)
+	IsEnabled(ctx context.Context, input IsEnabledInput) (IsEnabledOutput, error)
+	CreateChallenge(ctx context.Context, input CreateChallengeInput) (CreateChallengeOutput, error)
+	VerifyChallenge(ctx context.Context, input VerifyChallengeInput) (VerifyChallengeOutput, error)
+}
+
+type service struct {
+	logger log.Logger
+	repo   Repository
+	clock  clock.Clock
+	issuer string
+}
+
+// NewService initializes and returns a new Service implementation. The issuer is the name shown by the authenticator
+// apps next to the account.
+func NewService(logger log.Logger, repo Repository, clk clock.Clock, issuer string) Service {
+	return &service{logger: logger, repo: repo, clock: clk, issuer: issuer}
+}
+
+// EnrollInput represents the input required to start the MFA enrollment of a user.
+// AccountName identifies the account in the authenticator apps, usually the email of the user.
+type EnrollInput struct {
+	UserID      string
+	Role        string
+	AccountName string
+}
+
+// EnrollOutput contains the TOTP secret of the enrollment, and the URI to register it in the authenticator apps.
+type EnrollOutput struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// Enroll generates a new TOTP secret for the user. The enrollment is pending until it is confirmed with a first valid
+// code, and enrolling again replaces the pending secret. It returns ErrAlreadyEnabled if MFA is already enabled.
+func (s *service) Enroll(ctx context.Context, input EnrollInput) (EnrollOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	secret, err := GenerateSecret()
+	if err != nil {
+		logger.Error("failed to generate TOTP secret", err)
+		return EnrollOutput{}, err
+	}
+
+	if _, err := s.repo.UpsertPendingEnrollment(ctx, UpsertPendingEnrollmentParams{
+		UserID: input.UserID,
+		Role:   input.Role,
+		Secret: secret,
+	}); err != nil {
+		logger.Error("failed to store MFA enrollment", err)
+		return EnrollOutput{}, err
+	}
+
+	logger.Info("MFA enrollment started", log.Field{Key: "user_id", Value: input.UserID})
+	return EnrollOutput{
+		Secret:          secret,
+		ProvisioningURI: ProvisioningURI(s.issuer, input.AccountName, secret),
+	}, nil
+}
+
+// ConfirmEnrollmentInput represents the input required to confirm the pending MFA enrollment of a user.
+type ConfirmEnrollmentInput struct {
+	UserID string
+	Role   string
+	Code   string
+}
+
+// ConfirmEnrollmentOutput contains the recovery codes of the user. They are only returned once, as just their
+// digests are stored.
+type ConfirmEnrollmentOutput struct {
+	RecoveryCodes []string
+}
+
+// ConfirmEnrollment enables MFA for the user once the code proves the secret was registered in an authenticator app.
+func (s *service) ConfirmEnrollment(
+	ctx context.Context,
+	input ConfirmEnrollmentInput,
+) (ConfirmEnrollmentOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	enrollment, err := s.repo.FindEnrollment(ctx, FindEnrollmentParams{
+		UserID: input.UserID,
+		Role:   input.Role,
+	})
+	if err != nil {
+		logger.Error("failed to find MFA enrollment", err)
+		return ConfirmEnrollmentOutput{}, err
+	}
+	if enrollment.Confirmed {
+		logger.Warn("MFA already enabled", log.Field{Key: "user_id", Value: input.UserID})
+		return ConfirmEnrollmentOutput{}, ErrAlreadyEnabled
+	}
+
+	step, ok := validateTOTP(enrollment.Secret, input.Code, s.clock.Now())
+	if !ok {
+		logger.Warn("invalid TOTP code", log.Field{Key: "user_id", Value: input.UserID})
+		return ConfirmEnrollmentOutput{}, ErrInvalidCode
+	}
+
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		logger.Error("failed to generate recovery codes", err)
+		return ConfirmEnrollmentOutput{}, err
+	}
+	hashedCodes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashedCodes = append(hashedCodes, hashRecoveryCode(code))
+	}
+
+	if err := s.repo.ConfirmEnrollment(ctx, ConfirmEnrollmentParams{
+		ID:            enrollment.ID,
+		RecoveryCodes: hashedCodes,
+		Step:          step,
+	}); err != nil {
+		logger.Error("failed to confirm MFA enrollment", err)
+		return ConfirmEnrollmentOutput{}, err
+	}
+
+	logger.Info("MFA enabled", log.Field{Key: "user_id", Value: input.UserID})
+	return ConfirmEnrollmentOutput{RecoveryCodes: recoveryCodes}, nil
+}
+
+// IsEnabledInput represents the input required to check whether a user has MFA enabled.
+type IsEnabledInput struct {
+	UserID string
+	Role   string
+}
+
+// IsEnabledOutput represents whether a user has MFA enabled.
+type IsEnabledOutput struct {
+	Enabled bool
+}
+
+// IsEnabled checks whether the user has a confirmed enrollment. A pending enrollment does not enable MFA yet.
+func (s *service) IsEnabled(ctx context.Context, input IsEnabledInput) (IsEnabledOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	enrollment, err := s.repo.FindEnrollment(ctx, FindEnrollmentParams(input))
+	if err != nil {
+		if errors.Is(err, ErrEnrollmentNotFound) {
+			return IsEnabledOutput{Enabled: false}, nil
+		}
+		logger.Error("failed to find MFA enrollment", err)
+		return IsEnabledOutput{}, err
+	}
+	return IsEnabledOutput{Enabled: enrollment.Confirmed}, nil
+}
+
+// CreateChallengeInput represents the input required to issue a login challenge for a user.
+type CreateChallengeInput struct {
+	UserID   string
+	Role     string
+	TenantID string
+}
+
+// CreateChallengeOutput contains the challenge token, which must be exchanged together with a valid code.
+type CreateChallengeOutput struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func (s *service) CreateChallenge(ctx context.Context, input CreateChallengeInput) (CreateChallengeOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	token, err := generateChallengeToken()
+	if err != nil {
+		logger.Error("failed to generate MFA challenge token", err)
+		return CreateChallengeOutput{}, err
+	}
+
+	expiresAt := s.clock.Now().Add(DefaultChallengeExpiration)
+	if _, err := s.repo.CreateChallenge(ctx, CreateChallengeParams{
+		UserID:    input.UserID,
+		Role:      input.Role,
+		TenantID:  input.TenantID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		logger.Error("failed to store MFA challenge", err)
+		return CreateChallengeOutput{}, err
+	}
+
+	return CreateChallengeOutput{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// VerifyChallengeInput represents the input required to complete a login challenge. Either the TOTP Code or one of
+// the RecoveryCode values must be provided. Role must match the role the challenge was issued for.
+type VerifyChallengeInput struct {
+	Token        string
+	Role         string
+	Code         string
+	RecoveryCode string
+}
+
+// VerifyChallengeOutput represents the user that completed the login challenge.
+type VerifyChallengeOutput struct {
+	UserID   string
+	Role     string
+	TenantID string
+}
+
+// VerifyChallenge checks the code against the enrollment of the challenge owner, and consumes the challenge when it
+// is valid. Each wrong code counts as a failed attempt, and the challenge can't be used after MaxChallengeAttempts.
+func (s *service) VerifyChallenge(ctx context.Context, input VerifyChallengeInput) (VerifyChallengeOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	challenge, err := s.repo.FindActiveChallenge(ctx, FindActiveChallengeParams{
+		TokenHash:   hashToken(input.Token),
+		Role:        input.Role,
+		MaxAttempts: MaxChallengeAttempts,
+	})
+	if err != nil {
+		logger.Error("failed to find MFA challenge", err)
+		return VerifyChallengeOutput{}, err
+	}
+
+	// The enrollment belongs to the user, whatever the tenant the challenge was issued for
+	enrollment, err := s.repo.FindEnrollment(ctx, FindEnrollmentParams{
+		UserID: challenge.UserID,
+		Role:   challenge.Role,
+	})
+	if err != nil {
+		logger.Error("failed to find MFA enrollment", err)
+		return VerifyChallengeOutput{}, err
+	}
+
+	if err := s.verifyCode(ctx, enrollment, input.Code, input.RecoveryCode); err != nil {
+		if !errors.Is(err, ErrInvalidCode) {
+			logger.Error("failed to verify MFA code", err)
+			return VerifyChallengeOutput{}, err
+		}
+
+		logger.Warn("invalid MFA code", log.Field{Key: "user_id", Value: challenge.UserID})
+		if err := s.repo.RegisterFailedAttempt(ctx, challenge.ID); err != nil {
+			logger.Error("failed to register MFA challenge failed attempt", err)
+			return VerifyChallengeOutput{}, err
+		}
+		return VerifyChallengeOutput{}, ErrInvalidCode
+	}
+
+	if err := s.repo.ConsumeChallenge(ctx, challenge.ID); err != nil {
+		logger.Error("failed to consume MFA challenge", err)
+		return VerifyChallengeOutput{}, err
+	}
+
+	return VerifyChallengeOutput{
+		UserID:   challenge.UserID,
+		Role:     challenge.Role,
+		TenantID: challenge.TenantID,
+	}, nil
+}
+
+// verifyCode checks the TOTP code, or the recovery code when no TOTP code is provided, and marks it as used so it
+// can't be replayed. It returns ErrInvalidCode when the code is not valid.
+func (s *service) verifyCode(ctx context.Context, enrollment Enrollment, code, recoveryCode string) error {
+	if !enrollment.Confirmed {
+		return ErrInvalidCode
+	}
+
+	if code != "" {
+		step, ok := validateTOTP(enrollment.Secret, code, s.clock.Now())
+		if !ok {
+			return ErrInvalidCode
+		}
+		err := s.repo.UseTimeStep(ctx, UseTimeStepParams{ID: enrollment.ID, Step: step})
+		if errors.Is(err, ErrTimeStepAlreadyUsed) {
+			return ErrInvalidCode
+		}
+		return err
+	}
+
+	if recoveryCode != "" {
+		err := s.repo.ConsumeRecoveryCode(ctx, ConsumeRecoveryCodeParams{
+			ID:       enrollment.ID,
+			CodeHash: hashRecoveryCode(recoveryCode),
+		})
+		if errors.Is(err, ErrRecoveryCodeNotFound) {
+			return ErrInvalidCode
+		}
+		return err
+	}
+
+	return ErrInvalidCode
+}
+
+func generateChallengeToken() (string, error) {
+	b := make([]byte, DefaultChallengeTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+// generateRecoveryCodes generates the recovery codes, formatted as two groups of five characters to ease typing them.
+func generateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, RecoveryCodesCount)
+	for i := 0; i < RecoveryCodesCount; i++ {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+	return codes, nil
+}
+
+// hashRecoveryCode hashes the recovery code ignoring its formatting, so it can be typed with or without separators.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashToken(normalized)
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,528 @@
+//go:build unit
+
+package mfa_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa"
+	mfamocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa/mocks"
+)
+
+const testIssuer = "Test Issuer"
+
+var errRepo = errors.New("repository error")
+
+type mfaServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	mocksSetup func(repo *mfamocks.MockRepository)
+	want       W
+	wantErr    error
+}
+
+func TestService_Enroll(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	input := mfa.EnrollInput{
+		UserID:      "fake-user-id",
+		Role:        "fake-role",
+		AccountName: "test@example.com",
+	}
+
+	tests := []mfaServiceTestCase[mfa.EnrollInput, mfa.EnrollOutput]{
+		{
+			name:  "when the user already has MFA enabled, then it returns an already enabled error",
+			input: input,
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().UpsertPendingEnrollment(gomock.Any(), gomock.Any()).
+					Return(mfa.Enrollment{}, mfa.ErrAlreadyEnabled)
+			},
+			want:    mfa.EnrollOutput{},
+			wantErr: mfa.ErrAlreadyEnabled,
+		},
+		{
+			name:  "when there is an error storing the enrollment, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().UpsertPendingEnrollment(gomock.Any(), gomock.Any()).
+					Return(mfa.Enrollment{}, errRepo)
+			},
+			want:    mfa.EnrollOutput{},
+			wantErr: errRepo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.Enroll(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("when the enrollment is stored, then it returns the secret and its provisioning URI", func(t *testing.T) {
+		var storedSecret string
+		service, cleanup := serviceSetup(t, logger, now, func(repo *mfamocks.MockRepository) {
+			repo.EXPECT().UpsertPendingEnrollment(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, params mfa.UpsertPendingEnrollmentParams) (mfa.Enrollment, error) {
+					require.Equal(t, "fake-user-id", params.UserID)
+					require.Equal(t, "fake-role", params.Role)
+					require.NotEmpty(t, params.Secret)
+
+					storedSecret = params.Secret
+					return mfa.Enrollment{ID: "fake-id", Secret: params.Secret}, nil
+				})
+		})
+		defer cleanup()
+
+		got, err := service.Enroll(context.Background(), input)
+
+		require.NoError(t, err)
+		assert.Equal(t, mfa.EnrollOutput{
+			Secret:          storedSecret,
+			ProvisioningURI: mfa.ProvisioningURI(testIssuer, "test@example.com", storedSecret),
+		}, got)
+	})
+}
+
+func TestService_ConfirmEnrollment(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	code, err := mfa.GenerateTOTP(rfcSecret, now)
+	require.NoError(t, err)
+
+	input := mfa.ConfirmEnrollmentInput{
+		UserID: "fake-user-id",
+		Role:   "fake-role",
+		Code:   code,
+	}
+	pending := mfa.Enrollment{
+		ID:     "fake-id",
+		UserID: "fake-user-id",
+		Role:   "fake-role",
+		Secret: rfcSecret,
+	}
+
+	tests := []mfaServiceTestCase[mfa.ConfirmEnrollmentInput, mfa.ConfirmEnrollmentOutput]{
+		{
+			name:  "when there is no enrollment, then it returns an enrollment not found error",
+			input: input,
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).
+					Return(mfa.Enrollment{}, mfa.ErrEnrollmentNotFound)
+			},
+			want:    mfa.ConfirmEnrollmentOutput{},
+			wantErr: mfa.ErrEnrollmentNotFound,
+		},
+		{
+			name:  "when the enrollment is already confirmed, then it returns an already enabled error",
+			input: input,
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				confirmed := pending
+				confirmed.Confirmed = true
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(confirmed, nil)
+			},
+			want:    mfa.ConfirmEnrollmentOutput{},
+			wantErr: mfa.ErrAlreadyEnabled,
+		},
+		{
+			name: "when the code does not match the secret, then it returns an invalid code error",
+			input: mfa.ConfirmEnrollmentInput{
+				UserID: "fake-user-id",
+				Role:   "fake-role",
+				Code:   "000000",
+			},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(pending, nil)
+			},
+			want:    mfa.ConfirmEnrollmentOutput{},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name:  "when there is an error confirming the enrollment, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(pending, nil)
+				repo.EXPECT().ConfirmEnrollment(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    mfa.ConfirmEnrollmentOutput{},
+			wantErr: errRepo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.ConfirmEnrollment(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("when the code matches the secret, then it enables MFA and returns the recovery codes", func(t *testing.T) {
+		var params mfa.ConfirmEnrollmentParams
+		service, cleanup := serviceSetup(t, logger, now, func(repo *mfamocks.MockRepository) {
+			repo.EXPECT().FindEnrollment(gomock.Any(), mfa.FindEnrollmentParams{
+				UserID: "fake-user-id",
+				Role:   "fake-role",
+			}).Return(pending, nil)
+			repo.EXPECT().ConfirmEnrollment(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, p mfa.ConfirmEnrollmentParams) error {
+					params = p
+					return nil
+				})
+		})
+		defer cleanup()
+
+		got, err := service.ConfirmEnrollment(context.Background(), input)
+
+		require.NoError(t, err)
+		require.Len(t, got.RecoveryCodes, mfa.RecoveryCodesCount)
+		assert.Equal(t, "fake-id", params.ID)
+		assert.Equal(t, now.Unix()/int64(mfa.TOTPPeriod.Seconds()), params.Step)
+
+		// Only the digests of the returned recovery codes must be stored
+		require.Len(t, params.RecoveryCodes, mfa.RecoveryCodesCount)
+		for i, code := range got.RecoveryCodes {
+			assert.Equal(t, hashRecoveryCode(code), params.RecoveryCodes[i])
+		}
+	})
+}
+
+func TestService_IsEnabled(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	input := mfa.IsEnabledInput{
+		UserID: "fake-user-id",
+		Role:   "fake-role",
+	}
+
+	tests := []mfaServiceTestCase[mfa.IsEnabledInput, mfa.IsEnabledOutput]{
+		{
+			name:  "when there is no enrollment, then MFA is not enabled",
+			input: input,
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).
+					Return(mfa.Enrollment{}, mfa.ErrEnrollmentNotFound)
+			},
+			want:    mfa.IsEnabledOutput{Enabled: false},
+			wantErr: nil,
+		},
+		{
+			name:  "when there is an unexpected error finding the enrollment, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(mfa.Enrollment{}, errRepo)
+			},
+			want:    mfa.IsEnabledOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the enrollment is pending, then MFA is not enabled",
+			input: input,
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).
+					Return(mfa.Enrollment{ID: "fake-id", Confirmed: false}, nil)
+			},
+			want:    mfa.IsEnabledOutput{Enabled: false},
+			wantErr: nil,
+		},
+		{
+			name:  "when the enrollment is confirmed, then MFA is enabled",
+			input: input,
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindEnrollment(gomock.Any(), mfa.FindEnrollmentParams{
+					UserID: "fake-user-id",
+					Role:   "fake-role",
+				}).Return(mfa.Enrollment{ID: "fake-id", Confirmed: true}, nil)
+			},
+			want:    mfa.IsEnabledOutput{Enabled: true},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.IsEnabled(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_CreateChallenge(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(mfa.DefaultChallengeExpiration)
+	logger, _ := log.NewTest()
+
+	input := mfa.CreateChallengeInput{
+		UserID:   "fake-user-id",
+		Role:     "fake-role",
+		TenantID: "fake-tenant-id",
+	}
+
+	t.Run("when there is an error storing the challenge, then it propagates the error", func(t *testing.T) {
+		service, cleanup := serviceSetup(t, logger, now, func(repo *mfamocks.MockRepository) {
+			repo.EXPECT().CreateChallenge(gomock.Any(), gomock.Any()).Return(mfa.Challenge{}, errRepo)
+		})
+		defer cleanup()
+
+		got, err := service.CreateChallenge(context.Background(), input)
+
+		assert.ErrorIs(t, err, errRepo)
+		assert.Equal(t, mfa.CreateChallengeOutput{}, got)
+	})
+
+	t.Run("when the challenge is stored, then it returns the token whose digest was stored", func(t *testing.T) {
+		var storedHash string
+		service, cleanup := serviceSetup(t, logger, now, func(repo *mfamocks.MockRepository) {
+			repo.EXPECT().CreateChallenge(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, params mfa.CreateChallengeParams) (mfa.Challenge, error) {
+					require.Equal(t, "fake-user-id", params.UserID)
+					require.Equal(t, "fake-role", params.Role)
+					require.Equal(t, "fake-tenant-id", params.TenantID)
+					require.Equal(t, expiresAt, params.ExpiresAt)
+
+					storedHash = params.TokenHash
+					return mfa.Challenge{ID: "fake-id", TokenHash: params.TokenHash}, nil
+				})
+		})
+		defer cleanup()
+
+		got, err := service.CreateChallenge(context.Background(), input)
+
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, got.ExpiresAt)
+		assert.Equal(t, hashToken(got.Token), storedHash)
+	})
+}
+
+func TestService_VerifyChallenge(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	step := now.Unix() / int64(mfa.TOTPPeriod.Seconds())
+	logger, _ := log.NewTest()
+
+	code, err := mfa.GenerateTOTP(rfcSecret, now)
+	require.NoError(t, err)
+
+	challenge := mfa.Challenge{
+		ID:       "fake-challenge-id",
+		UserID:   "fake-user-id",
+		Role:     "fake-role",
+		TenantID: "fake-tenant-id",
+	}
+	enrollment := mfa.Enrollment{
+		ID:        "fake-enrollment-id",
+		UserID:    "fake-user-id",
+		Role:      "fake-role",
+		Secret:    rfcSecret,
+		Confirmed: true,
+	}
+	owner := mfa.VerifyChallengeOutput{
+		UserID:   "fake-user-id",
+		Role:     "fake-role",
+		TenantID: "fake-tenant-id",
+	}
+
+	tests := []mfaServiceTestCase[mfa.VerifyChallengeInput, mfa.VerifyChallengeOutput]{
+		{
+			name:  "when the challenge is not found, then it returns a challenge not found error",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role", Code: code},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), mfa.FindActiveChallengeParams{
+					TokenHash:   hashToken("fake-token"),
+					Role:        "fake-role",
+					MaxAttempts: mfa.MaxChallengeAttempts,
+				}).Return(mfa.Challenge{}, mfa.ErrChallengeNotFound)
+			},
+			want:    mfa.VerifyChallengeOutput{},
+			wantErr: mfa.ErrChallengeNotFound,
+		},
+		{
+			name:  "when there is an error finding the enrollment, then it propagates the error",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role", Code: code},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), gomock.Any()).Return(challenge, nil)
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(mfa.Enrollment{}, errRepo)
+			},
+			want:    mfa.VerifyChallengeOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when no code is provided, then it registers a failed attempt and returns an invalid code error",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role"},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), gomock.Any()).Return(challenge, nil)
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(enrollment, nil)
+				repo.EXPECT().RegisterFailedAttempt(gomock.Any(), "fake-challenge-id").Return(nil)
+			},
+			want:    mfa.VerifyChallengeOutput{},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "when the TOTP code is not valid, " +
+				"then it registers a failed attempt and returns an invalid code error",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role", Code: "000000"},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), gomock.Any()).Return(challenge, nil)
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(enrollment, nil)
+				repo.EXPECT().RegisterFailedAttempt(gomock.Any(), "fake-challenge-id").Return(nil)
+			},
+			want:    mfa.VerifyChallengeOutput{},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "when the TOTP code was already used, " +
+				"then it registers a failed attempt and returns an invalid code error",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role", Code: code},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), gomock.Any()).Return(challenge, nil)
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(enrollment, nil)
+				repo.EXPECT().UseTimeStep(gomock.Any(), gomock.Any()).Return(mfa.ErrTimeStepAlreadyUsed)
+				repo.EXPECT().RegisterFailedAttempt(gomock.Any(), "fake-challenge-id").Return(nil)
+			},
+			want:    mfa.VerifyChallengeOutput{},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "when the recovery code is not found, " +
+				"then it registers a failed attempt and returns an invalid code error",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role", RecoveryCode: "abcde-fghij"},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), gomock.Any()).Return(challenge, nil)
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(enrollment, nil)
+				repo.EXPECT().ConsumeRecoveryCode(gomock.Any(), gomock.Any()).Return(mfa.ErrRecoveryCodeNotFound)
+				repo.EXPECT().RegisterFailedAttempt(gomock.Any(), "fake-challenge-id").Return(nil)
+			},
+			want:    mfa.VerifyChallengeOutput{},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name:  "when there is an error registering the failed attempt, then it propagates the error",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role", Code: "000000"},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), gomock.Any()).Return(challenge, nil)
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(enrollment, nil)
+				repo.EXPECT().RegisterFailedAttempt(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    mfa.VerifyChallengeOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error consuming the challenge, then it propagates the error",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role", Code: code},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), gomock.Any()).Return(challenge, nil)
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(enrollment, nil)
+				repo.EXPECT().UseTimeStep(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().ConsumeChallenge(gomock.Any(), gomock.Any()).Return(mfa.ErrChallengeNotFound)
+			},
+			want:    mfa.VerifyChallengeOutput{},
+			wantErr: mfa.ErrChallengeNotFound,
+		},
+		{
+			name: "when the TOTP code is valid, then it marks its time step as used, " +
+				"consumes the challenge and returns its owner",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role", Code: code},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), gomock.Any()).Return(challenge, nil)
+				repo.EXPECT().FindEnrollment(gomock.Any(), mfa.FindEnrollmentParams{
+					UserID: "fake-user-id",
+					Role:   "fake-role",
+				}).Return(enrollment, nil)
+				repo.EXPECT().UseTimeStep(gomock.Any(), mfa.UseTimeStepParams{
+					ID:   "fake-enrollment-id",
+					Step: step,
+				}).Return(nil)
+				repo.EXPECT().ConsumeChallenge(gomock.Any(), "fake-challenge-id").Return(nil)
+			},
+			want:    owner,
+			wantErr: nil,
+		},
+		{
+			name: "when the recovery code is valid, then it consumes the recovery code and the challenge " +
+				"and returns its owner",
+			input: mfa.VerifyChallengeInput{Token: "fake-token", Role: "fake-role", RecoveryCode: "ABCDE FGHIJ"},
+			mocksSetup: func(repo *mfamocks.MockRepository) {
+				repo.EXPECT().FindActiveChallenge(gomock.Any(), gomock.Any()).Return(challenge, nil)
+				repo.EXPECT().FindEnrollment(gomock.Any(), gomock.Any()).Return(enrollment, nil)
+				repo.EXPECT().ConsumeRecoveryCode(gomock.Any(), mfa.ConsumeRecoveryCodeParams{
+					ID:       "fake-enrollment-id",
+					CodeHash: hashRecoveryCode("abcde-fghij"),
+				}).Return(nil)
+				repo.EXPECT().ConsumeChallenge(gomock.Any(), "fake-challenge-id").Return(nil)
+			},
+			want:    owner,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.VerifyChallenge(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// hashRecoveryCode hashes a recovery code as the service does, once its formatting is removed.
+func hashRecoveryCode(code string) string {
+	return hashToken(strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code)))
+}
+
+func serviceSetup(
+	t *testing.T,
+	logger log.Logger,
+	now time.Time,
+	mocksSetup func(repo *mfamocks.MockRepository),
+) (mfa.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	repo := mfamocks.NewMockRepository(ctrl)
+
+	if mocksSetup != nil {
+		mocksSetup(repo)
+	}
+
+	service := mfa.NewService(logger, repo, clock.FixedClock{FixedTime: now}, testIssuer)
+	return service, func() {
+		ctrl.Finish()
+	}
+}
@@ -0,0 +1,100 @@
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // HMAC-SHA1 is the algorithm defined by RFC 6238 and supported by every authenticator app
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// TOTPPeriod defines how long each TOTP code is valid.
+	TOTPPeriod = 30 * time.Second
+	// TOTPDigits defines the number of digits of the TOTP codes.
+	TOTPDigits = 6
+	// TOTPSkew defines how many periods before and after the current one are accepted, to tolerate clock drifts
+	// between the server and the authenticator apps.
+	TOTPSkew = 1
+	// SecretLength defines the number of random bytes of the TOTP secrets, as recommended by RFC 4226.
+	SecretLength = 20
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret generates a new random TOTP secret, encoded in base32 as expected by the authenticator apps.
+func GenerateSecret() (string, error) {
+	b := make([]byte, SecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretEncoding.EncodeToString(b), nil
+}
+
+// ProvisioningURI builds the otpauth URI used by the authenticator apps to register the secret, usually shown as a
+// QR code.
+func ProvisioningURI(issuer, accountName, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", TOTPDigits))
+	params.Set("period", fmt.Sprintf("%d", int(TOTPPeriod.Seconds())))
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// GenerateTOTP generates the TOTP code of the given secret for the period containing t, following RFC 6238.
+func GenerateTOTP(secret string, t time.Time) (string, error) {
+	return generateCode(secret, timeStep(t))
+}
+
+// validateTOTP checks the code against the periods around t. It returns the time step matching the code, so it can be
+// marked as used.
+func validateTOTP(secret, code string, t time.Time) (int64, bool) {
+	current := timeStep(t)
+	for step := current - TOTPSkew; step <= current+TOTPSkew; step++ {
+		expected, err := generateCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func timeStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod.Seconds())
+}
+
+func generateCode(secret string, step int64) (string, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation, as described in RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod), nil
+}
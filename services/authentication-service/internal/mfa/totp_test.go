@@ -0,0 +1,57 @@
+//go:build unit
+
+package mfa_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa"
+)
+
+// rfcSecret is the base32 encoding of the "12345678901234567890" secret used by the RFC 6238 test vectors.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTP(t *testing.T) {
+	// Test vectors from RFC 6238, Appendix B, truncated to 6 digits
+	tests := []struct {
+		name string
+		time time.Time
+		want string
+	}{
+		{name: "at 59 seconds", time: time.Unix(59, 0), want: "287082"},
+		{name: "at 1111111109 seconds", time: time.Unix(1111111109, 0), want: "081804"},
+		{name: "at 1111111111 seconds", time: time.Unix(1111111111, 0), want: "050471"},
+		{name: "at 1234567890 seconds", time: time.Unix(1234567890, 0), want: "005924"},
+		{name: "at 2000000000 seconds", time: time.Unix(2000000000, 0), want: "279037"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mfa.GenerateTOTP(rfcSecret, tt.time)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerateTOTP_InvalidSecret(t *testing.T) {
+	_, err := mfa.GenerateTOTP("not a base32 secret!", time.Unix(59, 0))
+
+	assert.Error(t, err)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	got := mfa.ProvisioningURI("Food Delivery", "test@example.com", rfcSecret)
+
+	assert.Equal(
+		t,
+		"otpauth://totp/Food%20Delivery:test@example.com?algorithm=SHA1&digits=6&issuer=Food+Delivery&period=30"+
+			"&secret="+rfcSecret,
+		got,
+	)
+}
@@ -0,0 +1,24 @@
+package notifier
+
+import (
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for delivering the notifications.
+type Config struct {
+	// FilePath is the file the notifications are appended to. When empty, the notifications are written to the logs.
+	FilePath string `env:"NOTIFIER_FILE_PATH"`
+}
+
+// LoadConfig loads the notifier configuration from environment variables and logs any errors encountered during
+// parsing. It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load notifier configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
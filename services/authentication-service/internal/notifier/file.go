@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+type fileNotifier struct {
+	logger log.Logger
+	path   string
+	mu     sync.Mutex
+}
+
+// NewFileNotifier creates a Notifier that appends the notifications to the given file, one JSON document per line.
+func NewFileNotifier(logger log.Logger, path string) Notifier {
+	return &fileNotifier{logger: logger, path: path}
+}
+
+// fileNotification represents a notification as it is written to the file.
+type fileNotification struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+func (n *fileNotifier) SendPasswordReset(ctx context.Context, notification PasswordResetNotification) error {
+	return n.write(ctx, fileNotification{Type: "password_reset", Data: notification})
+}
+
+func (n *fileNotifier) SendMagicLink(ctx context.Context, notification MagicLinkNotification) error {
+	return n.write(ctx, fileNotification{Type: "magic_link", Data: notification})
+}
+
+func (n *fileNotifier) write(ctx context.Context, notification fileNotification) error {
+	logger := n.logger.WithContext(ctx)
+
+	data, err := json.Marshal(notification)
+	if err != nil {
+		logger.Error("Failed to encode notification", err)
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		logger.Error("Failed to open notifications file", err)
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		logger.Error("Failed to write notification", err)
+		return err
+	}
+	return nil
+}
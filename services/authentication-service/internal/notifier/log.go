@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+type logNotifier struct {
+	logger log.Logger
+}
+
+// NewLogNotifier creates a Notifier that writes the notifications to the logs. The notifications contain secrets,
+// such as the password reset and the login tokens, so it must never be used in production.
+func NewLogNotifier(logger log.Logger) Notifier {
+	return &logNotifier{logger: logger}
+}
+
+func (n *logNotifier) SendPasswordReset(ctx context.Context, notification PasswordResetNotification) error {
+	n.logger.WithContext(ctx).Info(
+		"Password reset notification",
+		log.Field{Key: "email", Value: notification.Email},
+		log.Field{Key: "role", Value: notification.Role},
+		log.Field{Key: "tenant_id", Value: notification.TenantID},
+		log.Field{Key: "token", Value: notification.Token},
+		log.Field{Key: "expires_at", Value: notification.ExpiresAt},
+	)
+	return nil
+}
+
+func (n *logNotifier) SendMagicLink(ctx context.Context, notification MagicLinkNotification) error {
+	n.logger.WithContext(ctx).Info(
+		"Magic link notification",
+		log.Field{Key: "email", Value: notification.Email},
+		log.Field{Key: "role", Value: notification.Role},
+		log.Field{Key: "tenant_id", Value: notification.TenantID},
+		log.Field{Key: "token", Value: notification.Token},
+		log.Field{Key: "expires_at", Value: notification.ExpiresAt},
+	)
+	return nil
+}
@@ -0,0 +1,35 @@
+// Package notifier provides the delivery of the notifications sent to the users by the authentication service, such
+// as the password reset and the magic login links. The implementations in this package are meant for local environments, where no real
+// delivery channel is available.
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+// Notifier defines the interface for delivering notifications to the users.
+//
+//go:generate mockgen -destination=./mocks/notifier_mock.go -package=notifier_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/notifier Notifier
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, notification PasswordResetNotification) error
+	SendMagicLink(ctx context.Context, notification MagicLinkNotification) error
+}
+
+// PasswordResetNotification represents the details needed by a user to reset their password.
+type PasswordResetNotification struct {
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	TenantID  string    `json:"tenant_id,omitempty"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MagicLinkNotification represents the details needed by a user to log in without their password.
+type MagicLinkNotification struct {
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	TenantID  string    `json:"tenant_id,omitempty"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
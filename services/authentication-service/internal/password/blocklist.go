@@ -0,0 +1,126 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// blocklistFalsePositiveRate is the probability of a password being reported as blocked without being in the list.
+const blocklistFalsePositiveRate = 0.001
+
+//go:embed common_passwords.txt
+var commonPasswords string
+
+// Blocklist defines a set of passwords that are too common, or known to be breached, to be used.
+type Blocklist interface {
+	Contains(password string) bool
+}
+
+// BloomFilter is a compact Blocklist backed by a bloom filter. It never misses a listed password, but it may report,
+// with a very low probability, a password that is not listed. The passwords are compared regardless of the case.
+type BloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// NewBloomFilter creates an empty BloomFilter sized for the expected number of passwords.
+func NewBloomFilter(expected int) *BloomFilter {
+	n := float64(max(expected, 1))
+	size := uint64(math.Ceil(-n * math.Log(blocklistFalsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(max(math.Round(float64(size)/n*math.Ln2), 1))
+
+	return &BloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// Add adds the password to the filter.
+func (f *BloomFilter) Add(password string) {
+	h1, h2 := bloomHashes(password)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// Contains reports whether the password was added to the filter.
+func (f *BloomFilter) Contains(password string) bool {
+	h1, h2 := bloomHashes(password)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AddFrom adds every password read from r, one per line. Empty lines are ignored.
+func (f *BloomFilter) AddFrom(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			f.Add(line)
+		}
+	}
+	return scanner.Err()
+}
+
+// LoadBlocklist creates a BloomFilter with the bundled list of common passwords and, when a path is provided, the
+// passwords listed in that file, one per line.
+func LoadBlocklist(path string) (*BloomFilter, error) {
+	expected := countLines(strings.NewReader(commonPasswords))
+
+	var file *os.File
+	if path != "" {
+		var err error
+		if file, err = os.Open(path); err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+
+		// The file is read twice to size the filter, instead of keeping the whole list in memory
+		expected += countLines(file)
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	filter := NewBloomFilter(expected)
+	if err := filter.AddFrom(strings.NewReader(commonPasswords)); err != nil {
+		return nil, err
+	}
+	if file != nil {
+		if err := filter.AddFrom(file); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+func countLines(r io.Reader) int {
+	count := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		count++
+	}
+	return count
+}
+
+// bloomHashes returns the two hashes of the normalized password the filter positions are derived from.
+func bloomHashes(password string) (uint64, uint64) {
+	sum := sha256.Sum256([]byte(strings.ToLower(password)))
+	// The second hash must be odd, so the derived positions don't collapse into a single one
+	return binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16]) | 1
+}
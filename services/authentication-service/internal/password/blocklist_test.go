@@ -0,0 +1,54 @@
+//go:build unit
+
+package password_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+)
+
+func TestBloomFilter(t *testing.T) {
+	filter := password.NewBloomFilter(3)
+	require.NoError(t, filter.AddFrom(strings.NewReader("first-password\n\n  second-password  \nThird-Password\n")))
+
+	assert.True(t, filter.Contains("first-password"))
+	assert.True(t, filter.Contains("second-password"))
+	assert.True(t, filter.Contains("third-password"), "The passwords should be compared regardless of the case")
+	assert.False(t, filter.Contains("ValidPassword123"))
+}
+
+func TestLoadBlocklist(t *testing.T) {
+	t.Run("when no path is provided, then it should only contain the bundled passwords", func(t *testing.T) {
+		blocklist, err := password.LoadBlocklist("")
+		require.NoError(t, err)
+
+		assert.True(t, blocklist.Contains("password123"))
+		assert.True(t, blocklist.Contains("Qwerty123"))
+		assert.False(t, blocklist.Contains("ValidPassword123"))
+	})
+
+	t.Run("when a path is provided, then it should contain the passwords of the file too", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "blocklist.txt")
+		require.NoError(t, os.WriteFile(path, []byte("breached-password-1\nbreached-password-2\n"), 0o600))
+
+		blocklist, err := password.LoadBlocklist(path)
+		require.NoError(t, err)
+
+		assert.True(t, blocklist.Contains("password123"))
+		assert.True(t, blocklist.Contains("breached-password-1"))
+		assert.True(t, blocklist.Contains("breached-password-2"))
+		assert.False(t, blocklist.Contains("ValidPassword123"))
+	})
+
+	t.Run("when the file does not exist, then it should return an error", func(t *testing.T) {
+		_, err := password.LoadBlocklist(filepath.Join(t.TempDir(), "missing.txt"))
+		assert.Error(t, err)
+	})
+}
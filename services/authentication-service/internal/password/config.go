@@ -0,0 +1,52 @@
+package password
+
+import (
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for the password hashing and the password policy.
+type Config struct {
+	// Memory is the amount of memory, in KiB, used by the Argon2id algorithm.
+	Memory uint32 `env:"PASSWORD_ARGON2_MEMORY" envDefault:"65536"`
+	// Iterations is the number of passes over the memory of the Argon2id algorithm.
+	Iterations uint32 `env:"PASSWORD_ARGON2_ITERATIONS" envDefault:"3"`
+	// Parallelism is the number of threads used by the Argon2id algorithm.
+	Parallelism uint8 `env:"PASSWORD_ARGON2_PARALLELISM" envDefault:"2"`
+
+	// MinLength is the minimum number of characters of the passwords.
+	MinLength int `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	// MaxLength is the maximum number of characters of the passwords.
+	MaxLength int `env:"PASSWORD_MAX_LENGTH" envDefault:"128"`
+	// MinCharacterClasses is the minimum number of character classes, among uppercase letters, lowercase letters,
+	// digits and symbols, the passwords must contain.
+	MinCharacterClasses int  `env:"PASSWORD_MIN_CHARACTER_CLASSES" envDefault:"2"`
+	RequireUppercase    bool `env:"PASSWORD_REQUIRE_UPPERCASE" envDefault:"false"`
+	RequireLowercase    bool `env:"PASSWORD_REQUIRE_LOWERCASE" envDefault:"false"`
+	RequireDigit        bool `env:"PASSWORD_REQUIRE_DIGIT" envDefault:"false"`
+	RequireSymbol       bool `env:"PASSWORD_REQUIRE_SYMBOL" envDefault:"false"`
+	// BlocklistPath is the path of a file with common or breached passwords, one per line, rejected on top of the
+	// bundled list.
+	BlocklistPath string `env:"PASSWORD_BLOCKLIST_PATH"`
+}
+
+// Params returns the Argon2id cost parameters defined by the configuration.
+func (c Config) Params() Params {
+	return Params{
+		Memory:      c.Memory,
+		Iterations:  c.Iterations,
+		Parallelism: c.Parallelism,
+	}
+}
+
+// LoadConfig loads the password configuration from environment variables and logs any errors encountered
+// during parsing. It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load password configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
@@ -13,22 +13,48 @@ import (
 	"golang.org/x/crypto/argon2"
 )
 
-// These constants define the parameters for the Argon2id algorithm
+// These constants define the fixed parameters for the Argon2id algorithm
 const (
-	memory      = 64 * 1024 // 64MB
-	iterations  = 3
-	parallelism = 2
-	saltLength  = 16
-	keyLength   = 32
+	saltLength = 16
+	keyLength  = 32
 )
 
+// DefaultParams defines the cost parameters used for the Argon2id algorithm unless others are configured.
+var DefaultParams = Params{
+	Memory:      64 * 1024, // 64MB
+	Iterations:  3,
+	Parallelism: 2,
+}
+
+// currentParams are the cost parameters new hashes are created with.
+var currentParams = DefaultParams
+
 var (
 	// ErrInvalidHash indicates that the provided hash string is not in the correct format
 	ErrInvalidHash = errors.New("invalid hash format")
 	// ErrIncompatibleVersion indicates that the hash was created with an incompatible version
 	ErrIncompatibleVersion = errors.New("incompatible version of argon2")
+	// ErrInvalidParams indicates that the provided cost parameters are not valid for the Argon2id algorithm
+	ErrInvalidParams = errors.New("invalid argon2 parameters")
 )
 
+// Params represents the cost parameters of the Argon2id algorithm. Memory is expressed in KiB.
+type Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// SetParams sets the cost parameters new hashes are created with. The hashes created with weaker parameters are
+// reported by NeedsRehash. It is meant to be called once at startup, before any password is hashed.
+func SetParams(p Params) error {
+	if p.Memory == 0 || p.Iterations == 0 || p.Parallelism == 0 {
+		return ErrInvalidParams
+	}
+	currentParams = p
+	return nil
+}
+
 type params struct {
 	memory      uint32
 	iterations  uint32
@@ -37,8 +63,14 @@ type params struct {
 	keyLength   uint32
 }
 
-// Hash generates a bcrypt hashed version of the input password. Returns the hashed string or an error if hashing fails.
+// Hash generates an Argon2id hashed version of the input password with the current parameters. Returns the hashed
+// string or an error if hashing fails.
 func Hash(password string) (string, error) {
+	return HashWithParams(password, currentParams)
+}
+
+// HashWithParams generates an Argon2id hashed version of the input password with the given parameters.
+func HashWithParams(password string, p Params) (string, error) {
 	// Generate a random salt
 	salt := make([]byte, saltLength)
 	if _, err := rand.Read(salt); err != nil {
@@ -49,9 +81,9 @@ func Hash(password string) (string, error) {
 	hash := argon2.IDKey(
 		[]byte(password),
 		salt,
-		iterations,
-		memory,
-		parallelism,
+		p.Iterations,
+		p.Memory,
+		p.Parallelism,
 		keyLength,
 	)
 
@@ -63,15 +95,14 @@ func Hash(password string) (string, error) {
 	encodedHash := fmt.Sprintf(
 		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
 		argon2.Version,
-		memory,
-		iterations,
-		parallelism,
+		p.Memory,
+		p.Iterations,
+		p.Parallelism,
 		b64Salt,
 		b64Hash,
 	)
 
 	return encodedHash, nil
-
 }
 
 // Verify checks if the provided password matches the hashed password.
@@ -89,13 +120,28 @@ func Verify(hash, password string) bool {
 		params.iterations,
 		params.memory,
 		params.parallelism,
-		keyLength,
+		params.keyLength,
 	)
 
 	// Compare the hashes in constant time
 	return subtle.ConstantTimeCompare(key, otherKey) == 1
 }
 
+// NeedsRehash reports whether the hash was created with weaker parameters than the current ones, so the password
+// should be hashed again. Hashes that cannot be decoded always need to be rehashed.
+func NeedsRehash(hash string) bool {
+	params, _, _, err := decodeHash(hash)
+	if err != nil {
+		return true
+	}
+
+	return params.memory < currentParams.Memory ||
+		params.iterations < currentParams.Iterations ||
+		params.parallelism < currentParams.Parallelism ||
+		params.saltLength < saltLength ||
+		params.keyLength < keyLength
+}
+
 func decodeHash(encodedHash string) (p *params, salt, key []byte, err error) {
 	parts := strings.Split(encodedHash, "$")
 	if len(parts) != 6 {
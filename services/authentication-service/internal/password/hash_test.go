@@ -0,0 +1,114 @@
+//go:build unit
+
+package password_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+)
+
+func TestHash(t *testing.T) {
+	hash, err := password.Hash("ValidPassword123")
+	require.NoError(t, err)
+
+	assert.True(t, password.Verify(hash, "ValidPassword123"), "The password should match its hash")
+	assert.False(t, password.Verify(hash, "InvalidPassword123"), "Another password should not match the hash")
+	assert.False(t, password.NeedsRehash(hash), "A hash with the current parameters should not need a rehash")
+}
+
+func TestNeedsRehash(t *testing.T) {
+	defer func() {
+		require.NoError(t, password.SetParams(password.DefaultParams))
+	}()
+	require.NoError(t, password.SetParams(password.Params{Memory: 16 * 1024, Iterations: 2, Parallelism: 2}))
+
+	tests := []struct {
+		name   string
+		params password.Params
+		want   bool
+	}{
+		{
+			name:   "when the hash uses the current parameters, then it should not need a rehash",
+			params: password.Params{Memory: 16 * 1024, Iterations: 2, Parallelism: 2},
+			want:   false,
+		},
+		{
+			name:   "when the hash uses stronger parameters, then it should not need a rehash",
+			params: password.Params{Memory: 32 * 1024, Iterations: 3, Parallelism: 4},
+			want:   false,
+		},
+		{
+			name:   "when the hash uses less memory, then it should need a rehash",
+			params: password.Params{Memory: 8 * 1024, Iterations: 2, Parallelism: 2},
+			want:   true,
+		},
+		{
+			name:   "when the hash uses fewer iterations, then it should need a rehash",
+			params: password.Params{Memory: 16 * 1024, Iterations: 1, Parallelism: 2},
+			want:   true,
+		},
+		{
+			name:   "when the hash uses less parallelism, then it should need a rehash",
+			params: password.Params{Memory: 16 * 1024, Iterations: 2, Parallelism: 1},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := password.HashWithParams("ValidPassword123", tt.params)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, password.NeedsRehash(hash))
+			assert.True(t, password.Verify(hash, "ValidPassword123"), "The hash should remain verifiable")
+		})
+	}
+
+	t.Run("when the hash cannot be decoded, then it should need a rehash", func(t *testing.T) {
+		assert.True(t, password.NeedsRehash("invalid-hash"))
+	})
+}
+
+func TestSetParams(t *testing.T) {
+	defer func() {
+		require.NoError(t, password.SetParams(password.DefaultParams))
+	}()
+
+	tests := []struct {
+		name    string
+		params  password.Params
+		wantErr error
+	}{
+		{
+			name:    "when the memory is zero, then it should return an invalid params error",
+			params:  password.Params{Memory: 0, Iterations: 1, Parallelism: 1},
+			wantErr: password.ErrInvalidParams,
+		},
+		{
+			name:    "when the iterations are zero, then it should return an invalid params error",
+			params:  password.Params{Memory: 8 * 1024, Iterations: 0, Parallelism: 1},
+			wantErr: password.ErrInvalidParams,
+		},
+		{
+			name:    "when the parallelism is zero, then it should return an invalid params error",
+			params:  password.Params{Memory: 8 * 1024, Iterations: 1, Parallelism: 0},
+			wantErr: password.ErrInvalidParams,
+		},
+		{
+			name:    "when the parameters are valid, then it should not return an error",
+			params:  password.Params{Memory: 8 * 1024, Iterations: 1, Parallelism: 1},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := password.SetParams(tt.params)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
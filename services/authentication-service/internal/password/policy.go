@@ -0,0 +1,160 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrPolicyViolation indicates that the password does not meet the password policy. The violated rules are
+// available through PolicyError.
+var ErrPolicyViolation = errors.New("password does not meet the policy")
+
+// PolicyError represents the rules of the password policy a password violates.
+type PolicyError struct {
+	Violations []string
+}
+
+// Error returns the violated rules of the password policy.
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrPolicyViolation.Error(), strings.Join(e.Violations, ", "))
+}
+
+// Is reports whether the target is ErrPolicyViolation, so the error can be matched with errors.Is.
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrPolicyViolation
+}
+
+// Details returns the violated rules of the password policy, phrased for the given request field.
+func (e *PolicyError) Details(field string) []string {
+	details := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		details = append(details, field+" "+v)
+	}
+	return details
+}
+
+// Policy represents the rules a password must meet. The zero value of each rule disables it.
+type Policy struct {
+	// MinLength is the minimum number of characters of the password.
+	MinLength int
+	// MaxLength is the maximum number of characters of the password.
+	MaxLength int
+	// MinCharacterClasses is the minimum number of character classes, among uppercase letters, lowercase letters,
+	// digits and symbols, the password must contain.
+	MinCharacterClasses int
+	RequireUppercase    bool
+	RequireLowercase    bool
+	RequireDigit        bool
+	RequireSymbol       bool
+	// Blocklist contains the common or breached passwords that are rejected.
+	Blocklist Blocklist
+}
+
+// NewPolicy creates the password policy defined by the configuration, loading its blocklist.
+func NewPolicy(cfg Config) (Policy, error) {
+	blocklist, err := LoadBlocklist(cfg.BlocklistPath)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	return Policy{
+		MinLength:           cfg.MinLength,
+		MaxLength:           cfg.MaxLength,
+		MinCharacterClasses: cfg.MinCharacterClasses,
+		RequireUppercase:    cfg.RequireUppercase,
+		RequireLowercase:    cfg.RequireLowercase,
+		RequireDigit:        cfg.RequireDigit,
+		RequireSymbol:       cfg.RequireSymbol,
+		Blocklist:           blocklist,
+	}, nil
+}
+
+// Validate checks the password against every rule of the policy. The email of the account the password belongs to
+// is optional, and when provided the password must not contain it. It returns a *PolicyError with all the violated
+// rules, or nil if the password meets the policy.
+func (p Policy) Validate(password, email string) error {
+	var violations []string
+
+	length := utf8.RuneCountInString(password)
+	if p.MinLength > 0 && length < p.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, fmt.Sprintf("must not exceed %d characters long", p.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, "must contain at least one uppercase letter")
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, "must contain at least one lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain at least one digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain at least one symbol")
+	}
+	if classes := countTrue(hasUpper, hasLower, hasDigit, hasSymbol); classes < p.MinCharacterClasses {
+		violations = append(violations, fmt.Sprintf(
+			"must contain at least %d of uppercase letters, lowercase letters, digits and symbols",
+			p.MinCharacterClasses,
+		))
+	}
+
+	if containsEmail(password, email) {
+		violations = append(violations, "must not contain the email")
+	}
+	if p.Blocklist != nil && p.Blocklist.Contains(password) {
+		violations = append(violations, "is too common")
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// containsEmail reports whether the password contains the email, or its local part, regardless of the case.
+func containsEmail(password, email string) bool {
+	if email == "" {
+		return false
+	}
+
+	password = strings.ToLower(password)
+	email = strings.ToLower(email)
+	if strings.Contains(password, email) {
+		return true
+	}
+
+	// Very short local parts would reject many legit passwords, so they are ignored
+	localPart, _, _ := strings.Cut(email, "@")
+	return len(localPart) >= 3 && strings.Contains(password, localPart)
+}
+
+func countTrue(values ...bool) int {
+	count := 0
+	for _, v := range values {
+		if v {
+			count++
+		}
+	}
+	return count
+}
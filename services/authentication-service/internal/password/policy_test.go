@@ -0,0 +1,125 @@
+//go:build unit
+
+package password_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	blocklist := password.NewBloomFilter(1)
+	blocklist.Add("Password123!")
+
+	policy := password.Policy{
+		MinLength:           8,
+		MaxLength:           16,
+		MinCharacterClasses: 3,
+		Blocklist:           blocklist,
+	}
+
+	tests := []struct {
+		name           string
+		policy         password.Policy
+		password       string
+		email          string
+		wantViolations []string
+	}{
+		{
+			name:     "when the password meets the policy, then it should return no error",
+			policy:   policy,
+			password: "ValidPassword1",
+			email:    "test@example.com",
+		},
+		{
+			name:           "when the password is too short, then it should return the length violation",
+			policy:         policy,
+			password:       "Short1",
+			wantViolations: []string{"must be at least 8 characters long"},
+		},
+		{
+			name:           "when the password is too long, then it should return the length violation",
+			policy:         policy,
+			password:       "ValidPassword1234",
+			wantViolations: []string{"must not exceed 16 characters long"},
+		},
+		{
+			name:     "when the password has too few character classes, then it should return the classes violation",
+			policy:   policy,
+			password: "validpassword",
+			wantViolations: []string{
+				"must contain at least 3 of uppercase letters, lowercase letters, digits and symbols",
+			},
+		},
+		{
+			name: "when the password misses required character classes, then it should return each violation",
+			policy: password.Policy{
+				RequireUppercase: true,
+				RequireLowercase: true,
+				RequireDigit:     true,
+				RequireSymbol:    true,
+			},
+			password: "validpassword",
+			wantViolations: []string{
+				"must contain at least one uppercase letter",
+				"must contain at least one digit",
+				"must contain at least one symbol",
+			},
+		},
+		{
+			name:           "when the password contains the email, then it should return the email violation",
+			policy:         policy,
+			password:       "Test@Example.com1",
+			email:          "test@example.com",
+			wantViolations: []string{"must not exceed 16 characters long", "must not contain the email"},
+		},
+		{
+			name:           "when the password contains the email local part, then it should return the email violation",
+			policy:         policy,
+			password:       "MyTestPassword1",
+			email:          "test@example.com",
+			wantViolations: []string{"must not contain the email"},
+		},
+		{
+			name:     "when the email local part is too short, then it should not be checked",
+			policy:   policy,
+			password: "MyJoPassword1",
+			email:    "jo@example.com",
+		},
+		{
+			name:           "when the password is blocked, then it should return the blocklist violation",
+			policy:         policy,
+			password:       "PASSWORD123!",
+			wantViolations: []string{"is too common"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(tt.password, tt.email)
+
+			if tt.wantViolations == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, password.ErrPolicyViolation)
+			var policyErr *password.PolicyError
+			require.ErrorAs(t, err, &policyErr)
+			assert.Equal(t, tt.wantViolations, policyErr.Violations)
+		})
+	}
+}
+
+func TestPolicyError_Details(t *testing.T) {
+	err := &password.PolicyError{Violations: []string{"must be at least 8 characters long", "is too common"}}
+
+	assert.Equal(t, []string{
+		"new_password must be at least 8 characters long",
+		"new_password is too common",
+	}, err.Details("new_password"))
+}
@@ -0,0 +1,9 @@
+package passwordreset
+
+import "errors"
+
+var (
+	// ErrResetTokenNotFound indicates that the specified password reset token could not be found, or it can no longer
+	// be used.
+	ErrResetTokenNotFound = errors.New("password reset token not found")
+)
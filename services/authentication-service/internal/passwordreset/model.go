@@ -0,0 +1,16 @@
+package passwordreset
+
+import "time"
+
+// Token represents a single-use token that allows a user to set a new password without knowing the current one.
+// Only the TokenHash is stored, and UsedAt is set once the token has been consumed.
+type Token struct {
+	ID        string     `bson:"_id,omitempty"`
+	UserID    string     `bson:"user_id"`
+	Role      string     `bson:"role"`
+	TenantID  string     `bson:"tenant_id"`
+	TokenHash string     `bson:"token_hash"`
+	ExpiresAt time.Time  `bson:"expires_at"`
+	UsedAt    *time.Time `bson:"used_at,omitempty"`
+	CreatedAt time.Time  `bson:"created_at"`
+}
@@ -0,0 +1,170 @@
+package passwordreset
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// CollectionName defines the name of the database collection used to store password reset tokens.
+	CollectionName = "password_reset_tokens"
+
+	// FieldUserID represents the database field name for storing the ID of the token owner.
+	FieldUserID = "user_id"
+	// FieldRole represents the database field name for storing the role of the token owner.
+	FieldRole = "role"
+	// FieldTenantID represents the database field name for storing the tenant of the token owner.
+	FieldTenantID = "tenant_id"
+	// FieldTokenHash represents the database field name for storing the token digests.
+	FieldTokenHash = "token_hash"
+	// FieldExpiresAt represents the database field name for storing the expiration time of a token.
+	FieldExpiresAt = "expires_at"
+	// FieldUsedAt represents the database field name for storing when the token was consumed.
+	FieldUsedAt = "used_at"
+)
+
+// Repository defines a contract for storing and consuming password reset tokens in a persistence layer.
+//
+//go:generate mockgen -destination=./mocks/repository_mock.go -package=passwordreset_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset Repository
+type Repository interface {
+	Create(ctx context.Context, params CreateTokenParams) (Token, error)
+	Consume(ctx context.Context, params ConsumeTokenParams) (Token, error)
+	InvalidateAll(ctx context.Context, params InvalidateAllParams) (int64, error)
+}
+
+type repository struct {
+	logger     log.Logger
+	collection *mongo.Collection
+	clock      clock.Clock
+}
+
+// NewRepository creates a new Repository instance.
+func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repository {
+	return &repository{
+		logger:     logger,
+		collection: db.Collection(CollectionName),
+		clock:      clk,
+	}
+}
+
+// CreateTokenParams defines the parameters required to create a new password reset token for a user.
+type CreateTokenParams struct {
+	UserID    string
+	Role      string
+	TenantID  string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+func (r *repository) Create(ctx context.Context, params CreateTokenParams) (Token, error) {
+	logger := r.logger.WithContext(ctx)
+
+	token := Token{
+		UserID:    params.UserID,
+		Role:      params.Role,
+		TenantID:  params.TenantID,
+		TokenHash: params.TokenHash,
+		ExpiresAt: params.ExpiresAt,
+		CreatedAt: r.clock.Now(),
+	}
+
+	res, err := r.collection.InsertOne(ctx, token)
+	if err != nil {
+		logger.Error("Failed to store password reset token", err)
+		return Token{}, err
+	}
+
+	token.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	return token, nil
+}
+
+// ConsumeTokenParams defines the parameters needed to consume a password reset token.
+// Role restricts the consumption to the tokens issued for that role.
+type ConsumeTokenParams struct {
+	TokenHash string
+	Role      string
+}
+
+func (r *repository) Consume(ctx context.Context, params ConsumeTokenParams) (Token, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var token Token
+	now := r.clock.Now()
+	// The token is marked as used in the same operation it is found, so it can't be consumed twice
+	filter := bson.M{
+		FieldTokenHash: params.TokenHash,
+		FieldRole:      params.Role,
+		FieldUsedAt:    bson.M{"$exists": false},
+		FieldExpiresAt: bson.M{
+			"$gt": now,
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldUsedAt: now,
+		},
+	}
+
+	// Returning the updated document
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn("Password reset token not found")
+			return Token{}, ErrResetTokenNotFound
+		}
+		logger.Error("Failed to consume password reset token", err)
+		return Token{}, err
+	}
+	return token, nil
+}
+
+// InvalidateAllParams defines the parameters needed to invalidate all the pending password reset tokens of a user.
+// TenantID is empty for the non-tenant users, such as the customers.
+type InvalidateAllParams struct {
+	UserID   string
+	Role     string
+	TenantID string
+}
+
+func (r *repository) InvalidateAll(ctx context.Context, params InvalidateAllParams) (int64, error) {
+	logger := r.logger.WithContext(ctx)
+
+	now := r.clock.Now()
+	filter := bson.M{
+		FieldUserID:   params.UserID,
+		FieldRole:     params.Role,
+		FieldTenantID: params.TenantID,
+		FieldUsedAt:   bson.M{"$exists": false},
+		FieldExpiresAt: bson.M{
+			"$gt": now,
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldUsedAt: now,
+		},
+	}
+
+	res, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to invalidate password reset tokens", err)
+		return 0, err
+	}
+
+	logger.Info(
+		"Password reset tokens invalidated",
+		log.Field{Key: "user_id", Value: params.UserID},
+		log.Field{Key: "invalidated", Value: res.ModifiedCount},
+	)
+	return res.ModifiedCount, nil
+}
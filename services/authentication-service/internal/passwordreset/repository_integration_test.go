@@ -0,0 +1,375 @@
+//go:build integration
+
+package passwordreset_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset"
+)
+
+type passwordResetRepositoryTestCase[P, W any] struct {
+	name            string
+	insertDocuments func(t *testing.T, coll *mongo.Collection)
+	params          P
+	want            W
+	wantErr         error
+}
+
+func TestRepository_Create(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt = now.Add(30 * time.Minute)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []passwordResetRepositoryTestCase[passwordreset.CreateTokenParams, passwordreset.Token]{
+		{
+			name: "when the token is stored successfully, then it should return the stored token",
+			params: passwordreset.CreateTokenParams{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TenantID:  "fake-tenant-id",
+				TokenHash: "fake-token-hash",
+				ExpiresAt: expiresAt,
+			},
+			want: passwordreset.Token{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TenantID:  "fake-tenant-id",
+				TokenHash: "fake-token-hash",
+				ExpiresAt: expiresAt,
+				CreatedAt: now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestPasswordResetTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := passwordreset.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			token, err := repo.Create(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				// As the ID is generated by MongoDB, we just check that it is not empty
+				assert.NotEmpty(t, token.ID, "ID should not be empty")
+
+				tt.want.ID = token.ID
+				assert.Equal(t, tt.want, token)
+			}
+		})
+	}
+}
+
+func TestRepository_Create_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestPasswordResetTokenCollection(t, tdb.DB)
+
+	repo := passwordreset.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.Create(context.Background(), passwordreset.CreateTokenParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_Consume(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt = now.Add(30 * time.Minute)
+		expiredAt = now.Add(-time.Minute)
+		usedAt    = now.Add(-5 * time.Minute)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []passwordResetRepositoryTestCase[passwordreset.ConsumeTokenParams, passwordreset.Token]{
+		{
+			name: "when the token does not exist, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-other-token-hash",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params:  passwordreset.ConsumeTokenParams{TokenHash: "fake-token-hash", Role: "fake-role"},
+			want:    passwordreset.Token{},
+			wantErr: passwordreset.ErrResetTokenNotFound,
+		},
+		{
+			name: "when the token was issued for another role, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-other-role",
+					TokenHash: "fake-token-hash",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params:  passwordreset.ConsumeTokenParams{TokenHash: "fake-token-hash", Role: "fake-role"},
+			want:    passwordreset.Token{},
+			wantErr: passwordreset.ErrResetTokenNotFound,
+		},
+		{
+			name: "when the token is expired, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-token-hash",
+					ExpiresAt: expiredAt,
+					CreatedAt: now,
+				})
+			},
+			params:  passwordreset.ConsumeTokenParams{TokenHash: "fake-token-hash", Role: "fake-role"},
+			want:    passwordreset.Token{},
+			wantErr: passwordreset.ErrResetTokenNotFound,
+		},
+		{
+			name: "when the token was already used, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-token-hash",
+					ExpiresAt: expiresAt,
+					UsedAt:    &usedAt,
+					CreatedAt: now,
+				})
+			},
+			params:  passwordreset.ConsumeTokenParams{TokenHash: "fake-token-hash", Role: "fake-role"},
+			want:    passwordreset.Token{},
+			wantErr: passwordreset.ErrResetTokenNotFound,
+		},
+		{
+			name: "when the token is valid, then it should mark it as used and return it",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-token-hash",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params: passwordreset.ConsumeTokenParams{TokenHash: "fake-token-hash", Role: "fake-role"},
+			want: passwordreset.Token{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TenantID:  "fake-tenant-id",
+				TokenHash: "fake-token-hash",
+				ExpiresAt: expiresAt,
+				UsedAt:    &now,
+				CreatedAt: now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestPasswordResetTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := passwordreset.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			token, err := repo.Consume(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, token.ID, "ID should not be empty")
+
+				tt.want.ID = token.ID
+				assert.Equal(t, tt.want, token)
+
+				// A consumed token can't be consumed again
+				_, err = repo.Consume(context.Background(), tt.params)
+				assert.ErrorIs(t, err, passwordreset.ErrResetTokenNotFound)
+			}
+		})
+	}
+}
+
+func TestRepository_Consume_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestPasswordResetTokenCollection(t, tdb.DB)
+
+	repo := passwordreset.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.Consume(context.Background(), passwordreset.ConsumeTokenParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_InvalidateAll(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt = now.Add(30 * time.Minute)
+		expiredAt = now.Add(-time.Minute)
+		usedAt    = now.Add(-5 * time.Minute)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []passwordResetRepositoryTestCase[passwordreset.InvalidateAllParams, int64]{
+		{
+			name: "when the user has no pending tokens, then it should not invalidate any token",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-expired-token-hash",
+					ExpiresAt: expiredAt,
+					CreatedAt: now,
+				})
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-used-token-hash",
+					ExpiresAt: expiresAt,
+					UsedAt:    &usedAt,
+					CreatedAt: now,
+				})
+			},
+			params: passwordreset.InvalidateAllParams{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			want: 0,
+		},
+		{
+			name: "when the user has pending tokens, then it should invalidate only the ones of the user",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-token-hash-1",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "fake-token-hash-2",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+				mongodb.InsertTestDocument(t, coll, passwordreset.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-other-tenant-id",
+					TokenHash: "fake-token-hash-3",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params: passwordreset.InvalidateAllParams{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestPasswordResetTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := passwordreset.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			invalidated, err := repo.InvalidateAll(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, invalidated)
+
+			if tt.want > 0 {
+				count, err := coll.CountDocuments(context.Background(), bson.M{
+					passwordreset.FieldUserID:   tt.params.UserID,
+					passwordreset.FieldTenantID: tt.params.TenantID,
+					passwordreset.FieldUsedAt:   now,
+				})
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, count)
+			}
+		})
+	}
+}
+
+func TestRepository_InvalidateAll_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestPasswordResetTokenCollection(t, tdb.DB)
+
+	repo := passwordreset.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.InvalidateAll(context.Background(), passwordreset.InvalidateAllParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func setupTestPasswordResetTokenCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := db.Collection(passwordreset.CollectionName)
+
+	// Create unique index on the token digest
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: passwordreset.FieldTokenHash, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		t.Fatalf("Failed to create unique index: %v", err)
+	}
+
+	return coll
+}
@@ -0,0 +1,152 @@
+// Package passwordreset provides the functionality for issuing and consuming the single-use, time-limited tokens that
+// allow the users to set a new password when they forgot the current one.
+package passwordreset
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/notifier"
+)
+
+const (
+	// DefaultResetTokenLength defines the default length, in bytes, of a generated password reset token.
+	DefaultResetTokenLength = 32
+	// DefaultTokenExpiration specifies the default duration for which a password reset token remains valid.
+	DefaultTokenExpiration = 30 * time.Minute
+)
+
+// Service represents the core interface for password reset tokens.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=passwordreset_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset Service
+type Service interface {
+	RequestReset(ctx context.Context, input RequestResetInput) (RequestResetOutput, error)
+	Consume(ctx context.Context, input ConsumeInput) (ConsumeOutput, error)
+}
+
+type service struct {
+	logger   log.Logger
+	repo     Repository
+	notifier notifier.Notifier
+	clock    clock.Clock
+}
+
+// NewService initializes and returns a new Service implementation.
+func NewService(logger log.Logger, repo Repository, notifier notifier.Notifier, clk clock.Clock) Service {
+	return &service{logger: logger, repo: repo, notifier: notifier, clock: clk}
+}
+
+// RequestResetInput represents the input required to issue a password reset token and deliver it to the user.
+// TenantID is empty for the non-tenant users, such as the customers.
+type RequestResetInput struct {
+	UserID   string
+	Role     string
+	TenantID string
+	Email    string
+}
+
+// RequestResetOutput represents the result of a password reset request.
+type RequestResetOutput struct {
+	ExpiresAt time.Time
+}
+
+// RequestReset issues a new password reset token and sends it to the user. Only the latest issued token can be used,
+// so the pending ones are invalidated first.
+func (s *service) RequestReset(ctx context.Context, input RequestResetInput) (RequestResetOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	token, err := generateToken()
+	if err != nil {
+		logger.Error("failed to generate password reset token", err)
+		return RequestResetOutput{}, err
+	}
+
+	if _, err := s.repo.InvalidateAll(ctx, InvalidateAllParams{
+		UserID:   input.UserID,
+		Role:     input.Role,
+		TenantID: input.TenantID,
+	}); err != nil {
+		logger.Error("failed to invalidate pending password reset tokens", err)
+		return RequestResetOutput{}, err
+	}
+
+	expiresAt := s.clock.Now().Add(DefaultTokenExpiration)
+	if _, err := s.repo.Create(ctx, CreateTokenParams{
+		UserID:    input.UserID,
+		Role:      input.Role,
+		TenantID:  input.TenantID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		logger.Error("failed to store password reset token", err)
+		return RequestResetOutput{}, err
+	}
+
+	if err := s.notifier.SendPasswordReset(ctx, notifier.PasswordResetNotification{
+		Email:     input.Email,
+		Role:      input.Role,
+		TenantID:  input.TenantID,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		logger.Error("failed to send password reset notification", err)
+		return RequestResetOutput{}, err
+	}
+
+	logger.Info("password reset requested", log.Field{Key: "user_id", Value: input.UserID})
+	return RequestResetOutput{ExpiresAt: expiresAt}, nil
+}
+
+// ConsumeInput represents the input required to consume a password reset token.
+// Role must match the role the token was issued for.
+type ConsumeInput struct {
+	Token string
+	Role  string
+}
+
+// ConsumeOutput represents the owner of a consumed password reset token.
+type ConsumeOutput struct {
+	UserID   string
+	Role     string
+	TenantID string
+}
+
+func (s *service) Consume(ctx context.Context, input ConsumeInput) (ConsumeOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	token, err := s.repo.Consume(ctx, ConsumeTokenParams{
+		TokenHash: hashToken(input.Token),
+		Role:      input.Role,
+	})
+	if err != nil {
+		logger.Error("failed to consume password reset token", err)
+		return ConsumeOutput{}, err
+	}
+
+	return ConsumeOutput{
+		UserID:   token.UserID,
+		Role:     token.Role,
+		TenantID: token.TenantID,
+	}, nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, DefaultResetTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+// hashToken computes the digest the tokens are stored with. The tokens are long random values, so a plain SHA-256 is
+// enough to prevent them from being used if the database is leaked.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,223 @@
+//go:build unit
+
+package passwordreset_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/notifier"
+	notifiermocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/notifier/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset"
+	passwordresetmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset/mocks"
+)
+
+var (
+	errRepo     = errors.New("repository error")
+	errNotifier = errors.New("notifier error")
+)
+
+type passwordResetServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	mocksSetup func(repo *passwordresetmocks.MockRepository, ntf *notifiermocks.MockNotifier)
+	want       W
+	wantErr    error
+}
+
+func TestService_RequestReset(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(passwordreset.DefaultTokenExpiration)
+	logger, _ := log.NewTest()
+
+	input := passwordreset.RequestResetInput{
+		UserID:   "fake-user-id",
+		Role:     "fake-role",
+		TenantID: "fake-tenant-id",
+		Email:    "test@example.com",
+	}
+
+	tests := []passwordResetServiceTestCase[passwordreset.RequestResetInput, passwordreset.RequestResetOutput]{
+		{
+			name:  "when there is an error invalidating the pending tokens, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *passwordresetmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().InvalidateAll(gomock.Any(), gomock.Any()).Return(int64(0), errRepo)
+			},
+			want:    passwordreset.RequestResetOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error storing the token, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *passwordresetmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().InvalidateAll(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(passwordreset.Token{}, errRepo)
+			},
+			want:    passwordreset.RequestResetOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error sending the notification, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *passwordresetmocks.MockRepository, ntf *notifiermocks.MockNotifier) {
+				repo.EXPECT().InvalidateAll(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(passwordreset.Token{}, nil)
+				ntf.EXPECT().SendPasswordReset(gomock.Any(), gomock.Any()).Return(errNotifier)
+			},
+			want:    passwordreset.RequestResetOutput{},
+			wantErr: errNotifier,
+		},
+		{
+			name:  "when the reset is requested, then it stores the token digest and sends the token to the user",
+			input: input,
+			mocksSetup: func(repo *passwordresetmocks.MockRepository, ntf *notifiermocks.MockNotifier) {
+				var storedHash string
+				repo.EXPECT().InvalidateAll(gomock.Any(), passwordreset.InvalidateAllParams{
+					UserID:   "fake-user-id",
+					Role:     "fake-role",
+					TenantID: "fake-tenant-id",
+				}).Return(int64(1), nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params passwordreset.CreateTokenParams) (passwordreset.Token, error) {
+						require.Equal(t, "fake-user-id", params.UserID)
+						require.Equal(t, "fake-role", params.Role)
+						require.Equal(t, "fake-tenant-id", params.TenantID)
+						require.Equal(t, expiresAt, params.ExpiresAt)
+						require.NotEmpty(t, params.TokenHash)
+
+						storedHash = params.TokenHash
+						return passwordreset.Token{TokenHash: params.TokenHash}, nil
+					})
+				ntf.EXPECT().SendPasswordReset(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, notification notifier.PasswordResetNotification) error {
+						require.Equal(t, "test@example.com", notification.Email)
+						require.Equal(t, "fake-role", notification.Role)
+						require.Equal(t, "fake-tenant-id", notification.TenantID)
+						require.Equal(t, expiresAt, notification.ExpiresAt)
+						// Only the digest of the sent token must be stored
+						require.Equal(t, hashToken(notification.Token), storedHash)
+						return nil
+					})
+			},
+			want:    passwordreset.RequestResetOutput{ExpiresAt: expiresAt},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.RequestReset(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Consume(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	input := passwordreset.ConsumeInput{
+		Token: "fake-token",
+		Role:  "fake-role",
+	}
+
+	tests := []passwordResetServiceTestCase[passwordreset.ConsumeInput, passwordreset.ConsumeOutput]{
+		{
+			name:  "when the token can't be consumed, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *passwordresetmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().Consume(gomock.Any(), gomock.Any()).
+					Return(passwordreset.Token{}, passwordreset.ErrResetTokenNotFound)
+			},
+			want:    passwordreset.ConsumeOutput{},
+			wantErr: passwordreset.ErrResetTokenNotFound,
+		},
+		{
+			name:  "when there is an unexpected error consuming the token, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *passwordresetmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(passwordreset.Token{}, errRepo)
+			},
+			want:    passwordreset.ConsumeOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the token is consumed, then it returns the token owner",
+			input: input,
+			mocksSetup: func(repo *passwordresetmocks.MockRepository, _ *notifiermocks.MockNotifier) {
+				repo.EXPECT().Consume(gomock.Any(), passwordreset.ConsumeTokenParams{
+					TokenHash: hashToken("fake-token"),
+					Role:      "fake-role",
+				}).Return(passwordreset.Token{
+					ID:        "fake-id",
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: hashToken("fake-token"),
+					ExpiresAt: now.Add(time.Minute),
+					UsedAt:    &now,
+				}, nil)
+			},
+			want: passwordreset.ConsumeOutput{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.Consume(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func serviceSetup(
+	t *testing.T,
+	logger log.Logger,
+	now time.Time,
+	mocksSetup func(repo *passwordresetmocks.MockRepository, ntf *notifiermocks.MockNotifier),
+) (passwordreset.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	repo := passwordresetmocks.NewMockRepository(ctrl)
+	ntf := notifiermocks.NewMockNotifier(ctrl)
+
+	if mocksSetup != nil {
+		mocksSetup(repo, ntf)
+	}
+
+	service := passwordreset.NewService(logger, repo, ntf, clock.FixedClock{FixedTime: now})
+	return service, func() {
+		ctrl.Finish()
+	}
+}
@@ -0,0 +1,29 @@
+package refresh
+
+import (
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for storing the refresh tokens.
+type Config struct {
+	// HashKey is the secret used to compute the digest the refresh tokens are stored with.
+	HashKey string `env:"REFRESH_TOKEN_HASH_KEY"`
+	// AllowEphemeralKey allows generating a random hash key when none is configured. Only meant for local development,
+	// as the refresh tokens won't survive restarts nor scale out.
+	AllowEphemeralKey bool `env:"REFRESH_TOKEN_ALLOW_EPHEMERAL_KEY" envDefault:"false"`
+	// MigratePlaintextTokens enables the one-shot migration of the refresh tokens stored before they were hashed.
+	MigratePlaintextTokens bool `env:"REFRESH_TOKEN_MIGRATE_PLAINTEXT" envDefault:"false"`
+}
+
+// LoadConfig loads the refresh token configuration from environment variables and logs any errors encountered during
+// parsing. It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load refresh token configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
@@ -7,4 +7,6 @@ var (
 	ErrRefreshTokenNotFound = errors.New("refresh token not found")
 	// ErrRefreshTokenAlreadyExists represents an error indicating a refresh token already exists in the system.
 	ErrRefreshTokenAlreadyExists = errors.New("refresh token already exists")
+	// ErrHashKeyMissing represents an error when no hash key is configured and an ephemeral one is not allowed.
+	ErrHashKeyMissing = errors.New("refresh token hash key missing")
 )
@@ -0,0 +1,28 @@
+package refresh
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// TokenHasher computes the digest the refresh tokens are stored with, so the raw tokens are never persisted.
+type TokenHasher interface {
+	Hash(token string) string
+}
+
+type hmacHasher struct {
+	key []byte
+}
+
+// NewHMACHasher creates a TokenHasher that computes the HMAC-SHA256 of the tokens with the given key. The key must be
+// kept secret and stable, as changing it invalidates all the stored refresh tokens.
+func NewHMACHasher(key []byte) TokenHasher {
+	return &hmacHasher{key: key}
+}
+
+func (h *hmacHasher) Hash(token string) string {
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
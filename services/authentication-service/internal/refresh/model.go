@@ -13,17 +13,36 @@ const (
 )
 
 // Token represents a token used to refresh authentication credentials for a specific user and role.
+// Only the TokenHash is stored, so the raw token can't be taken from the database to hijack the session.
+// FamilyID links all the tokens issued by rotating the same original token, and RotatedAt is set once the token has
+// been exchanged for a new one. AccessToken identifies the access token issued along with it, if any.
 type Token struct {
-	ID         string      `bson:"_id,omitempty"`
-	UserID     string      `bson:"user_id"`
-	Role       string      `bson:"role"`
-	TenantID   string      `bson:"tenant_id"`
-	Token      string      `bson:"token"`
-	Status     TokenStatus `bson:"status"`
-	DeviceInfo DeviceInfo  `bson:"device_info"`
-	ExpiresAt  time.Time   `bson:"expires_at"`
-	CreatedAt  time.Time   `bson:"created_at"`
-	UpdatedAt  time.Time   `bson:"updated_at"`
+	ID          string       `bson:"_id,omitempty"`
+	UserID      string       `bson:"user_id"`
+	Role        string       `bson:"role"`
+	TenantID    string       `bson:"tenant_id"`
+	TokenHash   string       `bson:"token_hash"`
+	FamilyID    string       `bson:"family_id"`
+	Status      TokenStatus  `bson:"status"`
+	DeviceInfo  DeviceInfo   `bson:"device_info"`
+	AccessToken *AccessToken `bson:"access_token,omitempty"`
+	ExpiresAt   time.Time    `bson:"expires_at"`
+	RotatedAt   *time.Time   `bson:"rotated_at,omitempty"`
+	CreatedAt   time.Time    `bson:"created_at"`
+	UpdatedAt   time.Time    `bson:"updated_at"`
+}
+
+// AccessToken identifies the access token issued along with a refresh token by its jti, so it can be denied when the
+// session is revoked before the access token expires.
+type AccessToken struct {
+	ID        string    `bson:"id"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// PlaintextToken represents a refresh token stored before the tokens were hashed at rest.
+type PlaintextToken struct {
+	ID    string `bson:"_id"`
+	Token string `bson:"token"`
 }
 
 // DeviceInfo represents information about a device.
@@ -34,3 +53,11 @@ type DeviceInfo struct {
 	FirstUsedAt time.Time `bson:"first_used_at"`
 	LastUsedAt  time.Time `bson:"last_used_at"`
 }
+
+// Session represents the active refresh tokens of a user grouped by the device they were issued to.
+type Session struct {
+	DeviceID   string    `bson:"_id"`
+	UserAgent  string    `bson:"user_agent"`
+	IP         string    `bson:"ip"`
+	LastUsedAt time.Time `bson:"last_used_at"`
+}
@@ -19,14 +19,40 @@ const (
 	// CollectionName defines the name of the database collection used to store refresh tokens.
 	CollectionName = "refresh_tokens"
 
-	// FieldToken represents the database field name for storing token values.
+	// FieldUserID represents the database field name for storing the ID of the token owner.
+	FieldUserID = "user_id"
+	// FieldRole represents the database field name for storing the role of the token owner.
+	FieldRole = "role"
+	// FieldTenantID represents the database field name for storing the tenant of the token owner.
+	FieldTenantID = "tenant_id"
+	// FieldToken represents the database field name where the raw token values were stored before being hashed.
 	FieldToken = "token"
+	// FieldTokenHash represents the database field name for storing the token digests.
+	FieldTokenHash = "token_hash"
+	// FieldFamilyID represents the database field name for storing the family the token belongs to.
+	FieldFamilyID = "family_id"
+	// FieldRotatedAt represents the database field name for storing when the token was exchanged for a new one.
+	FieldRotatedAt = "rotated_at"
 	// FieldStatus represents the database field name for storing token status information.
 	FieldStatus = "status"
+	// FieldAccessTokenID represents the database field name for storing the jti of the access token issued along with
+	// the refresh token.
+	FieldAccessTokenID = "access_token.id"
+	// FieldAccessTokenExpiresAt represents the database field name for storing the expiration time of the access token
+	// issued along with the refresh token.
+	FieldAccessTokenExpiresAt = "access_token.expires_at"
 	// FieldExpiresAt represents the database field name for storing the expiration time of a token.
 	FieldExpiresAt = "expires_at"
 	// FieldUpdatedAt represents the database field name for storing the timestamp of the last update.
 	FieldUpdatedAt = "updated_at"
+	// FieldDeviceID represents the database field name for storing the ID of the device the token was issued to.
+	FieldDeviceID = "device_info.device_id"
+	// FieldDeviceUserAgent represents the database field name for storing the user agent of the device.
+	FieldDeviceUserAgent = "device_info.user_agent"
+	// FieldDeviceIP represents the database field name for storing the IP of the device.
+	FieldDeviceIP = "device_info.ip"
+	// FieldDeviceLastUsedAt represents the database field name for storing when the token was last used.
+	FieldDeviceLastUsedAt = "device_info.last_used_at"
 )
 
 // Repository defines a contract for storing and managing refresh tokens in a persistence layer.
@@ -34,8 +60,16 @@ const (
 //go:generate mockgen -destination=./mocks/repository_mock.go -package=refresh_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh Repository
 type Repository interface {
 	Create(ctx context.Context, params CreateTokenParams) (Token, error)
-	FindActiveToken(ctx context.Context, refreshToken string) (Token, error)
+	FindActiveToken(ctx context.Context, tokenHash string) (Token, error)
+	FindToken(ctx context.Context, tokenHash string) (Token, error)
 	Expire(ctx context.Context, params ExpireParams) (Token, error)
+	Revoke(ctx context.Context, params RevokeParams) (Token, error)
+	RevokeAll(ctx context.Context, params RevokeAllParams) (int64, error)
+	FindAccessTokens(ctx context.Context, params RevokeAllParams) ([]AccessToken, error)
+	RevokeFamily(ctx context.Context, params RevokeFamilyParams) (RevokedFamily, error)
+	FindActiveSessions(ctx context.Context, params FindActiveSessionsParams) ([]Session, error)
+	FindPlaintextTokens(ctx context.Context, params FindPlaintextTokensParams) ([]PlaintextToken, error)
+	HashPlaintextToken(ctx context.Context, params HashPlaintextTokenParams) error
 }
 
 type repository struct {
@@ -54,13 +88,16 @@ func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repos
 }
 
 // CreateTokenParams defines the parameters required to create a new token for a user.
+// AccessToken is optional, and links the access token issued along with the refresh token.
 type CreateTokenParams struct {
-	UserID    string
-	Role      string
-	TenantID  string
-	Token     string
-	Device    DeviceInfo
-	ExpiresAt time.Time
+	UserID      string
+	Role        string
+	TenantID    string
+	TokenHash   string
+	FamilyID    string
+	Device      DeviceInfo
+	AccessToken *AccessToken
+	ExpiresAt   time.Time
 }
 
 func (r *repository) Create(ctx context.Context, params CreateTokenParams) (Token, error) {
@@ -68,15 +105,17 @@ func (r *repository) Create(ctx context.Context, params CreateTokenParams) (Toke
 
 	now := r.clock.Now()
 	token := Token{
-		UserID:     params.UserID,
-		Role:       params.Role,
-		TenantID:   params.TenantID,
-		Token:      params.Token,
-		Status:     TokenStatusActive,
-		DeviceInfo: params.Device,
-		ExpiresAt:  params.ExpiresAt,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		UserID:      params.UserID,
+		Role:        params.Role,
+		TenantID:    params.TenantID,
+		TokenHash:   params.TokenHash,
+		FamilyID:    params.FamilyID,
+		Status:      TokenStatusActive,
+		DeviceInfo:  params.Device,
+		AccessToken: params.AccessToken,
+		ExpiresAt:   params.ExpiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
 	}
 	token.DeviceInfo.FirstUsedAt = now
 	token.DeviceInfo.LastUsedAt = now
@@ -95,13 +134,13 @@ func (r *repository) Create(ctx context.Context, params CreateTokenParams) (Toke
 	return token, nil
 }
 
-func (r *repository) FindActiveToken(ctx context.Context, refreshToken string) (Token, error) {
+func (r *repository) FindActiveToken(ctx context.Context, tokenHash string) (Token, error) {
 	logger := r.logger.WithContext(ctx)
 
 	token := Token{}
 	searchParams := bson.M{
-		FieldToken:  refreshToken,
-		FieldStatus: TokenStatusActive,
+		FieldTokenHash: tokenHash,
+		FieldStatus:    TokenStatusActive,
 		FieldExpiresAt: bson.M{
 			"$gt": r.clock.Now(),
 		},
@@ -119,11 +158,28 @@ func (r *repository) FindActiveToken(ctx context.Context, refreshToken string) (
 	return token, nil
 }
 
+func (r *repository) FindToken(ctx context.Context, tokenHash string) (Token, error) {
+	logger := r.logger.WithContext(ctx)
+
+	token := Token{}
+	err := r.collection.FindOne(ctx, bson.M{FieldTokenHash: tokenHash}).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn("Refresh token not found")
+			return Token{}, ErrRefreshTokenNotFound
+		}
+		logger.Error("Failed to find refresh token", err)
+		return Token{}, err
+	}
+
+	return token, nil
+}
+
 // ExpireParams defines parameters needed to update a token's expiration status.
-// Token represents the refresh token to be expired.
+// TokenHash represents the digest of the refresh token to be expired.
 // ExpiresAt specifies the new expiration time for the token.
 type ExpireParams struct {
-	Token     string
+	TokenHash string
 	ExpiresAt time.Time
 }
 
@@ -132,18 +188,22 @@ func (r *repository) Expire(ctx context.Context, params ExpireParams) (Token, er
 
 	var token Token
 	filter := bson.M{
-		FieldToken:  params.Token,
-		FieldStatus: TokenStatusActive,
+		FieldTokenHash: params.TokenHash,
+		FieldStatus:    TokenStatusActive,
 		// If the token was already expired, then we do nothing
 		FieldExpiresAt: bson.M{
 			"$gt": params.ExpiresAt,
 		},
 	}
 
+	// Expiring the token means it has been rotated, so the device usage is tracked as well
+	now := r.clock.Now()
 	update := bson.M{
 		"$set": bson.M{
-			FieldExpiresAt: params.ExpiresAt,
-			FieldUpdatedAt: r.clock.Now(),
+			FieldExpiresAt:        params.ExpiresAt,
+			FieldRotatedAt:        now,
+			FieldDeviceLastUsedAt: now,
+			FieldUpdatedAt:        now,
 		},
 	}
 
@@ -160,3 +220,323 @@ func (r *repository) Expire(ctx context.Context, params ExpireParams) (Token, er
 	}
 	return token, nil
 }
+
+// RevokeParams defines the parameters needed to revoke a single refresh token.
+type RevokeParams struct {
+	TokenHash string
+}
+
+func (r *repository) Revoke(ctx context.Context, params RevokeParams) (Token, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var token Token
+	now := r.clock.Now()
+	filter := bson.M{
+		FieldTokenHash: params.TokenHash,
+		FieldStatus:    TokenStatusActive,
+		// If the token was already expired, then we do nothing
+		FieldExpiresAt: bson.M{
+			"$gt": now,
+		},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			FieldStatus:    TokenStatusRevoked,
+			FieldUpdatedAt: now,
+		},
+	}
+
+	// Returning the updated document
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn("Refresh token not found")
+			return Token{}, ErrRefreshTokenNotFound
+		}
+		logger.Error("Failed to revoke refresh token", err)
+		return Token{}, err
+	}
+	return token, nil
+}
+
+// RevokeAllParams defines the parameters needed to revoke all the active refresh tokens of a user.
+// TenantID is empty for the non-tenant users, such as the customers, and it is ignored when AllTenants is set.
+// DeviceID, when set, restricts the revocation to the tokens issued to that device. Otherwise, ExceptDeviceID, when
+// set, keeps the tokens issued to that device active.
+type RevokeAllParams struct {
+	UserID         string
+	Role           string
+	TenantID       string
+	AllTenants     bool
+	DeviceID       string
+	ExceptDeviceID string
+}
+
+func (r *repository) RevokeAll(ctx context.Context, params RevokeAllParams) (int64, error) {
+	logger := r.logger.WithContext(ctx)
+
+	now := r.clock.Now()
+	filter := activeTokensFilter(params, now)
+
+	update := bson.M{
+		"$set": bson.M{
+			FieldStatus:    TokenStatusRevoked,
+			FieldUpdatedAt: now,
+		},
+	}
+
+	res, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to revoke refresh tokens", err)
+		return 0, err
+	}
+
+	logger.Info(
+		"Refresh tokens revoked",
+		log.Field{Key: "user_id", Value: params.UserID},
+		log.Field{Key: "revoked", Value: res.ModifiedCount},
+	)
+	return res.ModifiedCount, nil
+}
+
+// FindAccessTokens returns the access tokens, not expired yet, issued along with the active refresh tokens that
+// RevokeAll would revoke with the same parameters.
+func (r *repository) FindAccessTokens(ctx context.Context, params RevokeAllParams) ([]AccessToken, error) {
+	logger := r.logger.WithContext(ctx)
+
+	now := r.clock.Now()
+	filter := activeTokensFilter(params, now)
+	filter[FieldAccessTokenExpiresAt] = bson.M{
+		"$gt": now,
+	}
+	opts := options.Find().SetProjection(bson.M{"access_token": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Failed to find access tokens", err)
+		return nil, err
+	}
+
+	var tokens []Token
+	if err := cursor.All(ctx, &tokens); err != nil {
+		logger.Error("Failed to decode access tokens", err)
+		return nil, err
+	}
+
+	accessTokens := make([]AccessToken, 0, len(tokens))
+	for _, token := range tokens {
+		accessTokens = append(accessTokens, *token.AccessToken)
+	}
+	return accessTokens, nil
+}
+
+// activeTokensFilter builds the filter matching the active refresh tokens of a user, restricted to, or excluding, a
+// device when requested.
+func activeTokensFilter(params RevokeAllParams, now time.Time) bson.M {
+	filter := bson.M{
+		FieldUserID: params.UserID,
+		FieldRole:   params.Role,
+		FieldStatus: TokenStatusActive,
+		FieldExpiresAt: bson.M{
+			"$gt": now,
+		},
+	}
+	if !params.AllTenants {
+		filter[FieldTenantID] = params.TenantID
+	}
+	if params.DeviceID != "" {
+		filter[FieldDeviceID] = params.DeviceID
+	} else if params.ExceptDeviceID != "" {
+		filter[FieldDeviceID] = bson.M{"$ne": params.ExceptDeviceID}
+	}
+	return filter
+}
+
+// FindActiveSessionsParams defines the parameters needed to find the active sessions of a user.
+type FindActiveSessionsParams struct {
+	UserID   string
+	Role     string
+	TenantID string
+}
+
+func (r *repository) FindActiveSessions(ctx context.Context, params FindActiveSessionsParams) ([]Session, error) {
+	logger := r.logger.WithContext(ctx)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			FieldUserID:   params.UserID,
+			FieldRole:     params.Role,
+			FieldTenantID: params.TenantID,
+			FieldStatus:   TokenStatusActive,
+			FieldExpiresAt: bson.M{
+				"$gt": r.clock.Now(),
+			},
+		}}},
+		// Sorting before grouping, so the device details are taken from the most recently used token
+		{{Key: "$sort", Value: bson.D{{Key: FieldDeviceLastUsedAt, Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          "$" + FieldDeviceID,
+			"user_agent":   bson.M{"$first": "$" + FieldDeviceUserAgent},
+			"ip":           bson.M{"$first": "$" + FieldDeviceIP},
+			"last_used_at": bson.M{"$max": "$" + FieldDeviceLastUsedAt},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "last_used_at", Value: -1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		logger.Error("Failed to find active sessions", err)
+		return nil, err
+	}
+
+	sessions := make([]Session, 0)
+	if err := cursor.All(ctx, &sessions); err != nil {
+		logger.Error("Failed to decode active sessions", err)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeFamilyParams defines the parameters needed to revoke all the tokens of a family.
+type RevokeFamilyParams struct {
+	FamilyID string
+}
+
+// RevokedFamily represents the result of revoking a token family. AccessTokens are the access tokens, not expired yet,
+// issued along with the revoked tokens.
+type RevokedFamily struct {
+	RevokedTokens int64
+	AccessTokens  []AccessToken
+}
+
+func (r *repository) RevokeFamily(ctx context.Context, params RevokeFamilyParams) (RevokedFamily, error) {
+	logger := r.logger.WithContext(ctx)
+
+	now := r.clock.Now()
+	// The tokens in their grace period are revoked too, as they could be in the attacker's hands
+	filter := bson.M{
+		FieldFamilyID: params.FamilyID,
+		FieldStatus:   TokenStatusActive,
+	}
+
+	// The access tokens are found before revoking the refresh tokens, as only the active ones are matched
+	accessTokensFilter := bson.M{
+		FieldFamilyID: params.FamilyID,
+		FieldStatus:   TokenStatusActive,
+		FieldAccessTokenExpiresAt: bson.M{
+			"$gt": now,
+		},
+	}
+	opts := options.Find().SetProjection(bson.M{"access_token": 1})
+
+	cursor, err := r.collection.Find(ctx, accessTokensFilter, opts)
+	if err != nil {
+		logger.Error("Failed to find the access tokens of the refresh token family", err)
+		return RevokedFamily{}, err
+	}
+
+	var tokens []Token
+	if err := cursor.All(ctx, &tokens); err != nil {
+		logger.Error("Failed to decode the access tokens of the refresh token family", err)
+		return RevokedFamily{}, err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			FieldStatus:    TokenStatusRevoked,
+			FieldUpdatedAt: now,
+		},
+	}
+
+	res, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to revoke refresh token family", err)
+		return RevokedFamily{}, err
+	}
+
+	logger.Info(
+		"Refresh token family revoked",
+		log.Field{Key: "family_id", Value: params.FamilyID},
+		log.Field{Key: "revoked", Value: res.ModifiedCount},
+	)
+
+	accessTokens := make([]AccessToken, 0, len(tokens))
+	for _, token := range tokens {
+		accessTokens = append(accessTokens, *token.AccessToken)
+	}
+	return RevokedFamily{RevokedTokens: res.ModifiedCount, AccessTokens: accessTokens}, nil
+}
+
+// FindPlaintextTokensParams defines the parameters needed to find the refresh tokens that are not hashed yet.
+// Limit sets the maximum number of tokens returned at once.
+type FindPlaintextTokensParams struct {
+	Limit int64
+}
+
+func (r *repository) FindPlaintextTokens(ctx context.Context, params FindPlaintextTokensParams) ([]PlaintextToken, error) {
+	logger := r.logger.WithContext(ctx)
+
+	filter := bson.M{
+		FieldToken: bson.M{"$exists": true},
+	}
+	opts := options.Find().
+		SetProjection(bson.M{FieldToken: 1}).
+		SetLimit(params.Limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Failed to find plaintext refresh tokens", err)
+		return nil, err
+	}
+
+	tokens := make([]PlaintextToken, 0)
+	if err := cursor.All(ctx, &tokens); err != nil {
+		logger.Error("Failed to decode plaintext refresh tokens", err)
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// HashPlaintextTokenParams defines the parameters needed to replace a plaintext refresh token by its digest.
+type HashPlaintextTokenParams struct {
+	ID        string
+	Token     string
+	TokenHash string
+}
+
+func (r *repository) HashPlaintextToken(ctx context.Context, params HashPlaintextTokenParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	id, err := primitive.ObjectIDFromHex(params.ID)
+	if err != nil {
+		logger.Error("Invalid refresh token ID", err)
+		return err
+	}
+
+	// Filtering by the plaintext token too, so a token that was already migrated is never overwritten
+	filter := bson.M{
+		"_id":      id,
+		FieldToken: params.Token,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldTokenHash: params.TokenHash,
+		},
+		"$unset": bson.M{
+			FieldToken: "",
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to hash plaintext refresh token", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("Plaintext refresh token not found", log.Field{Key: "id", Value: params.ID})
+		return ErrRefreshTokenNotFound
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -41,7 +42,7 @@ func TestRepository_Create(t *testing.T) {
 					UserID:    "fake-user-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "fake-token",
+					TokenHash: "fake-token",
 					Status:    refresh.TokenStatusActive,
 					ExpiresAt: expiresAt,
 					CreatedAt: now,
@@ -59,7 +60,7 @@ func TestRepository_Create(t *testing.T) {
 				UserID:    "fake-user-id",
 				Role:      "fake-role",
 				TenantID:  "fake-tenant-id",
-				Token:     "fake-token",
+				TokenHash: "fake-token",
 				ExpiresAt: expiresAt,
 				Device: refresh.DeviceInfo{
 					DeviceID:    "fake-device-id",
@@ -78,7 +79,8 @@ func TestRepository_Create(t *testing.T) {
 				UserID:    "fake-user-id",
 				Role:      "fake-role",
 				TenantID:  "fake-tenant-id",
-				Token:     "fake-token",
+				TokenHash: "fake-token",
+				FamilyID:  "fake-family-id",
 				ExpiresAt: expiresAt,
 				Device: refresh.DeviceInfo{
 					DeviceID:    "fake-device-id",
@@ -92,7 +94,8 @@ func TestRepository_Create(t *testing.T) {
 				UserID:    "fake-user-id",
 				Role:      "fake-role",
 				TenantID:  "fake-tenant-id",
-				Token:     "fake-token",
+				TokenHash: "fake-token",
+				FamilyID:  "fake-family-id",
 				Status:    refresh.TokenStatusActive,
 				ExpiresAt: expiresAt,
 				CreatedAt: now,
@@ -170,7 +173,7 @@ func TestRepository_FindActiveToken(t *testing.T) {
 					UserID:    "fake-user-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "active-token",
+					TokenHash: "active-token",
 					Status:    refresh.TokenStatusActive,
 					ExpiresAt: expiresAt,
 					CreatedAt: now,
@@ -195,7 +198,7 @@ func TestRepository_FindActiveToken(t *testing.T) {
 					UserID:    "fake-user-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "revoked-token",
+					TokenHash: "revoked-token",
 					Status:    refresh.TokenStatusRevoked,
 					ExpiresAt: expiresAt,
 					CreatedAt: now,
@@ -220,7 +223,7 @@ func TestRepository_FindActiveToken(t *testing.T) {
 					UserID:    "fake-user-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "expired-token",
+					TokenHash: "expired-token",
 					Status:    refresh.TokenStatusActive,
 					ExpiresAt: expiredAt,
 					CreatedAt: now,
@@ -245,7 +248,7 @@ func TestRepository_FindActiveToken(t *testing.T) {
 					UserID:    "fake-user-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "active-token",
+					TokenHash: "active-token",
 					Status:    refresh.TokenStatusActive,
 					ExpiresAt: expiresAt,
 					CreatedAt: now,
@@ -264,7 +267,7 @@ func TestRepository_FindActiveToken(t *testing.T) {
 				UserID:    "fake-user-id",
 				Role:      "fake-role",
 				TenantID:  "fake-tenant-id",
-				Token:     "active-token",
+				TokenHash: "active-token",
 				Status:    refresh.TokenStatusActive,
 				ExpiresAt: expiresAt,
 				CreatedAt: now,
@@ -326,6 +329,93 @@ func TestRepository_FindActiveToken_UnexpectedFailure(t *testing.T) {
 	assert.Error(t, err, "Expected an error due to unexpected failure")
 }
 
+func TestRepository_FindToken(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		yesterday = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []refreshRepositoryTestCase[string, refresh.Token]{
+		{
+			name:    "when the refresh token does not exist, then it should return a refresh token not found error",
+			params:  "unexisting-token",
+			want:    refresh.Token{},
+			wantErr: refresh.ErrRefreshTokenNotFound,
+		},
+		{
+			name: "when the refresh token was rotated and expired, then it should return the token",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "rotated-token",
+					FamilyID:  "fake-family-id",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: yesterday,
+					RotatedAt: &yesterday,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+			},
+			params: "rotated-token",
+			want: refresh.Token{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TenantID:  "fake-tenant-id",
+				TokenHash: "rotated-token",
+				FamilyID:  "fake-family-id",
+				Status:    refresh.TokenStatusActive,
+				ExpiresAt: yesterday,
+				RotatedAt: &yesterday,
+				CreatedAt: yesterday,
+				UpdatedAt: yesterday,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestRefreshTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			token, err := repo.FindToken(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, token.ID, "ID should not be empty")
+
+				tt.want.ID = token.ID
+				assert.Equal(t, tt.want, token)
+			}
+		})
+	}
+}
+
+func TestRepository_FindToken_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestRefreshTokenCollection(t, tdb.DB)
+
+	repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindToken(context.Background(), "fake-token")
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
 func TestRepository_Expire(t *testing.T) {
 	var (
 		now          = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -343,7 +433,7 @@ func TestRepository_Expire(t *testing.T) {
 					UserID:    "fake-user-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "active-token",
+					TokenHash: "active-token",
 					Status:    refresh.TokenStatusActive,
 					ExpiresAt: expiresAt,
 					CreatedAt: now,
@@ -357,7 +447,7 @@ func TestRepository_Expire(t *testing.T) {
 					},
 				})
 			},
-			params:  refresh.ExpireParams{Token: "unexisting-token"},
+			params:  refresh.ExpireParams{TokenHash: "unexisting-token"},
 			want:    refresh.Token{},
 			wantErr: refresh.ErrRefreshTokenNotFound,
 		},
@@ -368,7 +458,7 @@ func TestRepository_Expire(t *testing.T) {
 					UserID:    "fake-user-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "revoked-token",
+					TokenHash: "revoked-token",
 					Status:    refresh.TokenStatusRevoked,
 					ExpiresAt: expiresAt,
 					CreatedAt: now,
@@ -382,7 +472,7 @@ func TestRepository_Expire(t *testing.T) {
 					},
 				})
 			},
-			params:  refresh.ExpireParams{Token: "revoked-token"},
+			params:  refresh.ExpireParams{TokenHash: "revoked-token"},
 			want:    refresh.Token{},
 			wantErr: refresh.ErrRefreshTokenNotFound,
 		},
@@ -393,7 +483,7 @@ func TestRepository_Expire(t *testing.T) {
 					UserID:    "fake-user-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "expired-token",
+					TokenHash: "expired-token",
 					Status:    refresh.TokenStatusActive,
 					ExpiresAt: newExpiresAt,
 					CreatedAt: now,
@@ -408,7 +498,7 @@ func TestRepository_Expire(t *testing.T) {
 				})
 			},
 			params: refresh.ExpireParams{
-				Token:     "expired-token",
+				TokenHash: "expired-token",
 				ExpiresAt: newExpiresAt,
 			},
 			want:    refresh.Token{},
@@ -421,7 +511,7 @@ func TestRepository_Expire(t *testing.T) {
 					UserID:    "fake-user-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "active-token",
+					TokenHash: "active-token",
 					Status:    refresh.TokenStatusActive,
 					ExpiresAt: expiresAt,
 					CreatedAt: yesterday,
@@ -436,16 +526,17 @@ func TestRepository_Expire(t *testing.T) {
 				})
 			},
 			params: refresh.ExpireParams{
-				Token:     "active-token",
+				TokenHash: "active-token",
 				ExpiresAt: newExpiresAt,
 			},
 			want: refresh.Token{
 				UserID:    "fake-user-id",
 				Role:      "fake-role",
 				TenantID:  "fake-tenant-id",
-				Token:     "active-token",
+				TokenHash: "active-token",
 				Status:    refresh.TokenStatusActive,
 				ExpiresAt: newExpiresAt,
+				RotatedAt: &now,
 				CreatedAt: yesterday,
 				UpdatedAt: now,
 				DeviceInfo: refresh.DeviceInfo{
@@ -453,7 +544,7 @@ func TestRepository_Expire(t *testing.T) {
 					UserAgent:   "fake-user-agent",
 					IP:          "fake-ip",
 					FirstUsedAt: yesterday,
-					LastUsedAt:  yesterday,
+					LastUsedAt:  now,
 				},
 			},
 		},
@@ -505,16 +596,1034 @@ func TestRepository_Expire_UnexpectedFailure(t *testing.T) {
 	assert.Error(t, err, "Expected an error due to unexpected failure")
 }
 
+func TestRepository_Revoke(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		yesterday = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+		expiresAt = time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []refreshRepositoryTestCase[refresh.RevokeParams, refresh.Token]{
+		{
+			name: "when the refresh token does not exist, then it should return a refresh token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "active-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params:  refresh.RevokeParams{TokenHash: "unexisting-token"},
+			want:    refresh.Token{},
+			wantErr: refresh.ErrRefreshTokenNotFound,
+		},
+		{
+			name: "when the refresh token is already revoked, then it should return a refresh token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "revoked-token",
+					Status:    refresh.TokenStatusRevoked,
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params:  refresh.RevokeParams{TokenHash: "revoked-token"},
+			want:    refresh.Token{},
+			wantErr: refresh.ErrRefreshTokenNotFound,
+		},
+		{
+			name: "when the refresh token is expired, then it should return a refresh token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "expired-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: yesterday,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+			},
+			params:  refresh.RevokeParams{TokenHash: "expired-token"},
+			want:    refresh.Token{},
+			wantErr: refresh.ErrRefreshTokenNotFound,
+		},
+		{
+			name: "when the refresh token is active, then it should return the token revoked",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "active-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+					DeviceInfo: refresh.DeviceInfo{
+						DeviceID:    "fake-device-id",
+						UserAgent:   "fake-user-agent",
+						IP:          "fake-ip",
+						FirstUsedAt: yesterday,
+						LastUsedAt:  yesterday,
+					},
+				})
+			},
+			params: refresh.RevokeParams{TokenHash: "active-token"},
+			want: refresh.Token{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TenantID:  "fake-tenant-id",
+				TokenHash: "active-token",
+				Status:    refresh.TokenStatusRevoked,
+				ExpiresAt: expiresAt,
+				CreatedAt: yesterday,
+				UpdatedAt: now,
+				DeviceInfo: refresh.DeviceInfo{
+					DeviceID:    "fake-device-id",
+					UserAgent:   "fake-user-agent",
+					IP:          "fake-ip",
+					FirstUsedAt: yesterday,
+					LastUsedAt:  yesterday,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestRefreshTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			token, err := repo.Revoke(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, token.ID, "ID should not be empty")
+
+				tt.want.ID = token.ID
+				assert.Equal(t, tt.want, token)
+			}
+		})
+	}
+}
+
+func TestRepository_Revoke_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestRefreshTokenCollection(t, tdb.DB)
+
+	repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.Revoke(context.Background(), refresh.RevokeParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_RevokeAll(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		yesterday = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+		expiresAt = time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []refreshRepositoryTestCase[refresh.RevokeAllParams, int64]{
+		{
+			name: "when the user has no active refresh tokens, then it should not revoke any token",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "revoked-token",
+					Status:    refresh.TokenStatusRevoked,
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "expired-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: yesterday,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+			},
+			params: refresh.RevokeAllParams{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			want: 0,
+		},
+		{
+			name: "when the user has active refresh tokens, then it should revoke only the tokens of that user",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "active-token-1",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "active-token-2",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "another-tenant-id",
+					TokenHash: "another-tenant-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "another-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "another-user-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+			},
+			params: refresh.RevokeAllParams{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			want: 2,
+		},
+		{
+			name: "when a device is provided, then it should revoke only the tokens of that device",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:     "fake-user-id",
+					Role:       "fake-role",
+					TenantID:   "fake-tenant-id",
+					TokenHash:  "device-token",
+					Status:     refresh.TokenStatusActive,
+					DeviceInfo: refresh.DeviceInfo{DeviceID: "fake-device-id"},
+					ExpiresAt:  expiresAt,
+					CreatedAt:  yesterday,
+					UpdatedAt:  yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:     "fake-user-id",
+					Role:       "fake-role",
+					TenantID:   "fake-tenant-id",
+					TokenHash:  "another-device-token",
+					Status:     refresh.TokenStatusActive,
+					DeviceInfo: refresh.DeviceInfo{DeviceID: "another-device-id"},
+					ExpiresAt:  expiresAt,
+					CreatedAt:  yesterday,
+					UpdatedAt:  yesterday,
+				})
+			},
+			params: refresh.RevokeAllParams{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+				DeviceID: "fake-device-id",
+			},
+			want: 1,
+		},
+		{
+			name: "when a device to keep is provided, then it should revoke the tokens of the other devices",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:     "fake-user-id",
+					Role:       "fake-role",
+					TenantID:   "fake-tenant-id",
+					TokenHash:  "device-token",
+					Status:     refresh.TokenStatusActive,
+					DeviceInfo: refresh.DeviceInfo{DeviceID: "fake-device-id"},
+					ExpiresAt:  expiresAt,
+					CreatedAt:  yesterday,
+					UpdatedAt:  yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:     "fake-user-id",
+					Role:       "fake-role",
+					TenantID:   "fake-tenant-id",
+					TokenHash:  "another-device-token-1",
+					Status:     refresh.TokenStatusActive,
+					DeviceInfo: refresh.DeviceInfo{DeviceID: "another-device-id"},
+					ExpiresAt:  expiresAt,
+					CreatedAt:  yesterday,
+					UpdatedAt:  yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:     "fake-user-id",
+					Role:       "fake-role",
+					TenantID:   "fake-tenant-id",
+					TokenHash:  "another-device-token-2",
+					Status:     refresh.TokenStatusActive,
+					DeviceInfo: refresh.DeviceInfo{DeviceID: "yet-another-device-id"},
+					ExpiresAt:  expiresAt,
+					CreatedAt:  yesterday,
+					UpdatedAt:  yesterday,
+				})
+			},
+			params: refresh.RevokeAllParams{
+				UserID:         "fake-user-id",
+				Role:           "fake-role",
+				TenantID:       "fake-tenant-id",
+				ExceptDeviceID: "fake-device-id",
+			},
+			want: 2,
+		},
+		{
+			name: "when all the tenants are requested, then it should revoke the tokens of the user in every tenant",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "active-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "another-tenant-id",
+					TokenHash: "another-tenant-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "another-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "another-user-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+			},
+			params: refresh.RevokeAllParams{
+				UserID:     "fake-user-id",
+				Role:       "fake-role",
+				AllTenants: true,
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestRefreshTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			revoked, err := repo.RevokeAll(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, revoked)
+
+			// No active tokens must remain for the user, or for the device when provided
+			if tt.wantErr == nil {
+				filter := bson.M{
+					refresh.FieldUserID: tt.params.UserID,
+					refresh.FieldRole:   tt.params.Role,
+					refresh.FieldStatus: refresh.TokenStatusActive,
+					refresh.FieldExpiresAt: bson.M{
+						"$gt": now,
+					},
+				}
+				if !tt.params.AllTenants {
+					filter[refresh.FieldTenantID] = tt.params.TenantID
+				}
+				if tt.params.DeviceID != "" {
+					filter[refresh.FieldDeviceID] = tt.params.DeviceID
+				} else if tt.params.ExceptDeviceID != "" {
+					filter[refresh.FieldDeviceID] = bson.M{"$ne": tt.params.ExceptDeviceID}
+				}
+				active, err := coll.CountDocuments(context.Background(), filter)
+				assert.NoError(t, err)
+				assert.Zero(t, active)
+
+				// The session to keep must remain active
+				if tt.params.ExceptDeviceID != "" {
+					kept, err := coll.CountDocuments(context.Background(), bson.M{
+						refresh.FieldDeviceID: tt.params.ExceptDeviceID,
+						refresh.FieldStatus:   refresh.TokenStatusActive,
+					})
+					assert.NoError(t, err)
+					assert.Equal(t, int64(1), kept)
+				}
+			}
+		})
+	}
+}
+
+func TestRepository_RevokeAll_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestRefreshTokenCollection(t, tdb.DB)
+
+	repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.RevokeAll(context.Background(), refresh.RevokeAllParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_FindAccessTokens(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		yesterday = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+		expiresAt = time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+		inAnHour  = time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	)
+	logger, _ := log.NewTest()
+
+	params := refresh.RevokeAllParams{
+		UserID:         "fake-user-id",
+		Role:           "fake-role",
+		TenantID:       "fake-tenant-id",
+		ExceptDeviceID: "fake-device-id",
+	}
+
+	tests := []refreshRepositoryTestCase[refresh.RevokeAllParams, []refresh.AccessToken]{
+		{
+			name:   "when the user has no active refresh tokens, then it should return no access tokens",
+			params: params,
+			want:   []refresh.AccessToken{},
+		},
+		{
+			name: "when the user has active refresh tokens, " +
+				"then it should return only the access tokens not expired yet of the matching sessions",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:      "fake-user-id",
+					Role:        "fake-role",
+					TenantID:    "fake-tenant-id",
+					TokenHash:   "active-token",
+					Status:      refresh.TokenStatusActive,
+					DeviceInfo:  refresh.DeviceInfo{DeviceID: "another-device-id"},
+					AccessToken: &refresh.AccessToken{ID: "fake-access-token-id", ExpiresAt: inAnHour},
+					ExpiresAt:   expiresAt,
+					CreatedAt:   yesterday,
+					UpdatedAt:   yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:      "fake-user-id",
+					Role:        "fake-role",
+					TenantID:    "fake-tenant-id",
+					TokenHash:   "expired-access-token",
+					Status:      refresh.TokenStatusActive,
+					DeviceInfo:  refresh.DeviceInfo{DeviceID: "another-device-id"},
+					AccessToken: &refresh.AccessToken{ID: "expired-access-token-id", ExpiresAt: now},
+					ExpiresAt:   expiresAt,
+					CreatedAt:   yesterday,
+					UpdatedAt:   yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:     "fake-user-id",
+					Role:       "fake-role",
+					TenantID:   "fake-tenant-id",
+					TokenHash:  "no-access-token",
+					Status:     refresh.TokenStatusActive,
+					DeviceInfo: refresh.DeviceInfo{DeviceID: "another-device-id"},
+					ExpiresAt:  expiresAt,
+					CreatedAt:  yesterday,
+					UpdatedAt:  yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:      "fake-user-id",
+					Role:        "fake-role",
+					TenantID:    "fake-tenant-id",
+					TokenHash:   "revoked-token",
+					Status:      refresh.TokenStatusRevoked,
+					DeviceInfo:  refresh.DeviceInfo{DeviceID: "another-device-id"},
+					AccessToken: &refresh.AccessToken{ID: "revoked-access-token-id", ExpiresAt: inAnHour},
+					ExpiresAt:   expiresAt,
+					CreatedAt:   yesterday,
+					UpdatedAt:   yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:      "fake-user-id",
+					Role:        "fake-role",
+					TenantID:    "fake-tenant-id",
+					TokenHash:   "kept-token",
+					Status:      refresh.TokenStatusActive,
+					DeviceInfo:  refresh.DeviceInfo{DeviceID: "fake-device-id"},
+					AccessToken: &refresh.AccessToken{ID: "kept-access-token-id", ExpiresAt: inAnHour},
+					ExpiresAt:   expiresAt,
+					CreatedAt:   yesterday,
+					UpdatedAt:   yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:      "another-user-id",
+					Role:        "fake-role",
+					TenantID:    "fake-tenant-id",
+					TokenHash:   "another-user-token",
+					Status:      refresh.TokenStatusActive,
+					DeviceInfo:  refresh.DeviceInfo{DeviceID: "another-device-id"},
+					AccessToken: &refresh.AccessToken{ID: "another-user-access-token-id", ExpiresAt: inAnHour},
+					ExpiresAt:   expiresAt,
+					CreatedAt:   yesterday,
+					UpdatedAt:   yesterday,
+				})
+			},
+			params: params,
+			want: []refresh.AccessToken{
+				{ID: "fake-access-token-id", ExpiresAt: inAnHour},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestRefreshTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.FindAccessTokens(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRepository_FindAccessTokens_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestRefreshTokenCollection(t, tdb.DB)
+
+	repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindAccessTokens(context.Background(), refresh.RevokeAllParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_RevokeFamily(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		yesterday = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+		expiresAt = time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+		inAnHour  = time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []refreshRepositoryTestCase[refresh.RevokeFamilyParams, refresh.RevokedFamily]{
+		{
+			name:   "when the family does not exist, then it should not revoke any token",
+			params: refresh.RevokeFamilyParams{FamilyID: "unexisting-family-id"},
+			want:   refresh.RevokedFamily{AccessTokens: []refresh.AccessToken{}},
+		},
+		{
+			name: "when the family has active tokens, then it should revoke only the tokens of that family " +
+				"and return their access tokens not expired yet",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "rotated-token",
+					FamilyID:  "fake-family-id",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: yesterday,
+					RotatedAt: &yesterday,
+					AccessToken: &refresh.AccessToken{
+						ID:        "expired-access-token-id",
+						ExpiresAt: yesterday,
+					},
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "active-token",
+					FamilyID:  "fake-family-id",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					AccessToken: &refresh.AccessToken{
+						ID:        "active-access-token-id",
+						ExpiresAt: inAnHour,
+					},
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "another-family-token",
+					FamilyID:  "another-family-id",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+				})
+			},
+			params: refresh.RevokeFamilyParams{FamilyID: "fake-family-id"},
+			want: refresh.RevokedFamily{
+				RevokedTokens: 2,
+				AccessTokens: []refresh.AccessToken{
+					{ID: "active-access-token-id", ExpiresAt: inAnHour},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestRefreshTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			revoked, err := repo.RevokeFamily(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, revoked)
+
+			// No active tokens must remain in the family
+			if tt.wantErr == nil {
+				active, err := coll.CountDocuments(context.Background(), bson.M{
+					refresh.FieldFamilyID: tt.params.FamilyID,
+					refresh.FieldStatus:   refresh.TokenStatusActive,
+				})
+				assert.NoError(t, err)
+				assert.Zero(t, active)
+			}
+		})
+	}
+}
+
+func TestRepository_RevokeFamily_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestRefreshTokenCollection(t, tdb.DB)
+
+	repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.RevokeFamily(context.Background(), refresh.RevokeFamilyParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_FindActiveSessions(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		yesterday = time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+		lastWeek  = time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+		expiresAt = time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []refreshRepositoryTestCase[refresh.FindActiveSessionsParams, []refresh.Session]{
+		{
+			name: "when the user has no active refresh tokens, then it should return an empty list",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "revoked-token",
+					Status:    refresh.TokenStatusRevoked,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+					DeviceInfo: refresh.DeviceInfo{
+						DeviceID:   "fake-device-id",
+						LastUsedAt: yesterday,
+					},
+				})
+			},
+			params: refresh.FindActiveSessionsParams{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			want: []refresh.Session{},
+		},
+		{
+			name: "when the user has active refresh tokens, " +
+				"then it should return them grouped by device and sorted by the most recently used",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "laptop-old-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: lastWeek,
+					UpdatedAt: lastWeek,
+					DeviceInfo: refresh.DeviceInfo{
+						DeviceID:    "laptop-device-id",
+						UserAgent:   "laptop-old-user-agent",
+						IP:          "laptop-old-ip",
+						FirstUsedAt: lastWeek,
+						LastUsedAt:  lastWeek,
+					},
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "phone-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: yesterday,
+					UpdatedAt: yesterday,
+					DeviceInfo: refresh.DeviceInfo{
+						DeviceID:    "phone-device-id",
+						UserAgent:   "phone-user-agent",
+						IP:          "phone-ip",
+						FirstUsedAt: yesterday,
+						LastUsedAt:  yesterday,
+					},
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "laptop-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+					UpdatedAt: now,
+					DeviceInfo: refresh.DeviceInfo{
+						DeviceID:    "laptop-device-id",
+						UserAgent:   "laptop-user-agent",
+						IP:          "laptop-ip",
+						FirstUsedAt: now,
+						LastUsedAt:  now,
+					},
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "another-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: "another-user-token",
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+					UpdatedAt: now,
+					DeviceInfo: refresh.DeviceInfo{
+						DeviceID:   "another-device-id",
+						LastUsedAt: now,
+					},
+				})
+			},
+			params: refresh.FindActiveSessionsParams{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			want: []refresh.Session{
+				{
+					DeviceID:   "laptop-device-id",
+					UserAgent:  "laptop-user-agent",
+					IP:         "laptop-ip",
+					LastUsedAt: now,
+				},
+				{
+					DeviceID:   "phone-device-id",
+					UserAgent:  "phone-user-agent",
+					IP:         "phone-ip",
+					LastUsedAt: yesterday,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestRefreshTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			sessions, err := repo.FindActiveSessions(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, sessions)
+		})
+	}
+}
+
+func TestRepository_FindActiveSessions_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestRefreshTokenCollection(t, tdb.DB)
+
+	repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindActiveSessions(context.Background(), refresh.FindActiveSessionsParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_FindPlaintextTokens(t *testing.T) {
+	var (
+		now      = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		legacyID = primitive.NewObjectID()
+	)
+	logger, _ := log.NewTest()
+
+	tests := []refreshRepositoryTestCase[refresh.FindPlaintextTokensParams, []refresh.PlaintextToken]{
+		{
+			name:   "when there are no plaintext tokens, then it should return an empty list",
+			params: refresh.FindPlaintextTokensParams{Limit: 10},
+			want:   []refresh.PlaintextToken{},
+		},
+		{
+			name: "when there are plaintext tokens, then it should return only them up to the limit",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, bson.M{
+					"_id":                 legacyID,
+					refresh.FieldToken:    "plaintext-token",
+					refresh.FieldStatus:   refresh.TokenStatusActive,
+					refresh.FieldUserID:   "fake-user-id",
+					refresh.FieldFamilyID: "fake-family-id",
+				})
+				mongodb.InsertTestDocument(t, coll, bson.M{
+					refresh.FieldToken:  "another-plaintext-token",
+					refresh.FieldStatus: refresh.TokenStatusActive,
+				})
+				mongodb.InsertTestDocument(t, coll, refresh.Token{
+					UserID:    "fake-user-id",
+					TokenHash: "hashed-token",
+					Status:    refresh.TokenStatusActive,
+				})
+			},
+			params: refresh.FindPlaintextTokensParams{Limit: 1},
+			want: []refresh.PlaintextToken{
+				{ID: legacyID.Hex(), Token: "plaintext-token"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestRefreshTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			tokens, err := repo.FindPlaintextTokens(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, tokens)
+		})
+	}
+}
+
+func TestRepository_FindPlaintextTokens_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestRefreshTokenCollection(t, tdb.DB)
+
+	repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindPlaintextTokens(context.Background(), refresh.FindPlaintextTokensParams{Limit: 1})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_HashPlaintextToken(t *testing.T) {
+	var (
+		now      = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		legacyID = primitive.NewObjectID()
+	)
+	logger, _ := log.NewTest()
+
+	tests := []refreshRepositoryTestCase[refresh.HashPlaintextTokenParams, any]{
+		{
+			name: "when the plaintext token does not exist, then it should return a refresh token not found error",
+			params: refresh.HashPlaintextTokenParams{
+				ID:        legacyID.Hex(),
+				Token:     "plaintext-token",
+				TokenHash: "hashed-token",
+			},
+			wantErr: refresh.ErrRefreshTokenNotFound,
+		},
+		{
+			name: "when the token was already migrated, then it should return a refresh token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, bson.M{
+					"_id":                  legacyID,
+					refresh.FieldTokenHash: "hashed-token",
+					refresh.FieldStatus:    refresh.TokenStatusActive,
+				})
+			},
+			params: refresh.HashPlaintextTokenParams{
+				ID:        legacyID.Hex(),
+				Token:     "plaintext-token",
+				TokenHash: "hashed-token",
+			},
+			wantErr: refresh.ErrRefreshTokenNotFound,
+		},
+		{
+			name: "when the plaintext token exists, then it should replace it by its digest",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, bson.M{
+					"_id":               legacyID,
+					refresh.FieldToken:  "plaintext-token",
+					refresh.FieldStatus: refresh.TokenStatusActive,
+				})
+			},
+			params: refresh.HashPlaintextTokenParams{
+				ID:        legacyID.Hex(),
+				Token:     "plaintext-token",
+				TokenHash: "hashed-token",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+			defer tdb.Close(t)
+
+			coll := setupTestRefreshTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.HashPlaintextToken(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// The raw token must not be stored anymore
+			if tt.wantErr == nil {
+				var doc bson.M
+				err := coll.FindOne(context.Background(), bson.M{"_id": legacyID}).Decode(&doc)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.params.TokenHash, doc[refresh.FieldTokenHash])
+				assert.NotContains(t, doc, refresh.FieldToken)
+			}
+		})
+	}
+}
+
+func TestRepository_HashPlaintextToken_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, "customers_test_authentication_service")
+	setupTestRefreshTokenCollection(t, tdb.DB)
+
+	repo := refresh.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.HashPlaintextToken(context.Background(), refresh.HashPlaintextTokenParams{
+		ID: primitive.NewObjectID().Hex(),
+	})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
 func setupTestRefreshTokenCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	coll := db.Collection(refresh.CollectionName)
 
-	// Create unique index on token.
+	// Create unique index on the token digest. The tokens stored before being hashed don't have it yet.
 	indexModel := mongo.IndexModel{
-		Keys:    bson.D{{Key: refresh.FieldToken, Value: 1}},
-		Options: options.Index().SetUnique(true),
+		Keys: bson.D{{Key: refresh.FieldTokenHash, Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{refresh.FieldTokenHash: bson.M{"$type": "string"}}),
 	}
 	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
 		t.Fatalf("Failed to create unique index: %v", err)
@@ -8,9 +8,13 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 )
@@ -22,6 +26,12 @@ const (
 	DefaultTokenExpiration = 7 * 24 * time.Hour
 	// DefaultTokenExpiresInSec represents when the token will be effectively expired from now, in seconds.
 	DefaultTokenExpiresInSec = 5
+
+	// PlaintextTokensBatchSize defines how many plaintext refresh tokens are hashed on each migration step.
+	PlaintextTokensBatchSize = 100
+
+	// SecurityEventTokenReuse identifies the security event logged when a rotated refresh token is presented again.
+	SecurityEventTokenReuse = "refresh_token_reuse"
 )
 
 // Service represents the core interface for refresh tokens.
@@ -30,25 +40,43 @@ const (
 type Service interface {
 	Generate(ctx context.Context, input GenerateTokenInput) (GenerateTokenOutput, error)
 	FindActiveToken(ctx context.Context, input FindActiveTokenInput) (FindActiveTokenOutput, error)
+	DetectReuse(ctx context.Context, input DetectReuseInput) (DetectReuseOutput, error)
 	Expire(ctx context.Context, input ExpireInput) (ExpireOutput, error)
+	Revoke(ctx context.Context, input RevokeInput) (RevokeOutput, error)
+	RevokeAll(ctx context.Context, input RevokeAllInput) (RevokeAllOutput, error)
+	ListSessions(ctx context.Context, input ListSessionsInput) (ListSessionsOutput, error)
+	MigratePlaintextTokens(ctx context.Context, input MigratePlaintextTokensInput) (MigratePlaintextTokensOutput, error)
 }
 
 type service struct {
-	logger log.Logger
-	repo   Repository
-	clock  clock.Clock
+	logger   log.Logger
+	repo     Repository
+	clock    clock.Clock
+	hasher   TokenHasher
+	denylist auth.Denylist
 }
 
-// NewService initializes and returns a new Service implementation.
-func NewService(logger log.Logger, repo Repository, clk clock.Clock) Service {
-	return &service{logger: logger, repo: repo, clock: clk}
+// NewService initializes and returns a new Service implementation. The access tokens issued along with the refresh
+// tokens are added to the denylist when their session is revoked.
+func NewService(
+	logger log.Logger,
+	repo Repository,
+	clk clock.Clock,
+	hasher TokenHasher,
+	denylist auth.Denylist,
+) Service {
+	return &service{logger: logger, repo: repo, clock: clk, hasher: hasher, denylist: denylist}
 }
 
 // GenerateTokenInput represents the input data required for generating a token.
+// FamilyID must be set when the token is issued by rotating a previous one. Otherwise, a new family is started.
+// AccessToken identifies the access token issued along with the refresh token, if any.
 type GenerateTokenInput struct {
-	UserID   string
-	Role     string
-	TenantID string
+	UserID      string
+	Role        string
+	TenantID    string
+	FamilyID    string
+	AccessToken AccessToken
 }
 
 // GenerateTokenOutput represents the output result of a token generation operation.
@@ -65,22 +93,31 @@ func (s *service) Generate(ctx context.Context, input GenerateTokenInput) (Gener
 		return GenerateTokenOutput{}, err
 	}
 
-	device := getDeviceFromContext(ctx)
+	familyID := input.FamilyID
+	if familyID == "" {
+		familyID = uuid.NewString()
+	}
+
+	now := s.clock.Now()
+	device := getDeviceFromContext(ctx, now)
 	params := CreateTokenParams{
 		UserID:    input.UserID,
 		Role:      input.Role,
 		TenantID:  input.TenantID,
-		Token:     token,
-		ExpiresAt: time.Now().Add(DefaultTokenExpiration),
+		TokenHash: s.hasher.Hash(token),
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(DefaultTokenExpiration),
 		Device:    device,
 	}
+	if input.AccessToken.ID != "" {
+		params.AccessToken = &input.AccessToken
+	}
 
-	refreshToken, err := s.repo.Create(ctx, params)
-	if err != nil {
+	if _, err := s.repo.Create(ctx, params); err != nil {
 		logger.Error("failed to store refresh token", err)
 		return GenerateTokenOutput{}, err
 	}
-	return GenerateTokenOutput{Token: refreshToken.Token}, nil
+	return GenerateTokenOutput{Token: token}, nil
 }
 
 // FindActiveTokenInput represents the input required to locate an active refresh token.
@@ -90,30 +127,97 @@ type FindActiveTokenInput struct {
 
 // FindActiveTokenOutput represents the result of a query to locate an active refresh token associated with a user.
 type FindActiveTokenOutput struct {
-	ID       string
-	Token    string
-	UserID   string
-	Role     string
-	TenantID string
-	Device   DeviceInfo
+	ID        string
+	Token     string
+	UserID    string
+	Role      string
+	TenantID  string
+	FamilyID  string
+	Device    DeviceInfo
+	ExpiresAt time.Time
+	CreatedAt time.Time
 }
 
 func (s *service) FindActiveToken(ctx context.Context, input FindActiveTokenInput) (FindActiveTokenOutput, error) {
 	logger := s.logger.WithContext(ctx)
 
-	token, err := s.repo.FindActiveToken(ctx, input.Token)
+	token, err := s.repo.FindActiveToken(ctx, s.hasher.Hash(input.Token))
 	if err != nil {
 		logger.Error("failed to find active refresh token", err)
 		return FindActiveTokenOutput{}, err
 	}
 
 	return FindActiveTokenOutput{
-		ID:       token.ID,
-		Token:    token.Token,
-		UserID:   token.UserID,
-		Role:     token.Role,
-		Device:   token.DeviceInfo,
-		TenantID: token.TenantID,
+		ID:        token.ID,
+		Token:     input.Token,
+		UserID:    token.UserID,
+		Role:      token.Role,
+		Device:    token.DeviceInfo,
+		TenantID:  token.TenantID,
+		FamilyID:  token.FamilyID,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}, nil
+}
+
+// DetectReuseInput represents the input required to check whether a refresh token has been reused.
+type DetectReuseInput struct {
+	Token string
+}
+
+// DetectReuseOutput represents the result of a refresh token reuse check.
+type DetectReuseOutput struct {
+	Reused        bool
+	UserID        string
+	FamilyID      string
+	RevokedTokens int64
+}
+
+// DetectReuse checks whether the given token was already rotated and its grace period is over. If so, the token is
+// considered stolen, and the whole family is revoked along with its access tokens, so neither the attacker nor the
+// legitimate user can keep using it. The legitimate user will have to log in again.
+func (s *service) DetectReuse(ctx context.Context, input DetectReuseInput) (DetectReuseOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	token, err := s.repo.FindToken(ctx, s.hasher.Hash(input.Token))
+	if err != nil {
+		if errors.Is(err, ErrRefreshTokenNotFound) {
+			return DetectReuseOutput{}, nil
+		}
+		logger.Error("failed to find refresh token", err)
+		return DetectReuseOutput{}, err
+	}
+
+	if token.RotatedAt == nil || token.ExpiresAt.After(s.clock.Now()) {
+		return DetectReuseOutput{}, nil
+	}
+
+	revoked, err := s.repo.RevokeFamily(ctx, RevokeFamilyParams{FamilyID: token.FamilyID})
+	if err != nil {
+		logger.Error("failed to revoke refresh token family", err)
+		return DetectReuseOutput{}, err
+	}
+
+	// The access tokens issued along with the family are denied too, as the attacker may be holding one of them
+	if err := s.denyAccessTokens(ctx, revoked.AccessTokens); err != nil {
+		return DetectReuseOutput{}, err
+	}
+
+	logger.Warn(
+		"security event: refresh token reuse detected",
+		log.Field{Key: "event", Value: SecurityEventTokenReuse},
+		log.Field{Key: "user_id", Value: token.UserID},
+		log.Field{Key: "role", Value: token.Role},
+		log.Field{Key: "tenant_id", Value: token.TenantID},
+		log.Field{Key: "family_id", Value: token.FamilyID},
+		log.Field{Key: "rotated_at", Value: token.RotatedAt},
+		log.Field{Key: "revoked_tokens", Value: revoked.RevokedTokens},
+	)
+	return DetectReuseOutput{
+		Reused:        true,
+		UserID:        token.UserID,
+		FamilyID:      token.FamilyID,
+		RevokedTokens: revoked.RevokedTokens,
 	}, nil
 }
 
@@ -133,7 +237,7 @@ func (s *service) Expire(ctx context.Context, input ExpireInput) (ExpireOutput,
 	logger := s.logger.WithContext(ctx)
 
 	token, err := s.repo.Expire(ctx, ExpireParams{
-		Token:     input.Token,
+		TokenHash: s.hasher.Hash(input.Token),
 		ExpiresAt: s.clock.Now().Add(DefaultTokenExpiresInSec * time.Second),
 	})
 	if err != nil {
@@ -142,11 +246,173 @@ func (s *service) Expire(ctx context.Context, input ExpireInput) (ExpireOutput,
 	}
 	return ExpireOutput{
 		ID:        token.ID,
-		Token:     token.Token,
+		Token:     input.Token,
 		ExpiresAt: token.ExpiresAt,
 	}, nil
 }
 
+// RevokeInput represents the input required to revoke a single refresh token.
+type RevokeInput struct {
+	Token string
+}
+
+// RevokeOutput represents the output structure of a token revocation operation.
+type RevokeOutput struct {
+	ID       string
+	UserID   string
+	Role     string
+	TenantID string
+}
+
+func (s *service) Revoke(ctx context.Context, input RevokeInput) (RevokeOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	token, err := s.repo.Revoke(ctx, RevokeParams{TokenHash: s.hasher.Hash(input.Token)})
+	if err != nil {
+		logger.Error("failed to revoke refresh token", err)
+		return RevokeOutput{}, err
+	}
+
+	if token.AccessToken != nil {
+		if err := s.denyAccessTokens(ctx, []AccessToken{*token.AccessToken}); err != nil {
+			return RevokeOutput{}, err
+		}
+	}
+	return RevokeOutput{
+		ID:       token.ID,
+		UserID:   token.UserID,
+		Role:     token.Role,
+		TenantID: token.TenantID,
+	}, nil
+}
+
+// RevokeAllInput represents the input required to revoke all the active refresh tokens of a user.
+// AllTenants revokes them in every tenant of the user, instead of only in TenantID.
+// DeviceID is optional, and restricts the revocation to a single session. ExceptDeviceID is optional too, and keeps
+// that session active while revoking the rest.
+type RevokeAllInput struct {
+	UserID         string
+	Role           string
+	TenantID       string
+	AllTenants     bool
+	DeviceID       string
+	ExceptDeviceID string
+}
+
+// RevokeAllOutput represents the output structure of a bulk token revocation operation.
+type RevokeAllOutput struct {
+	RevokedTokens int64
+}
+
+func (s *service) RevokeAll(ctx context.Context, input RevokeAllInput) (RevokeAllOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// The access tokens are found before revoking the refresh tokens, as only the active ones are matched
+	params := RevokeAllParams(input)
+	accessTokens, err := s.repo.FindAccessTokens(ctx, params)
+	if err != nil {
+		logger.Error("failed to find the access tokens of the refresh tokens", err)
+		return RevokeAllOutput{}, err
+	}
+
+	revoked, err := s.repo.RevokeAll(ctx, params)
+	if err != nil {
+		logger.Error("failed to revoke refresh tokens", err)
+		return RevokeAllOutput{}, err
+	}
+
+	if err := s.denyAccessTokens(ctx, accessTokens); err != nil {
+		return RevokeAllOutput{}, err
+	}
+	return RevokeAllOutput{RevokedTokens: revoked}, nil
+}
+
+// denyAccessTokens adds the access tokens to the denylist, so they can't be used anymore even if not expired yet.
+func (s *service) denyAccessTokens(ctx context.Context, accessTokens []AccessToken) error {
+	logger := s.logger.WithContext(ctx)
+
+	for _, accessToken := range accessTokens {
+		if err := s.denylist.Add(ctx, accessToken.ID, accessToken.ExpiresAt); err != nil {
+			logger.Error("failed to deny access token", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// ListSessionsInput represents the input required to list the active sessions of a user.
+type ListSessionsInput struct {
+	UserID   string
+	Role     string
+	TenantID string
+}
+
+// ListSessionsOutput represents the active sessions of a user, sorted by the most recently used first.
+type ListSessionsOutput struct {
+	Sessions []Session
+}
+
+func (s *service) ListSessions(ctx context.Context, input ListSessionsInput) (ListSessionsOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	sessions, err := s.repo.FindActiveSessions(ctx, FindActiveSessionsParams(input))
+	if err != nil {
+		logger.Error("failed to find active sessions", err)
+		return ListSessionsOutput{}, err
+	}
+	return ListSessionsOutput{Sessions: sessions}, nil
+}
+
+// MigratePlaintextTokensInput represents the input required to migrate the refresh tokens stored in plaintext.
+type MigratePlaintextTokensInput struct{}
+
+// MigratePlaintextTokensOutput represents the result of the plaintext refresh tokens migration.
+type MigratePlaintextTokensOutput struct {
+	MigratedTokens int64
+}
+
+// MigratePlaintextTokens replaces the refresh tokens stored before they were hashed at rest by their digest, so they
+// are still valid after the migration. It is safe to run it several times, or concurrently, as the tokens that were
+// already migrated are skipped.
+func (s *service) MigratePlaintextTokens(
+	ctx context.Context,
+	_ MigratePlaintextTokensInput,
+) (MigratePlaintextTokensOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	var migrated int64
+	for {
+		tokens, err := s.repo.FindPlaintextTokens(ctx, FindPlaintextTokensParams{Limit: PlaintextTokensBatchSize})
+		if err != nil {
+			logger.Error("failed to find plaintext refresh tokens", err)
+			return MigratePlaintextTokensOutput{}, err
+		}
+		if len(tokens) == 0 {
+			break
+		}
+
+		for _, token := range tokens {
+			err := s.repo.HashPlaintextToken(ctx, HashPlaintextTokenParams{
+				ID:        token.ID,
+				Token:     token.Token,
+				TokenHash: s.hasher.Hash(token.Token),
+			})
+			if err != nil {
+				// The token was migrated by another instance in the meantime
+				if errors.Is(err, ErrRefreshTokenNotFound) {
+					continue
+				}
+				logger.Error("failed to hash plaintext refresh token", err)
+				return MigratePlaintextTokensOutput{}, err
+			}
+			migrated++
+		}
+	}
+
+	logger.Info("plaintext refresh tokens migrated", log.Field{Key: "migrated", Value: migrated})
+	return MigratePlaintextTokensOutput{MigratedTokens: migrated}, nil
+}
+
 func generateToken() (string, error) {
 	// Creating a cryptographically secure random refresh token by:
 	// 1. Allocating a byte slice of defined length (32 bytes)
@@ -165,7 +431,12 @@ func generateDeviceID(userAgent, ip string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-func getDeviceFromContext(ctx context.Context) DeviceInfo {
+// DeviceIDFromContext returns the ID of the device performing the request, which identifies its session.
+func DeviceIDFromContext(ctx context.Context) string {
+	return generateDeviceID(log.UserAgentFromContext(ctx), log.RealIPFromContext(ctx))
+}
+
+func getDeviceFromContext(ctx context.Context, now time.Time) DeviceInfo {
 	ip := log.RealIPFromContext(ctx)
 	userAgent := log.UserAgentFromContext(ctx)
 	deviceID := generateDeviceID(userAgent, ip)
@@ -174,7 +445,7 @@ func getDeviceFromContext(ctx context.Context) DeviceInfo {
 		DeviceID:    deviceID,
 		UserAgent:   userAgent,
 		IP:          ip,
-		FirstUsedAt: time.Now(),
-		LastUsedAt:  time.Now(),
+		FirstUsedAt: now,
+		LastUsedAt:  now,
 	}
 }
@@ -12,24 +12,34 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
 	refreshmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh/mocks"
 )
 
-var errRepo = errors.New("repository error")
+var (
+	errRepo     = errors.New("repository error")
+	errDenylist = errors.New("denylist error")
+	hasher      = refresh.NewHMACHasher([]byte("fake-hash-key"))
+)
 
 type refreshServiceTestCase[I, W any] struct {
 	name       string
 	input      I
 	mocksSetup func(repo *refreshmocks.MockRepository)
-	want       W
-	wantErr    error
+	// denylistSetup is only needed by the operations revoking sessions, which deny their access tokens
+	denylistSetup func(denylist *authmocks.MockDenylist)
+	want          W
+	wantErr       error
 }
 
 func TestService_Generate(t *testing.T) {
 	logger, _ := log.NewTest()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// The generated token is random, so the stored digest is captured to check it matches the returned token
+	var storedHash string
 
 	tests := []refreshServiceTestCase[refresh.GenerateTokenInput, refresh.GenerateTokenOutput]{
 		{
@@ -59,14 +69,62 @@ func TestService_Generate(t *testing.T) {
 						require.Equal(t, "fake-user-id", params.UserID)
 						require.Equal(t, "fake-role", params.Role)
 						require.Equal(t, "fake-tenant-id", params.TenantID)
-						require.NotEmpty(t, params.Token)
-						require.NotEmpty(t, params.ExpiresAt)
+						require.NotEmpty(t, params.TokenHash)
+						require.Equal(t, now.Add(refresh.DefaultTokenExpiration), params.ExpiresAt)
+						require.Equal(t, now, params.Device.FirstUsedAt)
+						require.Equal(t, now, params.Device.LastUsedAt)
+						// A new family is started when the token is not issued by a rotation
+						require.NotEmpty(t, params.FamilyID)
+						require.Nil(t, params.AccessToken)
+
+						storedHash = params.TokenHash
+						return refresh.Token{TokenHash: params.TokenHash}, nil
+					})
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the refresh token is issued along with an access token, then it links the access token",
+			input: refresh.GenerateTokenInput{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+				AccessToken: refresh.AccessToken{
+					ID:        "fake-access-token-id",
+					ExpiresAt: time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
+				},
+			},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params refresh.CreateTokenParams) (refresh.Token, error) {
+						require.Equal(t, &refresh.AccessToken{
+							ID:        "fake-access-token-id",
+							ExpiresAt: time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC),
+						}, params.AccessToken)
+
+						storedHash = params.TokenHash
+						return refresh.Token{TokenHash: params.TokenHash}, nil
+					})
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the refresh token is generated from a rotation, then it keeps the token family",
+			input: refresh.GenerateTokenInput{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+				FamilyID: "fake-family-id",
+			},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params refresh.CreateTokenParams) (refresh.Token, error) {
+						require.Equal(t, "fake-family-id", params.FamilyID)
 
-						// Returning a fake-token to simplify the assertion
-						return refresh.Token{Token: "fake-token"}, nil
+						storedHash = params.TokenHash
+						return refresh.Token{TokenHash: params.TokenHash}, nil
 					})
 			},
-			want:    refresh.GenerateTokenOutput{Token: "fake-token"},
 			wantErr: nil,
 		},
 	}
@@ -81,11 +139,21 @@ func TestService_Generate(t *testing.T) {
 				tt.mocksSetup(repo)
 			}
 
-			service := refresh.NewService(logger, repo, clock.RealClock{})
+			service := refresh.NewService(
+				logger, repo, clock.FixedClock{FixedTime: now}, hasher, authmocks.NewMockDenylist(ctrl),
+			)
 			got, err := service.Generate(context.Background(), tt.input)
 
 			assert.ErrorIs(t, err, tt.wantErr)
-			assert.Equal(t, tt.want, got)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.want, got)
+				return
+			}
+
+			// Only the digest of the returned token must be stored
+			assert.NotEmpty(t, got.Token)
+			assert.NotEqual(t, got.Token, storedHash)
+			assert.Equal(t, hasher.Hash(got.Token), storedHash)
 		})
 	}
 }
@@ -129,14 +197,15 @@ func TestService_FindByActiveToken(t *testing.T) {
 				Token: "active-token",
 			},
 			mocksSetup: func(repo *refreshmocks.MockRepository) {
-				repo.EXPECT().FindActiveToken(gomock.Any(), "active-token").
+				repo.EXPECT().FindActiveToken(gomock.Any(), hasher.Hash("active-token")).
 					Return(refresh.Token{
-						ID:       "fake-id",
-						UserID:   "fake-user-id",
-						Role:     "fake-role",
-						TenantID: "fake-tenant-id",
-						Token:    "fake-token",
-						Status:   refresh.TokenStatusActive,
+						ID:        "fake-id",
+						UserID:    "fake-user-id",
+						Role:      "fake-role",
+						TenantID:  "fake-tenant-id",
+						TokenHash: hasher.Hash("active-token"),
+						FamilyID:  "fake-family-id",
+						Status:    refresh.TokenStatusActive,
 						DeviceInfo: refresh.DeviceInfo{
 							DeviceID:    "fake-device-id",
 							UserAgent:   "fake-user-agent",
@@ -151,10 +220,11 @@ func TestService_FindByActiveToken(t *testing.T) {
 			},
 			want: refresh.FindActiveTokenOutput{
 				ID:       "fake-id",
-				Token:    "fake-token",
+				Token:    "active-token",
 				UserID:   "fake-user-id",
 				Role:     "fake-role",
 				TenantID: "fake-tenant-id",
+				FamilyID: "fake-family-id",
 				Device: refresh.DeviceInfo{
 					DeviceID:    "fake-device-id",
 					UserAgent:   "fake-user-agent",
@@ -162,6 +232,8 @@ func TestService_FindByActiveToken(t *testing.T) {
 					FirstUsedAt: yesterday,
 					LastUsedAt:  now,
 				},
+				ExpiresAt: tomorrow,
+				CreatedAt: yesterday,
 			},
 		},
 	}
@@ -176,7 +248,7 @@ func TestService_FindByActiveToken(t *testing.T) {
 				tt.mocksSetup(repo)
 			}
 
-			service := refresh.NewService(logger, repo, clock.RealClock{})
+			service := refresh.NewService(logger, repo, clock.RealClock{}, hasher, authmocks.NewMockDenylist(ctrl))
 			got, err := service.FindActiveToken(context.Background(), tt.input)
 
 			assert.ErrorIs(t, err, tt.wantErr)
@@ -211,13 +283,13 @@ func TestService_Expire(t *testing.T) {
 			},
 			mocksSetup: func(repo *refreshmocks.MockRepository) {
 				repo.EXPECT().Expire(gomock.Any(), refresh.ExpireParams{
-					Token:     "fake-token",
+					TokenHash: hasher.Hash("fake-token"),
 					ExpiresAt: now.Add(5 * time.Second),
 				}).Return(refresh.Token{
 					ID:        "fake-token-id",
 					Role:      "fake-role",
 					TenantID:  "fake-tenant-id",
-					Token:     "fake-token",
+					TokenHash: hasher.Hash("fake-token"),
 					Status:    refresh.TokenStatusActive,
 					ExpiresAt: now.Add(5 * time.Second),
 					CreatedAt: yesterday,
@@ -243,7 +315,9 @@ func TestService_Expire(t *testing.T) {
 				tt.mocksSetup(repo)
 			}
 
-			service := refresh.NewService(logger, repo, clock.FixedClock{FixedTime: now})
+			service := refresh.NewService(
+				logger, repo, clock.FixedClock{FixedTime: now}, hasher, authmocks.NewMockDenylist(ctrl),
+			)
 			got, err := service.Expire(context.Background(), tt.input)
 
 			assert.ErrorIs(t, err, tt.wantErr)
@@ -251,3 +325,525 @@ func TestService_Expire(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Revoke(t *testing.T) {
+	logger, _ := log.NewTest()
+	accessTokenExpiresAt := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	tests := []refreshServiceTestCase[refresh.RevokeInput, refresh.RevokeOutput]{
+		{
+			name:  "when the token is not found, then it propagates the error",
+			input: refresh.RevokeInput{Token: "fake-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(refresh.Token{}, refresh.ErrRefreshTokenNotFound)
+			},
+			want:    refresh.RevokeOutput{},
+			wantErr: refresh.ErrRefreshTokenNotFound,
+		},
+		{
+			name:  "when unable to revoke the token, then it propagates the error",
+			input: refresh.RevokeInput{Token: "fake-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(refresh.Token{}, errRepo)
+			},
+			want:    refresh.RevokeOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the token is revoked, then it returns the token owner",
+			input: refresh.RevokeInput{Token: "fake-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().Revoke(gomock.Any(), refresh.RevokeParams{TokenHash: hasher.Hash("fake-token")}).Return(refresh.Token{
+					ID:        "fake-token-id",
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TenantID:  "fake-tenant-id",
+					TokenHash: hasher.Hash("fake-token"),
+					Status:    refresh.TokenStatusRevoked,
+				}, nil)
+			},
+			want: refresh.RevokeOutput{
+				ID:       "fake-token-id",
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "when unable to deny the access token of the revoked token, then it propagates the error",
+			input: refresh.RevokeInput{Token: "fake-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(refresh.Token{
+					ID:          "fake-token-id",
+					AccessToken: &refresh.AccessToken{ID: "fake-access-token-id", ExpiresAt: accessTokenExpiresAt},
+				}, nil)
+			},
+			denylistSetup: func(denylist *authmocks.MockDenylist) {
+				denylist.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).Return(errDenylist)
+			},
+			want:    refresh.RevokeOutput{},
+			wantErr: errDenylist,
+		},
+		{
+			name:  "when the revoked token has an access token, then it denies the access token",
+			input: refresh.RevokeInput{Token: "fake-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(refresh.Token{
+					ID:          "fake-token-id",
+					UserID:      "fake-user-id",
+					Role:        "fake-role",
+					TenantID:    "fake-tenant-id",
+					AccessToken: &refresh.AccessToken{ID: "fake-access-token-id", ExpiresAt: accessTokenExpiresAt},
+				}, nil)
+			},
+			denylistSetup: func(denylist *authmocks.MockDenylist) {
+				denylist.EXPECT().Add(gomock.Any(), "fake-access-token-id", accessTokenExpiresAt).Return(nil)
+			},
+			want: refresh.RevokeOutput{
+				ID:       "fake-token-id",
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := refreshmocks.NewMockRepository(ctrl)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo)
+			}
+
+			denylist := authmocks.NewMockDenylist(ctrl)
+			if tt.denylistSetup != nil {
+				tt.denylistSetup(denylist)
+			}
+
+			service := refresh.NewService(logger, repo, clock.RealClock{}, hasher, denylist)
+			got, err := service.Revoke(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RevokeAll(t *testing.T) {
+	logger, _ := log.NewTest()
+	accessTokenExpiresAt := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	tests := []refreshServiceTestCase[refresh.RevokeAllInput, refresh.RevokeAllOutput]{
+		{
+			name: "when unable to revoke the tokens, then it propagates the error",
+			input: refresh.RevokeAllInput{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindAccessTokens(gomock.Any(), gomock.Any()).Return([]refresh.AccessToken{}, nil)
+				repo.EXPECT().RevokeAll(gomock.Any(), gomock.Any()).Return(int64(0), errRepo)
+			},
+			want:    refresh.RevokeAllOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when unable to find the access tokens, then it propagates the error without revoking the tokens",
+			input: refresh.RevokeAllInput{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindAccessTokens(gomock.Any(), gomock.Any()).Return(nil, errRepo)
+			},
+			want:    refresh.RevokeAllOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when unable to deny the access tokens, then it propagates the error",
+			input: refresh.RevokeAllInput{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindAccessTokens(gomock.Any(), gomock.Any()).Return([]refresh.AccessToken{
+					{ID: "fake-access-token-id", ExpiresAt: accessTokenExpiresAt},
+				}, nil)
+				repo.EXPECT().RevokeAll(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+			},
+			denylistSetup: func(denylist *authmocks.MockDenylist) {
+				denylist.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).Return(errDenylist)
+			},
+			want:    refresh.RevokeAllOutput{},
+			wantErr: errDenylist,
+		},
+		{
+			name: "when the tokens are revoked, then it denies their access tokens and returns the number of revoked tokens",
+			input: refresh.RevokeAllInput{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				params := refresh.RevokeAllParams{
+					UserID:   "fake-user-id",
+					Role:     "fake-role",
+					TenantID: "fake-tenant-id",
+				}
+				repo.EXPECT().FindAccessTokens(gomock.Any(), params).Return([]refresh.AccessToken{
+					{ID: "fake-access-token-id", ExpiresAt: accessTokenExpiresAt},
+					{ID: "fake-other-access-token-id", ExpiresAt: accessTokenExpiresAt},
+				}, nil)
+				repo.EXPECT().RevokeAll(gomock.Any(), params).Return(int64(3), nil)
+			},
+			denylistSetup: func(denylist *authmocks.MockDenylist) {
+				denylist.EXPECT().Add(gomock.Any(), "fake-access-token-id", accessTokenExpiresAt).Return(nil)
+				denylist.EXPECT().Add(gomock.Any(), "fake-other-access-token-id", accessTokenExpiresAt).Return(nil)
+			},
+			want:    refresh.RevokeAllOutput{RevokedTokens: 3},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := refreshmocks.NewMockRepository(ctrl)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo)
+			}
+
+			denylist := authmocks.NewMockDenylist(ctrl)
+			if tt.denylistSetup != nil {
+				tt.denylistSetup(denylist)
+			}
+
+			service := refresh.NewService(logger, repo, clock.RealClock{}, hasher, denylist)
+			got, err := service.RevokeAll(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ListSessions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []refreshServiceTestCase[refresh.ListSessionsInput, refresh.ListSessionsOutput]{
+		{
+			name: "when unable to find the sessions, then it propagates the error",
+			input: refresh.ListSessionsInput{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindActiveSessions(gomock.Any(), gomock.Any()).Return(nil, errRepo)
+			},
+			want:    refresh.ListSessionsOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when the sessions are found, then it returns them",
+			input: refresh.ListSessionsInput{
+				UserID:   "fake-user-id",
+				Role:     "fake-role",
+				TenantID: "fake-tenant-id",
+			},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindActiveSessions(gomock.Any(), refresh.FindActiveSessionsParams{
+					UserID:   "fake-user-id",
+					Role:     "fake-role",
+					TenantID: "fake-tenant-id",
+				}).Return([]refresh.Session{
+					{
+						DeviceID:   "fake-device-id",
+						UserAgent:  "fake-user-agent",
+						IP:         "fake-ip",
+						LastUsedAt: now,
+					},
+				}, nil)
+			},
+			want: refresh.ListSessionsOutput{
+				Sessions: []refresh.Session{
+					{
+						DeviceID:   "fake-device-id",
+						UserAgent:  "fake-user-agent",
+						IP:         "fake-ip",
+						LastUsedAt: now,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := refreshmocks.NewMockRepository(ctrl)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo)
+			}
+
+			service := refresh.NewService(logger, repo, clock.RealClock{}, hasher, authmocks.NewMockDenylist(ctrl))
+			got, err := service.ListSessions(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_DetectReuse(t *testing.T) {
+	var (
+		now                  = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		rotatedAt            = time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC)
+		accessTokenExpiresAt = time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []refreshServiceTestCase[refresh.DetectReuseInput, refresh.DetectReuseOutput]{
+		{
+			name:  "when the token does not exist, then it is not considered a reuse",
+			input: refresh.DetectReuseInput{Token: "unexisting-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindToken(gomock.Any(), hasher.Hash("unexisting-token")).
+					Return(refresh.Token{}, refresh.ErrRefreshTokenNotFound)
+			},
+			want:    refresh.DetectReuseOutput{},
+			wantErr: nil,
+		},
+		{
+			name:  "when there is an unexpected error when finding the token, then it propagates the error",
+			input: refresh.DetectReuseInput{Token: "fake-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindToken(gomock.Any(), gomock.Any()).Return(refresh.Token{}, errRepo)
+			},
+			want:    refresh.DetectReuseOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the token was never rotated, then it is not considered a reuse",
+			input: refresh.DetectReuseInput{Token: "expired-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindToken(gomock.Any(), gomock.Any()).Return(refresh.Token{
+					UserID:    "fake-user-id",
+					FamilyID:  "fake-family-id",
+					TokenHash: hasher.Hash("expired-token"),
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: rotatedAt,
+				}, nil)
+			},
+			want:    refresh.DetectReuseOutput{},
+			wantErr: nil,
+		},
+		{
+			name:  "when the token was rotated but it is in its grace period, then it is not considered a reuse",
+			input: refresh.DetectReuseInput{Token: "rotated-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindToken(gomock.Any(), gomock.Any()).Return(refresh.Token{
+					UserID:    "fake-user-id",
+					FamilyID:  "fake-family-id",
+					TokenHash: hasher.Hash("rotated-token"),
+					Status:    refresh.TokenStatusRevoked,
+					ExpiresAt: now.Add(time.Second),
+					RotatedAt: &now,
+				}, nil)
+			},
+			want:    refresh.DetectReuseOutput{},
+			wantErr: nil,
+		},
+		{
+			name:  "when unable to revoke the token family, then it propagates the error",
+			input: refresh.DetectReuseInput{Token: "rotated-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindToken(gomock.Any(), gomock.Any()).Return(refresh.Token{
+					UserID:    "fake-user-id",
+					FamilyID:  "fake-family-id",
+					TokenHash: hasher.Hash("rotated-token"),
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: rotatedAt.Add(refresh.DefaultTokenExpiresInSec * time.Second),
+					RotatedAt: &rotatedAt,
+				}, nil)
+				repo.EXPECT().RevokeFamily(gomock.Any(), gomock.Any()).Return(refresh.RevokedFamily{}, errRepo)
+			},
+			want:    refresh.DetectReuseOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when unable to deny the access tokens of the token family, then it propagates the error",
+			input: refresh.DetectReuseInput{Token: "rotated-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindToken(gomock.Any(), gomock.Any()).Return(refresh.Token{
+					UserID:    "fake-user-id",
+					FamilyID:  "fake-family-id",
+					TokenHash: hasher.Hash("rotated-token"),
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: rotatedAt.Add(refresh.DefaultTokenExpiresInSec * time.Second),
+					RotatedAt: &rotatedAt,
+				}, nil)
+				repo.EXPECT().RevokeFamily(gomock.Any(), gomock.Any()).Return(refresh.RevokedFamily{
+					RevokedTokens: 1,
+					AccessTokens:  []refresh.AccessToken{{ID: "fake-access-token-id", ExpiresAt: accessTokenExpiresAt}},
+				}, nil)
+			},
+			denylistSetup: func(denylist *authmocks.MockDenylist) {
+				denylist.EXPECT().Add(gomock.Any(), gomock.Any(), gomock.Any()).Return(errDenylist)
+			},
+			want:    refresh.DetectReuseOutput{},
+			wantErr: errDenylist,
+		},
+		{
+			name: "when the token was rotated and its grace period is over, " +
+				"then it revokes the token family and denies its access tokens",
+			input: refresh.DetectReuseInput{Token: "rotated-token"},
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindToken(gomock.Any(), gomock.Any()).Return(refresh.Token{
+					UserID:    "fake-user-id",
+					FamilyID:  "fake-family-id",
+					TokenHash: hasher.Hash("rotated-token"),
+					Status:    refresh.TokenStatusActive,
+					ExpiresAt: rotatedAt.Add(refresh.DefaultTokenExpiresInSec * time.Second),
+					RotatedAt: &rotatedAt,
+				}, nil)
+				repo.EXPECT().RevokeFamily(gomock.Any(), refresh.RevokeFamilyParams{
+					FamilyID: "fake-family-id",
+				}).Return(refresh.RevokedFamily{
+					RevokedTokens: 2,
+					AccessTokens: []refresh.AccessToken{
+						{ID: "fake-access-token-id", ExpiresAt: accessTokenExpiresAt},
+						{ID: "fake-other-access-token-id", ExpiresAt: accessTokenExpiresAt},
+					},
+				}, nil)
+			},
+			denylistSetup: func(denylist *authmocks.MockDenylist) {
+				denylist.EXPECT().Add(gomock.Any(), "fake-access-token-id", accessTokenExpiresAt).Return(nil)
+				denylist.EXPECT().Add(gomock.Any(), "fake-other-access-token-id", accessTokenExpiresAt).Return(nil)
+			},
+			want: refresh.DetectReuseOutput{
+				Reused:        true,
+				UserID:        "fake-user-id",
+				FamilyID:      "fake-family-id",
+				RevokedTokens: 2,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := refreshmocks.NewMockRepository(ctrl)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo)
+			}
+
+			denylist := authmocks.NewMockDenylist(ctrl)
+			if tt.denylistSetup != nil {
+				tt.denylistSetup(denylist)
+			}
+
+			service := refresh.NewService(logger, repo, clock.FixedClock{FixedTime: now}, hasher, denylist)
+			got, err := service.DetectReuse(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_MigratePlaintextTokens(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []refreshServiceTestCase[refresh.MigratePlaintextTokensInput, refresh.MigratePlaintextTokensOutput]{
+		{
+			name: "when unable to find the plaintext tokens, then it propagates the error",
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindPlaintextTokens(gomock.Any(), gomock.Any()).Return(nil, errRepo)
+			},
+			want:    refresh.MigratePlaintextTokensOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there are no plaintext tokens, then it does not migrate any token",
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindPlaintextTokens(gomock.Any(), refresh.FindPlaintextTokensParams{
+					Limit: refresh.PlaintextTokensBatchSize,
+				}).Return([]refresh.PlaintextToken{}, nil)
+			},
+			want:    refresh.MigratePlaintextTokensOutput{MigratedTokens: 0},
+			wantErr: nil,
+		},
+		{
+			name: "when unable to hash a plaintext token, then it propagates the error",
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				repo.EXPECT().FindPlaintextTokens(gomock.Any(), gomock.Any()).Return([]refresh.PlaintextToken{
+					{ID: "fake-id", Token: "fake-token"},
+				}, nil)
+				repo.EXPECT().HashPlaintextToken(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    refresh.MigratePlaintextTokensOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there are plaintext tokens, then it replaces them by their digest until none is left",
+			mocksSetup: func(repo *refreshmocks.MockRepository) {
+				gomock.InOrder(
+					repo.EXPECT().FindPlaintextTokens(gomock.Any(), gomock.Any()).Return([]refresh.PlaintextToken{
+						{ID: "fake-id", Token: "fake-token"},
+						{ID: "migrated-id", Token: "migrated-token"},
+					}, nil),
+					repo.EXPECT().HashPlaintextToken(gomock.Any(), refresh.HashPlaintextTokenParams{
+						ID:        "fake-id",
+						Token:     "fake-token",
+						TokenHash: hasher.Hash("fake-token"),
+					}).Return(nil),
+					// The token was migrated by another instance in the meantime
+					repo.EXPECT().HashPlaintextToken(gomock.Any(), refresh.HashPlaintextTokenParams{
+						ID:        "migrated-id",
+						Token:     "migrated-token",
+						TokenHash: hasher.Hash("migrated-token"),
+					}).Return(refresh.ErrRefreshTokenNotFound),
+					repo.EXPECT().FindPlaintextTokens(gomock.Any(), gomock.Any()).Return([]refresh.PlaintextToken{}, nil),
+				)
+			},
+			want:    refresh.MigratePlaintextTokensOutput{MigratedTokens: 1},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := refreshmocks.NewMockRepository(ctrl)
+			if tt.mocksSetup != nil {
+				tt.mocksSetup(repo)
+			}
+
+			service := refresh.NewService(logger, repo, clock.RealClock{}, hasher, authmocks.NewMockDenylist(ctrl))
+			got, err := service.MigratePlaintextTokens(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Package refreshcookie provides the opt-in cookie mode for the browser clients, where the refresh token is kept in an
+// HttpOnly cookie instead of being handled by JavaScript. As the browser sends the cookie by itself, the refreshes are
+// protected against CSRF with a double-submit token, which the client must echo in a header. The CSRF token is kept in
+// a cookie readable by JavaScript, so the client can read it back after a page reload.
+//
+// Both cookies are scoped to the paths given by the caller, so the browser does not send them to the other services
+// behind the gateway.
+package refreshcookie
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
+)
+
+const (
+	// ModeHeader is the request header the browser clients opt in the cookie mode with.
+	ModeHeader = "X-Refresh-Token-Mode"
+	// ModeCookie is the value of ModeHeader that enables the cookie mode.
+	ModeCookie = "cookie"
+
+	// RefreshTokenCookieName is the name of the cookie the refresh token is stored in.
+	RefreshTokenCookieName = "refresh_token"
+	// CSRFCookieName is the name of the cookie the CSRF token is stored in.
+	CSRFCookieName = "csrf_token"
+	// CSRFHeader is the request header the CSRF token must be echoed in to refresh the tokens.
+	CSRFHeader = "X-CSRF-Token"
+
+	// DefaultCSRFTokenLength defines the default length, in bytes, of a generated CSRF token.
+	DefaultCSRFTokenLength = 32
+)
+
+// Enabled reports whether the client opted in the cookie mode for the request.
+func Enabled(c *gin.Context) bool {
+	return c.GetHeader(ModeHeader) == ModeCookie
+}
+
+// Set stores the refresh token in an HttpOnly cookie scoped to refreshPath, so the browser only sends it to the routes
+// using it, and a new CSRF token in a cookie readable by the client, scoped to csrfPath. It returns the CSRF token,
+// which must be given to the client to echo it.
+func Set(c *gin.Context, refreshPath, csrfPath, refreshToken string) (string, error) {
+	csrfToken, err := generateCSRFToken()
+	if err != nil {
+		return "", err
+	}
+
+	maxAge := int(refresh.DefaultTokenExpiration.Seconds())
+	setCookie(c, RefreshTokenCookieName, refreshToken, refreshPath, maxAge, true)
+	setCookie(c, CSRFCookieName, csrfToken, csrfPath, maxAge, false)
+	return csrfToken, nil
+}
+
+// Clear expires the refresh token cookie scoped to refreshPath and the CSRF cookie scoped to csrfPath.
+func Clear(c *gin.Context, refreshPath, csrfPath string) {
+	setCookie(c, RefreshTokenCookieName, "", refreshPath, -1, true)
+	setCookie(c, CSRFCookieName, "", csrfPath, -1, false)
+}
+
+// RefreshToken returns the refresh token of the request cookie, once the CSRF token of the header is checked against
+// its cookie.
+func RefreshToken(c *gin.Context) (string, error) {
+	refreshToken, err := c.Cookie(RefreshTokenCookieName)
+	if err != nil || refreshToken == "" {
+		return "", ErrRefreshTokenNotFound
+	}
+
+	csrfCookie, err := c.Cookie(CSRFCookieName)
+	csrfHeader := c.GetHeader(CSRFHeader)
+	if err != nil || csrfCookie == "" || subtle.ConstantTimeCompare([]byte(csrfCookie), []byte(csrfHeader)) != 1 {
+		return "", ErrInvalidCSRFToken
+	}
+	return refreshToken, nil
+}
+
+func setCookie(c *gin.Context, name, value, path string, maxAge int, httpOnly bool) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		MaxAge:   maxAge,
+		Secure:   true,
+		HttpOnly: httpOnly,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func generateCSRFToken() (string, error) {
+	b := make([]byte, DefaultCSRFTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
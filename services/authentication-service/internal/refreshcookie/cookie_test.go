@@ -0,0 +1,180 @@
+//go:build unit
+
+package refreshcookie_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refreshcookie"
+)
+
+const (
+	refreshPath = "/v1.0/customers/refresh"
+	csrfPath    = "/v1.0/customers"
+)
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{
+			name: "when the mode header is not provided, then it should not be enabled",
+			want: false,
+		},
+		{
+			name:    "when the mode header has another value, then it should not be enabled",
+			headers: map[string]string{refreshcookie.ModeHeader: "body"},
+			want:    false,
+		},
+		{
+			name:    "when the cookie mode is requested, then it should be enabled",
+			headers: map[string]string{refreshcookie.ModeHeader: refreshcookie.ModeCookie},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestContext(tt.headers, nil)
+
+			assert.Equal(t, tt.want, refreshcookie.Enabled(c))
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	c, w := newTestContext(nil, nil)
+
+	csrfToken, err := refreshcookie.Set(c, refreshPath, csrfPath, "fake-refresh-token")
+	require.NoError(t, err)
+	assert.NotEmpty(t, csrfToken)
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range w.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	require.Len(t, cookies, 2)
+
+	wantCookies := []http.Cookie{
+		{Name: refreshcookie.RefreshTokenCookieName, Value: "fake-refresh-token", Path: refreshPath, HttpOnly: true},
+		{Name: refreshcookie.CSRFCookieName, Value: csrfToken, Path: csrfPath, HttpOnly: false},
+	}
+	for _, want := range wantCookies {
+		cookie, ok := cookies[want.Name]
+		require.True(t, ok, "cookie %s should be set", want.Name)
+		assert.Equal(t, want.Value, cookie.Value)
+		assert.Equal(t, want.Path, cookie.Path)
+		assert.Equal(t, want.HttpOnly, cookie.HttpOnly)
+		assert.Equal(t, int(refresh.DefaultTokenExpiration.Seconds()), cookie.MaxAge)
+		assert.True(t, cookie.Secure)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+	}
+}
+
+func TestClear(t *testing.T) {
+	c, w := newTestContext(nil, nil)
+
+	refreshcookie.Clear(c, refreshPath, csrfPath)
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range w.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	require.Len(t, cookies, 2)
+
+	wantPaths := map[string]string{
+		refreshcookie.RefreshTokenCookieName: refreshPath,
+		refreshcookie.CSRFCookieName:         csrfPath,
+	}
+	for name, path := range wantPaths {
+		cookie, ok := cookies[name]
+		require.True(t, ok, "cookie %s should be cleared", name)
+		assert.Empty(t, cookie.Value)
+		assert.Equal(t, path, cookie.Path)
+		assert.Equal(t, -1, cookie.MaxAge)
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	refreshCookie := &http.Cookie{Name: refreshcookie.RefreshTokenCookieName, Value: "fake-refresh-token"}
+	csrfCookie := &http.Cookie{Name: refreshcookie.CSRFCookieName, Value: "fake-csrf-token"}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		cookies []*http.Cookie
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "when the refresh token cookie is not provided, then it should return a not found error",
+			headers: map[string]string{refreshcookie.CSRFHeader: "fake-csrf-token"},
+			cookies: []*http.Cookie{csrfCookie},
+			want:    "",
+			wantErr: refreshcookie.ErrRefreshTokenNotFound,
+		},
+		{
+			name:    "when the CSRF header is not provided, then it should return an invalid CSRF token error",
+			cookies: []*http.Cookie{refreshCookie, csrfCookie},
+			want:    "",
+			wantErr: refreshcookie.ErrInvalidCSRFToken,
+		},
+		{
+			name:    "when the CSRF cookie is not provided, then it should return an invalid CSRF token error",
+			headers: map[string]string{refreshcookie.CSRFHeader: "fake-csrf-token"},
+			cookies: []*http.Cookie{refreshCookie},
+			want:    "",
+			wantErr: refreshcookie.ErrInvalidCSRFToken,
+		},
+		{
+			name:    "when the CSRF header does not match its cookie, then it should return an invalid CSRF token error",
+			headers: map[string]string{refreshcookie.CSRFHeader: "fake-other-csrf-token"},
+			cookies: []*http.Cookie{refreshCookie, csrfCookie},
+			want:    "",
+			wantErr: refreshcookie.ErrInvalidCSRFToken,
+		},
+		{
+			name:    "when the CSRF header matches its cookie, then it should return the refresh token",
+			headers: map[string]string{refreshcookie.CSRFHeader: "fake-csrf-token"},
+			cookies: []*http.Cookie{refreshCookie, csrfCookie},
+			want:    "fake-refresh-token",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestContext(tt.headers, tt.cookies)
+
+			got, err := refreshcookie.RefreshToken(c)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newTestContext(headers map[string]string, cookies []*http.Cookie) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	req := httptest.NewRequest(http.MethodPost, refreshPath, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	c.Request = req
+	return c, w
+}
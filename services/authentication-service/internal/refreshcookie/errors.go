@@ -0,0 +1,17 @@
+package refreshcookie
+
+import "errors"
+
+var (
+	// ErrRefreshTokenNotFound indicates that the request in cookie mode does not carry the refresh token cookie.
+	ErrRefreshTokenNotFound = errors.New("refresh token cookie not found")
+	// ErrInvalidCSRFToken indicates that the CSRF token of the request header is missing or does not match its cookie.
+	ErrInvalidCSRFToken = errors.New("invalid csrf token")
+)
+
+const (
+	// CodeInvalidCSRFToken represents the error code for a cookie refresh without a matching CSRF token.
+	CodeInvalidCSRFToken = "INVALID_CSRF_TOKEN"
+	// MsgInvalidCSRFToken represents the error message for a cookie refresh without a matching CSRF token.
+	MsgInvalidCSRFToken = "invalid or missing csrf token"
+)
@@ -0,0 +1,30 @@
+package servicetokens
+
+import (
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for the service tokens.
+type Config struct {
+	// ClientSecrets maps the ID of each internal service to its client secret, as comma separated id=secret pairs.
+	// Secrets must not contain commas nor equal signs.
+	ClientSecrets map[string]string `env:"SERVICE_CLIENT_SECRETS" envSeparator:"," envKeyValSeparator:"="`
+	// ClientScopes maps the ID of each internal service to the space separated scopes it is granted, as comma
+	// separated id=scopes pairs.
+	ClientScopes map[string]string `env:"SERVICE_CLIENT_SCOPES" envSeparator:"," envKeyValSeparator:"="`
+	// TokenExpiration is the duration in seconds for which the service tokens remain valid.
+	TokenExpiration int `env:"SERVICE_TOKEN_EXPIRATION" envDefault:"300"`
+}
+
+// LoadConfig loads the service tokens configuration from environment variables and logs any errors encountered
+// during parsing. It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load service tokens configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
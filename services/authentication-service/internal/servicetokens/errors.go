@@ -0,0 +1,12 @@
+// Package servicetokens provides the client credentials authentication of the internal services, which obtain short
+// lived service tokens to call the internal endpoints of the authentication service.
+package servicetokens
+
+import "errors"
+
+var (
+	// ErrInvalidClient indicates that the client ID is unknown or the client secret does not match it.
+	ErrInvalidClient = errors.New("invalid client")
+	// ErrInvalidScope indicates that the requested scopes are not granted to the client.
+	ErrInvalidScope = errors.New("invalid scope")
+)
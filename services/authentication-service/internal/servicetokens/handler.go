@@ -0,0 +1,110 @@
+package servicetokens
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// CodeInvalidClient represents the error code for invalid client credentials.
+	CodeInvalidClient = "INVALID_CLIENT"
+	// CodeInvalidScope represents the error code for scopes not granted to the client.
+	CodeInvalidScope = "INVALID_SCOPE"
+
+	// MsgInvalidClient represents the error message for invalid client credentials.
+	MsgInvalidClient = "invalid client credentials"
+	// MsgInvalidScope represents the error message for scopes not granted to the client.
+	MsgInvalidScope = "requested scope is not granted to the client"
+)
+
+// Handler manages HTTP requests for service-token-related operations.
+type Handler struct {
+	logger  log.Logger
+	service Service
+}
+
+// NewHandler creates a new instance of Handler.
+func NewHandler(logger log.Logger, service Service) *Handler {
+	return &Handler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// RegisterRoutes registers the service token-related HTTP routes.
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/v1.0/auth/token", h.IssueToken)
+}
+
+// IssueTokenRequest represents the request payload for issuing a service token. Scope is a space separated list of
+// the requested scopes.
+type IssueTokenRequest struct {
+	ClientID     string `json:"client_id" binding:"required"`
+	ClientSecret string `json:"client_secret" binding:"required"`
+	Scope        string `json:"scope"`
+}
+
+// IssueTokenResponse represents the response payload for a successfully issued service token.
+type IssueTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+}
+
+// IssueToken processes the client credentials request of an internal service, returning a service token.
+func (h *Handler) IssueToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("IssueToken handler called")
+
+	var req IssueTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	output, err := h.service.IssueToken(ctx, IssueTokenInput{
+		ClientID:     req.ClientID,
+		ClientSecret: req.ClientSecret,
+		Scopes:       strings.Fields(req.Scope),
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidClient) {
+			logger.Warn("Invalid client credentials provided", log.Field{Key: "client_id", Value: req.ClientID})
+			c.JSON(http.StatusUnauthorized, customhttp.NewErrorResponse(CodeInvalidClient, MsgInvalidClient))
+			return
+		}
+		if errors.Is(err, ErrInvalidScope) {
+			logger.Warn("Invalid scope requested", log.Field{Key: "client_id", Value: req.ClientID})
+			c.JSON(http.StatusBadRequest, customhttp.NewErrorResponse(CodeInvalidScope, MsgInvalidScope))
+			return
+		}
+		logger.Error("Failed to issue service token", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := IssueTokenResponse{
+		AccessToken: output.AccessToken,
+		ExpiresIn:   output.ExpiresIn,
+		TokenType:   output.TokenType,
+		Scope:       strings.Join(output.Scopes, " "),
+	}
+	logger.Info("Service token issued successfully", log.Field{Key: "client_id", Value: req.ClientID})
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,129 @@
+//go:build unit
+
+package servicetokens_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/servicetokens"
+	servicetokensmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/servicetokens/mocks"
+)
+
+var errUnexpected = errors.New("unexpected error")
+
+func TestHandler_IssueToken(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []struct {
+		name        string
+		jsonPayload string
+		mocksSetup  func(service *servicetokensmocks.MockService)
+		wantJSON    string
+		wantStatus  int
+	}{
+		{
+			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			jsonPayload: `{"client_id": 1.2, "client_secret": true}`,
+			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"client_id is required",
+					"client_secret is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when there are invalid client credentials, then it should return a 401 with invalid client error",
+			jsonPayload: `{"client_id": "customer-service", "client_secret": "wrong-secret"}`,
+			mocksSetup: func(service *servicetokensmocks.MockService) {
+				service.EXPECT().IssueToken(gomock.Any(), gomock.Any()).
+					Return(servicetokens.IssueTokenOutput{}, servicetokens.ErrInvalidClient)
+			},
+			wantJSON: `{
+				"code": "INVALID_CLIENT",
+				"message": "invalid client credentials",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "when a scope not granted is requested, then it should return a 400 with invalid scope error",
+			jsonPayload: `{"client_id": "customer-service", "client_secret": "fake-secret", "scope": "other:scope"}`,
+			mocksSetup: func(service *servicetokensmocks.MockService) {
+				service.EXPECT().IssueToken(gomock.Any(), gomock.Any()).
+					Return(servicetokens.IssueTokenOutput{}, servicetokens.ErrInvalidScope)
+			},
+			wantJSON: `{
+				"code": "INVALID_SCOPE",
+				"message": "requested scope is not granted to the client",
+				"details": []
+			}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when unexpected error when issuing the token, then it should return a 500 with the internal error",
+			jsonPayload: `{"client_id": "customer-service", "client_secret": "fake-secret"}`,
+			mocksSetup: func(service *servicetokensmocks.MockService) {
+				service.EXPECT().IssueToken(gomock.Any(), gomock.Any()).
+					Return(servicetokens.IssueTokenOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when valid client credentials are provided, then it should return a 200 with the service token",
+			jsonPayload: `{"client_id": "customer-service", "client_secret": "fake-secret", "scope": "auth:register"}`,
+			mocksSetup: func(service *servicetokensmocks.MockService) {
+				service.EXPECT().IssueToken(
+					gomock.Any(), servicetokens.IssueTokenInput{
+						ClientID:     "customer-service",
+						ClientSecret: "fake-secret",
+						Scopes:       []string{"auth:register"},
+					},
+				).Return(
+					servicetokens.IssueTokenOutput{
+						AccessToken: "fake-token",
+						ExpiresIn:   300,
+						TokenType:   "Bearer",
+						Scopes:      []string{"auth:register"},
+					}, nil,
+				)
+			},
+			wantJSON: `{
+			  "access_token": "fake-token",
+			  "expires_in": 300,
+			  "token_type": "Bearer",
+			  "scope": "auth:register"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service := servicetokensmocks.NewMockService(gomock.NewController(t))
+				if tt.mocksSetup != nil {
+					tt.mocksSetup(service)
+				}
+
+				h := servicetokens.NewHandler(logger, service)
+				w := customhttp.ServeTestHTTPRequest(t, h, http.MethodPost, "/v1.0/auth/token", "", nil, tt.jsonPayload)
+
+				assert.Equal(t, tt.wantStatus, w.Code)
+				assert.JSONEq(t, tt.wantJSON, w.Body.String())
+			},
+		)
+	}
+}
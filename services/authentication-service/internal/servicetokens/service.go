@@ -0,0 +1,112 @@
+package servicetokens
+
+import (
+	"context"
+	"crypto/subtle"
+	"slices"
+	"strings"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Service defines the interface for issuing tokens to the internal services.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=servicetokens_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/servicetokens Service
+type Service interface {
+	IssueToken(ctx context.Context, input IssueTokenInput) (IssueTokenOutput, error)
+}
+
+// client represents an internal service allowed to obtain service tokens.
+type client struct {
+	secret string
+	scopes []string
+}
+
+type service struct {
+	logger      log.Logger
+	authService auth.Service
+	clients     map[string]client
+	expiration  int
+}
+
+// NewService creates a new instance of Service, allowing the clients defined in the configuration.
+func NewService(logger log.Logger, authService auth.Service, cfg Config) Service {
+	clients := make(map[string]client, len(cfg.ClientSecrets))
+	for id, secret := range cfg.ClientSecrets {
+		clients[id] = client{
+			secret: secret,
+			scopes: strings.Fields(cfg.ClientScopes[id]),
+		}
+	}
+
+	return &service{
+		logger:      logger,
+		authService: authService,
+		clients:     clients,
+		expiration:  cfg.TokenExpiration,
+	}
+}
+
+// IssueTokenInput represents the client credentials of an internal service, and the scopes it requests. All the
+// scopes granted to the client are requested when no scope is provided.
+type IssueTokenInput struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// IssueTokenOutput represents the service token issued to an internal service.
+type IssueTokenOutput struct {
+	AccessToken string
+	ExpiresIn   int
+	TokenType   string
+	Scopes      []string
+}
+
+// IssueToken authenticates the internal service with its client credentials, and issues it a service token limited
+// to the requested scopes.
+func (s *service) IssueToken(ctx context.Context, input IssueTokenInput) (IssueTokenOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("issuing service token", log.Field{Key: "client_id", Value: input.ClientID})
+	c, ok := s.clients[input.ClientID]
+	if !ok || subtle.ConstantTimeCompare([]byte(c.secret), []byte(input.ClientSecret)) != 1 {
+		logger.Warn("invalid client credentials", log.Field{Key: "client_id", Value: input.ClientID})
+		return IssueTokenOutput{}, ErrInvalidClient
+	}
+
+	scopes := c.scopes
+	if len(input.Scopes) > 0 {
+		for _, scope := range input.Scopes {
+			if !slices.Contains(c.scopes, scope) {
+				logger.Warn(
+					"scope not granted to the client",
+					log.Field{Key: "client_id", Value: input.ClientID},
+					log.Field{Key: "scope", Value: scope},
+				)
+				return IssueTokenOutput{}, ErrInvalidScope
+			}
+		}
+		scopes = input.Scopes
+	}
+
+	output, err := s.authService.GenerateToken(ctx, auth.GenerateTokenInput{
+		ID:         input.ClientID,
+		Expiration: s.expiration,
+		Role:       string(auth.RoleService),
+		Scopes:     scopes,
+	})
+	if err != nil {
+		logger.Error("failed to generate JWT", err)
+		return IssueTokenOutput{}, err
+	}
+
+	logger.Info("service token issued successfully", log.Field{Key: "client_id", Value: input.ClientID})
+	return IssueTokenOutput{
+		AccessToken: output.AccessToken,
+		ExpiresIn:   s.expiration,
+		TokenType:   auth.DefaultTokenType,
+		Scopes:      scopes,
+	}, nil
+}
@@ -0,0 +1,130 @@
+//go:build unit
+
+package servicetokens_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/servicetokens"
+)
+
+var (
+	errToken = errors.New("token error")
+
+	testConfig = servicetokens.Config{
+		ClientSecrets:   map[string]string{"customer-service": "fake-secret"},
+		ClientScopes:    map[string]string{"customer-service": "auth:register fake:scope"},
+		TokenExpiration: 300,
+	}
+)
+
+func TestService_IssueToken(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []struct {
+		name       string
+		input      servicetokens.IssueTokenInput
+		mocksSetup func(authService *authmocks.MockService)
+		want       servicetokens.IssueTokenOutput
+		wantErr    error
+	}{
+		{
+			name:    "when the client is unknown, then it should return an invalid client error",
+			input:   servicetokens.IssueTokenInput{ClientID: "unknown-service", ClientSecret: "fake-secret"},
+			want:    servicetokens.IssueTokenOutput{},
+			wantErr: servicetokens.ErrInvalidClient,
+		},
+		{
+			name:    "when the client secret does not match, then it should return an invalid client error",
+			input:   servicetokens.IssueTokenInput{ClientID: "customer-service", ClientSecret: "wrong-secret"},
+			want:    servicetokens.IssueTokenOutput{},
+			wantErr: servicetokens.ErrInvalidClient,
+		},
+		{
+			name: "when a scope not granted to the client is requested, then it should return an invalid scope error",
+			input: servicetokens.IssueTokenInput{
+				ClientID:     "customer-service",
+				ClientSecret: "fake-secret",
+				Scopes:       []string{"auth:register", "other:scope"},
+			},
+			want:    servicetokens.IssueTokenOutput{},
+			wantErr: servicetokens.ErrInvalidScope,
+		},
+		{
+			name:  "when there is an error generating the token, then it should propagate the error",
+			input: servicetokens.IssueTokenInput{ClientID: "customer-service", ClientSecret: "fake-secret"},
+			mocksSetup: func(authService *authmocks.MockService) {
+				authService.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).
+					Return(auth.GenerateTokenOutput{}, errToken)
+			},
+			want:    servicetokens.IssueTokenOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when no scope is requested, then it should return a token with all the granted scopes",
+			input: servicetokens.IssueTokenInput{ClientID: "customer-service", ClientSecret: "fake-secret"},
+			mocksSetup: func(authService *authmocks.MockService) {
+				authService.EXPECT().GenerateToken(gomock.Any(), auth.GenerateTokenInput{
+					ID:         "customer-service",
+					Expiration: 300,
+					Role:       "service",
+					Scopes:     []string{"auth:register", "fake:scope"},
+				}).Return(auth.GenerateTokenOutput{AccessToken: "fake-token"}, nil)
+			},
+			want: servicetokens.IssueTokenOutput{
+				AccessToken: "fake-token",
+				ExpiresIn:   300,
+				TokenType:   "Bearer",
+				Scopes:      []string{"auth:register", "fake:scope"},
+			},
+		},
+		{
+			name: "when granted scopes are requested, then it should return a token limited to those scopes",
+			input: servicetokens.IssueTokenInput{
+				ClientID:     "customer-service",
+				ClientSecret: "fake-secret",
+				Scopes:       []string{"auth:register"},
+			},
+			mocksSetup: func(authService *authmocks.MockService) {
+				authService.EXPECT().GenerateToken(gomock.Any(), auth.GenerateTokenInput{
+					ID:         "customer-service",
+					Expiration: 300,
+					Role:       "service",
+					Scopes:     []string{"auth:register"},
+				}).Return(auth.GenerateTokenOutput{AccessToken: "fake-token"}, nil)
+			},
+			want: servicetokens.IssueTokenOutput{
+				AccessToken: "fake-token",
+				ExpiresIn:   300,
+				TokenType:   "Bearer",
+				Scopes:      []string{"auth:register"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				authService := authmocks.NewMockService(ctrl)
+				if tt.mocksSetup != nil {
+					tt.mocksSetup(authService)
+				}
+
+				service := servicetokens.NewService(logger, authService, testConfig)
+				got, err := service.IssueToken(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
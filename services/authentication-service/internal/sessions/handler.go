@@ -0,0 +1,132 @@
+package sessions
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Handler manages HTTP requests for session-related operations.
+type Handler struct {
+	logger         log.Logger
+	service        Service
+	authMiddleware auth.Middleware
+}
+
+// NewHandler creates a new instance of Handler.
+func NewHandler(logger log.Logger, service Service, authMiddleware auth.Middleware) *Handler {
+	return &Handler{
+		logger:         logger,
+		service:        service,
+		authMiddleware: authMiddleware,
+	}
+}
+
+// RegisterRoutes registers the session-related HTTP routes for customers and staff users.
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	customersRouter := router.Group("/v1.0/customers/sessions", h.authMiddleware.RequireCustomer())
+	{
+		customersRouter.GET("", h.ListSessions)
+		customersRouter.DELETE("/:sessionID", h.RevokeSession)
+	}
+
+	staffRouter := router.Group("/v1.0/staff/sessions", h.authMiddleware.RequireStaff())
+	{
+		staffRouter.GET("", h.ListSessions)
+		staffRouter.DELETE("/:sessionID", h.RevokeSession)
+	}
+}
+
+// SessionResponse represents a single active session in the API responses.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// ListSessionsResponse represents the response payload containing the active sessions of the authenticated user.
+type ListSessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+// ListSessions handles the listing of the active sessions of the authenticated user.
+func (h *Handler) ListSessions(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ListSessions handler called")
+
+	output, err := h.service.ListSessions(ctx, ListSessionsInput{})
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			logger.Warn("Authentication context not found")
+			c.JSON(http.StatusUnauthorized, customhttp.NewErrorResponse(
+				auth.CodeUnauthorizedError,
+				auth.MessageUnauthorizedError,
+			))
+			return
+		}
+		logger.Error("Failed to list sessions", err)
+		c.JSON(http.StatusInternalServerError, customhttp.NewErrorResponse(
+			customhttp.CodeInternalError,
+			customhttp.MsgInternalError,
+		))
+		return
+	}
+
+	resp := ListSessionsResponse{Sessions: make([]SessionResponse, 0, len(output.Sessions))}
+	for _, session := range output.Sessions {
+		resp.Sessions = append(resp.Sessions, SessionResponse(session))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeSession handles the revocation of one of the sessions of the authenticated user. Revoking a session that
+// does not exist, or that was already revoked, is not an error.
+func (h *Handler) RevokeSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("RevokeSession handler called")
+
+	sessionID := c.Param("sessionID")
+	output, err := h.service.RevokeSession(ctx, RevokeSessionInput{SessionID: sessionID})
+	if err != nil {
+		if errors.Is(err, auth.ErrImpersonationNotAllowed) {
+			logger.Warn("Session revocation not allowed while impersonating")
+			c.JSON(http.StatusForbidden, customhttp.NewErrorResponse(
+				auth.CodeForbiddenError,
+				auth.MessageForbiddenError,
+			))
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidToken) {
+			logger.Warn("Authentication context not found")
+			c.JSON(http.StatusUnauthorized, customhttp.NewErrorResponse(
+				auth.CodeUnauthorizedError,
+				auth.MessageUnauthorizedError,
+			))
+			return
+		}
+		logger.Error("Failed to revoke session", err)
+		c.JSON(http.StatusInternalServerError, customhttp.NewErrorResponse(
+			customhttp.CodeInternalError,
+			customhttp.MsgInternalError,
+		))
+		return
+	}
+
+	logger.Info(
+		"Session revoked successfully",
+		log.Field{Key: "session_id", Value: sessionID},
+		log.Field{Key: "revoked_tokens", Value: output.RevokedTokens},
+	)
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,250 @@
+//go:build unit
+
+package sessions_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/sessions"
+	sessionsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/sessions/mocks"
+)
+
+type sessionsHandlerTestCase struct {
+	name        string
+	token       string
+	queryParams map[string]string
+	jsonPayload string
+	mocksSetup  func(service *sessionsmocks.MockService, authService *authmocks.MockService)
+	wantJSON    string
+	wantStatus  int
+}
+
+func TestHandler_ListSessions(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []sessionsHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a customer, then it should return a 403 with the forbidden error",
+			token: "staff-token",
+			mocksSetup: func(_ *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-tenant-id")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when unexpected error when listing the sessions, " +
+				"then it should return a 500 with the internal error",
+			token: "valid-token",
+			mocksSetup: func(service *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+				service.EXPECT().ListSessions(gomock.Any(), gomock.Any()).
+					Return(sessions.ListSessionsOutput{}, errRefresh)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "when the customer has no sessions, then it should return a 200 with an empty list",
+			token: "valid-token",
+			mocksSetup: func(service *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+				service.EXPECT().ListSessions(gomock.Any(), gomock.Any()).
+					Return(sessions.ListSessionsOutput{}, nil)
+			},
+			wantJSON:   `{"sessions": []}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "when the customer has active sessions, then it should return a 200 with the sessions",
+			token: "valid-token",
+			mocksSetup: func(service *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+				service.EXPECT().ListSessions(gomock.Any(), sessions.ListSessionsInput{}).
+					Return(sessions.ListSessionsOutput{
+						Sessions: []sessions.Session{
+							{
+								ID:         "fake-device-id",
+								UserAgent:  "fake-user-agent",
+								IP:         "fake-ip",
+								LastUsedAt: now,
+							},
+						},
+					}, nil)
+			},
+			wantJSON: `{
+				"sessions": [
+					{
+						"id": "fake-device-id",
+						"user_agent": "fake-user-agent",
+						"ip": "fake-ip",
+						"last_used_at": "2025-01-01T00:00:00Z"
+					}
+				]
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runSessionsHandlerTestCase(t, logger, http.MethodGet, "/v1.0/customers/sessions", tt)
+		})
+	}
+}
+
+func TestHandler_ListSessions_Staff(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []sessionsHandlerTestCase{
+		{
+			name:  "when authenticated user is not staff, then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:  "when the staff user has no sessions, then it should return a 200 with an empty list",
+			token: "valid-token",
+			mocksSetup: func(service *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-tenant-id")
+				service.EXPECT().ListSessions(gomock.Any(), gomock.Any()).
+					Return(sessions.ListSessionsOutput{}, nil)
+			},
+			wantJSON:   `{"sessions": []}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runSessionsHandlerTestCase(t, logger, http.MethodGet, "/v1.0/staff/sessions", tt)
+		})
+	}
+}
+
+func TestHandler_RevokeSession(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []sessionsHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the user is impersonated, " +
+				"then it should return a 403 with the forbidden error",
+			token: "impersonation-token",
+			mocksSetup: func(service *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-tenant-id")
+				service.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).
+					Return(sessions.RevokeSessionOutput{}, auth.ErrImpersonationNotAllowed)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when unexpected error when revoking the session, " +
+				"then it should return a 500 with the internal error",
+			token: "valid-token",
+			mocksSetup: func(service *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-tenant-id")
+				service.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).
+					Return(sessions.RevokeSessionOutput{}, errRefresh)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "when the session does not exist, then it should return a 204 without content",
+			token: "valid-token",
+			mocksSetup: func(service *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-tenant-id")
+				service.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).
+					Return(sessions.RevokeSessionOutput{RevokedTokens: 0}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:  "when the session is revoked, then it should return a 204 without content",
+			token: "valid-token",
+			mocksSetup: func(service *sessionsmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-tenant-id")
+				service.EXPECT().RevokeSession(gomock.Any(), sessions.RevokeSessionInput{
+					SessionID: "fake-device-id",
+				}).Return(sessions.RevokeSessionOutput{RevokedTokens: 1}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runSessionsHandlerTestCase(t, logger, http.MethodDelete, "/v1.0/staff/sessions/fake-device-id", tt)
+		})
+	}
+}
+
+func mockClaims(authService *authmocks.MockService, role auth.Role, tenant string) {
+	authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+		Return(auth.GetClaimsOutput{
+			Claims: &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-user-id"},
+				Role:             string(role),
+				Tenant:           tenant,
+			},
+		}, nil)
+}
+
+// runSessionsHandlerTestCase executes a test case for the sessions handler, which is common for all tests.
+func runSessionsHandlerTestCase(
+	t *testing.T,
+	logger log.Logger,
+	httpMethod string,
+	route string,
+	tt sessionsHandlerTestCase,
+) {
+	service := sessionsmocks.NewMockService(gomock.NewController(t))
+	authService := authmocks.NewMockService(gomock.NewController(t))
+	if tt.mocksSetup != nil {
+		tt.mocksSetup(service, authService)
+	}
+
+	// Initialize the authentication middleware
+	authMiddleware := auth.NewMiddleware(logger, authService)
+
+	// Initialize the handler
+	h := sessions.NewHandler(logger, service, authMiddleware)
+
+	// Make HTTP request
+	w := customhttp.ServeTestHTTPRequest(t, h, httpMethod, route, tt.token, tt.queryParams, tt.jsonPayload)
+
+	assert.Equal(t, tt.wantStatus, w.Code)
+	if tt.wantJSON == "" {
+		assert.Empty(t, w.Body.String())
+		return
+	}
+	assert.JSONEq(t, tt.wantJSON, w.Body.String())
+}
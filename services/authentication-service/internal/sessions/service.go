@@ -0,0 +1,152 @@
+// Package sessions provides the functionality for listing and revoking the active sessions of the authenticated user.
+// A session groups all the active refresh tokens issued to the same device.
+package sessions
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
+)
+
+// Service defines the interface for the session management service.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=sessions_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/sessions Service
+type Service interface {
+	ListSessions(ctx context.Context, input ListSessionsInput) (ListSessionsOutput, error)
+	RevokeSession(ctx context.Context, input RevokeSessionInput) (RevokeSessionOutput, error)
+}
+
+type service struct {
+	logger         log.Logger
+	refreshService refresh.Service
+	authctx        auth.ContextReader
+}
+
+// NewService creates a new instance of Service with the provided dependencies.
+func NewService(logger log.Logger, refreshService refresh.Service, authctx auth.ContextReader) Service {
+	return &service{
+		logger:         logger,
+		refreshService: refreshService,
+		authctx:        authctx,
+	}
+}
+
+// Session represents an active session of the user, identified by the device the refresh tokens were issued to.
+type Session struct {
+	ID         string
+	UserAgent  string
+	IP         string
+	LastUsedAt time.Time
+}
+
+// ListSessionsInput represents the input required to list the sessions of the authenticated user.
+type ListSessionsInput struct{}
+
+// ListSessionsOutput represents the active sessions of the authenticated user, sorted by the most recently used first.
+type ListSessionsOutput struct {
+	Sessions []Session
+}
+
+func (s *service) ListSessions(ctx context.Context, _ ListSessionsInput) (ListSessionsOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	owner, err := s.getOwner(ctx)
+	if err != nil {
+		return ListSessionsOutput{}, err
+	}
+
+	logger.Info("listing sessions", log.Field{Key: "user_id", Value: owner.UserID})
+	output, err := s.refreshService.ListSessions(ctx, refresh.ListSessionsInput{
+		UserID:   owner.UserID,
+		Role:     owner.Role,
+		TenantID: owner.TenantID,
+	})
+	if err != nil {
+		logger.Error("failed to list sessions", err)
+		return ListSessionsOutput{}, err
+	}
+
+	sessions := make([]Session, 0, len(output.Sessions))
+	for _, session := range output.Sessions {
+		sessions = append(sessions, Session{
+			ID:         session.DeviceID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			LastUsedAt: session.LastUsedAt,
+		})
+	}
+	return ListSessionsOutput{Sessions: sessions}, nil
+}
+
+// RevokeSessionInput represents the input required to revoke a session of the authenticated user.
+type RevokeSessionInput struct {
+	SessionID string
+}
+
+// RevokeSessionOutput represents the result of a session revocation.
+type RevokeSessionOutput struct {
+	RevokedTokens int64
+}
+
+func (s *service) RevokeSession(ctx context.Context, input RevokeSessionInput) (RevokeSessionOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	if err := s.authctx.RequireNotImpersonated(ctx); err != nil {
+		return RevokeSessionOutput{}, err
+	}
+
+	owner, err := s.getOwner(ctx)
+	if err != nil {
+		return RevokeSessionOutput{}, err
+	}
+
+	logger.Info(
+		"revoking session",
+		log.Field{Key: "user_id", Value: owner.UserID},
+		log.Field{Key: "session_id", Value: input.SessionID},
+	)
+	output, err := s.refreshService.RevokeAll(ctx, refresh.RevokeAllInput{
+		UserID:   owner.UserID,
+		Role:     owner.Role,
+		TenantID: owner.TenantID,
+		DeviceID: input.SessionID,
+	})
+	if err != nil {
+		logger.Error("failed to revoke session", err)
+		return RevokeSessionOutput{}, err
+	}
+	return RevokeSessionOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// sessionOwner identifies the user whose sessions are managed.
+type sessionOwner struct {
+	UserID   string
+	Role     string
+	TenantID string
+}
+
+// getOwner reads the authenticated user from the context. Sessions are always scoped to the token owner, so a user
+// can never see nor revoke the sessions of another one.
+func (s *service) getOwner(ctx context.Context) (sessionOwner, error) {
+	subject, ok := s.authctx.GetSubject(ctx)
+	if !ok || subject == "" {
+		s.logger.WithContext(ctx).Warn("authentication context not found")
+		return sessionOwner{}, auth.ErrInvalidToken
+	}
+	role, ok := s.authctx.GetRole(ctx)
+	if !ok {
+		s.logger.WithContext(ctx).Warn("authentication context not found")
+		return sessionOwner{}, auth.ErrInvalidToken
+	}
+	// Customers do not belong to any tenant, so an empty tenant is expected for them
+	tenant, _ := s.authctx.GetTenant(ctx)
+
+	return sessionOwner{
+		UserID:   subject,
+		Role:     string(role),
+		TenantID: tenant,
+	}, nil
+}
@@ -0,0 +1,195 @@
+//go:build unit
+
+package sessions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh"
+	refreshmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/refresh/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/sessions"
+)
+
+var errRefresh = errors.New("refresh error")
+
+type sessionsServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	mocksSetup func(
+		refreshService *refreshmocks.MockService,
+		authctx *authmocks.MockContextReader,
+	)
+	want    W
+	wantErr error
+}
+
+func TestService_ListSessions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []sessionsServiceTestCase[sessions.ListSessionsInput, sessions.ListSessionsOutput]{
+		{
+			name: "when there is no authentication context, then it returns an invalid token error",
+			mocksSetup: func(_ *refreshmocks.MockService, authctx *authmocks.MockContextReader) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("", false)
+			},
+			want:    sessions.ListSessionsOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name: "when there is an error listing the sessions, then it propagates the error",
+			mocksSetup: func(refreshService *refreshmocks.MockService, authctx *authmocks.MockContextReader) {
+				mockAuthContext(authctx, "fake-user-id", auth.RoleStaff, "fake-tenant-id")
+				refreshService.EXPECT().ListSessions(gomock.Any(), gomock.Any()).
+					Return(refresh.ListSessionsOutput{}, errRefresh)
+			},
+			want:    sessions.ListSessionsOutput{},
+			wantErr: errRefresh,
+		},
+		{
+			name: "when the user has active sessions, then it returns them",
+			mocksSetup: func(refreshService *refreshmocks.MockService, authctx *authmocks.MockContextReader) {
+				mockAuthContext(authctx, "fake-user-id", auth.RoleStaff, "fake-tenant-id")
+				refreshService.EXPECT().ListSessions(gomock.Any(), refresh.ListSessionsInput{
+					UserID:   "fake-user-id",
+					Role:     "staff",
+					TenantID: "fake-tenant-id",
+				}).Return(refresh.ListSessionsOutput{
+					Sessions: []refresh.Session{
+						{
+							DeviceID:   "fake-device-id",
+							UserAgent:  "fake-user-agent",
+							IP:         "fake-ip",
+							LastUsedAt: now,
+						},
+					},
+				}, nil)
+			},
+			want: sessions.ListSessionsOutput{
+				Sessions: []sessions.Session{
+					{
+						ID:         "fake-device-id",
+						UserAgent:  "fake-user-agent",
+						IP:         "fake-ip",
+						LastUsedAt: now,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.ListSessions(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RevokeSession(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []sessionsServiceTestCase[sessions.RevokeSessionInput, sessions.RevokeSessionOutput]{
+		{
+			name:  "when the user is impersonated, then it returns an impersonation not allowed error",
+			input: sessions.RevokeSessionInput{SessionID: "fake-device-id"},
+			mocksSetup: func(_ *refreshmocks.MockService, authctx *authmocks.MockContextReader) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(auth.ErrImpersonationNotAllowed)
+			},
+			want:    sessions.RevokeSessionOutput{},
+			wantErr: auth.ErrImpersonationNotAllowed,
+		},
+		{
+			name:  "when there is no authentication context, then it returns an invalid token error",
+			input: sessions.RevokeSessionInput{SessionID: "fake-device-id"},
+			mocksSetup: func(_ *refreshmocks.MockService, authctx *authmocks.MockContextReader) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("", false)
+			},
+			want:    sessions.RevokeSessionOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when there is an error revoking the session, then it propagates the error",
+			input: sessions.RevokeSessionInput{SessionID: "fake-device-id"},
+			mocksSetup: func(refreshService *refreshmocks.MockService, authctx *authmocks.MockContextReader) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx, "fake-user-id", auth.RoleCustomer, "")
+				refreshService.EXPECT().RevokeAll(gomock.Any(), gomock.Any()).
+					Return(refresh.RevokeAllOutput{}, errRefresh)
+			},
+			want:    sessions.RevokeSessionOutput{},
+			wantErr: errRefresh,
+		},
+		{
+			name:  "when the session is revoked, then it returns the number of revoked tokens",
+			input: sessions.RevokeSessionInput{SessionID: "fake-device-id"},
+			mocksSetup: func(refreshService *refreshmocks.MockService, authctx *authmocks.MockContextReader) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx, "fake-user-id", auth.RoleCustomer, "")
+				refreshService.EXPECT().RevokeAll(gomock.Any(), refresh.RevokeAllInput{
+					UserID:   "fake-user-id",
+					Role:     "customer",
+					TenantID: "",
+					DeviceID: "fake-device-id",
+				}).Return(refresh.RevokeAllOutput{RevokedTokens: 2}, nil)
+			},
+			want:    sessions.RevokeSessionOutput{RevokedTokens: 2},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.RevokeSession(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func mockAuthContext(authctx *authmocks.MockContextReader, subject string, role auth.Role, tenant string) {
+	authctx.EXPECT().GetSubject(gomock.Any()).Return(subject, true)
+	authctx.EXPECT().GetRole(gomock.Any()).Return(role, true)
+	authctx.EXPECT().GetTenant(gomock.Any()).Return(tenant, true)
+}
+
+func serviceSetup(
+	t *testing.T, logger log.Logger, mocksSetup func(
+		refreshService *refreshmocks.MockService,
+		authctx *authmocks.MockContextReader,
+	),
+) (sessions.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	refreshService := refreshmocks.NewMockService(ctrl)
+	authctx := authmocks.NewMockContextReader(ctrl)
+
+	if mocksSetup != nil {
+		mocksSetup(refreshService, authctx)
+	}
+
+	service := sessions.NewService(logger, refreshService, authctx)
+	return service, func() {
+		ctrl.Finish()
+	}
+}
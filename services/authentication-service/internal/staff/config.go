@@ -0,0 +1,25 @@
+package staff
+
+import (
+	"github.com/caarlos0/env/v10"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+// Config represents the configuration settings for the staff credentials.
+type Config struct {
+	// MigrateLegacyStaff enables the one-shot migration of the staff credentials stored before they could be linked to
+	// several restaurants.
+	MigrateLegacyStaff bool `env:"STAFF_MIGRATE_LEGACY" envDefault:"false"`
+}
+
+// LoadConfig loads the staff configuration from environment variables and logs any errors encountered during parsing.
+// It returns a Config object and an error if the configuration fails to load.
+func LoadConfig(logger log.Logger) (Config, error) {
+	cfg := Config{}
+	if err := env.Parse(&cfg); err != nil {
+		logger.Error("Failed to load staff configuration", err)
+		return Config{}, err
+	}
+	return cfg, nil
+}
@@ -8,4 +8,13 @@ var (
 	ErrStaffAlreadyExists = errors.New("staff already exists")
 	// ErrStaffNotFound indicates that a staff with the specified details could not be found in the system.
 	ErrStaffNotFound = errors.New("staff not found")
+	// ErrRestaurantAlreadyLinked indicates that the staff user is already linked to the restaurant.
+	ErrRestaurantAlreadyLinked = errors.New("restaurant already linked to the staff")
+	// ErrRestaurantNotLinked indicates that the staff user is not active in the restaurant it tried to access.
+	ErrRestaurantNotLinked = errors.New("restaurant not linked to the staff")
+	// ErrLegacyStaffConflict indicates that a legacy staff document cannot be merged into the credentials with the same
+	// email, as their passwords differ.
+	ErrLegacyStaffConflict = errors.New("legacy staff conflicts with the staff with the same email")
+	// ErrInvalidSelectionToken indicates that the restaurant selection token is invalid, expired or already used.
+	ErrInvalidSelectionToken = errors.New("invalid selection token")
 )
@@ -11,6 +11,7 @@ import (
 	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
 )
 
 const (
@@ -18,6 +19,22 @@ const (
 	CodeStaffAlreadyExists = "STAFF_ALREADY_EXISTS"
 	// MsgStaffAlreadyExists represents the error message indicating that the staff already exists in the system.
 	MsgStaffAlreadyExists = "staff already exists"
+	// CodeStaffNotFound represents the error code indicating the staff does not exist in the system.
+	CodeStaffNotFound = "STAFF_NOT_FOUND"
+	// MsgStaffNotFound represents the error message indicating that the staff does not exist in the system.
+	MsgStaffNotFound = "staff not found"
+	// CodeRestaurantAlreadyLinked represents the error code indicating the staff is already linked to the restaurant.
+	CodeRestaurantAlreadyLinked = "RESTAURANT_ALREADY_LINKED"
+	// MsgRestaurantAlreadyLinked represents the error message indicating the staff is already linked to the restaurant.
+	MsgRestaurantAlreadyLinked = "restaurant already linked to the staff"
+	// CodeRestaurantNotLinked represents the error code indicating the staff is not linked to the restaurant.
+	CodeRestaurantNotLinked = "RESTAURANT_NOT_LINKED"
+	// MsgRestaurantNotLinked represents the error message indicating the staff is not linked to the restaurant.
+	MsgRestaurantNotLinked = "restaurant not linked to the staff"
+	// CodeInvalidSelectionToken represents the error code indicating the restaurant selection token is not valid.
+	CodeInvalidSelectionToken = "INVALID_SELECTION_TOKEN"
+	// MsgInvalidSelectionToken represents the error message indicating the restaurant selection token is not valid.
+	MsgInvalidSelectionToken = "invalid, expired or already used selection token"
 )
 
 // Handler manages HTTP requests for auth-customer-related operations.
@@ -38,13 +55,35 @@ func NewHandler(logger log.Logger, service Service, authMiddleware auth.Middlewa
 
 // RegisterRoutes registers the customer-related HTTP routes.
 func (h *Handler) RegisterRoutes(router *gin.Engine) {
-	authRouter := router.Group("/v1.0/auth")
+	authRouter := router.Group("/v1.0/auth", h.authMiddleware.RequireServiceScope(auth.ScopeAuthRegister))
 	{
 		authRouter.POST("/staff", h.RegisterStaff)
 	}
 
+	manageRouter := router.Group("/v1.0/auth/staff/:staffID", h.authMiddleware.RequireServiceScope(auth.ScopeAuthManage))
+	{
+		manageRouter.POST("/restaurants", h.LinkStaffRestaurant)
+	}
+
 	router.POST("/v1.0/staff/login", h.LoginStaff)
+	router.POST("/v1.0/staff/login/restaurant", h.SelectStaffRestaurant)
 	router.POST("/v1.0/staff/refresh", h.RefreshStaff)
+	router.POST("/v1.0/staff/logout", h.LogoutStaff)
+	router.POST("/v1.0/staff/logout/all", h.LogoutStaffAllSessions)
+	router.POST("/v1.0/staff/password/forgot", h.ForgotStaffPassword)
+	router.POST("/v1.0/staff/password/reset", h.ResetStaffPassword)
+	router.PUT("/v1.0/staff/password", h.authMiddleware.RequireStaff(), h.ChangeStaffPassword)
+	router.POST("/v1.0/staff/login/mfa", h.VerifyStaffMFA)
+	router.POST("/v1.0/staff/mfa/enroll", h.authMiddleware.RequireStaff(), h.EnrollStaffMFA)
+	router.POST("/v1.0/staff/mfa/enroll/confirm", h.authMiddleware.RequireStaff(), h.ConfirmStaffMFA)
+	router.POST("/v1.0/staff/tenant/switch", h.authMiddleware.RequireStaff(), h.SwitchStaffTenant)
+
+	adminRouter := router.Group("/v1.0/admin/restaurants/:restaurantID/staff", h.authMiddleware.RequirePlatformAdmin())
+	{
+		adminRouter.POST("/:staffID/deactivate", h.DeactivateStaff)
+		adminRouter.POST("/:staffID/reactivate", h.ReactivateStaff)
+		adminRouter.POST("/:staffID/impersonate", h.ImpersonateStaff)
+	}
 }
 
 // RegisterStaffRequest represents the request payload for registering a new staff user.
@@ -53,11 +92,14 @@ type RegisterStaffRequest struct {
 	Email        string `json:"email" binding:"required,email"`
 	RestaurantID string `json:"restaurant_id" binding:"required"`
 	Password     string `json:"password" binding:"required,min=8"`
+	Owner        bool   `json:"owner"`
+	Role         string `json:"role" binding:"omitempty,oneof=manager cashier chef"`
 }
 
 // RegisterStaffResponse represents the response returned after successfully registering a new staff user.
 type RegisterStaffResponse struct {
 	ID           string    `json:"id"`
+	StaffID      string    `json:"staff_id"`
 	Email        string    `json:"email"`
 	RestaurantID string    `json:"restaurant_id"`
 	CreatedAt    time.Time `json:"created_at"`
@@ -83,6 +125,14 @@ func (h *Handler) RegisterStaff(c *gin.Context) {
 
 	output, err := h.service.RegisterStaff(ctx, input)
 	if err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			logger.Warn("Password does not meet the policy")
+			errResp := customhttp.NewErrorResponse(customhttp.CodeValidationError, customhttp.MsgValidationError)
+			errResp.Details = policyErr.Details("password")
+			c.JSON(http.StatusBadRequest, errResp)
+			return
+		}
 		if errors.Is(err, ErrStaffAlreadyExists) {
 			logger.Warn("Staff already exists", log.Field{Key: "email", Value: req.Email})
 			c.JSON(http.StatusConflict, customhttp.NewErrorResponse(CodeStaffAlreadyExists, MsgStaffAlreadyExists))
@@ -101,10 +151,11 @@ func (h *Handler) RegisterStaff(c *gin.Context) {
 	c.JSON(http.StatusCreated, resp)
 }
 
-// LoginStaffRequest represents the request payload for logging in a staff user.
+// LoginStaffRequest represents the request payload for logging in a staff user. The restaurant ID is optional, and
+// the restaurants of the staff user are returned to pick one of them when it is not provided.
 type LoginStaffRequest struct {
 	Email        string `json:"email" binding:"required,email"`
-	RestaurantID string `json:"restaurant_id" binding:"required"`
+	RestaurantID string `json:"restaurant_id"`
 	Password     string `json:"password" binding:"required,min=8"`
 }
 
@@ -113,6 +164,30 @@ type LoginStaffResponse struct {
 	authcore.TokenPairResponse
 }
 
+// LoginStaffMFAChallengeResponse represents the response payload for a staff user login that requires MFA. The
+// challenge token must be exchanged together with a valid code to get the token pair.
+type LoginStaffMFAChallengeResponse struct {
+	MFARequired    bool      `json:"mfa_required"`
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// LoginStaffRestaurantSelectionResponse represents the response payload for a staff user login without restaurant.
+// The selection token must be exchanged together with one of the restaurants to get the token pair scoped to it.
+type LoginStaffRestaurantSelectionResponse struct {
+	RestaurantSelectionRequired bool                      `json:"restaurant_selection_required"`
+	Restaurants                 []StaffRestaurantResponse `json:"restaurants"`
+	SelectionToken              string                    `json:"selection_token"`
+	ExpiresAt                   time.Time                 `json:"expires_at"`
+}
+
+// StaffRestaurantResponse represents a restaurant the staff user can log in to.
+type StaffRestaurantResponse struct {
+	RestaurantID string `json:"restaurant_id"`
+	Owner        bool   `json:"owner"`
+	Role         string `json:"role,omitempty"`
+}
+
 // LoginStaff processes the login request for a staff user using credentials provided in JSON format.
 func (h *Handler) LoginStaff(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -139,6 +214,14 @@ func (h *Handler) LoginStaff(c *gin.Context) {
 			))
 			return
 		}
+		if errors.Is(err, authcore.ErrAccountLocked) {
+			logger.Warn("Login locked after too many failed attempts", log.Field{Key: "email", Value: req.Email})
+			c.JSON(http.StatusTooManyRequests, customhttp.NewErrorResponse(
+				authcore.CodeAccountLocked,
+				authcore.MsgAccountLocked,
+			))
+			return
+		}
 		logger.Error("Failed to login staff user", err)
 		c.JSON(http.StatusInternalServerError, customhttp.NewErrorResponse(
 			customhttp.CodeInternalError,
@@ -147,11 +230,114 @@ func (h *Handler) LoginStaff(c *gin.Context) {
 		return
 	}
 
+	if output.RestaurantSelectionRequired {
+		restaurants := make([]StaffRestaurantResponse, 0, len(output.Restaurants))
+		for _, membership := range output.Restaurants {
+			restaurants = append(restaurants, StaffRestaurantResponse{
+				RestaurantID: membership.RestaurantID,
+				Owner:        membership.Owner,
+				Role:         membership.Role,
+			})
+		}
+		logger.Info("Staff restaurant selection required")
+		c.JSON(http.StatusOK, LoginStaffRestaurantSelectionResponse{
+			RestaurantSelectionRequired: true,
+			Restaurants:                 restaurants,
+			SelectionToken:              output.SelectionToken,
+			ExpiresAt:                   output.SelectionTokenExpiresAt,
+		})
+		return
+	}
+
+	if output.MFARequired {
+		logger.Info("Staff MFA challenge issued")
+		c.JSON(http.StatusOK, LoginStaffMFAChallengeResponse{
+			MFARequired:    true,
+			ChallengeToken: output.MFAChallengeToken,
+			ExpiresAt:      output.MFAChallengeExpiresAt,
+		})
+		return
+	}
+
 	resp := LoginStaffResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
 	logger.Info("Staff logged in successfully")
 	c.JSON(http.StatusOK, resp)
 }
 
+// SelectStaffRestaurantRequest represents the request payload for opening the session of a staff user in the
+// restaurant it picked after logging in without restaurant.
+type SelectStaffRestaurantRequest struct {
+	SelectionToken string `json:"selection_token" binding:"required"`
+	RestaurantID   string `json:"restaurant_id" binding:"required"`
+}
+
+// SelectStaffRestaurantResponse represents the response payload for a successful staff restaurant selection.
+type SelectStaffRestaurantResponse struct {
+	authcore.TokenPairResponse
+}
+
+// SelectStaffRestaurant handles the exchange of the selection token issued on a login without restaurant for the
+// token pair scoped to the restaurant picked by the staff user. The MFA challenge is returned instead when the staff
+// user has MFA enabled.
+func (h *Handler) SelectStaffRestaurant(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("SelectStaffRestaurant handler called")
+
+	var req SelectStaffRestaurantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := SelectStaffRestaurantInput(req)
+	output, err := h.service.SelectStaffRestaurant(ctx, input)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSelectionToken) {
+			logger.Warn("Invalid selection token provided")
+			c.JSON(
+				http.StatusUnauthorized,
+				customhttp.NewErrorResponse(CodeInvalidSelectionToken, MsgInvalidSelectionToken),
+			)
+			return
+		}
+		if errors.Is(err, ErrRestaurantNotLinked) {
+			logger.Warn("Restaurant not linked to the staff", log.Field{Key: "restaurant_id", Value: req.RestaurantID})
+			c.JSON(
+				http.StatusForbidden,
+				customhttp.NewErrorResponse(CodeRestaurantNotLinked, MsgRestaurantNotLinked),
+			)
+			return
+		}
+
+		logger.Error("Failed to select staff restaurant", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	if output.MFARequired {
+		logger.Info("Staff MFA challenge issued")
+		c.JSON(http.StatusOK, LoginStaffMFAChallengeResponse{
+			MFARequired:    true,
+			ChallengeToken: output.MFAChallengeToken,
+			ExpiresAt:      output.MFAChallengeExpiresAt,
+		})
+		return
+	}
+
+	resp := SelectStaffRestaurantResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
+	logger.Info("Staff restaurant selected successfully", log.Field{Key: "restaurant_id", Value: req.RestaurantID})
+	c.JSON(http.StatusOK, resp)
+}
+
 // RefreshStaffRequest represents a request to refresh staff information using tokens.
 type RefreshStaffRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
@@ -209,3 +395,710 @@ func (h *Handler) RefreshStaff(c *gin.Context) {
 	logger.Info("Staff refreshed successfully")
 	c.JSON(http.StatusOK, resp)
 }
+
+// LogoutStaffRequest represents the request payload for revoking the sessions of a staff.
+type LogoutStaffRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutStaff handles the revocation of the staff session linked to the presented refresh token.
+func (h *Handler) LogoutStaff(c *gin.Context) {
+	h.logoutStaff(c, "LogoutStaff", false)
+}
+
+// LogoutStaffAllSessions handles the revocation of all the active sessions of the staff who owns the presented refresh
+// token.
+func (h *Handler) LogoutStaffAllSessions(c *gin.Context) {
+	h.logoutStaff(c, "LogoutStaffAllSessions", true)
+}
+
+func (h *Handler) logoutStaff(c *gin.Context, handlerName string, allSessions bool) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info(handlerName + " handler called")
+
+	var req LogoutStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := LogoutStaffInput{
+		RefreshToken: req.RefreshToken,
+		AllSessions:  allSessions,
+	}
+	output, err := h.service.LogoutStaff(ctx, input)
+	if err != nil {
+		if errors.Is(err, auth.ErrImpersonationNotAllowed) {
+			logger.Warn("Logout from all sessions not allowed while impersonating")
+			c.JSON(
+				http.StatusForbidden,
+				customhttp.NewErrorResponse(auth.CodeForbiddenError, auth.MessageForbiddenError),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrInvalidRefreshToken) {
+			logger.Warn("Invalid refresh token provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidRefreshToken,
+					authcore.MsgInvalidRefreshToken,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to logout staff", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Staff logged out successfully", log.Field{Key: "revoked_tokens", Value: output.RevokedTokens})
+	c.Status(http.StatusNoContent)
+}
+
+// ForgotStaffPasswordRequest represents the request payload for requesting a staff password reset.
+type ForgotStaffPasswordRequest struct {
+	Email        string `json:"email" binding:"required,email"`
+	RestaurantID string `json:"restaurant_id" binding:"required"`
+}
+
+// ForgotStaffPassword handles the request of a password reset token for a staff user. The response is the same
+// whether the staff user exists or not.
+func (h *Handler) ForgotStaffPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ForgotStaffPassword handler called")
+
+	var req ForgotStaffPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := RequestStaffPasswordResetInput(req)
+	if _, err := h.service.RequestStaffPasswordReset(ctx, input); err != nil {
+		logger.Error("Failed to request staff password reset", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Staff password reset requested successfully")
+	c.Status(http.StatusAccepted)
+}
+
+// ResetStaffPasswordRequest represents the request payload for setting a new staff password with a reset token.
+type ResetStaffPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// ResetStaffPassword handles the reset of a staff password with a password reset token.
+func (h *Handler) ResetStaffPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ResetStaffPassword handler called")
+
+	var req ResetStaffPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := ResetStaffPasswordInput(req)
+	output, err := h.service.ResetStaffPassword(ctx, input)
+	if err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			logger.Warn("Password does not meet the policy")
+			errResp := customhttp.NewErrorResponse(customhttp.CodeValidationError, customhttp.MsgValidationError)
+			errResp.Details = policyErr.Details("password")
+			c.JSON(http.StatusBadRequest, errResp)
+			return
+		}
+		if errors.Is(err, authcore.ErrInvalidResetToken) {
+			logger.Warn("Invalid reset token provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidResetToken,
+					authcore.MsgInvalidResetToken,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to reset staff password", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Staff password reset successfully", log.Field{Key: "revoked_tokens", Value: output.RevokedTokens})
+	c.Status(http.StatusNoContent)
+}
+
+// ChangeStaffPasswordRequest represents the request payload for the authenticated staff user to change its password.
+type ChangeStaffPasswordRequest struct {
+	CurrentPassword     string `json:"current_password" binding:"required"`
+	NewPassword         string `json:"new_password" binding:"required,min=8"`
+	RevokeOtherSessions bool   `json:"revoke_other_sessions"`
+}
+
+// ChangeStaffPassword handles the password change of the authenticated staff user.
+func (h *Handler) ChangeStaffPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ChangeStaffPassword handler called")
+
+	var req ChangeStaffPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := ChangeStaffPasswordInput(req)
+	output, err := h.service.ChangeStaffPassword(ctx, input)
+	if err != nil {
+		if errors.Is(err, auth.ErrImpersonationNotAllowed) {
+			logger.Warn("Password change not allowed while impersonating")
+			c.JSON(
+				http.StatusForbidden,
+				customhttp.NewErrorResponse(auth.CodeForbiddenError, auth.MessageForbiddenError),
+			)
+			return
+		}
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			logger.Warn("Password does not meet the policy")
+			errResp := customhttp.NewErrorResponse(customhttp.CodeValidationError, customhttp.MsgValidationError)
+			errResp.Details = policyErr.Details("new_password")
+			c.JSON(http.StatusBadRequest, errResp)
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidToken) {
+			logger.Warn("Authentication context not found")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					auth.CodeUnauthorizedError,
+					auth.MessageUnauthorizedError,
+				),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrInvalidCurrentPassword) {
+			logger.Warn("Invalid current password provided")
+			c.JSON(
+				http.StatusForbidden, customhttp.NewErrorResponse(
+					authcore.CodeInvalidCurrentPassword,
+					authcore.MsgInvalidCurrentPassword,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to change staff password", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Staff password changed successfully", log.Field{Key: "revoked_tokens", Value: output.RevokedTokens})
+	c.Status(http.StatusNoContent)
+}
+
+// VerifyStaffMFARequest represents the request payload for completing the MFA challenge of a staff user login.
+// Either the TOTP code or one of the recovery codes must be provided.
+type VerifyStaffMFARequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+// VerifyStaffMFAResponse represents the response payload for a successful staff MFA challenge completion.
+type VerifyStaffMFAResponse struct {
+	authcore.TokenPairResponse
+}
+
+// VerifyStaffMFA handles the exchange of the MFA challenge issued on a staff user login for a token pair.
+func (h *Handler) VerifyStaffMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("VerifyStaffMFA handler called")
+
+	var req VerifyStaffMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := VerifyStaffMFAInput(req)
+	output, err := h.service.VerifyStaffMFA(ctx, input)
+	if err != nil {
+		if errors.Is(err, authcore.ErrInvalidMFAChallenge) {
+			logger.Warn("Invalid MFA challenge provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidMFAChallenge,
+					authcore.MsgInvalidMFAChallenge,
+				),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrInvalidMFACode) {
+			logger.Warn("Invalid MFA code provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidMFACode,
+					authcore.MsgInvalidMFACode,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to verify staff MFA", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := VerifyStaffMFAResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
+	logger.Info("Staff logged in successfully")
+	c.JSON(http.StatusOK, resp)
+}
+
+// EnrollStaffMFAResponse represents the response payload for starting the MFA enrollment of a staff user.
+type EnrollStaffMFAResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// EnrollStaffMFA handles the start of the MFA enrollment of the authenticated staff user.
+func (h *Handler) EnrollStaffMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("EnrollStaffMFA handler called")
+
+	output, err := h.service.EnrollStaffMFA(ctx, EnrollStaffMFAInput{})
+	if err != nil {
+		if errors.Is(err, auth.ErrImpersonationNotAllowed) {
+			logger.Warn("MFA enrollment not allowed while impersonating")
+			c.JSON(
+				http.StatusForbidden,
+				customhttp.NewErrorResponse(auth.CodeForbiddenError, auth.MessageForbiddenError),
+			)
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidToken) {
+			logger.Warn("Authentication context not found")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					auth.CodeUnauthorizedError,
+					auth.MessageUnauthorizedError,
+				),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrMFAAlreadyEnabled) {
+			logger.Warn("Staff MFA already enabled")
+			c.JSON(
+				http.StatusConflict, customhttp.NewErrorResponse(
+					authcore.CodeMFAAlreadyEnabled,
+					authcore.MsgMFAAlreadyEnabled,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to enroll staff MFA", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := EnrollStaffMFAResponse(output)
+	logger.Info("Staff MFA enrollment started successfully")
+	c.JSON(http.StatusOK, resp)
+}
+
+// ConfirmStaffMFARequest represents the request payload for confirming the MFA enrollment of a staff user.
+type ConfirmStaffMFARequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// ConfirmStaffMFAResponse represents the response payload for a confirmed staff MFA enrollment.
+type ConfirmStaffMFAResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ConfirmStaffMFA handles the confirmation of the MFA enrollment of the authenticated staff user.
+func (h *Handler) ConfirmStaffMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ConfirmStaffMFA handler called")
+
+	var req ConfirmStaffMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := ConfirmStaffMFAInput(req)
+	output, err := h.service.ConfirmStaffMFA(ctx, input)
+	if err != nil {
+		if errors.Is(err, auth.ErrImpersonationNotAllowed) {
+			logger.Warn("MFA confirmation not allowed while impersonating")
+			c.JSON(
+				http.StatusForbidden,
+				customhttp.NewErrorResponse(auth.CodeForbiddenError, auth.MessageForbiddenError),
+			)
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidToken) {
+			logger.Warn("Authentication context not found")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					auth.CodeUnauthorizedError,
+					auth.MessageUnauthorizedError,
+				),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrMFAEnrollmentNotFound) {
+			logger.Warn("Staff MFA enrollment not found")
+			c.JSON(
+				http.StatusNotFound, customhttp.NewErrorResponse(
+					authcore.CodeMFAEnrollmentNotFound,
+					authcore.MsgMFAEnrollmentNotFound,
+				),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrMFAAlreadyEnabled) {
+			logger.Warn("Staff MFA already enabled")
+			c.JSON(
+				http.StatusConflict, customhttp.NewErrorResponse(
+					authcore.CodeMFAAlreadyEnabled,
+					authcore.MsgMFAAlreadyEnabled,
+				),
+			)
+			return
+		}
+		if errors.Is(err, authcore.ErrInvalidMFACode) {
+			logger.Warn("Invalid MFA code provided")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					authcore.CodeInvalidMFACode,
+					authcore.MsgInvalidMFACode,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to confirm staff MFA", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := ConfirmStaffMFAResponse(output)
+	logger.Info("Staff MFA enabled successfully")
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeactivateStaff handles the deactivation of a staff user by a platform admin, revoking all its sessions.
+func (h *Handler) DeactivateStaff(c *gin.Context) {
+	h.setStaffActive(c, "DeactivateStaff", false)
+}
+
+// ReactivateStaff handles the reactivation of a deactivated staff user by a platform admin.
+func (h *Handler) ReactivateStaff(c *gin.Context) {
+	h.setStaffActive(c, "ReactivateStaff", true)
+}
+
+func (h *Handler) setStaffActive(c *gin.Context, handlerName string, active bool) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info(handlerName + " handler called")
+
+	input := SetStaffActiveInput{
+		StaffID:      c.Param("staffID"),
+		RestaurantID: c.Param("restaurantID"),
+		Active:       active,
+	}
+	output, err := h.service.SetStaffActive(ctx, input)
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("Staff not found", log.Field{Key: "staff_id", Value: input.StaffID})
+			c.JSON(
+				http.StatusNotFound,
+				customhttp.NewErrorResponse(CodeStaffNotFound, MsgStaffNotFound),
+			)
+			return
+		}
+
+		logger.Error("Failed to set staff active status", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info(
+		"Staff active status set successfully",
+		log.Field{Key: "staff_id", Value: input.StaffID},
+		log.Field{Key: "active", Value: active},
+		log.Field{Key: "revoked_tokens", Value: output.RevokedTokens},
+	)
+	c.Status(http.StatusNoContent)
+}
+
+// SwitchStaffTenantRequest represents the request payload for switching the session of the authenticated staff user to
+// another of its restaurants.
+type SwitchStaffTenantRequest struct {
+	RestaurantID string `json:"restaurant_id" binding:"required"`
+}
+
+// SwitchStaffTenantResponse represents the response payload for a successful staff tenant switch.
+type SwitchStaffTenantResponse struct {
+	authcore.TokenPairResponse
+}
+
+// SwitchStaffTenant handles the exchange of the session of the authenticated staff user for a token pair scoped to
+// another of its restaurants. The MFA challenge is returned instead when the staff user has MFA enabled.
+func (h *Handler) SwitchStaffTenant(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("SwitchStaffTenant handler called")
+
+	var req SwitchStaffTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := SwitchStaffTenantInput(req)
+	output, err := h.service.SwitchStaffTenant(ctx, input)
+	if err != nil {
+		if errors.Is(err, auth.ErrImpersonationNotAllowed) {
+			logger.Warn("Tenant switch not allowed while impersonating")
+			c.JSON(
+				http.StatusForbidden,
+				customhttp.NewErrorResponse(auth.CodeForbiddenError, auth.MessageForbiddenError),
+			)
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidToken) {
+			logger.Warn("Authentication context not found")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					auth.CodeUnauthorizedError,
+					auth.MessageUnauthorizedError,
+				),
+			)
+			return
+		}
+		if errors.Is(err, ErrRestaurantNotLinked) {
+			logger.Warn("Restaurant not linked to the staff", log.Field{Key: "restaurant_id", Value: req.RestaurantID})
+			c.JSON(
+				http.StatusForbidden,
+				customhttp.NewErrorResponse(CodeRestaurantNotLinked, MsgRestaurantNotLinked),
+			)
+			return
+		}
+
+		logger.Error("Failed to switch staff tenant", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	if output.MFARequired {
+		logger.Info("Staff MFA challenge issued")
+		c.JSON(http.StatusOK, LoginStaffMFAChallengeResponse{
+			MFARequired:    true,
+			ChallengeToken: output.MFAChallengeToken,
+			ExpiresAt:      output.MFAChallengeExpiresAt,
+		})
+		return
+	}
+
+	resp := SwitchStaffTenantResponse{TokenPairResponse: authcore.TokenPairResponse(output.TokenPair)}
+	logger.Info("Staff tenant switched successfully", log.Field{Key: "restaurant_id", Value: req.RestaurantID})
+	c.JSON(http.StatusOK, resp)
+}
+
+// LinkStaffRestaurantRequest represents the request payload for linking a staff user to another restaurant.
+type LinkStaffRestaurantRequest struct {
+	RestaurantID string `json:"restaurant_id" binding:"required"`
+	Owner        bool   `json:"owner"`
+	Role         string `json:"role" binding:"omitempty,oneof=manager cashier chef"`
+}
+
+// LinkStaffRestaurant handles the link of an existing staff user to another restaurant by an internal service, so
+// the staff user can log in to it with the same credentials.
+func (h *Handler) LinkStaffRestaurant(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("LinkStaffRestaurant handler called")
+
+	var req LinkStaffRestaurantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warn("Failed to bind request", log.Field{Key: "error", Value: err.Error()})
+		errResp := customhttp.GetErrorResponseFromValidationErr(err)
+		c.JSON(http.StatusBadRequest, errResp)
+		return
+	}
+
+	input := LinkStaffRestaurantInput{
+		StaffID:      c.Param("staffID"),
+		RestaurantID: req.RestaurantID,
+		Owner:        req.Owner,
+		Role:         req.Role,
+	}
+	if _, err := h.service.LinkStaffRestaurant(ctx, input); err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("Staff not found", log.Field{Key: "staff_id", Value: input.StaffID})
+			c.JSON(
+				http.StatusNotFound,
+				customhttp.NewErrorResponse(CodeStaffNotFound, MsgStaffNotFound),
+			)
+			return
+		}
+		if errors.Is(err, ErrRestaurantAlreadyLinked) {
+			logger.Warn("Restaurant already linked to the staff", log.Field{Key: "staff_id", Value: input.StaffID})
+			c.JSON(
+				http.StatusConflict,
+				customhttp.NewErrorResponse(CodeRestaurantAlreadyLinked, MsgRestaurantAlreadyLinked),
+			)
+			return
+		}
+
+		logger.Error("Failed to link staff restaurant", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	logger.Info("Staff restaurant linked successfully", log.Field{Key: "staff_id", Value: input.StaffID})
+	c.Status(http.StatusNoContent)
+}
+
+// ImpersonateStaffResponse represents the response returned when a platform admin impersonates a staff user.
+type ImpersonateStaffResponse struct {
+	authcore.ImpersonationTokenResponse
+}
+
+// ImpersonateStaff handles the issuing of a short-lived access token on behalf of a staff user within a restaurant, so
+// a platform admin can reproduce its problems.
+func (h *Handler) ImpersonateStaff(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := h.logger.WithContext(ctx)
+
+	logger.Info("ImpersonateStaff handler called")
+
+	input := ImpersonateStaffInput{
+		StaffID:      c.Param("staffID"),
+		RestaurantID: c.Param("restaurantID"),
+	}
+	output, err := h.service.ImpersonateStaff(ctx, input)
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("Staff not found", log.Field{Key: "staff_id", Value: input.StaffID})
+			c.JSON(
+				http.StatusNotFound,
+				customhttp.NewErrorResponse(CodeStaffNotFound, MsgStaffNotFound),
+			)
+			return
+		}
+		if errors.Is(err, auth.ErrInvalidToken) {
+			logger.Warn("Authentication context not found")
+			c.JSON(
+				http.StatusUnauthorized, customhttp.NewErrorResponse(
+					auth.CodeUnauthorizedError,
+					auth.MessageUnauthorizedError,
+				),
+			)
+			return
+		}
+
+		logger.Error("Failed to impersonate staff", err)
+		c.JSON(
+			http.StatusInternalServerError, customhttp.NewErrorResponse(
+				customhttp.CodeInternalError,
+				customhttp.MsgInternalError,
+			),
+		)
+		return
+	}
+
+	resp := ImpersonateStaffResponse{
+		ImpersonationTokenResponse: authcore.ImpersonationTokenResponse(output.ImpersonationToken),
+	}
+	logger.Info("Staff impersonated successfully", log.Field{Key: "staff_id", Value: input.StaffID})
+	c.JSON(http.StatusOK, resp)
+}
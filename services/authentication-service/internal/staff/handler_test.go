@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
@@ -16,6 +17,7 @@ import (
 	customhttp "github.com/alexgrauroca/practice-food-delivery-platform/pkg/http"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/staff"
 	staffmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/staff/mocks"
 )
@@ -38,15 +40,48 @@ func TestHandler_RegisterStaff(t *testing.T) {
 	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	tests := []staffHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a service, then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the service token does not have the register scope, " +
+				"then it should return a 403 with the forbidden error",
+			token: "service-token",
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, "fake:scope")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
 		{
 			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			token:       "service-token",
 			jsonPayload: `{"password": 1.2, "email": true}`,
-			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
-			wantStatus:  http.StatusBadRequest,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON:   customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus: http.StatusBadRequest,
 		},
 		{
 			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "service-token",
 			jsonPayload: `{}`,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
 			wantJSON: customhttp.NewValidationErrorRespBuilder().
 				WithDetails(
 					"staff_id is required",
@@ -57,40 +92,69 @@ func TestHandler_RegisterStaff(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 		},
 		{
-			name: "when invalid email is provided, then it should return a 400 with the email validation error",
+			name:  "when invalid email is provided, then it should return a 400 with the email validation error",
+			token: "service-token",
 			jsonPayload: `{
 				"staff_id": "fake-staff-id",
 				"email": "invalid-email",
 				"restaurant_id": "fake-restaurant-id",
 				"password": "ValidPassword123"
 			}`,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
 			wantJSON: customhttp.NewValidationErrorRespBuilder().
 				WithDetails("email must be a valid email address").
 				Build(),
 			wantStatus: http.StatusBadRequest,
 		},
 		{
-			name: "when invalid password is provided, then it should return a 400 with the pwd validation error",
+			name:  "when invalid password is provided, then it should return a 400 with the pwd validation error",
+			token: "service-token",
 			jsonPayload: `{
 				"staff_id": "fake-staff-id",
 				"email": "test@example.com",
 				"restaurant_id": "fake-restaurant-id",
 				"password": "short"
 			}`,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
 			wantJSON: customhttp.NewValidationErrorRespBuilder().
 				WithDetails("password must be a valid password with at least 8 characters long").
 				Build(),
 			wantStatus: http.StatusBadRequest,
 		},
 		{
-			name: "when the staff already exists, then it should return a 409 with the staff already exists error",
+			name:  "when the password does not meet the policy, then it should return a 400 with the policy violations",
+			token: "service-token",
+			jsonPayload: `{
+				"staff_id": "fake-staff-id",
+				"email": "test@example.com",
+				"restaurant_id": "fake-restaurant-id",
+				"password": "password"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+				service.EXPECT().RegisterStaff(gomock.Any(), gomock.Any()).
+					Return(staff.RegisterStaffOutput{}, &password.PolicyError{Violations: []string{"must be at least 10 characters long", "is too common"}})
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("password must be at least 10 characters long", "password is too common").
+				Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "when the staff already exists, then it should return a 409 with the staff already exists error",
+			token: "service-token",
 			jsonPayload: `{
 				"staff_id": "fake-staff-id",
 				"email": "test@example.com",
 				"restaurant_id": "fake-restaurant-id",
 				"password": "ValidPassword123"
 			}`,
-			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
 				service.EXPECT().RegisterStaff(gomock.Any(), gomock.Any()).
 					Return(staff.RegisterStaffOutput{}, staff.ErrStaffAlreadyExists)
 			},
@@ -104,13 +168,15 @@ func TestHandler_RegisterStaff(t *testing.T) {
 		{
 			name: "when unexpected error when registering the staff, " +
 				"then it should return a 500 with the internal error",
+			token: "service-token",
 			jsonPayload: `{
 				"staff_id": "fake-staff-id",
 				"email": "test@example.com",
 				"restaurant_id": "fake-restaurant-id",
 				"password": "ValidPassword123"
 			}`,
-			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
 				service.EXPECT().RegisterStaff(gomock.Any(), gomock.Any()).
 					Return(staff.RegisterStaffOutput{}, errUnexpected)
 			},
@@ -118,21 +184,42 @@ func TestHandler_RegisterStaff(t *testing.T) {
 			wantStatus: http.StatusInternalServerError,
 		},
 		{
-			name: "when the staff is successfully registered, then it should return a 201 with the staff details",
+			name:  "when the role is unknown, then it should return a 400 with the validation error",
+			token: "service-token",
 			jsonPayload: `{
 				"staff_id": "fake-staff-id",
 				"email": "test@example.com",
 				"restaurant_id": "fake-restaurant-id",
-				"password": "ValidPassword123"
+				"password": "ValidPassword123",
+				"role": "waiter"
 			}`,
-			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("role is invalid").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "when the staff is successfully registered, then it should return a 201 with the staff details",
+			token: "service-token",
+			jsonPayload: `{
+				"staff_id": "fake-staff-id",
+				"email": "test@example.com",
+				"restaurant_id": "fake-restaurant-id",
+				"password": "ValidPassword123",
+				"role": "manager"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
 				service.EXPECT().RegisterStaff(gomock.Any(), staff.RegisterStaffInput{
 					StaffID:      "fake-staff-id",
 					Email:        "test@example.com",
 					RestaurantID: "fake-restaurant-id",
 					Password:     "ValidPassword123",
+					Role:         staff.RoleManager,
 				}).Return(staff.RegisterStaffOutput{
 					ID:           "fake-id",
+					StaffID:      "fake-staff-id",
 					Email:        "test@example.com",
 					RestaurantID: "fake-restaurant-id",
 					CreatedAt:    now,
@@ -141,6 +228,7 @@ func TestHandler_RegisterStaff(t *testing.T) {
 			},
 			wantJSON: `{
 				"id":"fake-id",
+				"staff_id":"fake-staff-id",
 				"email":"test@example.com",
 				"restaurant_id":"fake-restaurant-id",
 				"created_at":"2025-01-01T00:00:00Z",
@@ -152,7 +240,7 @@ func TestHandler_RegisterStaff(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			runStaffHandlerTestCase(t, logger, http.MethodPost, "/v1.0/auth/staff", tt, "")
+			runStaffHandlerTestCase(t, logger, http.MethodPost, "/v1.0/auth/staff", tt, tt.token)
 		})
 	}
 }
@@ -173,7 +261,6 @@ func TestHandler_LoginStaff(t *testing.T) {
 			wantJSON: customhttp.NewValidationErrorRespBuilder().
 				WithDetails(
 					"email is required",
-					"restaurant_id is required",
 					"password is required",
 				).Build(),
 			wantStatus: http.StatusBadRequest,
@@ -222,6 +309,24 @@ func TestHandler_LoginStaff(t *testing.T) {
 			}`,
 			wantStatus: http.StatusUnauthorized,
 		},
+		{
+			name: "when the staff login is locked, then it should return a 429 with account locked error",
+			jsonPayload: `{
+				"email": "test@example.com",
+				"password": "ValidPassword123",
+				"restaurant_id": "fake-restaurant-id"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginStaff(gomock.Any(), gomock.Any()).
+					Return(staff.LoginStaffOutput{}, authcore.ErrAccountLocked)
+			},
+			wantJSON: `{
+				"code": "ACCOUNT_LOCKED",
+				"message": "too many failed login attempts, try again later",
+				"details": []
+			}`,
+			wantStatus: http.StatusTooManyRequests,
+		},
 		{
 			name: "when unexpected error when login the staff, " +
 				"then it should return a 500 with the internal error",
@@ -251,11 +356,13 @@ func TestHandler_LoginStaff(t *testing.T) {
 					RestaurantID: "fake-restaurant-id",
 					Password:     "ValidPassword123",
 				}).Return(staff.LoginStaffOutput{
-					TokenPair: authcore.TokenPair{
-						AccessToken:  "fake-token",
-						RefreshToken: "fake-refresh-token",
-						ExpiresIn:    staff.DefaultTokenExpiration,
-						TokenType:    auth.DefaultTokenType,
+					TenantSession: staff.TenantSession{
+						TokenPair: authcore.TokenPair{
+							AccessToken:  "fake-token",
+							RefreshToken: "fake-refresh-token",
+							ExpiresIn:    staff.DefaultTokenExpiration,
+							TokenType:    auth.DefaultTokenType,
+						},
 					},
 				}, nil)
 			},
@@ -267,6 +374,62 @@ func TestHandler_LoginStaff(t *testing.T) {
 			}`,
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "when the staff has MFA enabled, then it should return a 200 with the MFA challenge",
+			jsonPayload: `{
+				"email": "test@example.com",
+				"password": "ValidPassword123",
+				"restaurant_id": "fake-restaurant-id"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginStaff(gomock.Any(), gomock.Any()).
+					Return(staff.LoginStaffOutput{
+						TenantSession: staff.TenantSession{
+							MFARequired:           true,
+							MFAChallengeToken:     "fake-challenge-token",
+							MFAChallengeExpiresAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+						},
+					}, nil)
+			},
+			wantJSON: `{
+			  "mfa_required": true,
+			  "challenge_token": "fake-challenge-token",
+			  "expires_at": "2025-01-01T00:05:00Z"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "when the restaurant is not provided, " +
+				"then it should return a 200 with the restaurants the staff can log in to",
+			jsonPayload: `{
+				"email": "test@example.com",
+				"password": "ValidPassword123"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LoginStaff(gomock.Any(), staff.LoginStaffInput{
+					Email:    "test@example.com",
+					Password: "ValidPassword123",
+				}).Return(staff.LoginStaffOutput{
+					RestaurantSelectionRequired: true,
+					Restaurants: []staff.Membership{
+						{RestaurantID: "fake-restaurant-id", Owner: true, Active: true},
+						{RestaurantID: "fake-other-restaurant-id", Role: staff.RoleCashier, Active: true},
+					},
+					SelectionToken:          "fake-selection-token",
+					SelectionTokenExpiresAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+				}, nil)
+			},
+			wantJSON: `{
+			  "restaurant_selection_required": true,
+			  "restaurants": [
+			    {"restaurant_id": "fake-restaurant-id", "owner": true},
+			    {"restaurant_id": "fake-other-restaurant-id", "owner": false, "role": "cashier"}
+			  ],
+			  "selection_token": "fake-selection-token",
+			  "expires_at": "2025-01-01T00:05:00Z"
+			}`,
+			wantStatus: http.StatusOK,
+		},
 	}
 
 	for _, tt := range tests {
@@ -276,6 +439,129 @@ func TestHandler_LoginStaff(t *testing.T) {
 	}
 }
 
+func TestHandler_SelectStaffRestaurant(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("selection_token is required", "restaurant_id is required").
+				Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the selection token is not valid, " +
+				"then it should return a 401 with the invalid selection token error",
+			jsonPayload: `{
+				"selection_token": "fake-selection-token",
+				"restaurant_id": "fake-restaurant-id"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().SelectStaffRestaurant(gomock.Any(), gomock.Any()).
+					Return(staff.SelectStaffRestaurantOutput{}, staff.ErrInvalidSelectionToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_SELECTION_TOKEN",
+				"message": "invalid, expired or already used selection token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the restaurant is not linked to the staff, " +
+				"then it should return a 403 with the restaurant not linked error",
+			jsonPayload: `{
+				"selection_token": "fake-selection-token",
+				"restaurant_id": "fake-restaurant-id"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().SelectStaffRestaurant(gomock.Any(), gomock.Any()).
+					Return(staff.SelectStaffRestaurantOutput{}, staff.ErrRestaurantNotLinked)
+			},
+			wantJSON: `{
+				"code": "RESTAURANT_NOT_LINKED",
+				"message": "restaurant not linked to the staff",
+				"details": []
+			}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when unexpected error when selecting the restaurant, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{
+				"selection_token": "fake-selection-token",
+				"restaurant_id": "fake-restaurant-id"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().SelectStaffRestaurant(gomock.Any(), gomock.Any()).
+					Return(staff.SelectStaffRestaurantOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "when the staff has MFA enabled, then it should return a 200 with the MFA challenge",
+			jsonPayload: `{
+				"selection_token": "fake-selection-token",
+				"restaurant_id": "fake-restaurant-id"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().SelectStaffRestaurant(gomock.Any(), gomock.Any()).
+					Return(staff.SelectStaffRestaurantOutput{
+						TenantSession: staff.TenantSession{
+							MFARequired:           true,
+							MFAChallengeToken:     "fake-challenge-token",
+							MFAChallengeExpiresAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+						},
+					}, nil)
+			},
+			wantJSON: `{
+			  "mfa_required": true,
+			  "challenge_token": "fake-challenge-token",
+			  "expires_at": "2025-01-01T00:05:00Z"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "when the restaurant is selected, then it should return a 200 with the token pair",
+			jsonPayload: `{
+				"selection_token": "fake-selection-token",
+				"restaurant_id": "fake-restaurant-id"
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().SelectStaffRestaurant(gomock.Any(), staff.SelectStaffRestaurantInput{
+					SelectionToken: "fake-selection-token",
+					RestaurantID:   "fake-restaurant-id",
+				}).Return(staff.SelectStaffRestaurantOutput{
+					TenantSession: staff.TenantSession{
+						TokenPair: authcore.TokenPair{
+							AccessToken:  "fake-token",
+							RefreshToken: "fake-refresh-token",
+							ExpiresIn:    staff.DefaultTokenExpiration,
+							TokenType:    auth.DefaultTokenType,
+						},
+					},
+				}, nil)
+			},
+			wantJSON: `{
+				"access_token": "fake-token",
+				"refresh_token": "fake-refresh-token",
+				"expires_in": 3600,
+				"token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runStaffHandlerTestCase(t, logger, http.MethodPost, "/v1.0/staff/login/restaurant", tt, "")
+		})
+	}
+}
+
 func TestHandler_RefreshStaff(t *testing.T) {
 	logger := customhttp.SetupTestEnv()
 
@@ -370,31 +656,1188 @@ func TestHandler_RefreshStaff(t *testing.T) {
 	}
 }
 
-// runStaffHandlerTestCase executes a test case for the staff handler, which is common for all tests.
-func runStaffHandlerTestCase(
-	t *testing.T,
-	logger log.Logger,
-	httpMethod string,
-	route string,
-	tt staffHandlerTestCase,
-	token string,
-) {
-	// Create a new mock service
-	service := staffmocks.NewMockService(gomock.NewController(t))
-	authService := authmocks.NewMockService(gomock.NewController(t))
-	if tt.mocksSetup != nil {
-		tt.mocksSetup(service, authService)
-	}
+func TestHandler_LogoutStaff(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
 
-	// Initialize the authentication middleware
-	authMiddleware := auth.NewMiddleware(logger, authService)
+	tests := []staffHandlerTestCase{
+		{
+			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			jsonPayload: `{"refresh_token": true}`,
+			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("refresh_token is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when invalid refresh token provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			jsonPayload: `{"refresh_token": "invalid-refresh-token"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutStaff(gomock.Any(), gomock.Any()).
+					Return(staff.LogoutStaffOutput{}, authcore.ErrInvalidRefreshToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when unexpected error when logging out the staff, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutStaff(gomock.Any(), gomock.Any()).
+					Return(staff.LogoutStaffOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the staff is logged out, then it should return a 204 without content",
+			jsonPayload: `{"refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutStaff(gomock.Any(), staff.LogoutStaffInput{
+					RefreshToken: "valid-refresh-token",
+					AllSessions:  false,
+				}).Return(staff.LogoutStaffOutput{RevokedTokens: 1}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
 
-	// Initialize the handler
-	h := staff.NewHandler(logger, service, authMiddleware)
+	route := "/v1.0/staff/logout"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+		})
+	}
+}
 
-	// Make HTTP request
-	w := customhttp.ServeTestHTTPRequest(t, h, httpMethod, route, token, tt.queryParams, tt.jsonPayload)
+func TestHandler_LogoutStaffAllSessions(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
 
-	assert.Equal(t, tt.wantStatus, w.Code)
+	tests := []staffHandlerTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("refresh_token is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when all the sessions are revoked while impersonating, " +
+				"then it should return a 403 with the forbidden error",
+			jsonPayload: `{"refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutStaff(gomock.Any(), gomock.Any()).
+					Return(staff.LogoutStaffOutput{}, auth.ErrImpersonationNotAllowed)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when invalid refresh token provided, " +
+				"then it should return a 401 with the invalid refresh token error",
+			jsonPayload: `{"refresh_token": "invalid-refresh-token"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutStaff(gomock.Any(), gomock.Any()).
+					Return(staff.LogoutStaffOutput{}, authcore.ErrInvalidRefreshToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_REFRESH_TOKEN",
+				"message": "invalid or expired refresh token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "when all the staff sessions are revoked, then it should return a 204 without content",
+			jsonPayload: `{"refresh_token": "valid-refresh-token"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().LogoutStaff(gomock.Any(), staff.LogoutStaffInput{
+					RefreshToken: "valid-refresh-token",
+					AllSessions:  true,
+				}).Return(staff.LogoutStaffOutput{RevokedTokens: 3}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/staff/logout/all"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+		})
+	}
+}
+
+func TestHandler_ForgotStaffPassword(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:        "when invalid payload is provided, then it should return a 400 with invalid request error",
+			jsonPayload: `{"email": true}`,
+			wantJSON:    customhttp.NewInvalidRequestRespBuilder().Build(),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "when invalid email is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{"email": "invalid-email", "restaurant_id": "fake-restaurant-id"}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("email must be a valid email address").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when the restaurant is missing, then it should return a 400 with the validation error",
+			jsonPayload: `{"email": "test@example.com"}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("restaurant_id is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when unexpected error when requesting the password reset, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"email": "test@example.com", "restaurant_id": "fake-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RequestStaffPasswordReset(gomock.Any(), gomock.Any()).
+					Return(staff.RequestStaffPasswordResetOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the password reset is requested, then it should return a 202 without content",
+			jsonPayload: `{"email": "test@example.com", "restaurant_id": "fake-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().RequestStaffPasswordReset(gomock.Any(), staff.RequestStaffPasswordResetInput{
+					Email:        "test@example.com",
+					RestaurantID: "fake-restaurant-id",
+				}).Return(staff.RequestStaffPasswordResetOutput{}, nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+	}
+
+	route := "/v1.0/staff/password/forgot"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+			},
+		)
+	}
+}
+
+func TestHandler_ResetStaffPassword(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"token is required",
+					"password is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when invalid password is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{"token": "fake-reset-token", "password": "short"}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("password must be a valid password with at least 8 characters long").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when invalid reset token provided, " +
+				"then it should return a 401 with the invalid reset token error",
+			jsonPayload: `{"token": "invalid-reset-token", "password": "NewPassword123"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().ResetStaffPassword(gomock.Any(), gomock.Any()).
+					Return(staff.ResetStaffPasswordOutput{}, authcore.ErrInvalidResetToken)
+			},
+			wantJSON: `{
+				"code": "INVALID_RESET_TOKEN",
+				"message": "invalid or expired reset token",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when unexpected error when resetting the password, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"token": "fake-reset-token", "password": "NewPassword123"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().ResetStaffPassword(gomock.Any(), gomock.Any()).
+					Return(staff.ResetStaffPasswordOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the password is reset, then it should return a 204 without content",
+			jsonPayload: `{"token": "fake-reset-token", "password": "NewPassword123"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().ResetStaffPassword(gomock.Any(), staff.ResetStaffPasswordInput{
+					Token:    "fake-reset-token",
+					Password: "NewPassword123",
+				}).Return(staff.ResetStaffPasswordOutput{RevokedTokens: 2}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/staff/password/reset"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, "")
+			},
+		)
+	}
+}
+
+func TestHandler_ChangeStaffPassword(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a staff user, then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "valid-token",
+			jsonPayload: `{}`,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"current_password is required",
+					"new_password is required",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when invalid new password is provided, then it should return a 400 with the validation error",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "short"}`,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("new_password must be at least 8 characters long").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the new password does not meet the policy, " +
+				"then it should return a 400 with the policy violations",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "password"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ChangeStaffPassword(gomock.Any(), gomock.Any()).
+					Return(staff.ChangeStaffPasswordOutput{}, &password.PolicyError{Violations: []string{"must be at least 10 characters long", "is too common"}})
+			},
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails("new_password must be at least 10 characters long", "new_password is too common").
+				Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the current password is not valid, " +
+				"then it should return a 403 with the invalid current password error",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "WrongPassword123", "new_password": "NewPassword123"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ChangeStaffPassword(gomock.Any(), gomock.Any()).
+					Return(staff.ChangeStaffPasswordOutput{}, authcore.ErrInvalidCurrentPassword)
+			},
+			wantJSON: `{
+				"code": "INVALID_CURRENT_PASSWORD",
+				"message": "current password is incorrect",
+				"details": []
+			}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the staff user is impersonated, " +
+				"then it should return a 403 with the forbidden error",
+			token:       "impersonation-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "NewPassword123"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ChangeStaffPassword(gomock.Any(), gomock.Any()).
+					Return(staff.ChangeStaffPasswordOutput{}, auth.ErrImpersonationNotAllowed)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the authenticated staff user no longer exists, " +
+				"then it should return a 401 with the unauthorized error",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "NewPassword123"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ChangeStaffPassword(gomock.Any(), gomock.Any()).
+					Return(staff.ChangeStaffPasswordOutput{}, auth.ErrInvalidToken)
+			},
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when unexpected error when changing the password, " +
+				"then it should return a 500 with the internal error",
+			token:       "valid-token",
+			jsonPayload: `{"current_password": "CurrentPassword123", "new_password": "NewPassword123"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ChangeStaffPassword(gomock.Any(), gomock.Any()).
+					Return(staff.ChangeStaffPasswordOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "when the password is changed, then it should return a 204 without content",
+			token: "valid-token",
+			jsonPayload: `{
+				"current_password": "CurrentPassword123",
+				"new_password": "NewPassword123",
+				"revoke_other_sessions": true
+			}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ChangeStaffPassword(gomock.Any(), staff.ChangeStaffPasswordInput{
+					CurrentPassword:     "CurrentPassword123",
+					NewPassword:         "NewPassword123",
+					RevokeOtherSessions: true,
+				}).Return(staff.ChangeStaffPasswordOutput{RevokedTokens: 2}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/staff/password"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPut, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_VerifyStaffMFA(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			jsonPayload: `{}`,
+			wantJSON: customhttp.NewValidationErrorRespBuilder().
+				WithDetails(
+					"challenge_token is required",
+					"code is invalid",
+				).Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the MFA challenge is not valid, " +
+				"then it should return a 401 with the invalid MFA challenge error",
+			jsonPayload: `{"challenge_token": "fake-challenge-token", "code": "123456"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().VerifyStaffMFA(gomock.Any(), gomock.Any()).
+					Return(staff.VerifyStaffMFAOutput{}, authcore.ErrInvalidMFAChallenge)
+			},
+			wantJSON: `{
+				"code": "INVALID_MFA_CHALLENGE",
+				"message": "invalid or expired mfa challenge",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "when the MFA code is not valid, then it should return a 401 with the invalid MFA code error",
+			jsonPayload: `{"challenge_token": "fake-challenge-token", "code": "123456"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().VerifyStaffMFA(gomock.Any(), gomock.Any()).
+					Return(staff.VerifyStaffMFAOutput{}, authcore.ErrInvalidMFACode)
+			},
+			wantJSON: `{
+				"code": "INVALID_MFA_CODE",
+				"message": "invalid mfa code",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when unexpected error when verifying the MFA challenge, " +
+				"then it should return a 500 with the internal error",
+			jsonPayload: `{"challenge_token": "fake-challenge-token", "code": "123456"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().VerifyStaffMFA(gomock.Any(), gomock.Any()).
+					Return(staff.VerifyStaffMFAOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "when the MFA challenge is completed with a recovery code, " +
+				"then it should return a 200 with the token",
+			jsonPayload: `{"challenge_token": "fake-challenge-token", "recovery_code": "abcde-fghij"}`,
+			mocksSetup: func(service *staffmocks.MockService, _ *authmocks.MockService) {
+				service.EXPECT().VerifyStaffMFA(gomock.Any(), staff.VerifyStaffMFAInput{
+					ChallengeToken: "fake-challenge-token",
+					RecoveryCode:   "abcde-fghij",
+				}).Return(staff.VerifyStaffMFAOutput{
+					TokenPair: authcore.TokenPair{
+						AccessToken:  "fake-token",
+						RefreshToken: "fake-refresh-token",
+						ExpiresIn:    staff.DefaultTokenExpiration,
+						TokenType:    auth.DefaultTokenType,
+					},
+				}, nil)
+			},
+			wantJSON: `{
+			  "access_token": "fake-token",
+			  "refresh_token": "fake-refresh-token",
+			  "expires_in": 3600,
+			  "token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runStaffHandlerTestCase(t, logger, http.MethodPost, "/v1.0/staff/login/mfa", tt, "")
+		})
+	}
+}
+
+func TestHandler_EnrollStaffMFA(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a staff user, then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the staff user is impersonated, " +
+				"then it should return a 403 with the forbidden error",
+			token: "impersonation-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().EnrollStaffMFA(gomock.Any(), gomock.Any()).
+					Return(staff.EnrollStaffMFAOutput{}, auth.ErrImpersonationNotAllowed)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the staff already has MFA enabled, " +
+				"then it should return a 409 with the MFA already enabled error",
+			token: "valid-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().EnrollStaffMFA(gomock.Any(), gomock.Any()).
+					Return(staff.EnrollStaffMFAOutput{}, authcore.ErrMFAAlreadyEnabled)
+			},
+			wantJSON: `{
+				"code": "MFA_ALREADY_ENABLED",
+				"message": "mfa is already enabled",
+				"details": []
+			}`,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "when unexpected error when enrolling the MFA, " +
+				"then it should return a 500 with the internal error",
+			token: "valid-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().EnrollStaffMFA(gomock.Any(), gomock.Any()).
+					Return(staff.EnrollStaffMFAOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "when the MFA enrollment is started, " +
+				"then it should return a 200 with the secret and provisioning URI",
+			token: "valid-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().EnrollStaffMFA(gomock.Any(), staff.EnrollStaffMFAInput{}).
+					Return(staff.EnrollStaffMFAOutput{
+						Secret:          "fake-secret",
+						ProvisioningURI: "otpauth://totp/fake",
+					}, nil)
+			},
+			wantJSON: `{
+				"secret": "fake-secret",
+				"provisioning_uri": "otpauth://totp/fake"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	route := "/v1.0/staff/mfa/enroll"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_ConfirmStaffMFA(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "valid-token",
+			jsonPayload: `{}`,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("code is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the staff user is impersonated, " +
+				"then it should return a 403 with the forbidden error",
+			token:       "impersonation-token",
+			jsonPayload: `{"code": "123456"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ConfirmStaffMFA(gomock.Any(), gomock.Any()).
+					Return(staff.ConfirmStaffMFAOutput{}, auth.ErrImpersonationNotAllowed)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when there is no pending MFA enrollment, " +
+				"then it should return a 404 with the MFA enrollment not found error",
+			token:       "valid-token",
+			jsonPayload: `{"code": "123456"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ConfirmStaffMFA(gomock.Any(), gomock.Any()).
+					Return(staff.ConfirmStaffMFAOutput{}, authcore.ErrMFAEnrollmentNotFound)
+			},
+			wantJSON: `{
+				"code": "MFA_ENROLLMENT_NOT_FOUND",
+				"message": "mfa enrollment not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:        "when the code is not valid, then it should return a 401 with the invalid MFA code error",
+			token:       "valid-token",
+			jsonPayload: `{"code": "123456"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ConfirmStaffMFA(gomock.Any(), gomock.Any()).
+					Return(staff.ConfirmStaffMFAOutput{}, authcore.ErrInvalidMFACode)
+			},
+			wantJSON: `{
+				"code": "INVALID_MFA_CODE",
+				"message": "invalid mfa code",
+				"details": []
+			}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "when the MFA enrollment is confirmed, then it should return a 200 with the recovery codes",
+			token:       "valid-token",
+			jsonPayload: `{"code": "123456"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().ConfirmStaffMFA(gomock.Any(), staff.ConfirmStaffMFAInput{Code: "123456"}).
+					Return(staff.ConfirmStaffMFAOutput{RecoveryCodes: []string{"abcde-fghij"}}, nil)
+			},
+			wantJSON:   `{"recovery_codes": ["abcde-fghij"]}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	route := "/v1.0/staff/mfa/enroll/confirm"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_DeactivateStaff(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when authenticated user is not a platform admin, " +
+				"then it should return a 403 with the forbidden error",
+			token: "owner-token",
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:  "when the staff does not exist, then it should return a 404 with the staff not found error",
+			token: "admin-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetStaffActive(gomock.Any(), gomock.Any()).
+					Return(staff.SetStaffActiveOutput{}, staff.ErrStaffNotFound)
+			},
+			wantJSON: `{
+				"code": "STAFF_NOT_FOUND",
+				"message": "staff not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "when unexpected error when deactivating the staff, " +
+				"then it should return a 500 with the internal error",
+			token: "admin-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetStaffActive(gomock.Any(), gomock.Any()).
+					Return(staff.SetStaffActiveOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:  "when the staff is deactivated, then it should return a 204 without content",
+			token: "admin-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetStaffActive(gomock.Any(), staff.SetStaffActiveInput{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-restaurant-id",
+					Active:       false,
+				}).Return(staff.SetStaffActiveOutput{RevokedTokens: 2}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/admin/restaurants/fake-restaurant-id/staff/fake-staff-id/deactivate"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_ReactivateStaff(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name: "when authenticated user is not a platform admin, " +
+				"then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:  "when the staff does not exist, then it should return a 404 with the staff not found error",
+			token: "admin-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetStaffActive(gomock.Any(), gomock.Any()).
+					Return(staff.SetStaffActiveOutput{}, staff.ErrStaffNotFound)
+			},
+			wantJSON: `{
+				"code": "STAFF_NOT_FOUND",
+				"message": "staff not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "when the staff is reactivated, then it should return a 204 without content",
+			token: "admin-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().SetStaffActive(gomock.Any(), staff.SetStaffActiveInput{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-restaurant-id",
+					Active:       true,
+				}).Return(staff.SetStaffActiveOutput{}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/admin/restaurants/fake-restaurant-id/staff/fake-staff-id/reactivate"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_SwitchStaffTenant(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:  "when authenticated user is not a staff user, then it should return a 403 with the forbidden error",
+			token: "customer-token",
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleCustomer, "")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "valid-token",
+			jsonPayload: `{}`,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("restaurant_id is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "when the staff is not linked to the restaurant, " +
+				"then it should return a 403 with the restaurant not linked error",
+			token:       "valid-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().SwitchStaffTenant(gomock.Any(), gomock.Any()).
+					Return(staff.SwitchStaffTenantOutput{}, staff.ErrRestaurantNotLinked)
+			},
+			wantJSON: `{
+				"code": "RESTAURANT_NOT_LINKED",
+				"message": "restaurant not linked to the staff",
+				"details": []
+			}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the staff user is impersonated, " +
+				"then it should return a 403 with the forbidden error",
+			token:       "impersonation-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().SwitchStaffTenant(gomock.Any(), gomock.Any()).
+					Return(staff.SwitchStaffTenantOutput{}, auth.ErrImpersonationNotAllowed)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "when the authentication context is not found, " +
+				"then it should return a 401 with the unauthorized error",
+			token:       "valid-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().SwitchStaffTenant(gomock.Any(), gomock.Any()).
+					Return(staff.SwitchStaffTenantOutput{}, auth.ErrInvalidToken)
+			},
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when unexpected error when switching the tenant, " +
+				"then it should return a 500 with the internal error",
+			token:       "valid-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().SwitchStaffTenant(gomock.Any(), gomock.Any()).
+					Return(staff.SwitchStaffTenantOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "when the staff has MFA enabled in the restaurant, " +
+				"then it should return a 200 with the MFA challenge",
+			token:       "valid-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().SwitchStaffTenant(gomock.Any(), gomock.Any()).
+					Return(staff.SwitchStaffTenantOutput{
+						TenantSession: staff.TenantSession{
+							MFARequired:           true,
+							MFAChallengeToken:     "fake-challenge-token",
+							MFAChallengeExpiresAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+						},
+					}, nil)
+			},
+			wantJSON: `{
+			  "mfa_required": true,
+			  "challenge_token": "fake-challenge-token",
+			  "expires_at": "2025-01-01T00:05:00Z"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "when the staff is linked to the restaurant, " +
+				"then it should return a 200 with the token scoped to it",
+			token:       "valid-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+				service.EXPECT().SwitchStaffTenant(gomock.Any(), staff.SwitchStaffTenantInput{
+					RestaurantID: "fake-other-restaurant-id",
+				}).Return(staff.SwitchStaffTenantOutput{
+					TenantSession: staff.TenantSession{
+						TokenPair: authcore.TokenPair{
+							AccessToken:  "fake-token",
+							RefreshToken: "fake-refresh-token",
+							ExpiresIn:    staff.DefaultTokenExpiration,
+							TokenType:    auth.DefaultTokenType,
+						},
+					},
+				}, nil)
+			},
+			wantJSON: `{
+			  "access_token": "fake-token",
+			  "refresh_token": "fake-refresh-token",
+			  "expires_in": 3600,
+			  "token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	route := "/v1.0/staff/tenant/switch"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_LinkStaffRestaurant(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when the service token does not have the manage scope, " +
+				"then it should return a 403 with the forbidden error",
+			token: "service-token",
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthRegister)
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "when empty payload is provided, then it should return a 400 with the validation error",
+			token:       "service-token",
+			jsonPayload: `{}`,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("restaurant_id is required").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when the role is unknown, then it should return a 400 with the validation error",
+			token:       "service-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id", "role": "waiter"}`,
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+			},
+			wantJSON:   customhttp.NewValidationErrorRespBuilder().WithDetails("role is invalid").Build(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "when the staff does not exist, then it should return a 404 with the staff not found error",
+			token:       "service-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().LinkStaffRestaurant(gomock.Any(), gomock.Any()).
+					Return(staff.LinkStaffRestaurantOutput{}, staff.ErrStaffNotFound)
+			},
+			wantJSON: `{
+				"code": "STAFF_NOT_FOUND",
+				"message": "staff not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "when the staff is already linked to the restaurant, " +
+				"then it should return a 409 with the restaurant already linked error",
+			token:       "service-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().LinkStaffRestaurant(gomock.Any(), gomock.Any()).
+					Return(staff.LinkStaffRestaurantOutput{}, staff.ErrRestaurantAlreadyLinked)
+			},
+			wantJSON: `{
+				"code": "RESTAURANT_ALREADY_LINKED",
+				"message": "restaurant already linked to the staff",
+				"details": []
+			}`,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "when unexpected error when linking the restaurant, " +
+				"then it should return a 500 with the internal error",
+			token:       "service-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().LinkStaffRestaurant(gomock.Any(), gomock.Any()).
+					Return(staff.LinkStaffRestaurantOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "when the staff is linked to the restaurant, then it should return a 204 without content",
+			token:       "service-token",
+			jsonPayload: `{"restaurant_id": "fake-other-restaurant-id", "role": "chef"}`,
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockServiceClaims(authService, auth.ScopeAuthManage)
+				service.EXPECT().LinkStaffRestaurant(gomock.Any(), staff.LinkStaffRestaurantInput{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-other-restaurant-id",
+					Role:         staff.RoleChef,
+				}).Return(staff.LinkStaffRestaurantOutput{}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	route := "/v1.0/auth/staff/fake-staff-id/restaurants"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func TestHandler_ImpersonateStaff(t *testing.T) {
+	logger := customhttp.SetupTestEnv()
+
+	tests := []staffHandlerTestCase{
+		{
+			name:       "when any token is provided, then it should return a 401 with the unauthorized error",
+			token:      "",
+			wantJSON:   auth.NewUnauthorizedRespBuilder().Build(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "when authenticated user is not a platform admin, " +
+				"then it should return a 403 with the forbidden error",
+			token: "owner-token",
+			mocksSetup: func(_ *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RoleStaff, "fake-restaurant-id")
+			},
+			wantJSON:   auth.NewForbiddenRespBuilder().Build(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:  "when the staff does not exist, then it should return a 404 with the staff not found error",
+			token: "admin-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().ImpersonateStaff(gomock.Any(), gomock.Any()).
+					Return(staff.ImpersonateStaffOutput{}, staff.ErrStaffNotFound)
+			},
+			wantJSON: `{
+				"code": "STAFF_NOT_FOUND",
+				"message": "staff not found",
+				"details": []
+			}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "when unexpected error when impersonating the staff, " +
+				"then it should return a 500 with the internal error",
+			token: "admin-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().ImpersonateStaff(gomock.Any(), gomock.Any()).
+					Return(staff.ImpersonateStaffOutput{}, errUnexpected)
+			},
+			wantJSON:   customhttp.NewInternalErrorRespBuilder().Build(),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "when the staff is impersonated, " +
+				"then it should return a 200 with the access token and without refresh token",
+			token: "admin-token",
+			mocksSetup: func(service *staffmocks.MockService, authService *authmocks.MockService) {
+				mockClaims(authService, auth.RolePlatformAdmin, "")
+				service.EXPECT().ImpersonateStaff(gomock.Any(), staff.ImpersonateStaffInput{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-restaurant-id",
+				}).Return(staff.ImpersonateStaffOutput{
+					ImpersonationToken: authcore.ImpersonationToken{
+						AccessToken: "fake-token",
+						ExpiresIn:   authcore.ImpersonationTokenExpiration,
+						TokenType:   "Bearer",
+					},
+				}, nil)
+			},
+			wantJSON: `{
+				"access_token": "fake-token",
+				"expires_in": 600,
+				"token_type": "Bearer"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	route := "/v1.0/admin/restaurants/fake-restaurant-id/staff/fake-staff-id/impersonate"
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				runStaffHandlerTestCase(t, logger, http.MethodPost, route, tt, tt.token)
+			},
+		)
+	}
+}
+
+func mockClaims(authService *authmocks.MockService, role auth.Role, tenant string) {
+	authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+		Return(auth.GetClaimsOutput{
+			Claims: &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-user-id"},
+				Role:             string(role),
+				Tenant:           tenant,
+			},
+		}, nil)
+}
+
+func mockServiceClaims(authService *authmocks.MockService, scope string) {
+	authService.EXPECT().GetClaims(gomock.Any(), gomock.Any()).
+		Return(auth.GetClaimsOutput{
+			Claims: &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "fake-service-id"},
+				Role:             string(auth.RoleService),
+				Scope:            scope,
+			},
+		}, nil)
+}
+
+// runStaffHandlerTestCase executes a test case for the staff handler, which is common for all tests.
+func runStaffHandlerTestCase(
+	t *testing.T,
+	logger log.Logger,
+	httpMethod string,
+	route string,
+	tt staffHandlerTestCase,
+	token string,
+) {
+	// Create a new mock service
+	service := staffmocks.NewMockService(gomock.NewController(t))
+	authService := authmocks.NewMockService(gomock.NewController(t))
+	if tt.mocksSetup != nil {
+		tt.mocksSetup(service, authService)
+	}
+
+	// Initialize the authentication middleware
+	authMiddleware := auth.NewMiddleware(logger, authService)
+
+	// Initialize the handler
+	h := staff.NewHandler(logger, service, authMiddleware)
+
+	// Make HTTP request
+	w := customhttp.ServeTestHTTPRequest(t, h, httpMethod, route, token, tt.queryParams, tt.jsonPayload)
+
+	assert.Equal(t, tt.wantStatus, w.Code)
+	if tt.wantJSON == "" {
+		assert.Empty(t, w.Body.String())
+		return
+	}
 	assert.JSONEq(t, tt.wantJSON, w.Body.String())
 }
@@ -0,0 +1,64 @@
+package staff
+
+import (
+	"slices"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+)
+
+const (
+	// RoleOwner is the role of the staff users that own the restaurant. It is granted by the owner flag of the
+	// membership, not assigned as a secondary role.
+	RoleOwner = "owner"
+	// RoleManager is the secondary role of the staff users that run the daily operations of the restaurant
+	RoleManager = "manager"
+	// RoleCashier is the secondary role of the staff users that take the orders of the restaurant
+	RoleCashier = "cashier"
+	// RoleChef is the secondary role of the staff users that prepare the orders of the restaurant
+	RoleChef = "chef"
+)
+
+// rolePermissions is the role-to-permission table used to populate the permissions claim of the staff tokens. The
+// staff users without a secondary role can only read the menu and the orders of their restaurant.
+var rolePermissions = map[string][]string{
+	RoleOwner: {
+		auth.PermissionRestaurantWrite,
+		auth.PermissionMenuRead,
+		auth.PermissionMenuWrite,
+		auth.PermissionOrdersRead,
+		auth.PermissionOrdersWrite,
+		auth.PermissionStaffManage,
+	},
+	RoleManager: {
+		auth.PermissionRestaurantWrite,
+		auth.PermissionMenuRead,
+		auth.PermissionMenuWrite,
+		auth.PermissionOrdersRead,
+		auth.PermissionOrdersWrite,
+	},
+	RoleCashier: {
+		auth.PermissionMenuRead,
+		auth.PermissionOrdersRead,
+		auth.PermissionOrdersWrite,
+	},
+	RoleChef: {
+		auth.PermissionMenuRead,
+		auth.PermissionMenuWrite,
+		auth.PermissionOrdersRead,
+		auth.PermissionOrdersWrite,
+	},
+	"": {
+		auth.PermissionMenuRead,
+		auth.PermissionOrdersRead,
+	},
+}
+
+// Permissions returns the permissions granted to the staff user in the restaurant of the membership. The owners are
+// granted every permission, whatever their secondary role.
+func (m Membership) Permissions() []string {
+	role := m.Role
+	if m.Owner {
+		role = RoleOwner
+	}
+	return slices.Clone(rolePermissions[role])
+}
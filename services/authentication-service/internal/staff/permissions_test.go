@@ -0,0 +1,74 @@
+//go:build unit
+
+package staff_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/staff"
+)
+
+func TestMembership_Permissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		membership staff.Membership
+		want       []string
+	}{
+		{
+			name:       "when the staff owns the restaurant, then it should be granted every permission",
+			membership: staff.Membership{Owner: true, Role: staff.RoleCashier},
+			want: []string{
+				auth.PermissionRestaurantWrite,
+				auth.PermissionMenuRead,
+				auth.PermissionMenuWrite,
+				auth.PermissionOrdersRead,
+				auth.PermissionOrdersWrite,
+				auth.PermissionStaffManage,
+			},
+		},
+		{
+			name:       "when the staff is a manager, then it should be granted all but the staff management",
+			membership: staff.Membership{Role: staff.RoleManager},
+			want: []string{
+				auth.PermissionRestaurantWrite,
+				auth.PermissionMenuRead,
+				auth.PermissionMenuWrite,
+				auth.PermissionOrdersRead,
+				auth.PermissionOrdersWrite,
+			},
+		},
+		{
+			name:       "when the staff is a cashier, then it should be granted to read the menu and handle the orders",
+			membership: staff.Membership{Role: staff.RoleCashier},
+			want: []string{
+				auth.PermissionMenuRead,
+				auth.PermissionOrdersRead,
+				auth.PermissionOrdersWrite,
+			},
+		},
+		{
+			name:       "when the staff is a chef, then it should be granted to manage the menu and handle the orders",
+			membership: staff.Membership{Role: staff.RoleChef},
+			want: []string{
+				auth.PermissionMenuRead,
+				auth.PermissionMenuWrite,
+				auth.PermissionOrdersRead,
+				auth.PermissionOrdersWrite,
+			},
+		},
+		{
+			name:       "when the staff has no secondary role, then it should only be granted to read",
+			membership: staff.Membership{},
+			want:       []string{auth.PermissionMenuRead, auth.PermissionOrdersRead},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.membership.Permissions())
+		})
+	}
+}
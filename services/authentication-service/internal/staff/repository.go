@@ -8,6 +8,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
@@ -18,24 +19,89 @@ const (
 	// CollectionName is the name of the staff collection in the database
 	CollectionName = "staff"
 
+	// FieldStaffID represents the field name used to store or query the public ID of a staff in the database.
+	FieldStaffID = "staff_id"
 	// FieldEmail represents the field name used to store or query email addresses in the database.
 	FieldEmail = "email"
 	// FieldActive represents the field name used to indicate the active status of a staff in the database.
 	FieldActive = "active"
-	// FieldRestaurantID represents the field name used to store the restaurant ID associated with a staff in the database.
+	// FieldRestaurants represents the field name used to store the restaurants a staff is linked to in the database.
+	FieldRestaurants = "restaurants"
+	// FieldRestaurantID represents the field name used to store the restaurant ID of a staff membership in the database.
 	FieldRestaurantID = "restaurant_id"
+	// FieldMembershipRestaurantID represents the path used to query the restaurant IDs of the staff memberships.
+	FieldMembershipRestaurantID = FieldRestaurants + "." + FieldRestaurantID
+	// FieldPassword represents the field name used to store the hashed password of a staff in the database.
+	FieldPassword = "password"
+	// FieldUpdatedAt represents the field name used to store the timestamp of the last update in the database.
+	FieldUpdatedAt = "updated_at"
+	// FieldOwner represents the field name used to store whether a legacy staff document owns its restaurant.
+	FieldOwner = "owner"
 )
 
 // Staff represents a user in the system with associated details such as email, name, and account activation status.
+// The same credentials can be linked to several restaurants, such as the locations of a chain.
 type Staff struct {
-	ID           string    `bson:"_id,omitempty"`
-	StaffID      string    `bson:"staff_id"`
-	Email        string    `bson:"email"`
-	RestaurantID string    `bson:"restaurant_id"`
-	Active       bool      `bson:"active"`
-	Password     string    `bson:"password,omitempty"`
-	CreatedAt    time.Time `bson:"created_at,omitempty"`
-	UpdatedAt    time.Time `bson:"updated_at,omitempty"`
+	ID          string       `bson:"_id,omitempty"`
+	StaffID     string       `bson:"staff_id"`
+	Email       string       `bson:"email"`
+	Restaurants []Membership `bson:"restaurants"`
+	Active      bool         `bson:"active"`
+	Password    string       `bson:"password,omitempty"`
+	CreatedAt   time.Time    `bson:"created_at,omitempty"`
+	UpdatedAt   time.Time    `bson:"updated_at,omitempty"`
+}
+
+// Membership represents the link of a staff user to one of its restaurants. A deactivated membership does not allow
+// the staff user to log in to the restaurant, while keeping the access to the other ones.
+type Membership struct {
+	RestaurantID string `bson:"restaurant_id"`
+	Owner        bool   `bson:"owner"`
+	Active       bool   `bson:"active"`
+	// Role is the secondary role of the staff user in the restaurant, which defines its permissions
+	Role string `bson:"role,omitempty"`
+	// LegacyStaffID is the staff ID of the legacy document merged into the credentials, kept to reconcile it with
+	// the staff stored by the restaurant-service
+	LegacyStaffID string `bson:"legacy_staff_id,omitempty"`
+}
+
+// Membership returns the active membership of the staff user to the restaurant, if any.
+func (s Staff) Membership(restaurantID string) (Membership, bool) {
+	for _, m := range s.Restaurants {
+		if m.RestaurantID == restaurantID && m.Active {
+			return m, true
+		}
+	}
+	return Membership{}, false
+}
+
+// ActiveMemberships returns the memberships of the restaurants the staff user can log in to.
+func (s Staff) ActiveMemberships() []Membership {
+	memberships := make([]Membership, 0, len(s.Restaurants))
+	for _, m := range s.Restaurants {
+		if m.Active {
+			memberships = append(memberships, m)
+		}
+	}
+	return memberships
+}
+
+// LegacyStaff represents a staff document stored before the credentials could be linked to several restaurants, when
+// each document was scoped to a single restaurant through its top-level restaurant_id and owner fields.
+type LegacyStaff struct {
+	ID           string `bson:"_id"`
+	StaffID      string `bson:"staff_id"`
+	Email        string `bson:"email"`
+	RestaurantID string `bson:"restaurant_id"`
+	Owner        bool   `bson:"owner"`
+	Active       bool   `bson:"active"`
+	Password     string `bson:"password"`
+}
+
+// Membership returns the membership the legacy document is folded into. Its active status was scoped to the
+// restaurant, so it becomes the one of the membership.
+func (s LegacyStaff) Membership() Membership {
+	return Membership{RestaurantID: s.RestaurantID, Owner: s.Owner, Active: s.Active}
 }
 
 // Repository defines the interface for the staff repository.
@@ -44,6 +110,13 @@ type Staff struct {
 type Repository interface {
 	CreateStaff(ctx context.Context, params CreateStaffParams) (Staff, error)
 	FindStaff(ctx context.Context, params FindStaffParams) (Staff, error)
+	FindByStaffID(ctx context.Context, params FindByStaffIDParams) (Staff, error)
+	UpdatePassword(ctx context.Context, params UpdatePasswordParams) error
+	UpdateActive(ctx context.Context, params UpdateActiveParams) error
+	AddRestaurant(ctx context.Context, params AddRestaurantParams) error
+	FindLegacyStaff(ctx context.Context, params FindLegacyStaffParams) ([]LegacyStaff, error)
+	MergeLegacyStaff(ctx context.Context, params MergeLegacyStaffParams) error
+	FoldLegacyStaff(ctx context.Context, params FoldLegacyStaffParams) error
 }
 
 type repository struct {
@@ -61,12 +134,14 @@ func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repos
 	}
 }
 
-// CreateStaffParams represents the parameters required to create a new staff user.
+// CreateStaffParams represents the parameters required to create a new staff user, linked to its first restaurant.
 type CreateStaffParams struct {
 	StaffID      string `json:"staff_id"`
 	Email        string `json:"email"`
 	RestaurantID string `json:"restaurant_id"`
 	Password     string `json:"password"`
+	Owner        bool   `json:"owner"`
+	Role         string `json:"role"`
 }
 
 func (r *repository) CreateStaff(ctx context.Context, params CreateStaffParams) (Staff, error) {
@@ -74,13 +149,15 @@ func (r *repository) CreateStaff(ctx context.Context, params CreateStaffParams)
 
 	now := r.clock.Now()
 	c := Staff{
-		StaffID:      params.StaffID,
-		Email:        params.Email,
-		RestaurantID: params.RestaurantID,
-		Password:     params.Password,
-		CreatedAt:    now,
-		UpdatedAt:    now,
-		Active:       true,
+		StaffID: params.StaffID,
+		Email:   params.Email,
+		Restaurants: []Membership{
+			{RestaurantID: params.RestaurantID, Owner: params.Owner, Role: params.Role, Active: true},
+		},
+		Password:  params.Password,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Active:    true,
 	}
 	res, err := r.collection.InsertOne(ctx, c)
 	if err != nil {
@@ -96,6 +173,8 @@ func (r *repository) CreateStaff(ctx context.Context, params CreateStaffParams)
 	return c, nil
 }
 
+// FindStaffParams represents the parameters needed to find a staff user by its email.
+// RestaurantID is optional. When it is empty, the staff user must be active in any of its restaurants.
 type FindStaffParams struct {
 	Email        string
 	RestaurantID string
@@ -106,9 +185,9 @@ func (r *repository) FindStaff(ctx context.Context, params FindStaffParams) (Sta
 
 	var staff Staff
 	filter := bson.M{
-		FieldEmail:        params.Email,
-		FieldRestaurantID: params.RestaurantID,
-		FieldActive:       true,
+		FieldEmail:       params.Email,
+		FieldActive:      true,
+		FieldRestaurants: activeMembershipFilter(params.RestaurantID),
 	}
 
 	if err := r.collection.FindOne(ctx, filter).Decode(&staff); err != nil {
@@ -125,3 +204,375 @@ func (r *repository) FindStaff(ctx context.Context, params FindStaffParams) (Sta
 	}
 	return staff, nil
 }
+
+// FindByStaffIDParams represents the parameters needed to find a staff user by its public ID within a restaurant.
+type FindByStaffIDParams struct {
+	StaffID      string
+	RestaurantID string
+}
+
+func (r *repository) FindByStaffID(ctx context.Context, params FindByStaffIDParams) (Staff, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var staff Staff
+	filter := bson.M{
+		FieldStaffID:     params.StaffID,
+		FieldActive:      true,
+		FieldRestaurants: activeMembershipFilter(params.RestaurantID),
+	}
+
+	if err := r.collection.FindOne(ctx, filter).Decode(&staff); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn(
+				"Staff not found",
+				log.Field{Key: "staff_id", Value: params.StaffID},
+				log.Field{Key: "restaurant_id", Value: params.RestaurantID},
+			)
+			return Staff{}, ErrStaffNotFound
+		}
+		logger.Error("Failed to find staff", err)
+		return Staff{}, err
+	}
+	return staff, nil
+}
+
+// UpdatePasswordParams represents the parameters needed to replace the password of a staff user.
+// Password must be already hashed. RestaurantID is optional, and requires the staff user to be active in it.
+type UpdatePasswordParams struct {
+	StaffID      string
+	RestaurantID string
+	Password     string
+}
+
+func (r *repository) UpdatePassword(ctx context.Context, params UpdatePasswordParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	filter := bson.M{
+		FieldStaffID:     params.StaffID,
+		FieldActive:      true,
+		FieldRestaurants: activeMembershipFilter(params.RestaurantID),
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldPassword:  params.Password,
+			FieldUpdatedAt: r.clock.Now(),
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to update staff password", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn(
+			"Staff not found",
+			log.Field{Key: "staff_id", Value: params.StaffID},
+			log.Field{Key: "restaurant_id", Value: params.RestaurantID},
+		)
+		return ErrStaffNotFound
+	}
+
+	logger.Info("Staff password updated successfully", log.Field{Key: "staff_id", Value: params.StaffID})
+	return nil
+}
+
+// UpdateActiveParams represents the parameters needed to activate or deactivate a staff user within a restaurant.
+// Only the membership of the restaurant is updated, so the staff user keeps the access to its other restaurants.
+type UpdateActiveParams struct {
+	StaffID      string
+	RestaurantID string
+	Active       bool
+}
+
+func (r *repository) UpdateActive(ctx context.Context, params UpdateActiveParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	filter := bson.M{
+		FieldStaffID:                params.StaffID,
+		FieldMembershipRestaurantID: params.RestaurantID,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			// The positional operator updates the membership of the restaurant matched by the filter
+			FieldRestaurants + ".$." + FieldActive: params.Active,
+			FieldUpdatedAt:                         r.clock.Now(),
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to update staff active status", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn(
+			"Staff not found",
+			log.Field{Key: "staff_id", Value: params.StaffID},
+			log.Field{Key: "restaurant_id", Value: params.RestaurantID},
+		)
+		return ErrStaffNotFound
+	}
+
+	logger.Info(
+		"Staff active status updated successfully",
+		log.Field{Key: "staff_id", Value: params.StaffID},
+		log.Field{Key: "active", Value: params.Active},
+	)
+	return nil
+}
+
+// AddRestaurantParams represents the parameters needed to link a staff user to another restaurant.
+type AddRestaurantParams struct {
+	StaffID      string
+	RestaurantID string
+	Owner        bool
+	Role         string
+}
+
+func (r *repository) AddRestaurant(ctx context.Context, params AddRestaurantParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	staffFilter := bson.M{
+		FieldStaffID: params.StaffID,
+		FieldActive:  true,
+	}
+	filter := bson.M{
+		FieldStaffID:                params.StaffID,
+		FieldActive:                 true,
+		FieldMembershipRestaurantID: bson.M{"$ne": params.RestaurantID},
+	}
+	update := bson.M{
+		"$push": bson.M{
+			FieldRestaurants: Membership{
+				RestaurantID: params.RestaurantID,
+				Owner:        params.Owner,
+				Role:         params.Role,
+				Active:       true,
+			},
+		},
+		"$set": bson.M{
+			FieldUpdatedAt: r.clock.Now(),
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to link staff to restaurant", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		// The staff user is either unknown or already linked to the restaurant
+		count, err := r.collection.CountDocuments(ctx, staffFilter)
+		if err != nil {
+			logger.Error("Failed to count staff", err)
+			return err
+		}
+		if count == 0 {
+			logger.Warn("Staff not found", log.Field{Key: "staff_id", Value: params.StaffID})
+			return ErrStaffNotFound
+		}
+		logger.Warn(
+			"Staff already linked to restaurant",
+			log.Field{Key: "staff_id", Value: params.StaffID},
+			log.Field{Key: "restaurant_id", Value: params.RestaurantID},
+		)
+		return ErrRestaurantAlreadyLinked
+	}
+
+	logger.Info(
+		"Staff linked to restaurant successfully",
+		log.Field{Key: "staff_id", Value: params.StaffID},
+		log.Field{Key: "restaurant_id", Value: params.RestaurantID},
+	)
+	return nil
+}
+
+// FindLegacyStaffParams defines the parameters needed to find the staff documents scoped to a single restaurant.
+// The documents are sorted by ID, and AfterID skips the ones up to the given ID, so the documents that could not be
+// migrated are not returned again. Limit sets the maximum number of documents returned at once.
+type FindLegacyStaffParams struct {
+	AfterID string
+	Limit   int64
+}
+
+func (r *repository) FindLegacyStaff(ctx context.Context, params FindLegacyStaffParams) ([]LegacyStaff, error) {
+	logger := r.logger.WithContext(ctx)
+
+	filter := legacyStaffFilter()
+	if params.AfterID != "" {
+		afterID, err := primitive.ObjectIDFromHex(params.AfterID)
+		if err != nil {
+			logger.Error("Invalid staff ID", err)
+			return nil, err
+		}
+		filter["_id"] = bson.M{"$gt": afterID}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(params.Limit)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		logger.Error("Failed to find legacy staff", err)
+		return nil, err
+	}
+
+	staff := make([]LegacyStaff, 0)
+	if err := cursor.All(ctx, &staff); err != nil {
+		logger.Error("Failed to decode legacy staff", err)
+		return nil, err
+	}
+	return staff, nil
+}
+
+// MergeLegacyStaffParams defines the parameters needed to merge a legacy staff document into the credentials
+// already linked to other restaurants with the same email. StaffID and Password are the ones of the legacy document.
+type MergeLegacyStaffParams struct {
+	ID         string
+	StaffID    string
+	Email      string
+	Password   string
+	Membership Membership
+}
+
+// MergeLegacyStaff links the restaurant of the legacy document to the credentials with the same email, and removes
+// the legacy document. The staff ID of the legacy document is kept in the membership. It returns ErrStaffNotFound
+// when there are no such credentials, and ErrLegacyStaffConflict when their password differs, in which case the
+// legacy document is kept.
+func (r *repository) MergeLegacyStaff(ctx context.Context, params MergeLegacyStaffParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	id, err := primitive.ObjectIDFromHex(params.ID)
+	if err != nil {
+		logger.Error("Invalid staff ID", err)
+		return err
+	}
+
+	staffFilter := bson.M{
+		FieldEmail:       params.Email,
+		FieldActive:      true,
+		FieldRestaurants: bson.M{"$exists": true},
+	}
+	filter := bson.M{
+		FieldEmail:                  params.Email,
+		FieldActive:                 true,
+		FieldRestaurants:            bson.M{"$exists": true},
+		FieldMembershipRestaurantID: bson.M{"$ne": params.Membership.RestaurantID},
+		FieldPassword:               params.Password,
+	}
+	membership := params.Membership
+	membership.LegacyStaffID = params.StaffID
+	update := bson.M{
+		"$push": bson.M{
+			FieldRestaurants: membership,
+		},
+		"$set": bson.M{
+			FieldUpdatedAt: r.clock.Now(),
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to merge legacy staff", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		// The credentials are either unknown, protected by another password, or already linked to the restaurant if a
+		// previous merge was interrupted
+		var staff Staff
+		if err := r.collection.FindOne(ctx, staffFilter).Decode(&staff); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return ErrStaffNotFound
+			}
+			logger.Error("Failed to find staff", err)
+			return err
+		}
+		if staff.Password != params.Password {
+			logger.Warn(
+				"Legacy staff password conflicts with the staff with the same email",
+				log.Field{Key: "legacy_staff_id", Value: params.StaffID},
+				log.Field{Key: "staff_id", Value: staff.StaffID},
+			)
+			return ErrLegacyStaffConflict
+		}
+	}
+
+	legacyFilter := legacyStaffFilter()
+	legacyFilter["_id"] = id
+	if _, err := r.collection.DeleteOne(ctx, legacyFilter); err != nil {
+		logger.Error("Failed to delete legacy staff", err)
+		return err
+	}
+
+	logger.Info(
+		"Legacy staff merged successfully",
+		log.Field{Key: "email", Value: params.Email},
+		log.Field{Key: "legacy_staff_id", Value: params.StaffID},
+	)
+	return nil
+}
+
+// FoldLegacyStaffParams defines the parameters needed to fold the restaurant of a legacy staff document into its list
+// of memberships.
+type FoldLegacyStaffParams struct {
+	ID         string
+	Membership Membership
+}
+
+// FoldLegacyStaff replaces the restaurant fields of the legacy document by its list of memberships. The credentials
+// remain active, as deactivating the staff user only applied to its restaurant. It returns ErrStaffNotFound when the
+// document was already migrated.
+func (r *repository) FoldLegacyStaff(ctx context.Context, params FoldLegacyStaffParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	id, err := primitive.ObjectIDFromHex(params.ID)
+	if err != nil {
+		logger.Error("Invalid staff ID", err)
+		return err
+	}
+
+	// Filtering by the legacy fields too, so a document that was already migrated is never overwritten
+	filter := legacyStaffFilter()
+	filter["_id"] = id
+	update := bson.M{
+		"$set": bson.M{
+			FieldRestaurants: []Membership{params.Membership},
+			FieldActive:      true,
+			FieldUpdatedAt:   r.clock.Now(),
+		},
+		"$unset": bson.M{
+			FieldRestaurantID: "",
+			FieldOwner:        "",
+		},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.Error("Failed to fold legacy staff", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("Legacy staff not found", log.Field{Key: "id", Value: params.ID})
+		return ErrStaffNotFound
+	}
+
+	logger.Info("Legacy staff folded successfully", log.Field{Key: "id", Value: params.ID})
+	return nil
+}
+
+// legacyStaffFilter returns the filter matching the staff documents scoped to a single restaurant.
+func legacyStaffFilter() bson.M {
+	return bson.M{
+		FieldRestaurantID: bson.M{"$exists": true},
+		FieldRestaurants:  bson.M{"$exists": false},
+	}
+}
+
+// activeMembershipFilter returns the filter matching the staff users active in the restaurant, or in any of their
+// restaurants when restaurantID is empty.
+func activeMembershipFilter(restaurantID string) bson.M {
+	membership := bson.M{FieldActive: true}
+	if restaurantID != "" {
+		membership[FieldRestaurantID] = restaurantID
+	}
+	return bson.M{"$elemMatch": membership}
+}
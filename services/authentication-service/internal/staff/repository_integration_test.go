@@ -4,11 +4,14 @@ package staff_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -38,13 +41,13 @@ func TestRepository_CreateStaff(t *testing.T) {
 			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
 				mongodb.InsertTestDocument(
 					t, coll, staff.Staff{
-						StaffID:      "fake-staff-id",
-						Email:        "test@example.com",
-						RestaurantID: "fake-restaurant-id",
-						Password:     "fakehashedpassword",
-						Active:       true,
-						CreatedAt:    now,
-						UpdatedAt:    now,
+						StaffID:     "fake-staff-id",
+						Email:       "test@example.com",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+						Password:    "fakehashedpassword",
+						Active:      true,
+						CreatedAt:   now,
+						UpdatedAt:   now,
 					},
 				)
 			},
@@ -64,15 +67,18 @@ func TestRepository_CreateStaff(t *testing.T) {
 				Email:        "test@example.com",
 				RestaurantID: "fake-restaurant-id",
 				Password:     "ValidPassword123",
+				Role:         staff.RoleManager,
 			},
 			want: staff.Staff{
-				StaffID:      "fake-staff-id",
-				Email:        "test@example.com",
-				RestaurantID: "fake-restaurant-id",
-				Active:       true,
-				Password:     "ValidPassword123",
-				CreatedAt:    now,
-				UpdatedAt:    now,
+				StaffID: "fake-staff-id",
+				Email:   "test@example.com",
+				Restaurants: []staff.Membership{
+					{RestaurantID: "fake-restaurant-id", Active: true, Role: staff.RoleManager},
+				},
+				Active:    true,
+				Password:  "ValidPassword123",
+				CreatedAt: now,
+				UpdatedAt: now,
 			},
 			wantErr: nil,
 		},
@@ -135,23 +141,23 @@ func TestRepository_FindStaff(t *testing.T) {
 			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
 				mongodb.InsertTestDocument(
 					t, coll, staff.Staff{
-						Email:        "test@example.com",
-						Password:     "fakehashedpassword",
-						RestaurantID: "fake-restaurant-id",
-						Active:       false,
-						CreatedAt:    now,
-						UpdatedAt:    now,
+						Email:       "test@example.com",
+						Password:    "fakehashedpassword",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+						Active:      false,
+						CreatedAt:   now,
+						UpdatedAt:   now,
 					},
 				)
 
 				mongodb.InsertTestDocument(
 					t, coll, staff.Staff{
-						Email:        "test@example.com",
-						Password:     "fakehashedpassword",
-						RestaurantID: "another-fake-restaurant-id",
-						Active:       true,
-						CreatedAt:    now,
-						UpdatedAt:    now,
+						Email:       "test@example.com",
+						Password:    "fakehashedpassword",
+						Restaurants: []staff.Membership{{RestaurantID: "another-fake-restaurant-id", Active: true}},
+						Active:      true,
+						CreatedAt:   now,
+						UpdatedAt:   now,
 					},
 				)
 			},
@@ -163,27 +169,43 @@ func TestRepository_FindStaff(t *testing.T) {
 			wantErr: staff.ErrStaffNotFound,
 		},
 		{
-			name: "when there is an active staff with the email and restaurant, then it should return the staff",
+			name: "when the staff is not active in the restaurant, then it should return a staff not found error",
 			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
 				mongodb.InsertTestDocument(
 					t, coll, staff.Staff{
-						Email:        "test@example.com",
-						Password:     "fakehashedpassword",
-						RestaurantID: "another-fake-restaurant-id",
-						Active:       true,
-						CreatedAt:    now,
-						UpdatedAt:    now,
+						Email:    "test@example.com",
+						Password: "fakehashedpassword",
+						Restaurants: []staff.Membership{
+							{RestaurantID: "fake-restaurant-id", Active: false},
+							{RestaurantID: "another-fake-restaurant-id", Active: true},
+						},
+						Active:    true,
+						CreatedAt: now,
+						UpdatedAt: now,
 					},
 				)
-
+			},
+			params: staff.FindStaffParams{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+			},
+			want:    staff.Staff{},
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when there is an active staff with the email and restaurant, then it should return the staff",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
 				mongodb.InsertTestDocument(
 					t, coll, staff.Staff{
-						Email:        "test@example.com",
-						Password:     "fakehashedpassword",
-						RestaurantID: "fake-restaurant-id",
-						Active:       true,
-						CreatedAt:    now,
-						UpdatedAt:    now,
+						Email:    "test@example.com",
+						Password: "fakehashedpassword",
+						Restaurants: []staff.Membership{
+							{RestaurantID: "another-fake-restaurant-id", Active: true},
+							{RestaurantID: "fake-restaurant-id", Owner: true, Active: true},
+						},
+						Active:    true,
+						CreatedAt: now,
+						UpdatedAt: now,
 					},
 				)
 			},
@@ -192,12 +214,66 @@ func TestRepository_FindStaff(t *testing.T) {
 				RestaurantID: "fake-restaurant-id",
 			},
 			want: staff.Staff{
-				Email:        "test@example.com",
-				RestaurantID: "fake-restaurant-id",
-				Active:       true,
-				Password:     "fakehashedpassword",
-				CreatedAt:    now,
-				UpdatedAt:    now,
+				Email: "test@example.com",
+				Restaurants: []staff.Membership{
+					{RestaurantID: "another-fake-restaurant-id", Active: true},
+					{RestaurantID: "fake-restaurant-id", Owner: true, Active: true},
+				},
+				Active:    true,
+				Password:  "fakehashedpassword",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the restaurant is not provided and the staff is not active in any restaurant, " +
+				"then it should return a staff not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(
+					t, coll, staff.Staff{
+						Email:       "test@example.com",
+						Password:    "fakehashedpassword",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: false}},
+						Active:      true,
+						CreatedAt:   now,
+						UpdatedAt:   now,
+					},
+				)
+			},
+			params:  staff.FindStaffParams{Email: "test@example.com"},
+			want:    staff.Staff{},
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when the restaurant is not provided and the staff is active in any restaurant, " +
+				"then it should return the staff",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(
+					t, coll, staff.Staff{
+						Email:    "test@example.com",
+						Password: "fakehashedpassword",
+						Restaurants: []staff.Membership{
+							{RestaurantID: "fake-restaurant-id", Active: false},
+							{RestaurantID: "another-fake-restaurant-id", Active: true},
+						},
+						Active:    true,
+						CreatedAt: now,
+						UpdatedAt: now,
+					},
+				)
+			},
+			params: staff.FindStaffParams{Email: "test@example.com"},
+			want: staff.Staff{
+				Email: "test@example.com",
+				Restaurants: []staff.Membership{
+					{RestaurantID: "fake-restaurant-id", Active: false},
+					{RestaurantID: "another-fake-restaurant-id", Active: true},
+				},
+				Active:    true,
+				Password:  "fakehashedpassword",
+				CreatedAt: now,
+				UpdatedAt: now,
 			},
 			wantErr: nil,
 		},
@@ -248,6 +324,778 @@ func TestRepository_FindStaff_UnexpectedFailure(t *testing.T) {
 	assert.NotErrorIs(t, err, staff.ErrStaffNotFound)
 }
 
+func TestRepository_FindByStaffID(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []staffRepositoryTestCase[staff.FindByStaffIDParams, staff.Staff]{
+		{
+			name: "when there is not an active staff with the id and restaurant id, " +
+				"then it should return a staff not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(
+					t, coll, staff.Staff{
+						StaffID:     "fake-staff-id",
+						Email:       "test@example.com",
+						Password:    "fakehashedpassword",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+						Active:      false,
+						CreatedAt:   now,
+						UpdatedAt:   now,
+					},
+				)
+
+				mongodb.InsertTestDocument(
+					t, coll, staff.Staff{
+						StaffID:     "fake-staff-id",
+						Email:       "test@example.com",
+						Password:    "fakehashedpassword",
+						Restaurants: []staff.Membership{{RestaurantID: "another-fake-restaurant-id", Active: true}},
+						Active:      true,
+						CreatedAt:   now,
+						UpdatedAt:   now,
+					},
+				)
+			},
+			params: staff.FindByStaffIDParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+			},
+			want:    staff.Staff{},
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when there is an active staff with the id and restaurant id, then it should return the staff",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(
+					t, coll, staff.Staff{
+						StaffID:     "fake-staff-id",
+						Email:       "test@example.com",
+						Password:    "fakehashedpassword",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+						Active:      true,
+						CreatedAt:   now,
+						UpdatedAt:   now,
+					},
+				)
+			},
+			params: staff.FindByStaffIDParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+			},
+			want: staff.Staff{
+				StaffID:     "fake-staff-id",
+				Email:       "test@example.com",
+				Password:    "fakehashedpassword",
+				Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+				Active:      true,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestStaffCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.FindByStaffID(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the got only if there is no error expected
+			if tt.wantErr == nil {
+				// As the ID is generated by MongoDB, we just check that it is not empty
+				assert.NotEmpty(t, got.ID, "ID should not be empty")
+
+				tt.want.ID = got.ID
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRepository_FindByStaffID_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindByStaffID(context.Background(), staff.FindByStaffIDParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, staff.ErrStaffNotFound)
+}
+
+func TestRepository_UpdatePassword(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []staffRepositoryTestCase[staff.UpdatePasswordParams, string]{
+		{
+			name: "when there is not an active staff with the id and restaurant id, " +
+				"then it should return a staff not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(
+					t, coll, staff.Staff{
+						StaffID:     "fake-staff-id",
+						Email:       "test@example.com",
+						Password:    "fakehashedpassword",
+						Restaurants: []staff.Membership{{RestaurantID: "another-fake-restaurant-id", Active: true}},
+						Active:      true,
+						CreatedAt:   now,
+						UpdatedAt:   now,
+					},
+				)
+			},
+			params: staff.UpdatePasswordParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "newfakehashedpassword",
+			},
+			want:    "fakehashedpassword",
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when there is an active staff with the id and restaurant id, then it should replace the password",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(
+					t, coll, staff.Staff{
+						StaffID:     "fake-staff-id",
+						Email:       "test@example.com",
+						Password:    "fakehashedpassword",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+						Active:      true,
+						CreatedAt:   now,
+						UpdatedAt:   now,
+					},
+				)
+			},
+			params: staff.UpdatePasswordParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "newfakehashedpassword",
+			},
+			want:    "newfakehashedpassword",
+			wantErr: nil,
+		},
+		{
+			name: "when the restaurant is not provided and there is an active staff with the id, " +
+				"then it should replace the password",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(
+					t, coll, staff.Staff{
+						StaffID:     "fake-staff-id",
+						Email:       "test@example.com",
+						Password:    "fakehashedpassword",
+						Restaurants: []staff.Membership{{RestaurantID: "another-fake-restaurant-id", Active: true}},
+						Active:      true,
+						CreatedAt:   now,
+						UpdatedAt:   now,
+					},
+				)
+			},
+			params: staff.UpdatePasswordParams{
+				StaffID:  "fake-staff-id",
+				Password: "newfakehashedpassword",
+			},
+			want:    "newfakehashedpassword",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestStaffCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.UpdatePassword(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var got staff.Staff
+			err = coll.FindOne(context.Background(), bson.M{staff.FieldStaffID: tt.params.StaffID}).Decode(&got)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Password)
+		})
+	}
+}
+
+func TestRepository_UpdatePassword_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.UpdatePassword(context.Background(), staff.UpdatePasswordParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, staff.ErrStaffNotFound)
+}
+
+func TestRepository_UpdateActive(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tests := []staffRepositoryTestCase[staff.UpdateActiveParams, []staff.Membership]{
+		{
+			name: "when there is not a staff with the id in the restaurant, then it should return a staff not found error",
+			params: staff.UpdateActiveParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Active:       false,
+			},
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when the staff is active in the restaurant, then it should deactivate it only in the restaurant",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, staff.Staff{
+					StaffID: "fake-staff-id",
+					Email:   "test@example.com",
+					Restaurants: []staff.Membership{
+						{RestaurantID: "another-fake-restaurant-id", Active: true},
+						{RestaurantID: "fake-restaurant-id", Owner: true, Active: true},
+					},
+					Password:  "fakehashedpassword",
+					Active:    true,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			},
+			params: staff.UpdateActiveParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Active:       false,
+			},
+			want: []staff.Membership{
+				{RestaurantID: "another-fake-restaurant-id", Active: true},
+				{RestaurantID: "fake-restaurant-id", Owner: true, Active: false},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the staff is inactive in the restaurant, then it should reactivate it",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, staff.Staff{
+					StaffID:     "fake-staff-id",
+					Email:       "test@example.com",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: false}},
+					Password:    "fakehashedpassword",
+					Active:      true,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				})
+			},
+			params: staff.UpdateActiveParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Active:       true,
+			},
+			want:    []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestStaffCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.UpdateActive(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the stored memberships only if the staff exists
+			if !errors.Is(tt.wantErr, staff.ErrStaffNotFound) {
+				var got staff.Staff
+				err = coll.FindOne(context.Background(), bson.M{staff.FieldStaffID: tt.params.StaffID}).Decode(&got)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got.Restaurants)
+			}
+		})
+	}
+}
+
+func TestRepository_UpdateActive_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.UpdateActive(context.Background(), staff.UpdateActiveParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, staff.ErrStaffNotFound)
+}
+
+func TestRepository_AddRestaurant(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	linkedStaff := staff.Staff{
+		StaffID:     "fake-staff-id",
+		Email:       "test@example.com",
+		Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+		Password:    "fakehashedpassword",
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	tests := []staffRepositoryTestCase[staff.AddRestaurantParams, []staff.Membership]{
+		{
+			name: "when there is not an active staff with the id, then it should return a staff not found error",
+			params: staff.AddRestaurantParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "another-fake-restaurant-id",
+			},
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when the staff is already linked to the restaurant, " +
+				"then it should return a restaurant already linked error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, linkedStaff)
+			},
+			params: staff.AddRestaurantParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Owner:        true,
+			},
+			want:    []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+			wantErr: staff.ErrRestaurantAlreadyLinked,
+		},
+		{
+			name: "when the staff is not linked to the restaurant, then it should link it",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, linkedStaff)
+			},
+			params: staff.AddRestaurantParams{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "another-fake-restaurant-id",
+				Role:         staff.RoleChef,
+			},
+			want: []staff.Membership{
+				{RestaurantID: "fake-restaurant-id", Active: true},
+				{RestaurantID: "another-fake-restaurant-id", Active: true, Role: staff.RoleChef},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestStaffCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.AddRestaurant(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the stored memberships only if the staff exists
+			if !errors.Is(tt.wantErr, staff.ErrStaffNotFound) {
+				var got staff.Staff
+				err = coll.FindOne(context.Background(), bson.M{staff.FieldStaffID: tt.params.StaffID}).Decode(&got)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got.Restaurants)
+			}
+		})
+	}
+}
+
+func TestRepository_AddRestaurant_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.AddRestaurant(context.Background(), staff.AddRestaurantParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, staff.ErrStaffNotFound)
+}
+
+func TestRepository_FindLegacyStaff(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	legacyID := primitive.NewObjectID()
+	nextLegacyID := primitive.NewObjectID()
+
+	tests := []staffRepositoryTestCase[staff.FindLegacyStaffParams, []staff.LegacyStaff]{
+		{
+			name:    "when there is no legacy staff, then it should return an empty list",
+			params:  staff.FindLegacyStaffParams{Limit: 10},
+			want:    []staff.LegacyStaff{},
+			wantErr: nil,
+		},
+		{
+			name: "when there is legacy staff, then it should return only the documents scoped to a single restaurant",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, bson.M{
+					"_id":                   legacyID,
+					staff.FieldStaffID:      "fake-staff-id",
+					staff.FieldEmail:        "test@example.com",
+					staff.FieldRestaurantID: "fake-restaurant-id",
+					staff.FieldOwner:        true,
+					staff.FieldActive:       false,
+					staff.FieldPassword:     "fakehashedpassword",
+				})
+				mongodb.InsertTestDocument(t, coll, staff.Staff{
+					StaffID:     "fake-other-staff-id",
+					Email:       "other@example.com",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					Active:      true,
+				})
+			},
+			params: staff.FindLegacyStaffParams{Limit: 10},
+			want: []staff.LegacyStaff{
+				{
+					ID:           legacyID.Hex(),
+					StaffID:      "fake-staff-id",
+					Email:        "test@example.com",
+					RestaurantID: "fake-restaurant-id",
+					Owner:        true,
+					Active:       false,
+					Password:     "fakehashedpassword",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when an ID is given to start after, then it should only return the legacy staff after it",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, bson.M{
+					"_id":                   legacyID,
+					staff.FieldStaffID:      "fake-staff-id",
+					staff.FieldEmail:        "test@example.com",
+					staff.FieldRestaurantID: "fake-restaurant-id",
+					staff.FieldActive:       true,
+				})
+				mongodb.InsertTestDocument(t, coll, bson.M{
+					"_id":                   nextLegacyID,
+					staff.FieldStaffID:      "fake-next-staff-id",
+					staff.FieldEmail:        "next@example.com",
+					staff.FieldRestaurantID: "fake-next-restaurant-id",
+					staff.FieldActive:       true,
+				})
+			},
+			params: staff.FindLegacyStaffParams{AfterID: legacyID.Hex(), Limit: 10},
+			want: []staff.LegacyStaff{
+				{
+					ID:           nextLegacyID.Hex(),
+					StaffID:      "fake-next-staff-id",
+					Email:        "next@example.com",
+					RestaurantID: "fake-next-restaurant-id",
+					Active:       true,
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			// The legacy documents predate the unique email index
+			coll := tdb.DB.Collection(staff.CollectionName)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			got, err := repo.FindLegacyStaff(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRepository_FindLegacyStaff_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.FindLegacyStaff(context.Background(), staff.FindLegacyStaffParams{Limit: 10})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_MergeLegacyStaff(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	legacyID := primitive.NewObjectID()
+	legacyStaff := bson.M{
+		"_id":                   legacyID,
+		staff.FieldStaffID:      "fake-legacy-staff-id",
+		staff.FieldEmail:        "test@example.com",
+		staff.FieldRestaurantID: "fake-other-restaurant-id",
+		staff.FieldOwner:        true,
+		staff.FieldActive:       true,
+		staff.FieldPassword:     "fakehashedpassword",
+	}
+	linkedStaff := staff.Staff{
+		StaffID:     "fake-staff-id",
+		Email:       "test@example.com",
+		Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+		Password:    "fakehashedpassword",
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	params := staff.MergeLegacyStaffParams{
+		ID:         legacyID.Hex(),
+		StaffID:    "fake-legacy-staff-id",
+		Email:      "test@example.com",
+		Password:   "fakehashedpassword",
+		Membership: staff.Membership{RestaurantID: "fake-other-restaurant-id", Owner: true, Active: true},
+	}
+
+	tests := []staffRepositoryTestCase[staff.MergeLegacyStaffParams, []staff.Membership]{
+		{
+			name: "when there are no credentials with the same email, then it should return a staff not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, legacyStaff)
+			},
+			params:  params,
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when there are credentials with the same email and another password, " +
+				"then it should return a legacy staff conflict error and keep the legacy document",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, legacyStaff)
+				otherPassword := linkedStaff
+				otherPassword.Password = "fakeotherhashedpassword"
+				mongodb.InsertTestDocument(t, coll, otherPassword)
+			},
+			params:  params,
+			wantErr: staff.ErrLegacyStaffConflict,
+		},
+		{
+			name: "when there are credentials with the same email and password, " +
+				"then it should link the restaurant to them with the legacy staff ID and remove the legacy document",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, legacyStaff)
+				mongodb.InsertTestDocument(t, coll, linkedStaff)
+			},
+			params: params,
+			want: []staff.Membership{
+				{RestaurantID: "fake-restaurant-id", Active: true},
+				{
+					RestaurantID:  "fake-other-restaurant-id",
+					Owner:         true,
+					Active:        true,
+					LegacyStaffID: "fake-legacy-staff-id",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the credentials are already linked to the restaurant, " +
+				"then it should only remove the legacy document",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, legacyStaff)
+				alreadyMerged := linkedStaff
+				alreadyMerged.Restaurants = []staff.Membership{
+					{RestaurantID: "fake-restaurant-id", Active: true},
+					{RestaurantID: "fake-other-restaurant-id", Owner: true, Active: true},
+				}
+				mongodb.InsertTestDocument(t, coll, alreadyMerged)
+			},
+			params: params,
+			want: []staff.Membership{
+				{RestaurantID: "fake-restaurant-id", Active: true},
+				{RestaurantID: "fake-other-restaurant-id", Owner: true, Active: true},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			// The legacy documents predate the unique email index
+			coll := tdb.DB.Collection(staff.CollectionName)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.MergeLegacyStaff(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// The legacy document is only removed once merged
+			count, err := coll.CountDocuments(context.Background(), bson.M{"_id": legacyID})
+			require.NoError(t, err)
+			if tt.wantErr != nil {
+				assert.Equal(t, int64(1), count)
+				return
+			}
+			assert.Equal(t, int64(0), count)
+
+			var got staff.Staff
+			err = coll.FindOne(context.Background(), bson.M{staff.FieldStaffID: "fake-staff-id"}).Decode(&got)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Restaurants)
+		})
+	}
+}
+
+func TestRepository_MergeLegacyStaff_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.MergeLegacyStaff(context.Background(), staff.MergeLegacyStaffParams{ID: primitive.NewObjectID().Hex()})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, staff.ErrStaffNotFound)
+}
+
+func TestRepository_FoldLegacyStaff(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	legacyID := primitive.NewObjectID()
+	params := staff.FoldLegacyStaffParams{
+		ID:         legacyID.Hex(),
+		Membership: staff.Membership{RestaurantID: "fake-restaurant-id", Owner: true, Active: false},
+	}
+
+	tests := []staffRepositoryTestCase[staff.FoldLegacyStaffParams, []staff.Membership]{
+		{
+			name:    "when the legacy staff does not exist, then it should return a staff not found error",
+			params:  params,
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when the legacy staff exists, " +
+				"then it should fold its restaurant into its memberships and keep the credentials active",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, bson.M{
+					"_id":                   legacyID,
+					staff.FieldStaffID:      "fake-staff-id",
+					staff.FieldEmail:        "test@example.com",
+					staff.FieldRestaurantID: "fake-restaurant-id",
+					staff.FieldOwner:        true,
+					staff.FieldActive:       false,
+				})
+			},
+			params:  params,
+			want:    []staff.Membership{{RestaurantID: "fake-restaurant-id", Owner: true, Active: false}},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestStaffCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.FoldLegacyStaff(context.Background(), tt.params)
+
+			// Error assertion
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the folded document only if it existed
+			if tt.wantErr == nil {
+				var got bson.M
+				err = coll.FindOne(context.Background(), bson.M{"_id": legacyID}).Decode(&got)
+				require.NoError(t, err)
+				assert.NotContains(t, got, staff.FieldRestaurantID)
+				assert.NotContains(t, got, staff.FieldOwner)
+				assert.Equal(t, true, got[staff.FieldActive])
+
+				var folded staff.Staff
+				err = coll.FindOne(context.Background(), bson.M{"_id": legacyID}).Decode(&folded)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, folded.Restaurants)
+			}
+		})
+	}
+}
+
+func TestRepository_FoldLegacyStaff_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	err := repo.FoldLegacyStaff(context.Background(), staff.FoldLegacyStaffParams{ID: primitive.NewObjectID().Hex()})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, staff.ErrStaffNotFound)
+}
+
 func setupTestStaffCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -258,7 +1106,6 @@ func setupTestStaffCollection(t *testing.T, db *mongo.Database) *mongo.Collectio
 	indexModel := mongo.IndexModel{
 		Keys: bson.D{
 			{Key: staff.FieldEmail, Value: 1},
-			{Key: staff.FieldRestaurantID, Value: 1},
 		},
 		Options: options.Index().
 			SetUnique(true).
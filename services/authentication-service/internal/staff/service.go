@@ -5,9 +5,15 @@ import (
 	"errors"
 	"time"
 
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/tenantselection"
 )
 
 const (
@@ -16,6 +22,9 @@ const (
 	DefaultTokenExpiration = 3600
 	// DefaultTokenRole represents the default role assigned to a generated JWT token for customers.
 	DefaultTokenRole = "staff"
+
+	// LegacyStaffBatchSize defines how many legacy staff documents are migrated on each migration step.
+	LegacyStaffBatchSize = 100
 )
 
 // Service defines the interface for the staff service.
@@ -24,13 +33,36 @@ const (
 type Service interface {
 	RegisterStaff(ctx context.Context, input RegisterStaffInput) (RegisterStaffOutput, error)
 	LoginStaff(ctx context.Context, input LoginStaffInput) (LoginStaffOutput, error)
+	SelectStaffRestaurant(ctx context.Context, input SelectStaffRestaurantInput) (SelectStaffRestaurantOutput, error)
 	RefreshStaff(ctx context.Context, input RefreshStaffInput) (RefreshStaffOutput, error)
+	LogoutStaff(ctx context.Context, input LogoutStaffInput) (LogoutStaffOutput, error)
+	RequestStaffPasswordReset(
+		ctx context.Context,
+		input RequestStaffPasswordResetInput,
+	) (RequestStaffPasswordResetOutput, error)
+	ResetStaffPassword(ctx context.Context, input ResetStaffPasswordInput) (ResetStaffPasswordOutput, error)
+	ChangeStaffPassword(ctx context.Context, input ChangeStaffPasswordInput) (ChangeStaffPasswordOutput, error)
+	VerifyStaffMFA(ctx context.Context, input VerifyStaffMFAInput) (VerifyStaffMFAOutput, error)
+	EnrollStaffMFA(ctx context.Context, input EnrollStaffMFAInput) (EnrollStaffMFAOutput, error)
+	ConfirmStaffMFA(ctx context.Context, input ConfirmStaffMFAInput) (ConfirmStaffMFAOutput, error)
+	SetStaffActive(ctx context.Context, input SetStaffActiveInput) (SetStaffActiveOutput, error)
+	SwitchStaffTenant(ctx context.Context, input SwitchStaffTenantInput) (SwitchStaffTenantOutput, error)
+	LinkStaffRestaurant(ctx context.Context, input LinkStaffRestaurantInput) (LinkStaffRestaurantOutput, error)
+	ImpersonateStaff(ctx context.Context, input ImpersonateStaffInput) (ImpersonateStaffOutput, error)
+	MigrateLegacyStaff(ctx context.Context, input MigrateLegacyStaffInput) (MigrateLegacyStaffOutput, error)
 }
 
 type service struct {
-	logger          log.Logger
-	repo            Repository
-	authCoreService authcore.Service
+	logger                 log.Logger
+	repo                   Repository
+	authCoreService        authcore.Service
+	passwordResetService   passwordreset.Service
+	mfaService             mfa.Service
+	lockoutService         lockout.Service
+	tenantSelectionService tenantselection.Service
+	passwordPolicy         password.Policy
+	authctx                auth.ContextReader
+	authEventsService      authevents.Service
 }
 
 // NewService creates a new instance of Service with the provided dependencies.
@@ -38,11 +70,25 @@ func NewService(
 	logger log.Logger,
 	repo Repository,
 	authCoreService authcore.Service,
+	passwordResetService passwordreset.Service,
+	mfaService mfa.Service,
+	lockoutService lockout.Service,
+	tenantSelectionService tenantselection.Service,
+	passwordPolicy password.Policy,
+	authctx auth.ContextReader,
+	authEventsService authevents.Service,
 ) Service {
 	return &service{
-		logger:          logger,
-		repo:            repo,
-		authCoreService: authCoreService,
+		logger:                 logger,
+		repo:                   repo,
+		authCoreService:        authCoreService,
+		passwordResetService:   passwordResetService,
+		mfaService:             mfaService,
+		lockoutService:         lockoutService,
+		tenantSelectionService: tenantSelectionService,
+		passwordPolicy:         passwordPolicy,
+		authctx:                authctx,
+		authEventsService:      authEventsService,
 	}
 }
 
@@ -52,11 +98,15 @@ type RegisterStaffInput struct {
 	Email        string
 	RestaurantID string
 	Password     string
+	Owner        bool
+	Role         string
 }
 
-// RegisterStaffOutput represents the output data returned after successfully registering a new staff.
+// RegisterStaffOutput represents the output data returned after successfully registering a new staff. StaffID is the
+// one carried by the tokens, which is the staff ID of the existing credentials when the restaurant is linked to them.
 type RegisterStaffOutput struct {
 	ID           string
+	StaffID      string
 	Email        string
 	RestaurantID string
 	CreatedAt    time.Time
@@ -67,6 +117,11 @@ func (s *service) RegisterStaff(ctx context.Context, input RegisterStaffInput) (
 	logger := s.logger.WithContext(ctx)
 
 	logger.Info("registering staff", log.Field{Key: "email", Value: input.Email})
+	if err := s.passwordPolicy.Validate(input.Password, input.Email); err != nil {
+		logger.Warn("password does not meet the policy", log.Field{Key: "email", Value: input.Email})
+		return RegisterStaffOutput{}, err
+	}
+
 	hashedPassword, err := password.Hash(input.Password)
 	if err != nil {
 		logger.Error("failed to hash password", err)
@@ -78,18 +133,32 @@ func (s *service) RegisterStaff(ctx context.Context, input RegisterStaffInput) (
 		Email:        input.Email,
 		RestaurantID: input.RestaurantID,
 		Password:     hashedPassword,
+		Owner:        input.Owner,
+		Role:         input.Role,
 	}
 
 	staff, err := s.repo.CreateStaff(ctx, params)
 	if err != nil {
+		if errors.Is(err, ErrStaffAlreadyExists) {
+			return s.linkExistingStaff(ctx, input)
+		}
 		logger.Error("failed to create staff", err)
 		return RegisterStaffOutput{}, err
 	}
 
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeRegistration,
+		Outcome:  authevents.OutcomeSuccess,
+		Subject:  staff.StaffID,
+		Role:     DefaultTokenRole,
+		TenantID: input.RestaurantID,
+	})
+
 	output := RegisterStaffOutput{
 		ID:           staff.ID,
+		StaffID:      staff.StaffID,
 		Email:        staff.Email,
-		RestaurantID: staff.RestaurantID,
+		RestaurantID: input.RestaurantID,
 		CreatedAt:    staff.CreatedAt,
 		UpdatedAt:    staff.UpdatedAt,
 	}
@@ -97,53 +166,351 @@ func (s *service) RegisterStaff(ctx context.Context, input RegisterStaffInput) (
 	return output, nil
 }
 
+// linkExistingStaff links the restaurant being registered to the credentials already registered with the same email,
+// such as when the owner of a chain registers a new location. The password must match the existing credentials, so
+// it counts as a login attempt. Otherwise, the registration fails as the staff already exists.
+func (s *service) linkExistingStaff(ctx context.Context, input RegisterStaffInput) (RegisterStaffOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	if _, err := s.lockoutService.Check(ctx, lockout.CheckInput{
+		Role:       DefaultTokenRole,
+		Identifier: input.Email,
+	}); err != nil {
+		if errors.Is(err, lockout.ErrLocked) {
+			logger.Warn("staff login locked", log.Field{Key: "email", Value: input.Email})
+			return RegisterStaffOutput{}, ErrStaffAlreadyExists
+		}
+		logger.Error("failed to check the staff login lock", err)
+		return RegisterStaffOutput{}, err
+	}
+
+	staff, err := s.repo.FindStaff(ctx, FindStaffParams{Email: input.Email})
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff already exists", log.Field{Key: "email", Value: input.Email})
+			return RegisterStaffOutput{}, ErrStaffAlreadyExists
+		}
+		logger.Error("failed to find staff by email", err)
+		return RegisterStaffOutput{}, err
+	}
+
+	if !password.Verify(staff.Password, input.Password) {
+		logger.Warn("staff already exists with other credentials", log.Field{Key: "email", Value: input.Email})
+		if _, err := s.lockoutService.RegisterFailure(ctx, lockout.RegisterFailureInput{
+			Role:       DefaultTokenRole,
+			Identifier: input.Email,
+		}); err != nil {
+			logger.Error("failed to register the failed staff login", err)
+			return RegisterStaffOutput{}, err
+		}
+		return RegisterStaffOutput{}, ErrStaffAlreadyExists
+	}
+
+	if err := s.repo.AddRestaurant(ctx, AddRestaurantParams{
+		StaffID:      staff.StaffID,
+		RestaurantID: input.RestaurantID,
+		Owner:        input.Owner,
+		Role:         input.Role,
+	}); err != nil {
+		if errors.Is(err, ErrRestaurantAlreadyLinked) {
+			logger.Warn("restaurant already linked to the staff", log.Field{Key: "staff_id", Value: staff.StaffID})
+			return RegisterStaffOutput{}, ErrStaffAlreadyExists
+		}
+		logger.Error("failed to link the staff restaurant", err)
+		return RegisterStaffOutput{}, err
+	}
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeRegistration,
+		Outcome:  authevents.OutcomeSuccess,
+		Subject:  staff.StaffID,
+		Role:     DefaultTokenRole,
+		TenantID: input.RestaurantID,
+	})
+
+	logger.Info("existing staff linked to the restaurant", log.Field{Key: "staff_id", Value: staff.StaffID})
+	return RegisterStaffOutput{
+		ID:           staff.ID,
+		StaffID:      staff.StaffID,
+		Email:        staff.Email,
+		RestaurantID: input.RestaurantID,
+		CreatedAt:    staff.CreatedAt,
+		UpdatedAt:    staff.UpdatedAt,
+	}, nil
+}
+
 // LoginStaffInput represents the input required for the staff user login process.
+// RestaurantID is optional. Without it, the restaurants of the staff user are returned so it can pick one of them.
 type LoginStaffInput struct {
 	Email        string
 	RestaurantID string
 	Password     string
 }
 
+// TenantSession represents the session opened by a staff user in one of its restaurants. When the staff user has MFA
+// enabled, MFARequired is set and the MFA challenge is returned instead of the token pair.
+type TenantSession struct {
+	authcore.TokenPair
+	MFARequired           bool
+	MFAChallengeToken     string
+	MFAChallengeExpiresAt time.Time
+}
+
 // LoginStaffOutput represents the output returned upon successful login of a staff user.
+// When the restaurant is not provided, RestaurantSelectionRequired is set and the restaurants the staff user can log
+// in to are returned instead of the session, together with the SelectionToken to exchange for the session in one of
+// them without sending the password again.
 type LoginStaffOutput struct {
-	authcore.TokenPair
+	TenantSession
+	RestaurantSelectionRequired bool
+	Restaurants                 []Membership
+	SelectionToken              string
+	SelectionTokenExpiresAt     time.Time
 }
 
 func (s *service) LoginStaff(ctx context.Context, input LoginStaffInput) (LoginStaffOutput, error) {
 	logger := s.logger.WithContext(ctx)
 
+	// The credentials are shared by all the restaurants of the staff user, so the lock is not scoped to any of them
 	logger.Info("logging in", log.Field{Key: "email", Value: input.Email})
-	customer, err := s.repo.FindStaff(ctx, FindStaffParams{
+	if _, err := s.lockoutService.Check(ctx, lockout.CheckInput{
+		Role:       DefaultTokenRole,
+		Identifier: input.Email,
+	}); err != nil {
+		if errors.Is(err, lockout.ErrLocked) {
+			logger.Warn("staff login locked", log.Field{Key: "email", Value: input.Email})
+			s.authEventsService.Record(ctx, authevents.RecordInput{
+				Type:     authevents.EventTypeLogin,
+				Outcome:  authevents.OutcomeFailure,
+				Reason:   authevents.ReasonAccountLocked,
+				Role:     DefaultTokenRole,
+				TenantID: input.RestaurantID,
+			})
+			return LoginStaffOutput{}, authcore.ErrAccountLocked
+		}
+		logger.Error("failed to check the staff login lock", err)
+		return LoginStaffOutput{}, err
+	}
+
+	staff, err := s.repo.FindStaff(ctx, FindStaffParams{
 		Email:        input.Email,
 		RestaurantID: input.RestaurantID,
 	})
 	if err != nil {
 		if errors.Is(err, ErrStaffNotFound) {
-			logger.Warn("customer not found", log.Field{Key: "email", Value: input.Email})
-			return LoginStaffOutput{}, authcore.ErrInvalidCredentials
+			logger.Warn("staff not found", log.Field{Key: "email", Value: input.Email})
+			return LoginStaffOutput{}, s.registerLoginFailure(ctx, input, "")
 		}
-		logger.Error("failed to find customer by email", err)
+		logger.Error("failed to find staff by email", err)
 		return LoginStaffOutput{}, err
 	}
 
 	// Check if the stored password matches the provided password
-	if !password.Verify(customer.Password, input.Password) {
+	if !password.Verify(staff.Password, input.Password) {
 		logger.Warn("invalid credentials")
-		return LoginStaffOutput{}, authcore.ErrInvalidCredentials
+		return LoginStaffOutput{}, s.registerLoginFailure(ctx, input, staff.StaffID)
 	}
+	s.upgradePasswordHash(ctx, staff, input.Password)
 
-	tokenPair, err := s.authCoreService.GenerateTokenPair(ctx, authcore.GenerateTokenPairInput{
-		UserID:     customer.StaffID,
-		Expiration: DefaultTokenExpiration,
+	if _, err := s.lockoutService.RegisterSuccess(ctx, lockout.RegisterSuccessInput{
 		Role:       DefaultTokenRole,
-		TenantID:   customer.RestaurantID,
+		Identifier: input.Email,
+	}); err != nil {
+		logger.Error("failed to register the successful staff login", err)
+		return LoginStaffOutput{}, err
+	}
+
+	if input.RestaurantID == "" {
+		selection, err := s.tenantSelectionService.Issue(ctx, tenantselection.IssueInput{
+			UserID: staff.StaffID,
+			Role:   DefaultTokenRole,
+		})
+		if err != nil {
+			logger.Error("failed to issue the restaurant selection token", err)
+			return LoginStaffOutput{}, err
+		}
+
+		logger.Info("staff restaurant selection required", log.Field{Key: "staff_id", Value: staff.StaffID})
+		return LoginStaffOutput{
+			RestaurantSelectionRequired: true,
+			Restaurants:                 staff.ActiveMemberships(),
+			SelectionToken:              selection.Token,
+			SelectionTokenExpiresAt:     selection.ExpiresAt,
+		}, nil
+	}
+
+	membership, _ := staff.Membership(input.RestaurantID)
+	session, err := s.openTenantSession(ctx, staff, membership, authevents.EventTypeLogin)
+	if err != nil {
+		return LoginStaffOutput{}, err
+	}
+	return LoginStaffOutput{TenantSession: session}, nil
+}
+
+// SelectStaffRestaurantInput represents the input required to open the session of a staff user in the restaurant it
+// picked, with the selection token issued on login.
+type SelectStaffRestaurantInput struct {
+	SelectionToken string
+	RestaurantID   string
+}
+
+// SelectStaffRestaurantOutput represents the session opened in the restaurant the staff user picked.
+type SelectStaffRestaurantOutput struct {
+	TenantSession
+}
+
+// SelectStaffRestaurant exchanges the selection token issued on a login without restaurant for the session of the
+// staff user in the restaurant it picked. The password was already verified on login, so it is not required again.
+func (s *service) SelectStaffRestaurant(
+	ctx context.Context,
+	input SelectStaffRestaurantInput,
+) (SelectStaffRestaurantOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("selecting staff restaurant", log.Field{Key: "restaurant_id", Value: input.RestaurantID})
+	owner, err := s.tenantSelectionService.Consume(ctx, tenantselection.ConsumeInput{
+		Token: input.SelectionToken,
+		Role:  DefaultTokenRole,
+	})
+	if err != nil {
+		if errors.Is(err, tenantselection.ErrSelectionTokenNotFound) {
+			logger.Warn("restaurant selection token not found")
+			return SelectStaffRestaurantOutput{}, ErrInvalidSelectionToken
+		}
+		logger.Error("failed to consume the restaurant selection token", err)
+		return SelectStaffRestaurantOutput{}, err
+	}
+
+	staff, err := s.repo.FindByStaffID(ctx, FindByStaffIDParams{
+		StaffID:      owner.UserID,
+		RestaurantID: input.RestaurantID,
+	})
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("restaurant not linked to the staff", log.Field{Key: "staff_id", Value: owner.UserID})
+			s.authEventsService.Record(ctx, authevents.RecordInput{
+				Type:     authevents.EventTypeLogin,
+				Outcome:  authevents.OutcomeFailure,
+				Reason:   authevents.ReasonRestaurantNotLinked,
+				Subject:  owner.UserID,
+				Role:     DefaultTokenRole,
+				TenantID: input.RestaurantID,
+			})
+			return SelectStaffRestaurantOutput{}, ErrRestaurantNotLinked
+		}
+		logger.Error("failed to find staff by id", err)
+		return SelectStaffRestaurantOutput{}, err
+	}
+
+	membership, _ := staff.Membership(input.RestaurantID)
+	session, err := s.openTenantSession(ctx, staff, membership, authevents.EventTypeLogin)
+	if err != nil {
+		return SelectStaffRestaurantOutput{}, err
+	}
+	return SelectStaffRestaurantOutput{TenantSession: session}, nil
+}
+
+// openTenantSession issues the token pair of the staff user scoped to the restaurant of the membership, or the MFA
+// challenge when the staff user has MFA enabled. MFA is enabled for the staff user, not for a restaurant, so it is
+// required in all of them. The eventType is recorded once the token pair is issued.
+func (s *service) openTenantSession(
+	ctx context.Context,
+	staff Staff,
+	membership Membership,
+	eventType string,
+) (TenantSession, error) {
+	logger := s.logger.WithContext(ctx)
+
+	mfaStatus, err := s.mfaService.IsEnabled(ctx, mfa.IsEnabledInput{
+		UserID: staff.StaffID,
+		Role:   DefaultTokenRole,
+	})
+	if err != nil {
+		logger.Error("failed to check the staff MFA status", err)
+		return TenantSession{}, err
+	}
+	if mfaStatus.Enabled {
+		challenge, err := s.mfaService.CreateChallenge(ctx, mfa.CreateChallengeInput{
+			UserID:   staff.StaffID,
+			Role:     DefaultTokenRole,
+			TenantID: membership.RestaurantID,
+		})
+		if err != nil {
+			logger.Error("failed to create the MFA challenge", err)
+			return TenantSession{}, err
+		}
+
+		logger.Info("MFA challenge issued", log.Field{Key: "staff_id", Value: staff.StaffID})
+		return TenantSession{
+			MFARequired:           true,
+			MFAChallengeToken:     challenge.Token,
+			MFAChallengeExpiresAt: challenge.ExpiresAt,
+		}, nil
+	}
+
+	tokenPair, err := s.authCoreService.GenerateTokenPair(ctx, authcore.GenerateTokenPairInput{
+		UserID:      staff.StaffID,
+		Expiration:  DefaultTokenExpiration,
+		Role:        DefaultTokenRole,
+		TenantID:    membership.RestaurantID,
+		Owner:       membership.Owner,
+		Permissions: membership.Permissions(),
 	})
 	if err != nil {
 		logger.Error("failed to generate token pair", err)
-		return LoginStaffOutput{}, err
+		return TenantSession{}, err
 	}
 
-	return LoginStaffOutput{TokenPair: tokenPair}, nil
+	s.recordSessionSuccess(ctx, eventType, staff.StaffID, membership.RestaurantID)
+	return TenantSession{TokenPair: tokenPair}, nil
+}
+
+// registerLoginFailure registers the failed login of the staff user, and returns the error the login must fail with.
+// The staffID is empty when the email is not registered, or not active in the restaurant.
+func (s *service) registerLoginFailure(ctx context.Context, input LoginStaffInput, staffID string) error {
+	logger := s.logger.WithContext(ctx)
+
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     authevents.EventTypeLogin,
+		Outcome:  authevents.OutcomeFailure,
+		Reason:   authevents.ReasonInvalidCredentials,
+		Subject:  staffID,
+		Role:     DefaultTokenRole,
+		TenantID: input.RestaurantID,
+	})
+
+	if _, err := s.lockoutService.RegisterFailure(ctx, lockout.RegisterFailureInput{
+		Role:       DefaultTokenRole,
+		Identifier: input.Email,
+	}); err != nil {
+		logger.Error("failed to register the failed staff login", err)
+		return err
+	}
+	return authcore.ErrInvalidCredentials
+}
+
+// upgradePasswordHash hashes the password of the staff again when the stored hash was created with weaker
+// parameters than the current ones. It is best effort, so a failure does not prevent the staff from logging in.
+func (s *service) upgradePasswordHash(ctx context.Context, staff Staff, plainPassword string) {
+	logger := s.logger.WithContext(ctx)
+
+	if !password.NeedsRehash(staff.Password) {
+		return
+	}
+
+	hashedPassword, err := password.Hash(plainPassword)
+	if err != nil {
+		logger.Error("failed to rehash the staff password", err)
+		return
+	}
+	if err := s.repo.UpdatePassword(ctx, UpdatePasswordParams{
+		StaffID:  staff.StaffID,
+		Password: hashedPassword,
+	}); err != nil {
+		logger.Error("failed to upgrade the staff password hash", err)
+		return
+	}
+	logger.Info("staff password hash upgraded", log.Field{Key: "staff_id", Value: staff.StaffID})
 }
 
 // RefreshStaffInput represents the input required to refresh a staff's authentication tokens.
@@ -163,10 +530,11 @@ func (s *service) RefreshStaff(ctx context.Context, input RefreshStaffInput) (Re
 	logger.Info("refreshing staff token")
 
 	tokenPair, err := s.authCoreService.RefreshToken(ctx, authcore.RefreshTokenInput{
-		RefreshToken: input.RefreshToken,
-		AccessToken:  input.AccessToken,
-		Expiration:   DefaultTokenExpiration,
-		Role:         DefaultTokenRole,
+		RefreshToken:    input.RefreshToken,
+		AccessToken:     input.AccessToken,
+		Expiration:      DefaultTokenExpiration,
+		Role:            DefaultTokenRole,
+		AuthorizeTenant: s.authorizeRestaurant,
 	})
 	if err != nil {
 		logger.Error("failed to refresh the staff token", err)
@@ -175,3 +543,782 @@ func (s *service) RefreshStaff(ctx context.Context, input RefreshStaffInput) (Re
 
 	return RefreshStaffOutput{TokenPair: tokenPair}, nil
 }
+
+// authorizeRestaurant returns the current access of the staff user to the restaurant, derived from its membership,
+// so a refreshed token carries the latest owner flag and permissions. The refresh is rejected once the staff user is
+// not active in the restaurant anymore.
+func (s *service) authorizeRestaurant(
+	ctx context.Context,
+	staffID, restaurantID string,
+) (authcore.TenantAccess, error) {
+	logger := s.logger.WithContext(ctx)
+
+	staff, err := s.repo.FindByStaffID(ctx, FindByStaffIDParams{StaffID: staffID, RestaurantID: restaurantID})
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn(
+				"staff not active in the restaurant",
+				log.Field{Key: "staff_id", Value: staffID},
+				log.Field{Key: "restaurant_id", Value: restaurantID},
+			)
+			s.authEventsService.Record(ctx, authevents.RecordInput{
+				Type:     authevents.EventTypeRefresh,
+				Outcome:  authevents.OutcomeFailure,
+				Reason:   authevents.ReasonRestaurantNotLinked,
+				Subject:  staffID,
+				Role:     DefaultTokenRole,
+				TenantID: restaurantID,
+			})
+			return authcore.TenantAccess{}, authcore.ErrInvalidRefreshToken
+		}
+		logger.Error("failed to find staff by id", err)
+		return authcore.TenantAccess{}, err
+	}
+
+	membership, _ := staff.Membership(restaurantID)
+	return authcore.TenantAccess{Owner: membership.Owner, Permissions: membership.Permissions()}, nil
+}
+
+// LogoutStaffInput represents the input required to revoke the sessions of a staff.
+type LogoutStaffInput struct {
+	RefreshToken string
+	// AllSessions revokes every active session of the staff, not only the one of the presented refresh token.
+	AllSessions bool
+}
+
+// LogoutStaffOutput represents the result of a staff logout operation.
+type LogoutStaffOutput struct {
+	RevokedTokens int64
+}
+
+func (s *service) LogoutStaff(ctx context.Context, input LogoutStaffInput) (LogoutStaffOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	// Revoking all the sessions locks the user out of its other devices, so it is only allowed to the user itself
+	if input.AllSessions {
+		if err := s.authctx.RequireNotImpersonated(ctx); err != nil {
+			return LogoutStaffOutput{}, err
+		}
+	}
+
+	logger.Info("logging out staff")
+
+	output, err := s.authCoreService.Logout(ctx, authcore.LogoutInput{
+		RefreshToken: input.RefreshToken,
+		Role:         DefaultTokenRole,
+		AllSessions:  input.AllSessions,
+	})
+	if err != nil {
+		logger.Error("failed to logout the staff", err)
+		return LogoutStaffOutput{}, err
+	}
+
+	return LogoutStaffOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// RequestStaffPasswordResetInput represents the input required to request a password reset for a staff user.
+type RequestStaffPasswordResetInput struct {
+	Email        string
+	RestaurantID string
+}
+
+// RequestStaffPasswordResetOutput represents the result of a staff password reset request.
+type RequestStaffPasswordResetOutput struct{}
+
+// RequestStaffPasswordReset sends a password reset token to the staff user with the given email in the restaurant.
+// To avoid disclosing which emails are registered, requesting it for an unknown staff user is not an error.
+func (s *service) RequestStaffPasswordReset(
+	ctx context.Context,
+	input RequestStaffPasswordResetInput,
+) (RequestStaffPasswordResetOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("requesting staff password reset", log.Field{Key: "email", Value: input.Email})
+	staff, err := s.repo.FindStaff(ctx, FindStaffParams(input))
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff not found", log.Field{Key: "email", Value: input.Email})
+			return RequestStaffPasswordResetOutput{}, nil
+		}
+		logger.Error("failed to find staff", err)
+		return RequestStaffPasswordResetOutput{}, err
+	}
+
+	if _, err := s.passwordResetService.RequestReset(ctx, passwordreset.RequestResetInput{
+		UserID:   staff.StaffID,
+		Role:     DefaultTokenRole,
+		TenantID: input.RestaurantID,
+		Email:    staff.Email,
+	}); err != nil {
+		logger.Error("failed to request the password reset", err)
+		return RequestStaffPasswordResetOutput{}, err
+	}
+
+	return RequestStaffPasswordResetOutput{}, nil
+}
+
+// ResetStaffPasswordInput represents the input required to set a new staff password with a reset token.
+type ResetStaffPasswordInput struct {
+	Token    string
+	Password string
+}
+
+// ResetStaffPasswordOutput represents the result of a staff password reset.
+type ResetStaffPasswordOutput struct {
+	RevokedTokens int64
+}
+
+// ResetStaffPassword consumes the reset token and sets the new password of its owner. All the sessions of the staff
+// user are revoked afterward, in all its restaurants, as they could have been opened by someone knowing the previous
+// password.
+func (s *service) ResetStaffPassword(ctx context.Context, input ResetStaffPasswordInput) (ResetStaffPasswordOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("resetting staff password")
+	// The email of the token owner is unknown yet, and the token must not be consumed by a rejected password
+	if err := s.passwordPolicy.Validate(input.Password, ""); err != nil {
+		logger.Warn("password does not meet the policy")
+		return ResetStaffPasswordOutput{}, err
+	}
+
+	owner, err := s.passwordResetService.Consume(ctx, passwordreset.ConsumeInput{
+		Token: input.Token,
+		Role:  DefaultTokenRole,
+	})
+	if err != nil {
+		if errors.Is(err, passwordreset.ErrResetTokenNotFound) {
+			logger.Warn("password reset token not found")
+			return ResetStaffPasswordOutput{}, authcore.ErrInvalidResetToken
+		}
+		logger.Error("failed to consume the password reset token", err)
+		return ResetStaffPasswordOutput{}, err
+	}
+
+	hashedPassword, err := password.Hash(input.Password)
+	if err != nil {
+		logger.Error("failed to hash password", err)
+		return ResetStaffPasswordOutput{}, err
+	}
+
+	if err := s.repo.UpdatePassword(ctx, UpdatePasswordParams{
+		StaffID:      owner.UserID,
+		RestaurantID: owner.TenantID,
+		Password:     hashedPassword,
+	}); err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff not found", log.Field{Key: "staff_id", Value: owner.UserID})
+			return ResetStaffPasswordOutput{}, authcore.ErrInvalidResetToken
+		}
+		logger.Error("failed to update the staff password", err)
+		return ResetStaffPasswordOutput{}, err
+	}
+
+	// The password is shared by all the restaurants of the staff user
+	output, err := s.authCoreService.RevokeSessions(ctx, authcore.RevokeSessionsInput{
+		UserID:     owner.UserID,
+		Role:       DefaultTokenRole,
+		AllTenants: true,
+	})
+	if err != nil {
+		logger.Error("failed to revoke the staff sessions", err)
+		return ResetStaffPasswordOutput{}, err
+	}
+
+	logger.Info("staff password reset successfully", log.Field{Key: "staff_id", Value: owner.UserID})
+	return ResetStaffPasswordOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// ChangeStaffPasswordInput represents the input required for the authenticated staff user to change its password.
+type ChangeStaffPasswordInput struct {
+	CurrentPassword string
+	NewPassword     string
+	// RevokeOtherSessions revokes every active session of the staff user, in all its restaurants, except the one
+	// performing the change.
+	RevokeOtherSessions bool
+}
+
+// ChangeStaffPasswordOutput represents the result of a staff password change.
+type ChangeStaffPasswordOutput struct {
+	RevokedTokens int64
+}
+
+// ChangeStaffPassword replaces the password of the authenticated staff user, once its current password is verified.
+func (s *service) ChangeStaffPassword(
+	ctx context.Context,
+	input ChangeStaffPasswordInput,
+) (ChangeStaffPasswordOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	if err := s.authctx.RequireNotImpersonated(ctx); err != nil {
+		return ChangeStaffPasswordOutput{}, err
+	}
+
+	staffID, restaurantID, err := s.authenticatedStaff(ctx)
+	if err != nil {
+		return ChangeStaffPasswordOutput{}, err
+	}
+
+	logger.Info(
+		"changing staff password",
+		log.Field{Key: "staff_id", Value: staffID},
+		log.Field{Key: "restaurant_id", Value: restaurantID},
+	)
+	staff, err := s.repo.FindByStaffID(ctx, FindByStaffIDParams{StaffID: staffID, RestaurantID: restaurantID})
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff not found", log.Field{Key: "staff_id", Value: staffID})
+			return ChangeStaffPasswordOutput{}, auth.ErrInvalidToken
+		}
+		logger.Error("failed to find staff by id", err)
+		return ChangeStaffPasswordOutput{}, err
+	}
+
+	if !password.Verify(staff.Password, input.CurrentPassword) {
+		logger.Warn("invalid current password")
+		return ChangeStaffPasswordOutput{}, authcore.ErrInvalidCurrentPassword
+	}
+
+	if err := s.passwordPolicy.Validate(input.NewPassword, staff.Email); err != nil {
+		logger.Warn("password does not meet the policy")
+		return ChangeStaffPasswordOutput{}, err
+	}
+
+	hashedPassword, err := password.Hash(input.NewPassword)
+	if err != nil {
+		logger.Error("failed to hash password", err)
+		return ChangeStaffPasswordOutput{}, err
+	}
+
+	if err := s.repo.UpdatePassword(ctx, UpdatePasswordParams{
+		StaffID:      staffID,
+		RestaurantID: restaurantID,
+		Password:     hashedPassword,
+	}); err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff not found", log.Field{Key: "staff_id", Value: staffID})
+			return ChangeStaffPasswordOutput{}, auth.ErrInvalidToken
+		}
+		logger.Error("failed to update the staff password", err)
+		return ChangeStaffPasswordOutput{}, err
+	}
+
+	if !input.RevokeOtherSessions {
+		logger.Info("staff password changed successfully", log.Field{Key: "staff_id", Value: staffID})
+		return ChangeStaffPasswordOutput{}, nil
+	}
+
+	// The password is shared by all the restaurants of the staff user
+	output, err := s.authCoreService.RevokeSessions(ctx, authcore.RevokeSessionsInput{
+		UserID:             staffID,
+		Role:               DefaultTokenRole,
+		AllTenants:         true,
+		KeepCurrentSession: true,
+	})
+	if err != nil {
+		logger.Error("failed to revoke the staff sessions", err)
+		return ChangeStaffPasswordOutput{}, err
+	}
+
+	logger.Info("staff password changed successfully", log.Field{Key: "staff_id", Value: staffID})
+	return ChangeStaffPasswordOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// VerifyStaffMFAInput represents the input required to complete the MFA challenge issued on a staff login.
+// Either the TOTP Code or one of the RecoveryCode values of the staff user must be provided.
+type VerifyStaffMFAInput struct {
+	ChallengeToken string
+	Code           string
+	RecoveryCode   string
+}
+
+// VerifyStaffMFAOutput represents the output returned upon successful completion of the staff MFA challenge.
+type VerifyStaffMFAOutput struct {
+	authcore.TokenPair
+}
+
+// VerifyStaffMFA exchanges the MFA challenge issued on login, together with a valid code, for a token pair.
+func (s *service) VerifyStaffMFA(ctx context.Context, input VerifyStaffMFAInput) (VerifyStaffMFAOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info("verifying staff MFA challenge")
+	challenge, err := s.mfaService.VerifyChallenge(ctx, mfa.VerifyChallengeInput{
+		Token:        input.ChallengeToken,
+		Role:         DefaultTokenRole,
+		Code:         input.Code,
+		RecoveryCode: input.RecoveryCode,
+	})
+	if err != nil {
+		if errors.Is(err, mfa.ErrChallengeNotFound) {
+			logger.Warn("MFA challenge not found")
+			return VerifyStaffMFAOutput{}, authcore.ErrInvalidMFAChallenge
+		}
+		if errors.Is(err, mfa.ErrInvalidCode) {
+			logger.Warn("invalid MFA code")
+			return VerifyStaffMFAOutput{}, authcore.ErrInvalidMFACode
+		}
+		logger.Error("failed to verify the MFA challenge", err)
+		return VerifyStaffMFAOutput{}, err
+	}
+
+	// The staff user is fetched again, so the token reflects its current ownership of the restaurant
+	staff, err := s.repo.FindByStaffID(ctx, FindByStaffIDParams{
+		StaffID:      challenge.UserID,
+		RestaurantID: challenge.TenantID,
+	})
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff not found", log.Field{Key: "staff_id", Value: challenge.UserID})
+			return VerifyStaffMFAOutput{}, authcore.ErrInvalidMFAChallenge
+		}
+		logger.Error("failed to find staff by id", err)
+		return VerifyStaffMFAOutput{}, err
+	}
+	membership, _ := staff.Membership(challenge.TenantID)
+
+	tokenPair, err := s.authCoreService.GenerateTokenPair(ctx, authcore.GenerateTokenPairInput{
+		UserID:      staff.StaffID,
+		Expiration:  DefaultTokenExpiration,
+		Role:        DefaultTokenRole,
+		TenantID:    membership.RestaurantID,
+		Owner:       membership.Owner,
+		Permissions: membership.Permissions(),
+	})
+	if err != nil {
+		logger.Error("failed to generate token pair", err)
+		return VerifyStaffMFAOutput{}, err
+	}
+
+	s.recordSessionSuccess(ctx, authevents.EventTypeLogin, staff.StaffID, membership.RestaurantID)
+	return VerifyStaffMFAOutput{TokenPair: tokenPair}, nil
+}
+
+// recordSessionSuccess records the successful login or tenant switch of the staff user, once the token pair is
+// issued. With MFA enabled, it happens when the MFA challenge is completed.
+func (s *service) recordSessionSuccess(ctx context.Context, eventType, staffID, restaurantID string) {
+	s.authEventsService.Record(ctx, authevents.RecordInput{
+		Type:     eventType,
+		Outcome:  authevents.OutcomeSuccess,
+		Subject:  staffID,
+		Role:     DefaultTokenRole,
+		TenantID: restaurantID,
+	})
+}
+
+// EnrollStaffMFAInput represents the input required for the authenticated staff user to start its MFA enrollment.
+type EnrollStaffMFAInput struct{}
+
+// EnrollStaffMFAOutput contains the TOTP secret to register in an authenticator app, and its provisioning URI.
+type EnrollStaffMFAOutput struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// EnrollStaffMFA starts the MFA enrollment of the authenticated staff user. MFA is not required on login until the
+// enrollment is confirmed, and then it is required in all the restaurants of the staff user.
+func (s *service) EnrollStaffMFA(ctx context.Context, _ EnrollStaffMFAInput) (EnrollStaffMFAOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	if err := s.authctx.RequireNotImpersonated(ctx); err != nil {
+		return EnrollStaffMFAOutput{}, err
+	}
+
+	staffID, restaurantID, err := s.authenticatedStaff(ctx)
+	if err != nil {
+		return EnrollStaffMFAOutput{}, err
+	}
+
+	logger.Info("enrolling staff MFA", log.Field{Key: "staff_id", Value: staffID})
+	staff, err := s.repo.FindByStaffID(ctx, FindByStaffIDParams{StaffID: staffID, RestaurantID: restaurantID})
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff not found", log.Field{Key: "staff_id", Value: staffID})
+			return EnrollStaffMFAOutput{}, auth.ErrInvalidToken
+		}
+		logger.Error("failed to find staff by id", err)
+		return EnrollStaffMFAOutput{}, err
+	}
+
+	output, err := s.mfaService.Enroll(ctx, mfa.EnrollInput{
+		UserID:      staff.StaffID,
+		Role:        DefaultTokenRole,
+		AccountName: staff.Email,
+	})
+	if err != nil {
+		if errors.Is(err, mfa.ErrAlreadyEnabled) {
+			logger.Warn("staff MFA already enabled", log.Field{Key: "staff_id", Value: staffID})
+			return EnrollStaffMFAOutput{}, authcore.ErrMFAAlreadyEnabled
+		}
+		logger.Error("failed to enroll the staff MFA", err)
+		return EnrollStaffMFAOutput{}, err
+	}
+
+	return EnrollStaffMFAOutput{Secret: output.Secret, ProvisioningURI: output.ProvisioningURI}, nil
+}
+
+// ConfirmStaffMFAInput represents the input required for the authenticated staff user to confirm its MFA enrollment.
+type ConfirmStaffMFAInput struct {
+	Code string
+}
+
+// ConfirmStaffMFAOutput contains the recovery codes of the staff user, which are returned only once.
+type ConfirmStaffMFAOutput struct {
+	RecoveryCodes []string
+}
+
+// ConfirmStaffMFA enables MFA for the authenticated staff user, once the code proves the secret was registered.
+func (s *service) ConfirmStaffMFA(ctx context.Context, input ConfirmStaffMFAInput) (ConfirmStaffMFAOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	if err := s.authctx.RequireNotImpersonated(ctx); err != nil {
+		return ConfirmStaffMFAOutput{}, err
+	}
+
+	staffID, _, err := s.authenticatedStaff(ctx)
+	if err != nil {
+		return ConfirmStaffMFAOutput{}, err
+	}
+
+	logger.Info("confirming staff MFA", log.Field{Key: "staff_id", Value: staffID})
+	output, err := s.mfaService.ConfirmEnrollment(ctx, mfa.ConfirmEnrollmentInput{
+		UserID: staffID,
+		Role:   DefaultTokenRole,
+		Code:   input.Code,
+	})
+	if err != nil {
+		if errors.Is(err, mfa.ErrEnrollmentNotFound) {
+			logger.Warn("staff MFA enrollment not found", log.Field{Key: "staff_id", Value: staffID})
+			return ConfirmStaffMFAOutput{}, authcore.ErrMFAEnrollmentNotFound
+		}
+		if errors.Is(err, mfa.ErrAlreadyEnabled) {
+			logger.Warn("staff MFA already enabled", log.Field{Key: "staff_id", Value: staffID})
+			return ConfirmStaffMFAOutput{}, authcore.ErrMFAAlreadyEnabled
+		}
+		if errors.Is(err, mfa.ErrInvalidCode) {
+			logger.Warn("invalid MFA code", log.Field{Key: "staff_id", Value: staffID})
+			return ConfirmStaffMFAOutput{}, authcore.ErrInvalidMFACode
+		}
+		logger.Error("failed to confirm the staff MFA", err)
+		return ConfirmStaffMFAOutput{}, err
+	}
+
+	logger.Info("staff MFA enabled successfully", log.Field{Key: "staff_id", Value: staffID})
+	return ConfirmStaffMFAOutput{RecoveryCodes: output.RecoveryCodes}, nil
+}
+
+// SetStaffActiveInput represents the input required for a platform admin to activate or deactivate a staff user.
+type SetStaffActiveInput struct {
+	StaffID      string
+	RestaurantID string
+	Active       bool
+}
+
+// SetStaffActiveOutput represents the result of activating or deactivating a staff user.
+type SetStaffActiveOutput struct {
+	RevokedTokens int64
+}
+
+// SetStaffActive activates or deactivates a staff user within a restaurant. Deactivated staff users can no longer log
+// in to the restaurant, and all their active sessions in it are revoked. Their other restaurants are not affected.
+func (s *service) SetStaffActive(ctx context.Context, input SetStaffActiveInput) (SetStaffActiveOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info(
+		"setting staff active status",
+		log.Field{Key: "staff_id", Value: input.StaffID},
+		log.Field{Key: "restaurant_id", Value: input.RestaurantID},
+		log.Field{Key: "active", Value: input.Active},
+	)
+	if err := s.repo.UpdateActive(ctx, UpdateActiveParams(input)); err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff not found", log.Field{Key: "staff_id", Value: input.StaffID})
+			return SetStaffActiveOutput{}, err
+		}
+		logger.Error("failed to update the staff active status", err)
+		return SetStaffActiveOutput{}, err
+	}
+
+	if input.Active {
+		logger.Info("staff activated successfully", log.Field{Key: "staff_id", Value: input.StaffID})
+		return SetStaffActiveOutput{}, nil
+	}
+
+	output, err := s.authCoreService.RevokeSessions(ctx, authcore.RevokeSessionsInput{
+		UserID:   input.StaffID,
+		Role:     DefaultTokenRole,
+		TenantID: input.RestaurantID,
+	})
+	if err != nil {
+		logger.Error("failed to revoke the staff sessions", err)
+		return SetStaffActiveOutput{}, err
+	}
+
+	logger.Info("staff deactivated successfully", log.Field{Key: "staff_id", Value: input.StaffID})
+	return SetStaffActiveOutput{RevokedTokens: output.RevokedTokens}, nil
+}
+
+// SwitchStaffTenantInput represents the input required for the authenticated staff user to switch its session to
+// another of its restaurants.
+type SwitchStaffTenantInput struct {
+	RestaurantID string
+}
+
+// SwitchStaffTenantOutput represents the session opened in the restaurant the staff user switched to.
+type SwitchStaffTenantOutput struct {
+	TenantSession
+}
+
+// SwitchStaffTenant exchanges the session of the authenticated staff user for a new one scoped to another of its
+// restaurants, without logging in again. The current session is kept active. It is not allowed while impersonating,
+// as the new session would come with a refresh token.
+func (s *service) SwitchStaffTenant(
+	ctx context.Context,
+	input SwitchStaffTenantInput,
+) (SwitchStaffTenantOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	if err := s.authctx.RequireNotImpersonated(ctx); err != nil {
+		return SwitchStaffTenantOutput{}, err
+	}
+
+	staffID, restaurantID, err := s.authenticatedStaff(ctx)
+	if err != nil {
+		return SwitchStaffTenantOutput{}, err
+	}
+
+	logger.Info(
+		"switching staff tenant",
+		log.Field{Key: "staff_id", Value: staffID},
+		log.Field{Key: "from_restaurant_id", Value: restaurantID},
+		log.Field{Key: "to_restaurant_id", Value: input.RestaurantID},
+	)
+	staff, err := s.repo.FindByStaffID(ctx, FindByStaffIDParams{StaffID: staffID, RestaurantID: input.RestaurantID})
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("restaurant not linked to the staff", log.Field{Key: "staff_id", Value: staffID})
+			s.authEventsService.Record(ctx, authevents.RecordInput{
+				Type:     authevents.EventTypeTenantSwitch,
+				Outcome:  authevents.OutcomeFailure,
+				Reason:   authevents.ReasonRestaurantNotLinked,
+				Subject:  staffID,
+				Role:     DefaultTokenRole,
+				TenantID: input.RestaurantID,
+			})
+			return SwitchStaffTenantOutput{}, ErrRestaurantNotLinked
+		}
+		logger.Error("failed to find staff by id", err)
+		return SwitchStaffTenantOutput{}, err
+	}
+
+	membership, _ := staff.Membership(input.RestaurantID)
+	session, err := s.openTenantSession(ctx, staff, membership, authevents.EventTypeTenantSwitch)
+	if err != nil {
+		return SwitchStaffTenantOutput{}, err
+	}
+	return SwitchStaffTenantOutput{TenantSession: session}, nil
+}
+
+// LinkStaffRestaurantInput represents the input required to link an existing staff user to another restaurant.
+type LinkStaffRestaurantInput struct {
+	StaffID      string
+	RestaurantID string
+	Owner        bool
+	Role         string
+}
+
+// LinkStaffRestaurantOutput represents the result of linking a staff user to another restaurant.
+type LinkStaffRestaurantOutput struct{}
+
+// LinkStaffRestaurant links the credentials of a staff user to another restaurant, such as a new location of its
+// chain, so it can log in to it with the same email and password.
+func (s *service) LinkStaffRestaurant(
+	ctx context.Context,
+	input LinkStaffRestaurantInput,
+) (LinkStaffRestaurantOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	logger.Info(
+		"linking staff restaurant",
+		log.Field{Key: "staff_id", Value: input.StaffID},
+		log.Field{Key: "restaurant_id", Value: input.RestaurantID},
+	)
+	if err := s.repo.AddRestaurant(ctx, AddRestaurantParams(input)); err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff not found", log.Field{Key: "staff_id", Value: input.StaffID})
+			return LinkStaffRestaurantOutput{}, err
+		}
+		if errors.Is(err, ErrRestaurantAlreadyLinked) {
+			logger.Warn("restaurant already linked to the staff", log.Field{Key: "staff_id", Value: input.StaffID})
+			return LinkStaffRestaurantOutput{}, err
+		}
+		logger.Error("failed to link the staff restaurant", err)
+		return LinkStaffRestaurantOutput{}, err
+	}
+
+	logger.Info("staff restaurant linked successfully", log.Field{Key: "staff_id", Value: input.StaffID})
+	return LinkStaffRestaurantOutput{}, nil
+}
+
+// ImpersonateStaffInput represents the input required for a platform admin to impersonate a staff user within a
+// restaurant.
+type ImpersonateStaffInput struct {
+	StaffID      string
+	RestaurantID string
+}
+
+// ImpersonateStaffOutput represents the short-lived access token issued to impersonate a staff user.
+type ImpersonateStaffOutput struct {
+	authcore.ImpersonationToken
+}
+
+// ImpersonateStaff issues a short-lived access token on behalf of a staff user active in the restaurant, naming the
+// authenticated platform admin as its actor. The token carries the permissions of the staff user in the restaurant,
+// but no refresh token is issued, so the impersonation can't be extended.
+func (s *service) ImpersonateStaff(ctx context.Context, input ImpersonateStaffInput) (ImpersonateStaffOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	adminID, ok := s.authctx.GetSubject(ctx)
+	if !ok || adminID == "" {
+		logger.Warn("authentication context not found")
+		return ImpersonateStaffOutput{}, auth.ErrInvalidToken
+	}
+
+	logger.Info(
+		"impersonating staff",
+		log.Field{Key: "staff_id", Value: input.StaffID},
+		log.Field{Key: "restaurant_id", Value: input.RestaurantID},
+		log.Field{Key: "admin_id", Value: adminID},
+	)
+	staff, err := s.repo.FindByStaffID(ctx, FindByStaffIDParams(input))
+	if err != nil {
+		if errors.Is(err, ErrStaffNotFound) {
+			logger.Warn("staff not found", log.Field{Key: "staff_id", Value: input.StaffID})
+			return ImpersonateStaffOutput{}, err
+		}
+		logger.Error("failed to find staff by id", err)
+		return ImpersonateStaffOutput{}, err
+	}
+
+	membership, _ := staff.Membership(input.RestaurantID)
+	token, err := s.authCoreService.GenerateImpersonationToken(ctx, authcore.GenerateImpersonationTokenInput{
+		UserID:      staff.StaffID,
+		Role:        DefaultTokenRole,
+		TenantID:    membership.RestaurantID,
+		Owner:       membership.Owner,
+		Permissions: membership.Permissions(),
+		ActorID:     adminID,
+	})
+	if err != nil {
+		logger.Error("failed to generate impersonation token", err)
+		return ImpersonateStaffOutput{}, err
+	}
+
+	logger.Info("staff impersonation token generated", log.Field{Key: "staff_id", Value: input.StaffID})
+	return ImpersonateStaffOutput{ImpersonationToken: token}, nil
+}
+
+// MigrateLegacyStaffInput represents the input required to migrate the staff documents scoped to a single restaurant.
+type MigrateLegacyStaffInput struct{}
+
+// MigrateLegacyStaffOutput represents the result of the legacy staff migration. Conflicts lists the legacy staff
+// documents that were not merged, as their password differs from the one of the credentials with the same email.
+type MigrateLegacyStaffOutput struct {
+	MigratedStaff int64
+	Conflicts     []LegacyStaffConflict
+}
+
+// LegacyStaffConflict represents a legacy staff document that must be reconciled manually.
+type LegacyStaffConflict struct {
+	StaffID      string
+	Email        string
+	RestaurantID string
+}
+
+// MigrateLegacyStaff folds the restaurant of the staff documents stored before the credentials could be linked to
+// several restaurants into their list of memberships. The documents sharing the email of other credentials are merged
+// into them instead, so a single set of credentials is linked to all the restaurants, and their staff ID is kept in the
+// membership. The documents whose password differs from the one of those credentials are never merged, but reported as
+// conflicts. It is safe to run it several times, or concurrently, as the documents that were already migrated are
+// skipped.
+func (s *service) MigrateLegacyStaff(ctx context.Context, _ MigrateLegacyStaffInput) (MigrateLegacyStaffOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	var (
+		migrated  int64
+		conflicts []LegacyStaffConflict
+		afterID   string
+	)
+	for {
+		legacy, err := s.repo.FindLegacyStaff(ctx, FindLegacyStaffParams{
+			AfterID: afterID,
+			Limit:   LegacyStaffBatchSize,
+		})
+		if err != nil {
+			logger.Error("failed to find legacy staff", err)
+			return MigrateLegacyStaffOutput{}, err
+		}
+		if len(legacy) == 0 {
+			break
+		}
+
+		for _, staff := range legacy {
+			afterID = staff.ID
+			err := s.repo.MergeLegacyStaff(ctx, MergeLegacyStaffParams{
+				ID:         staff.ID,
+				StaffID:    staff.StaffID,
+				Email:      staff.Email,
+				Password:   staff.Password,
+				Membership: staff.Membership(),
+			})
+			// There are no other credentials with the same email, so the document keeps its own
+			if errors.Is(err, ErrStaffNotFound) {
+				err = s.repo.FoldLegacyStaff(ctx, FoldLegacyStaffParams{ID: staff.ID, Membership: staff.Membership()})
+			}
+			if err != nil {
+				// The document was migrated by another instance in the meantime
+				if errors.Is(err, ErrStaffNotFound) {
+					continue
+				}
+				if errors.Is(err, ErrLegacyStaffConflict) {
+					logger.Warn(
+						"legacy staff not merged, as its password conflicts with the staff with the same email",
+						log.Field{Key: "staff_id", Value: staff.StaffID},
+						log.Field{Key: "restaurant_id", Value: staff.RestaurantID},
+					)
+					conflicts = append(conflicts, LegacyStaffConflict{
+						StaffID:      staff.StaffID,
+						Email:        staff.Email,
+						RestaurantID: staff.RestaurantID,
+					})
+					continue
+				}
+				logger.Error("failed to migrate legacy staff", err)
+				return MigrateLegacyStaffOutput{}, err
+			}
+			migrated++
+		}
+	}
+
+	logger.Info(
+		"legacy staff migrated",
+		log.Field{Key: "migrated", Value: migrated},
+		log.Field{Key: "conflicts", Value: len(conflicts)},
+	)
+	return MigrateLegacyStaffOutput{MigratedStaff: migrated, Conflicts: conflicts}, nil
+}
+
+// authenticatedStaff returns the staff and restaurant IDs of the authenticated staff user.
+func (s *service) authenticatedStaff(ctx context.Context) (string, string, error) {
+	logger := s.logger.WithContext(ctx)
+
+	staffID, ok := s.authctx.GetSubject(ctx)
+	if !ok || staffID == "" {
+		logger.Warn("authentication context not found")
+		return "", "", auth.ErrInvalidToken
+	}
+	restaurantID, ok := s.authctx.GetTenant(ctx)
+	if !ok || restaurantID == "" {
+		logger.Warn("authentication context not found")
+		return "", "", auth.ErrInvalidToken
+	}
+	return staffID, restaurantID, nil
+}
@@ -12,17 +12,39 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth"
+	authmocks "github.com/alexgrauroca/practice-food-delivery-platform/pkg/auth/mocks"
 	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore"
 	authcoremocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authcore/mocks"
+	autheventsmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/authevents/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout"
+	lockoutmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/lockout/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa"
+	mfamocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/mfa/mocks"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/password"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset"
+	passwordresetmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/passwordreset/mocks"
 	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/staff"
 	staffmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/staff/mocks"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/tenantselection"
+	tenantselectionmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/tenantselection/mocks"
 )
 
 var (
 	errRepo  = errors.New("repository error")
 	errToken = errors.New("token error")
+
+	testPasswordPolicy = password.Policy{MinLength: 8, MaxLength: 128, MinCharacterClasses: 2}
+
+	ownerPermissions = []string{
+		auth.PermissionRestaurantWrite,
+		auth.PermissionMenuRead,
+		auth.PermissionMenuWrite,
+		auth.PermissionOrdersRead,
+		auth.PermissionOrdersWrite,
+		auth.PermissionStaffManage,
+	}
 )
 
 type staffServiceTestCase[I, W any] struct {
@@ -32,6 +54,11 @@ type staffServiceTestCase[I, W any] struct {
 	mocksSetup func(
 		repo *staffmocks.MockRepository,
 		authCoreService *authcoremocks.MockService,
+		passwordResetService *passwordresetmocks.MockService,
+		mfaService *mfamocks.MockService,
+		lockoutService *lockoutmocks.MockService,
+		_ *tenantselectionmocks.MockService,
+		authctx *authmocks.MockContextReader,
 	)
 	wantErr error
 }
@@ -42,7 +69,81 @@ func TestService_RegisterStaff(t *testing.T) {
 
 	tests := []staffServiceTestCase[staff.RegisterStaffInput, staff.RegisterStaffOutput]{
 		{
-			name: "when there is an active staff with the same email, " +
+			name: "when the password does not meet the policy, then it should return a policy violation error",
+			input: staff.RegisterStaffInput{
+				StaffID:      "fake-staff-id",
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "mytestpassword",
+			},
+			want:    staff.RegisterStaffOutput{},
+			wantErr: password.ErrPolicyViolation,
+		},
+		{
+			name: "when there is an active staff with the same email and another password, " +
+				"then it should return a staff already exists error and register the failed login",
+			input: staff.RegisterStaffInput{
+				StaffID:      "fake-staff-id",
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				hashedPassword, err := password.Hash("OtherPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().CreateStaff(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{}, staff.ErrStaffAlreadyExists)
+				lockoutService.EXPECT().Check(gomock.Any(), lockout.CheckInput{
+					Role:       staff.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindStaff(gomock.Any(), staff.FindStaffParams{Email: "test@example.com"}).
+					Return(staff.Staff{StaffID: "fake-existing-staff-id", Password: hashedPassword}, nil)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), lockout.RegisterFailureInput{
+					Role:       staff.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterFailureOutput{}, nil)
+			},
+			want:    staff.RegisterStaffOutput{},
+			wantErr: staff.ErrStaffAlreadyExists,
+		},
+		{
+			name: "when there is a staff with the same email but the login is locked, " +
+				"then it should return a staff already exists error",
+			input: staff.RegisterStaffInput{
+				StaffID:      "fake-staff-id",
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().CreateStaff(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{}, staff.ErrStaffAlreadyExists)
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).
+					Return(lockout.CheckOutput{}, lockout.ErrLocked)
+			},
+			want:    staff.RegisterStaffOutput{},
+			wantErr: staff.ErrStaffAlreadyExists,
+		},
+		{
+			name: "when there is a staff with the same email already linked to the restaurant, " +
 				"then it should return a staff already exists error",
 			input: staff.RegisterStaffInput{
 				StaffID:      "fake-staff-id",
@@ -53,13 +154,79 @@ func TestService_RegisterStaff(t *testing.T) {
 			mocksSetup: func(
 				repo *staffmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
 				repo.EXPECT().CreateStaff(gomock.Any(), gomock.Any()).
 					Return(staff.Staff{}, staff.ErrStaffAlreadyExists)
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{StaffID: "fake-existing-staff-id", Password: hashedPassword}, nil)
+				repo.EXPECT().AddRestaurant(gomock.Any(), gomock.Any()).Return(staff.ErrRestaurantAlreadyLinked)
 			},
 			want:    staff.RegisterStaffOutput{},
 			wantErr: staff.ErrStaffAlreadyExists,
 		},
+		{
+			name: "when there is a staff with the same email and password, " +
+				"then it should link the restaurant to the existing staff",
+			input: staff.RegisterStaffInput{
+				StaffID:      "fake-staff-id",
+				Email:        "test@example.com",
+				RestaurantID: "fake-other-restaurant-id",
+				Password:     "ValidPassword123",
+				Owner:        true,
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().CreateStaff(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{}, staff.ErrStaffAlreadyExists)
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindStaff(gomock.Any(), staff.FindStaffParams{Email: "test@example.com"}).
+					Return(staff.Staff{
+						ID:       "fake-existing-id",
+						StaffID:  "fake-existing-staff-id",
+						Email:    "test@example.com",
+						Password: hashedPassword,
+						Restaurants: []staff.Membership{
+							{RestaurantID: "fake-restaurant-id", Owner: true, Active: true},
+						},
+						CreatedAt: now,
+						UpdatedAt: now,
+						Active:    true,
+					}, nil)
+				repo.EXPECT().AddRestaurant(gomock.Any(), staff.AddRestaurantParams{
+					StaffID:      "fake-existing-staff-id",
+					RestaurantID: "fake-other-restaurant-id",
+					Owner:        true,
+				}).Return(nil)
+			},
+			want: staff.RegisterStaffOutput{
+				ID:           "fake-existing-id",
+				StaffID:      "fake-existing-staff-id",
+				Email:        "test@example.com",
+				RestaurantID: "fake-other-restaurant-id",
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			},
+			wantErr: nil,
+		},
 		{
 			name: "when there is an unexpected error when creating the staff, then it should propagate the error",
 			input: staff.RegisterStaffInput{
@@ -71,6 +238,11 @@ func TestService_RegisterStaff(t *testing.T) {
 			mocksSetup: func(
 				repo *staffmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
 				repo.EXPECT().CreateStaff(gomock.Any(), gomock.Any()).
 					Return(staff.Staff{}, errRepo)
@@ -85,31 +257,39 @@ func TestService_RegisterStaff(t *testing.T) {
 				Email:        "test@example.com",
 				RestaurantID: "fake-restaurant-id",
 				Password:     "ValidPassword123",
+				Role:         staff.RoleCashier,
 			},
 			mocksSetup: func(
 				repo *staffmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
 				repo.EXPECT().CreateStaff(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(_ context.Context, params staff.CreateStaffParams) (staff.Staff, error) {
 						// Assert that the password is hashed
 						ok := password.Verify(params.Password, "ValidPassword123")
 						require.True(t, ok, "Password should be hashed and match the input password")
+						assert.Equal(t, staff.RoleCashier, params.Role)
 
 						return staff.Staff{
-							ID:           "fake-id",
-							StaffID:      params.StaffID,
-							Email:        params.Email,
-							Password:     params.Password,
-							RestaurantID: params.RestaurantID,
-							CreatedAt:    now,
-							UpdatedAt:    now,
-							Active:       true,
+							ID:          "fake-id",
+							StaffID:     params.StaffID,
+							Email:       params.Email,
+							Password:    params.Password,
+							Restaurants: []staff.Membership{{RestaurantID: params.RestaurantID, Active: true}},
+							CreatedAt:   now,
+							UpdatedAt:   now,
+							Active:      true,
 						}, nil
 					})
 			},
 			want: staff.RegisterStaffOutput{
 				ID:           "fake-id",
+				StaffID:      "fake-staff-id",
 				Email:        "test@example.com",
 				RestaurantID: "fake-restaurant-id",
 				CreatedAt:    now,
@@ -135,8 +315,114 @@ func TestService_RegisterStaff(t *testing.T) {
 func TestService_LoginStaff(t *testing.T) {
 	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	logger, _ := log.NewTest()
+	weakParams := password.Params{Memory: 8 * 1024, Iterations: 1, Parallelism: 1}
 
 	tests := []staffServiceTestCase[staff.LoginStaffInput, staff.LoginStaffOutput]{
+		{
+			name: "when the login is locked, then it should return an account locked error",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), lockout.CheckInput{
+					Role:       staff.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.CheckOutput{}, lockout.ErrLocked)
+			},
+			want:    staff.LoginStaffOutput{},
+			wantErr: authcore.ErrAccountLocked,
+		},
+		{
+			name: "when there is an unexpected error checking the login lock, then it should propagate the error",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, errRepo)
+			},
+			want:    staff.LoginStaffOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is an unexpected error registering the failed login, " +
+				"then it should propagate the error",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{}, staff.ErrStaffNotFound)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), lockout.RegisterFailureInput{
+					Role:       staff.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterFailureOutput{}, errRepo)
+			},
+			want:    staff.LoginStaffOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is an unexpected error registering the successful login, " +
+				"then it should propagate the error",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{ID: "fake-id", Password: hashedPassword, Active: true}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), lockout.RegisterSuccessInput{
+					Role:       staff.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterSuccessOutput{}, errRepo)
+			},
+			want:    staff.LoginStaffOutput{},
+			wantErr: errRepo,
+		},
 		{
 			name: "when there is not an active staff with the same email and restaurant, " +
 				"then it should return an invalid credentials error",
@@ -148,9 +434,17 @@ func TestService_LoginStaff(t *testing.T) {
 			mocksSetup: func(
 				repo *staffmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
 				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).
 					Return(staff.Staff{}, staff.ErrStaffNotFound)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterFailureOutput{}, nil)
 			},
 			want:    staff.LoginStaffOutput{},
 			wantErr: authcore.ErrInvalidCredentials,
@@ -166,20 +460,29 @@ func TestService_LoginStaff(t *testing.T) {
 			mocksSetup: func(
 				repo *staffmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
 				hashedPassword, err := password.Hash("ValidPassword123")
 				require.NoError(t, err)
 
 				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).
 					Return(staff.Staff{
-						ID:           "fake-id",
-						Email:        "test@example.com",
-						RestaurantID: "fake-restaurant-id",
-						Password:     hashedPassword, // This should be a hashed password
-						CreatedAt:    now,
-						UpdatedAt:    now,
-						Active:       true,
+						ID:          "fake-id",
+						Email:       "test@example.com",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+						Password:    hashedPassword, // This should be a hashed password
+						CreatedAt:   now,
+						UpdatedAt:   now,
+						Active:      true,
 					}, nil)
+				lockoutService.EXPECT().RegisterFailure(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterFailureOutput{}, nil)
 			},
 			want:    staff.LoginStaffOutput{},
 			wantErr: authcore.ErrInvalidCredentials,
@@ -194,7 +497,13 @@ func TestService_LoginStaff(t *testing.T) {
 			mocksSetup: func(
 				repo *staffmocks.MockRepository,
 				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
 				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).
 					Return(staff.Staff{}, errRepo)
 			},
@@ -211,23 +520,35 @@ func TestService_LoginStaff(t *testing.T) {
 			mocksSetup: func(
 				repo *staffmocks.MockRepository,
 				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
 				hashedPassword, err := password.Hash("ValidPassword123")
 				require.NoError(t, err)
 
 				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).
 					Return(staff.Staff{
-						ID:           "fake-id",
-						Email:        "test@example.com",
-						RestaurantID: "fake-restaurant-id",
-						Password:     hashedPassword, // This should be a hashed password
-						CreatedAt:    now,
-						UpdatedAt:    now,
-						Active:       true,
+						ID:          "fake-id",
+						Email:       "test@example.com",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+						Password:    hashedPassword, // This should be a hashed password
+						CreatedAt:   now,
+						UpdatedAt:   now,
+						Active:      true,
 					}, nil)
 
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{Enabled: false}, nil)
+
 				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
 					Return(authcore.TokenPair{}, errToken)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
 			},
 			want:    staff.LoginStaffOutput{},
 			wantErr: errToken,
@@ -242,7 +563,14 @@ func TestService_LoginStaff(t *testing.T) {
 			mocksSetup: func(
 				repo *staffmocks.MockRepository,
 				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
 				hashedPassword, err := password.Hash("ValidPassword123")
 				require.NoError(t, err)
 
@@ -250,16 +578,19 @@ func TestService_LoginStaff(t *testing.T) {
 					Email:        "test@example.com",
 					RestaurantID: "fake-restaurant-id",
 				}).Return(staff.Staff{
-					ID:           "fake-staff-id",
-					StaffID:      "fake-id",
-					Email:        "test@example.com",
-					RestaurantID: "fake-restaurant-id",
-					Password:     hashedPassword, // This should be a hashed password
-					CreatedAt:    now,
-					UpdatedAt:    now,
-					Active:       true,
+					ID:          "fake-staff-id",
+					StaffID:     "fake-id",
+					Email:       "test@example.com",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					Password:    hashedPassword, // This should be a hashed password
+					CreatedAt:   now,
+					UpdatedAt:   now,
+					Active:      true,
 				}, nil)
 
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{Enabled: false}, nil)
+
 				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
 					Return(authcore.TokenPair{
 						AccessToken:  "fake-token",
@@ -267,76 +598,2099 @@ func TestService_LoginStaff(t *testing.T) {
 						ExpiresIn:    3600,
 						TokenType:    "Bearer",
 					}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
 			},
 			want: staff.LoginStaffOutput{
-				TokenPair: authcore.TokenPair{
-					AccessToken:  "fake-token",
-					ExpiresIn:    3600, // 1 hour
-					TokenType:    "Bearer",
-					RefreshToken: "fake-refresh-token",
+				TenantSession: staff.TenantSession{
+					TokenPair: authcore.TokenPair{
+						AccessToken:  "fake-token",
+						ExpiresIn:    3600, // 1 hour
+						TokenType:    "Bearer",
+						RefreshToken: "fake-refresh-token",
+					},
 				},
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
-			defer cleanup()
+		{
+			name: "when the staff is the restaurant owner, then it should return a token flagged as owner",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
 
-			got, err := service.LoginStaff(context.Background(), tt.input)
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
 
-			assert.ErrorIs(t, err, tt.wantErr)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).Return(staff.Staff{
+					ID:          "fake-staff-id",
+					StaffID:     "fake-id",
+					Email:       "test@example.com",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Owner: true, Active: true}},
+					Password:    hashedPassword,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+					Active:      true,
+				}, nil)
 
-func TestService_RefreshStaff(t *testing.T) {
-	logger, _ := log.NewTest()
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{Enabled: false}, nil)
 
-	tests := []staffServiceTestCase[staff.RefreshStaffInput, staff.RefreshStaffOutput]{
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), authcore.GenerateTokenPairInput{
+					UserID:      "fake-id",
+					Expiration:  staff.DefaultTokenExpiration,
+					Role:        staff.DefaultTokenRole,
+					TenantID:    "fake-restaurant-id",
+					Owner:       true,
+					Permissions: ownerPermissions,
+				}).Return(authcore.TokenPair{
+					AccessToken:  "fake-token",
+					RefreshToken: "fake-refresh-token",
+					ExpiresIn:    3600,
+					TokenType:    "Bearer",
+				}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+			},
+			want: staff.LoginStaffOutput{
+				TenantSession: staff.TenantSession{
+					TokenPair: authcore.TokenPair{
+						AccessToken:  "fake-token",
+						ExpiresIn:    3600, // 1 hour
+						TokenType:    "Bearer",
+						RefreshToken: "fake-refresh-token",
+					},
+				},
+			},
+		},
 		{
-			name: "when there is an error refreshing the token, then it should propagate the error",
-			input: staff.RefreshStaffInput{
-				RefreshToken: "InvalidRefreshToken",
-				AccessToken:  "ValidAccessToken",
+			name: "when there is an error checking the MFA status, then it should propagate the error",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
 			},
 			mocksSetup: func(
-				_ *staffmocks.MockRepository,
-				authCoreService *authcoremocks.MockService,
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
-				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
-					Return(authcore.TokenPair{}, errToken)
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).Return(staff.Staff{
+					StaffID:     "fake-id",
+					Email:       "test@example.com",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					Password:    hashedPassword,
+					Active:      true,
+				}, nil)
+
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{}, errRepo)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
 			},
-			want:    staff.RefreshStaffOutput{},
-			wantErr: errToken,
+			want:    staff.LoginStaffOutput{},
+			wantErr: errRepo,
 		},
 		{
-			name: "when the new access token is generated correctly, then it should return the new token",
-			input: staff.RefreshStaffInput{
-				RefreshToken: "ValidRefreshToken",
-				AccessToken:  "ValidAccessToken",
+			name: "when the staff has MFA enabled and the challenge can't be created, " +
+				"then it should propagate the error",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
 			},
 			mocksSetup: func(
-				_ *staffmocks.MockRepository,
-				authCoreService *authcoremocks.MockService,
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
 			) {
-				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
-					Return(authcore.TokenPair{
-						AccessToken:  "fake-token",
-						RefreshToken: "fake-refresh-token",
-						ExpiresIn:    3600,
-						TokenType:    "Bearer",
-					}, nil)
-			},
-			want: staff.RefreshStaffOutput{
-				TokenPair: authcore.TokenPair{
-					AccessToken:  "fake-token",
-					RefreshToken: "fake-refresh-token",
-					ExpiresIn:    3600,
-					TokenType:    "Bearer",
-				},
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).Return(staff.Staff{
+					StaffID:     "fake-id",
+					Email:       "test@example.com",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					Password:    hashedPassword,
+					Active:      true,
+				}, nil)
+
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{Enabled: true}, nil)
+				mfaService.EXPECT().CreateChallenge(gomock.Any(), gomock.Any()).
+					Return(mfa.CreateChallengeOutput{}, errRepo)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+			},
+			want:    staff.LoginStaffOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when the staff has MFA enabled, then it should return an MFA challenge instead of the token pair",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).Return(staff.Staff{
+					StaffID:     "fake-id",
+					Email:       "test@example.com",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					Password:    hashedPassword,
+					Active:      true,
+				}, nil)
+
+				mfaService.EXPECT().IsEnabled(gomock.Any(), mfa.IsEnabledInput{
+					UserID: "fake-id",
+					Role:   staff.DefaultTokenRole,
+				}).Return(mfa.IsEnabledOutput{Enabled: true}, nil)
+				mfaService.EXPECT().CreateChallenge(gomock.Any(), mfa.CreateChallengeInput{
+					UserID:   "fake-id",
+					Role:     staff.DefaultTokenRole,
+					TenantID: "fake-restaurant-id",
+				}).Return(mfa.CreateChallengeOutput{
+					Token:     "fake-challenge-token",
+					ExpiresAt: now.Add(mfa.DefaultChallengeExpiration),
+				}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+			},
+			want: staff.LoginStaffOutput{
+				TenantSession: staff.TenantSession{
+					MFARequired:           true,
+					MFAChallengeToken:     "fake-challenge-token",
+					MFAChallengeExpiresAt: now.Add(mfa.DefaultChallengeExpiration),
+				},
+			},
+		},
+		{
+			name: "when the stored password was hashed with weaker parameters, " +
+				"then it should rehash it and return the token",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.HashWithParams("ValidPassword123", weakParams)
+				require.NoError(t, err)
+
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).Return(staff.Staff{
+					StaffID:     "fake-id",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					Password:    hashedPassword,
+					Active:      true,
+				}, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params staff.UpdatePasswordParams) error {
+						assert.Equal(t, "fake-id", params.StaffID)
+						assert.Empty(t, params.RestaurantID)
+						assert.False(t, password.NeedsRehash(params.Password), "The password should be rehashed")
+						assert.True(t, password.Verify(params.Password, "ValidPassword123"))
+						return nil
+					})
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{Enabled: false}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: staff.LoginStaffOutput{
+				TenantSession: staff.TenantSession{
+					TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+				},
+			},
+		},
+		{
+			name: "when there is an error upgrading the password hash, " +
+				"then it should still return the token",
+			input: staff.LoginStaffInput{
+				Email:        "test@example.com",
+				RestaurantID: "fake-restaurant-id",
+				Password:     "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.HashWithParams("ValidPassword123", weakParams)
+				require.NoError(t, err)
+
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).Return(staff.Staff{
+					StaffID:     "fake-id",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					Password:    hashedPassword,
+					Active:      true,
+				}, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params staff.UpdatePasswordParams) error {
+						assert.Equal(t, "fake-id", params.StaffID)
+						assert.Empty(t, params.RestaurantID)
+						assert.False(t, password.NeedsRehash(params.Password), "The password should be rehashed")
+						assert.True(t, password.Verify(params.Password, "ValidPassword123"))
+						return errRepo
+					})
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), gomock.Any()).
+					Return(lockout.RegisterSuccessOutput{}, nil)
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{Enabled: false}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: staff.LoginStaffOutput{
+				TenantSession: staff.TenantSession{
+					TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+				},
+			},
+		},
+		{
+			name: "when the restaurant is not provided, " +
+				"then it should return the restaurants the staff can log in to instead of the token pair",
+			input: staff.LoginStaffInput{
+				Email:    "test@example.com",
+				Password: "ValidPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				lockoutService *lockoutmocks.MockService,
+				tenantSelectionService *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				lockoutService.EXPECT().Check(gomock.Any(), lockout.CheckInput{
+					Role:       staff.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.CheckOutput{}, nil)
+
+				hashedPassword, err := password.Hash("ValidPassword123")
+				require.NoError(t, err)
+
+				tenantSelectionService.EXPECT().Issue(gomock.Any(), tenantselection.IssueInput{
+					UserID: "fake-id",
+					Role:   staff.DefaultTokenRole,
+				}).Return(tenantselection.IssueOutput{Token: "fake-selection-token", ExpiresAt: now}, nil)
+				repo.EXPECT().FindStaff(gomock.Any(), staff.FindStaffParams{Email: "test@example.com"}).
+					Return(staff.Staff{
+						StaffID: "fake-id",
+						Email:   "test@example.com",
+						Restaurants: []staff.Membership{
+							{RestaurantID: "fake-restaurant-id", Owner: true, Active: true},
+							{RestaurantID: "fake-inactive-restaurant-id", Active: false},
+							{RestaurantID: "fake-other-restaurant-id", Active: true},
+						},
+						Password: hashedPassword,
+						Active:   true,
+					}, nil)
+				lockoutService.EXPECT().RegisterSuccess(gomock.Any(), lockout.RegisterSuccessInput{
+					Role:       staff.DefaultTokenRole,
+					Identifier: "test@example.com",
+				}).Return(lockout.RegisterSuccessOutput{}, nil)
+			},
+			want: staff.LoginStaffOutput{
+				RestaurantSelectionRequired: true,
+				Restaurants: []staff.Membership{
+					{RestaurantID: "fake-restaurant-id", Owner: true, Active: true},
+					{RestaurantID: "fake-other-restaurant-id", Active: true},
+				},
+				SelectionToken:          "fake-selection-token",
+				SelectionTokenExpiresAt: now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.LoginStaff(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_SelectStaffRestaurant(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	input := staff.SelectStaffRestaurantInput{
+		SelectionToken: "fake-selection-token",
+		RestaurantID:   "fake-other-restaurant-id",
+	}
+	owner := tenantselection.ConsumeOutput{UserID: "fake-staff-id", Role: staff.DefaultTokenRole}
+	linkedStaff := staff.Staff{
+		StaffID: "fake-staff-id",
+		Email:   "test@example.com",
+		Restaurants: []staff.Membership{
+			{RestaurantID: "fake-restaurant-id", Active: true},
+			{RestaurantID: "fake-other-restaurant-id", Role: staff.RoleChef, Active: true},
+		},
+		Active: true,
+	}
+
+	tests := []staffServiceTestCase[staff.SelectStaffRestaurantInput, staff.SelectStaffRestaurantOutput]{
+		{
+			name:  "when the selection token is not valid, then it should return an invalid selection token error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				tenantSelectionService *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				tenantSelectionService.EXPECT().Consume(gomock.Any(), tenantselection.ConsumeInput{
+					Token: "fake-selection-token",
+					Role:  staff.DefaultTokenRole,
+				}).Return(tenantselection.ConsumeOutput{}, tenantselection.ErrSelectionTokenNotFound)
+			},
+			want:    staff.SelectStaffRestaurantOutput{},
+			wantErr: staff.ErrInvalidSelectionToken,
+		},
+		{
+			name: "when there is an unexpected error consuming the selection token, " +
+				"then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				tenantSelectionService *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				tenantSelectionService.EXPECT().Consume(gomock.Any(), gomock.Any()).
+					Return(tenantselection.ConsumeOutput{}, errRepo)
+			},
+			want:    staff.SelectStaffRestaurantOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when the staff is not active in the restaurant, " +
+				"then it should return a restaurant not linked error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				tenantSelectionService *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				tenantSelectionService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().FindByStaffID(gomock.Any(), staff.FindByStaffIDParams{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-other-restaurant-id",
+				}).Return(staff.Staff{}, staff.ErrStaffNotFound)
+			},
+			want:    staff.SelectStaffRestaurantOutput{},
+			wantErr: staff.ErrRestaurantNotLinked,
+		},
+		{
+			name: "when the staff has MFA enabled, " +
+				"then it should return an MFA challenge for the restaurant instead of the token pair",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				tenantSelectionService *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				tenantSelectionService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(linkedStaff, nil)
+				mfaService.EXPECT().IsEnabled(gomock.Any(), mfa.IsEnabledInput{
+					UserID: "fake-staff-id",
+					Role:   staff.DefaultTokenRole,
+				}).Return(mfa.IsEnabledOutput{Enabled: true}, nil)
+				mfaService.EXPECT().CreateChallenge(gomock.Any(), mfa.CreateChallengeInput{
+					UserID:   "fake-staff-id",
+					Role:     staff.DefaultTokenRole,
+					TenantID: "fake-other-restaurant-id",
+				}).Return(mfa.CreateChallengeOutput{
+					Token:     "fake-challenge-token",
+					ExpiresAt: now.Add(mfa.DefaultChallengeExpiration),
+				}, nil)
+			},
+			want: staff.SelectStaffRestaurantOutput{
+				TenantSession: staff.TenantSession{
+					MFARequired:           true,
+					MFAChallengeToken:     "fake-challenge-token",
+					MFAChallengeExpiresAt: now.Add(mfa.DefaultChallengeExpiration),
+				},
+			},
+		},
+		{
+			name: "when the staff is active in the restaurant, " +
+				"then it should return a token pair scoped to it with the permissions of its role",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				tenantSelectionService *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				tenantSelectionService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(linkedStaff, nil)
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{Enabled: false}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), authcore.GenerateTokenPairInput{
+					UserID:     "fake-staff-id",
+					Expiration: staff.DefaultTokenExpiration,
+					Role:       staff.DefaultTokenRole,
+					TenantID:   "fake-other-restaurant-id",
+					Permissions: []string{
+						auth.PermissionMenuRead,
+						auth.PermissionMenuWrite,
+						auth.PermissionOrdersRead,
+						auth.PermissionOrdersWrite,
+					},
+				}).Return(authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil)
+			},
+			want: staff.SelectStaffRestaurantOutput{
+				TenantSession: staff.TenantSession{
+					TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.SelectStaffRestaurant(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RefreshStaff(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []staffServiceTestCase[staff.RefreshStaffInput, staff.RefreshStaffOutput]{
+		{
+			name: "when there is an error refreshing the token, then it should propagate the error",
+			input: staff.RefreshStaffInput{
+				RefreshToken: "InvalidRefreshToken",
+				AccessToken:  "ValidAccessToken",
+			},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{}, errToken)
+			},
+			want:    staff.RefreshStaffOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the new access token is generated correctly, then it should return the new token",
+			input: staff.RefreshStaffInput{
+				RefreshToken: "ValidRefreshToken",
+				AccessToken:  "ValidAccessToken",
+			},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{
+						AccessToken:  "fake-token",
+						RefreshToken: "fake-refresh-token",
+						ExpiresIn:    3600,
+						TokenType:    "Bearer",
+					}, nil)
+			},
+			want: staff.RefreshStaffOutput{
+				TokenPair: authcore.TokenPair{
+					AccessToken:  "fake-token",
+					RefreshToken: "fake-refresh-token",
+					ExpiresIn:    3600,
+					TokenType:    "Bearer",
+				},
+			},
+		},
+		{
+			name: "when the staff is not active in the restaurant anymore, " +
+				"then it should return an invalid refresh token error",
+			input: staff.RefreshStaffInput{
+				RefreshToken: "ValidRefreshToken",
+				AccessToken:  "ValidAccessToken",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByStaffID(gomock.Any(), staff.FindByStaffIDParams{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-restaurant-id",
+				}).Return(staff.Staff{}, staff.ErrStaffNotFound)
+				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input authcore.RefreshTokenInput) (authcore.TokenPair, error) {
+						_, err := input.AuthorizeTenant(ctx, "fake-staff-id", "fake-restaurant-id")
+						return authcore.TokenPair{}, err
+					})
+			},
+			want:    staff.RefreshStaffOutput{},
+			wantErr: authcore.ErrInvalidRefreshToken,
+		},
+		{
+			name: "when the role of the staff in the restaurant changed, " +
+				"then it should refresh the token with the permissions of the current role",
+			input: staff.RefreshStaffInput{
+				RefreshToken: "ValidRefreshToken",
+				AccessToken:  "ValidAccessToken",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staff.Staff{
+					StaffID: "fake-staff-id",
+					Restaurants: []staff.Membership{
+						{RestaurantID: "fake-restaurant-id", Role: staff.RoleCashier, Active: true},
+					},
+					Active: true,
+				}, nil)
+				authCoreService.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input authcore.RefreshTokenInput) (authcore.TokenPair, error) {
+						assert.Equal(t, staff.DefaultTokenRole, input.Role)
+						access, err := input.AuthorizeTenant(ctx, "fake-staff-id", "fake-restaurant-id")
+						require.NoError(t, err)
+						assert.Equal(t, authcore.TenantAccess{
+							Owner: false,
+							Permissions: []string{
+								auth.PermissionMenuRead,
+								auth.PermissionOrdersRead,
+								auth.PermissionOrdersWrite,
+							},
+						}, access)
+						return authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"}, nil
+					})
+			},
+			want: staff.RefreshStaffOutput{
+				TokenPair: authcore.TokenPair{AccessToken: "fake-token", RefreshToken: "fake-refresh-token"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.RefreshStaff(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_LogoutStaff(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []staffServiceTestCase[staff.LogoutStaffInput, staff.LogoutStaffOutput]{
+		{
+			name: "when there is an error logging out, then it should propagate the error",
+			input: staff.LogoutStaffInput{
+				RefreshToken: "InvalidRefreshToken",
+			},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				authCoreService.EXPECT().Logout(gomock.Any(), gomock.Any()).
+					Return(authcore.LogoutOutput{}, authcore.ErrInvalidRefreshToken)
+			},
+			want:    staff.LogoutStaffOutput{},
+			wantErr: authcore.ErrInvalidRefreshToken,
+		},
+		{
+			name: "when all the sessions are revoked while impersonating, " +
+				"then it should return an impersonation not allowed error",
+			input: staff.LogoutStaffInput{
+				RefreshToken: "ValidRefreshToken",
+				AllSessions:  true,
+			},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(auth.ErrImpersonationNotAllowed)
+			},
+			want:    staff.LogoutStaffOutput{},
+			wantErr: auth.ErrImpersonationNotAllowed,
+		},
+		{
+			name: "when the staff is logged out, then it should return the number of revoked tokens",
+			input: staff.LogoutStaffInput{
+				RefreshToken: "ValidRefreshToken",
+				AllSessions:  true,
+			},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authCoreService.EXPECT().Logout(gomock.Any(), authcore.LogoutInput{
+					RefreshToken: "ValidRefreshToken",
+					Role:         staff.DefaultTokenRole,
+					AllSessions:  true,
+				}).Return(authcore.LogoutOutput{RevokedTokens: 2}, nil)
+			},
+			want: staff.LogoutStaffOutput{RevokedTokens: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.LogoutStaff(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_RequestStaffPasswordReset(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := staff.RequestStaffPasswordResetInput{
+		Email:        "test@example.com",
+		RestaurantID: "fake-restaurant-id",
+	}
+	foundStaff := staff.Staff{
+		StaffID:     "fake-staff-id",
+		Email:       "test@example.com",
+		Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+	}
+
+	tests := []staffServiceTestCase[staff.RequestStaffPasswordResetInput, staff.RequestStaffPasswordResetOutput]{
+		{
+			name:  "when the staff is not found, then it should not return any error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindStaff(gomock.Any(), staff.FindStaffParams{
+					Email:        "test@example.com",
+					RestaurantID: "fake-restaurant-id",
+				}).Return(staff.Staff{}, staff.ErrStaffNotFound)
+			},
+			want:    staff.RequestStaffPasswordResetOutput{},
+			wantErr: nil,
+		},
+		{
+			name:  "when there is an unexpected error finding the staff, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).Return(staff.Staff{}, errRepo)
+			},
+			want:    staff.RequestStaffPasswordResetOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error requesting the password reset, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).Return(foundStaff, nil)
+				passwordResetService.EXPECT().RequestReset(gomock.Any(), gomock.Any()).
+					Return(passwordreset.RequestResetOutput{}, errToken)
+			},
+			want:    staff.RequestStaffPasswordResetOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the password reset is requested, then it should not return any error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindStaff(gomock.Any(), gomock.Any()).Return(foundStaff, nil)
+				passwordResetService.EXPECT().RequestReset(gomock.Any(), passwordreset.RequestResetInput{
+					UserID:   "fake-staff-id",
+					Role:     staff.DefaultTokenRole,
+					TenantID: "fake-restaurant-id",
+					Email:    "test@example.com",
+				}).Return(passwordreset.RequestResetOutput{}, nil)
+			},
+			want:    staff.RequestStaffPasswordResetOutput{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.RequestStaffPasswordReset(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_ResetStaffPassword(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := staff.ResetStaffPasswordInput{
+		Token:    "fake-reset-token",
+		Password: "NewPassword123",
+	}
+	owner := passwordreset.ConsumeOutput{
+		UserID:   "fake-staff-id",
+		Role:     staff.DefaultTokenRole,
+		TenantID: "fake-restaurant-id",
+	}
+
+	tests := []staffServiceTestCase[staff.ResetStaffPasswordInput, staff.ResetStaffPasswordOutput]{
+		{
+			name: "when the new password does not meet the policy, " +
+				"then it should return a policy violation error without consuming the reset token",
+			input: staff.ResetStaffPasswordInput{
+				Token:    "fake-reset-token",
+				Password: "newpassword",
+			},
+			want:    staff.ResetStaffPasswordOutput{},
+			wantErr: password.ErrPolicyViolation,
+		},
+		{
+			name:  "when the reset token is not valid, then it should return an invalid reset token error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), passwordreset.ConsumeInput{
+					Token: "fake-reset-token",
+					Role:  staff.DefaultTokenRole,
+				}).Return(passwordreset.ConsumeOutput{}, passwordreset.ErrResetTokenNotFound)
+			},
+			want:    staff.ResetStaffPasswordOutput{},
+			wantErr: authcore.ErrInvalidResetToken,
+		},
+		{
+			name:  "when there is an unexpected error consuming the reset token, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).
+					Return(passwordreset.ConsumeOutput{}, errToken)
+			},
+			want:    staff.ResetStaffPasswordOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the staff no longer exists, then it should return an invalid reset token error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(staff.ErrStaffNotFound)
+			},
+			want:    staff.ResetStaffPasswordOutput{},
+			wantErr: authcore.ErrInvalidResetToken,
+		},
+		{
+			name:  "when there is an unexpected error updating the password, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    staff.ResetStaffPasswordOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error revoking the sessions, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), gomock.Any()).
+					Return(authcore.RevokeSessionsOutput{}, errToken)
+			},
+			want:    staff.ResetStaffPasswordOutput{},
+			wantErr: errToken,
+		},
+		{
+			name:  "when the password is reset, then it should revoke all the staff sessions in all its restaurants",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				passwordResetService *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				passwordResetService.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(owner, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params staff.UpdatePasswordParams) error {
+						require.Equal(t, "fake-staff-id", params.StaffID)
+						require.Equal(t, "fake-restaurant-id", params.RestaurantID)
+						require.True(t, password.Verify(params.Password, "NewPassword123"))
+						return nil
+					})
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), authcore.RevokeSessionsInput{
+					UserID:     "fake-staff-id",
+					Role:       staff.DefaultTokenRole,
+					AllTenants: true,
+				}).Return(authcore.RevokeSessionsOutput{RevokedTokens: 2}, nil)
+			},
+			want:    staff.ResetStaffPasswordOutput{RevokedTokens: 2},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+				defer cleanup()
+
+				got, err := service.ResetStaffPassword(context.Background(), tt.input)
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestService_ChangeStaffPassword(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	hashedPassword, err := password.Hash("CurrentPassword123")
+	require.NoError(t, err)
+	staffUser := staff.Staff{
+		StaffID:     "fake-staff-id",
+		Email:       "test@example.com",
+		Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+		Password:    hashedPassword,
+		Active:      true,
+	}
+	input := staff.ChangeStaffPasswordInput{
+		CurrentPassword: "CurrentPassword123",
+		NewPassword:     "NewPassword123",
+	}
+
+	tests := []staffServiceTestCase[staff.ChangeStaffPasswordInput, staff.ChangeStaffPasswordOutput]{
+		{
+			name:  "when the staff is impersonated, then it should return an impersonation not allowed error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(auth.ErrImpersonationNotAllowed)
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: auth.ErrImpersonationNotAllowed,
+		},
+		{
+			name: "when the new password does not meet the policy, then it should return a policy violation error",
+			input: staff.ChangeStaffPasswordInput{
+				CurrentPassword: "CurrentPassword123",
+				NewPassword:     "Test@Example.com",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staffUser, nil)
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: password.ErrPolicyViolation,
+		},
+		{
+			name:  "when there is no authentication context, then it should return an invalid token error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("", false)
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when there is no tenant in the authentication context, then it should return an invalid token error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-staff-id", true)
+				authctx.EXPECT().GetTenant(gomock.Any()).Return("", false)
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when the staff user no longer exists, then it should return an invalid token error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), staff.FindByStaffIDParams{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-restaurant-id",
+				}).
+					Return(staff.Staff{}, staff.ErrStaffNotFound)
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when there is an unexpected error finding the staff user, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staff.Staff{}, errRepo)
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when the current password is not valid, then it should return an invalid current password error",
+			input: staff.ChangeStaffPasswordInput{
+				CurrentPassword: "WrongPassword123",
+				NewPassword:     "NewPassword123",
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staffUser, nil)
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: authcore.ErrInvalidCurrentPassword,
+		},
+		{
+			name:  "when there is an unexpected error updating the password, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staffUser, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the password is changed without revoking the other sessions, then it should keep them",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staffUser, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params staff.UpdatePasswordParams) error {
+						require.Equal(t, "fake-staff-id", params.StaffID)
+						require.Equal(t, "fake-restaurant-id", params.RestaurantID)
+						require.True(t, password.Verify(params.Password, "NewPassword123"))
+						return nil
+					})
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: nil,
+		},
+		{
+			name: "when there is an error revoking the other sessions, then it should propagate the error",
+			input: staff.ChangeStaffPasswordInput{
+				CurrentPassword:     "CurrentPassword123",
+				NewPassword:         "NewPassword123",
+				RevokeOtherSessions: true,
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staffUser, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), gomock.Any()).
+					Return(authcore.RevokeSessionsOutput{}, errToken)
+			},
+			want:    staff.ChangeStaffPasswordOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the password is changed revoking the other sessions, " +
+				"then it should keep only the current session across all the restaurants",
+			input: staff.ChangeStaffPasswordInput{
+				CurrentPassword:     "CurrentPassword123",
+				NewPassword:         "NewPassword123",
+				RevokeOtherSessions: true,
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staffUser, nil)
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), authcore.RevokeSessionsInput{
+					UserID:             "fake-staff-id",
+					Role:               staff.DefaultTokenRole,
+					AllTenants:         true,
+					KeepCurrentSession: true,
+				}).Return(authcore.RevokeSessionsOutput{RevokedTokens: 2}, nil)
+			},
+			want:    staff.ChangeStaffPasswordOutput{RevokedTokens: 2},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.ChangeStaffPassword(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_VerifyStaffMFA(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []staffServiceTestCase[staff.VerifyStaffMFAInput, staff.VerifyStaffMFAOutput]{
+		{
+			name:  "when the MFA challenge is not found, then it should return an invalid MFA challenge error",
+			input: staff.VerifyStaffMFAInput{ChallengeToken: "fake-challenge-token", Code: "123456"},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				mfaService.EXPECT().VerifyChallenge(gomock.Any(), gomock.Any()).
+					Return(mfa.VerifyChallengeOutput{}, mfa.ErrChallengeNotFound)
+			},
+			want:    staff.VerifyStaffMFAOutput{},
+			wantErr: authcore.ErrInvalidMFAChallenge,
+		},
+		{
+			name:  "when the MFA code is invalid, then it should return an invalid MFA code error",
+			input: staff.VerifyStaffMFAInput{ChallengeToken: "fake-challenge-token", Code: "123456"},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				mfaService.EXPECT().VerifyChallenge(gomock.Any(), gomock.Any()).
+					Return(mfa.VerifyChallengeOutput{}, mfa.ErrInvalidCode)
+			},
+			want:    staff.VerifyStaffMFAOutput{},
+			wantErr: authcore.ErrInvalidMFACode,
+		},
+		{
+			name:  "when there is an unexpected error verifying the challenge, then it should propagate the error",
+			input: staff.VerifyStaffMFAInput{ChallengeToken: "fake-challenge-token", Code: "123456"},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				mfaService.EXPECT().VerifyChallenge(gomock.Any(), gomock.Any()).
+					Return(mfa.VerifyChallengeOutput{}, errRepo)
+			},
+			want:    staff.VerifyStaffMFAOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when the staff of the challenge no longer exists, " +
+				"then it should return an invalid MFA challenge error",
+			input: staff.VerifyStaffMFAInput{ChallengeToken: "fake-challenge-token", Code: "123456"},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				mfaService.EXPECT().VerifyChallenge(gomock.Any(), gomock.Any()).
+					Return(mfa.VerifyChallengeOutput{
+						UserID:   "fake-staff-id",
+						Role:     staff.DefaultTokenRole,
+						TenantID: "fake-restaurant-id",
+					}, nil)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{}, staff.ErrStaffNotFound)
+			},
+			want:    staff.VerifyStaffMFAOutput{},
+			wantErr: authcore.ErrInvalidMFAChallenge,
+		},
+		{
+			name:  "when there is an error generating the token pair, then it should propagate the error",
+			input: staff.VerifyStaffMFAInput{ChallengeToken: "fake-challenge-token", Code: "123456"},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				mfaService.EXPECT().VerifyChallenge(gomock.Any(), gomock.Any()).
+					Return(mfa.VerifyChallengeOutput{
+						UserID:   "fake-staff-id",
+						Role:     staff.DefaultTokenRole,
+						TenantID: "fake-restaurant-id",
+					}, nil)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{
+						StaffID:     "fake-staff-id",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{}, errToken)
+			},
+			want:    staff.VerifyStaffMFAOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the MFA challenge is completed with a valid code, " +
+				"then it should return the token pair of the staff",
+			input: staff.VerifyStaffMFAInput{ChallengeToken: "fake-challenge-token", RecoveryCode: "abcde-fghij"},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				mfaService.EXPECT().VerifyChallenge(gomock.Any(), mfa.VerifyChallengeInput{
+					Token:        "fake-challenge-token",
+					Role:         staff.DefaultTokenRole,
+					RecoveryCode: "abcde-fghij",
+				}).Return(mfa.VerifyChallengeOutput{
+					UserID:   "fake-staff-id",
+					Role:     staff.DefaultTokenRole,
+					TenantID: "fake-restaurant-id",
+				}, nil)
+				repo.EXPECT().FindByStaffID(gomock.Any(), staff.FindByStaffIDParams{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-restaurant-id",
+				}).Return(staff.Staff{
+					StaffID:     "fake-staff-id",
+					Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Owner: true, Active: true}},
+				}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), authcore.GenerateTokenPairInput{
+					UserID:      "fake-staff-id",
+					Expiration:  staff.DefaultTokenExpiration,
+					Role:        staff.DefaultTokenRole,
+					TenantID:    "fake-restaurant-id",
+					Owner:       true,
+					Permissions: ownerPermissions,
+				}).Return(authcore.TokenPair{
+					AccessToken:  "fake-token",
+					RefreshToken: "fake-refresh-token",
+					ExpiresIn:    3600,
+					TokenType:    "Bearer",
+				}, nil)
+			},
+			want: staff.VerifyStaffMFAOutput{
+				TokenPair: authcore.TokenPair{
+					AccessToken:  "fake-token",
+					RefreshToken: "fake-refresh-token",
+					ExpiresIn:    3600,
+					TokenType:    "Bearer",
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.VerifyStaffMFA(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_EnrollStaffMFA(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []staffServiceTestCase[staff.EnrollStaffMFAInput, staff.EnrollStaffMFAOutput]{
+		{
+			name: "when the staff is impersonated, " +
+				"then it should return an impersonation not allowed error",
+			input: staff.EnrollStaffMFAInput{},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(auth.ErrImpersonationNotAllowed)
+			},
+			want:    staff.EnrollStaffMFAOutput{},
+			wantErr: auth.ErrImpersonationNotAllowed,
+		},
+		{
+			name:  "when there is no authenticated staff, then it should return an invalid token error",
+			input: staff.EnrollStaffMFAInput{},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("", false)
+			},
+			want:    staff.EnrollStaffMFAOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when the authenticated staff is not found, then it should return an invalid token error",
+			input: staff.EnrollStaffMFAInput{},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{}, staff.ErrStaffNotFound)
+			},
+			want:    staff.EnrollStaffMFAOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when the staff already has MFA enabled, then it should return an MFA already enabled error",
+			input: staff.EnrollStaffMFAInput{},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{
+						StaffID:     "fake-staff-id",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					}, nil)
+				mfaService.EXPECT().Enroll(gomock.Any(), gomock.Any()).
+					Return(mfa.EnrollOutput{}, mfa.ErrAlreadyEnabled)
+			},
+			want:    staff.EnrollStaffMFAOutput{},
+			wantErr: authcore.ErrMFAAlreadyEnabled,
+		},
+		{
+			name:  "when the MFA enrollment can be started, then it should return the secret and provisioning URI",
+			input: staff.EnrollStaffMFAInput{},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{
+						StaffID:     "fake-staff-id",
+						Email:       "test@example.com",
+						Restaurants: []staff.Membership{{RestaurantID: "fake-restaurant-id", Active: true}},
+					}, nil)
+				mfaService.EXPECT().Enroll(gomock.Any(), mfa.EnrollInput{
+					UserID:      "fake-staff-id",
+					Role:        staff.DefaultTokenRole,
+					AccountName: "test@example.com",
+				}).Return(mfa.EnrollOutput{
+					Secret:          "fake-secret",
+					ProvisioningURI: "otpauth://totp/fake",
+				}, nil)
+			},
+			want: staff.EnrollStaffMFAOutput{
+				Secret:          "fake-secret",
+				ProvisioningURI: "otpauth://totp/fake",
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.EnrollStaffMFA(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ConfirmStaffMFA(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []staffServiceTestCase[staff.ConfirmStaffMFAInput, staff.ConfirmStaffMFAOutput]{
+		{
+			name: "when the staff is impersonated, " +
+				"then it should return an impersonation not allowed error",
+			input: staff.ConfirmStaffMFAInput{Code: "123456"},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(auth.ErrImpersonationNotAllowed)
+			},
+			want:    staff.ConfirmStaffMFAOutput{},
+			wantErr: auth.ErrImpersonationNotAllowed,
+		},
+		{
+			name:  "when there is no authenticated staff, then it should return an invalid token error",
+			input: staff.ConfirmStaffMFAInput{Code: "123456"},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("", false)
+			},
+			want:    staff.ConfirmStaffMFAOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name: "when there is no pending MFA enrollment, " +
+				"then it should return an MFA enrollment not found error",
+			input: staff.ConfirmStaffMFAInput{Code: "123456"},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				mfaService.EXPECT().ConfirmEnrollment(gomock.Any(), gomock.Any()).
+					Return(mfa.ConfirmEnrollmentOutput{}, mfa.ErrEnrollmentNotFound)
+			},
+			want:    staff.ConfirmStaffMFAOutput{},
+			wantErr: authcore.ErrMFAEnrollmentNotFound,
+		},
+		{
+			name:  "when the MFA is already enabled, then it should return an MFA already enabled error",
+			input: staff.ConfirmStaffMFAInput{Code: "123456"},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				mfaService.EXPECT().ConfirmEnrollment(gomock.Any(), gomock.Any()).
+					Return(mfa.ConfirmEnrollmentOutput{}, mfa.ErrAlreadyEnabled)
+			},
+			want:    staff.ConfirmStaffMFAOutput{},
+			wantErr: authcore.ErrMFAAlreadyEnabled,
+		},
+		{
+			name:  "when the code is invalid, then it should return an invalid MFA code error",
+			input: staff.ConfirmStaffMFAInput{Code: "123456"},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				mfaService.EXPECT().ConfirmEnrollment(gomock.Any(), gomock.Any()).
+					Return(mfa.ConfirmEnrollmentOutput{}, mfa.ErrInvalidCode)
+			},
+			want:    staff.ConfirmStaffMFAOutput{},
+			wantErr: authcore.ErrInvalidMFACode,
+		},
+		{
+			name:  "when the code is valid, then it should return the recovery codes",
+			input: staff.ConfirmStaffMFAInput{Code: "123456"},
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				mfaService.EXPECT().ConfirmEnrollment(gomock.Any(), mfa.ConfirmEnrollmentInput{
+					UserID: "fake-staff-id",
+					Role:   staff.DefaultTokenRole,
+					Code:   "123456",
+				}).Return(mfa.ConfirmEnrollmentOutput{RecoveryCodes: []string{"abcde-fghij"}}, nil)
+			},
+			want:    staff.ConfirmStaffMFAOutput{RecoveryCodes: []string{"abcde-fghij"}},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.ConfirmStaffMFA(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func mockAuthContext(authctx *authmocks.MockContextReader) {
+	authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-staff-id", true)
+	authctx.EXPECT().GetTenant(gomock.Any()).Return("fake-restaurant-id", true)
+}
+
+func TestService_SetStaffActive(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	tests := []staffServiceTestCase[staff.SetStaffActiveInput, staff.SetStaffActiveOutput]{
+		{
+			name: "when the staff does not exist, then it should return a staff not found error",
+			input: staff.SetStaffActiveInput{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Active:       false,
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateActive(gomock.Any(), gomock.Any()).Return(staff.ErrStaffNotFound)
+			},
+			want:    staff.SetStaffActiveOutput{},
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when there is an error revoking the staff sessions, then it should propagate the error",
+			input: staff.SetStaffActiveInput{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Active:       false,
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateActive(gomock.Any(), gomock.Any()).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), gomock.Any()).
+					Return(authcore.RevokeSessionsOutput{}, errToken)
+			},
+			want:    staff.SetStaffActiveOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the staff is deactivated, " +
+				"then it should revoke its sessions and return the number of revoked tokens",
+			input: staff.SetStaffActiveInput{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Active:       false,
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateActive(gomock.Any(), staff.UpdateActiveParams{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-restaurant-id",
+					Active:       false,
+				}).Return(nil)
+				authCoreService.EXPECT().RevokeSessions(gomock.Any(), authcore.RevokeSessionsInput{
+					UserID:   "fake-staff-id",
+					Role:     staff.DefaultTokenRole,
+					TenantID: "fake-restaurant-id",
+				}).Return(authcore.RevokeSessionsOutput{RevokedTokens: 2}, nil)
+			},
+			want: staff.SetStaffActiveOutput{RevokedTokens: 2},
+		},
+		{
+			name: "when the staff is reactivated, then it should not revoke any session",
+			input: staff.SetStaffActiveInput{
+				StaffID:      "fake-staff-id",
+				RestaurantID: "fake-restaurant-id",
+				Active:       true,
+			},
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().UpdateActive(gomock.Any(), staff.UpdateActiveParams{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-restaurant-id",
+					Active:       true,
+				}).Return(nil)
+			},
+			want: staff.SetStaffActiveOutput{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.SetStaffActive(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_SwitchStaffTenant(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	input := staff.SwitchStaffTenantInput{RestaurantID: "fake-other-restaurant-id"}
+	linkedStaff := staff.Staff{
+		StaffID: "fake-staff-id",
+		Email:   "test@example.com",
+		Restaurants: []staff.Membership{
+			{RestaurantID: "fake-restaurant-id", Active: true},
+			{RestaurantID: "fake-other-restaurant-id", Role: staff.RoleChef, Active: true},
+		},
+		Active: true,
+	}
+
+	tests := []staffServiceTestCase[staff.SwitchStaffTenantInput, staff.SwitchStaffTenantOutput]{
+		{
+			name:  "when the staff is impersonated, then it should return an impersonation not allowed error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(auth.ErrImpersonationNotAllowed)
+			},
+			want:    staff.SwitchStaffTenantOutput{},
+			wantErr: auth.ErrImpersonationNotAllowed,
+		},
+		{
+			name:  "when there is no authenticated staff, then it should return an invalid token error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("", false)
+			},
+			want:    staff.SwitchStaffTenantOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name: "when the staff is not active in the restaurant, " +
+				"then it should return a restaurant not linked error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), staff.FindByStaffIDParams{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-other-restaurant-id",
+				}).Return(staff.Staff{}, staff.ErrStaffNotFound)
+			},
+			want:    staff.SwitchStaffTenantOutput{},
+			wantErr: staff.ErrRestaurantNotLinked,
+		},
+		{
+			name:  "when there is an unexpected error when fetching the staff, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staff.Staff{}, errRepo)
+			},
+			want:    staff.SwitchStaffTenantOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error generating the token pair, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(linkedStaff, nil)
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{Enabled: false}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).
+					Return(authcore.TokenPair{}, errToken)
+			},
+			want:    staff.SwitchStaffTenantOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the staff has MFA enabled, " +
+				"then it should return an MFA challenge for the other restaurant instead of the token pair",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(linkedStaff, nil)
+				mfaService.EXPECT().IsEnabled(gomock.Any(), mfa.IsEnabledInput{
+					UserID: "fake-staff-id",
+					Role:   staff.DefaultTokenRole,
+				}).Return(mfa.IsEnabledOutput{Enabled: true}, nil)
+				mfaService.EXPECT().CreateChallenge(gomock.Any(), mfa.CreateChallengeInput{
+					UserID:   "fake-staff-id",
+					Role:     staff.DefaultTokenRole,
+					TenantID: "fake-other-restaurant-id",
+				}).Return(mfa.CreateChallengeOutput{
+					Token:     "fake-challenge-token",
+					ExpiresAt: now.Add(mfa.DefaultChallengeExpiration),
+				}, nil)
+			},
+			want: staff.SwitchStaffTenantOutput{
+				TenantSession: staff.TenantSession{
+					MFARequired:           true,
+					MFAChallengeToken:     "fake-challenge-token",
+					MFAChallengeExpiresAt: now.Add(mfa.DefaultChallengeExpiration),
+				},
+			},
+		},
+		{
+			name: "when the staff is active in the restaurant, " +
+				"then it should return a token pair scoped to it with the permissions of its role",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				mfaService *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().RequireNotImpersonated(gomock.Any()).Return(nil)
+				mockAuthContext(authctx)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(linkedStaff, nil)
+				mfaService.EXPECT().IsEnabled(gomock.Any(), gomock.Any()).
+					Return(mfa.IsEnabledOutput{Enabled: false}, nil)
+				authCoreService.EXPECT().GenerateTokenPair(gomock.Any(), authcore.GenerateTokenPairInput{
+					UserID:     "fake-staff-id",
+					Expiration: staff.DefaultTokenExpiration,
+					Role:       staff.DefaultTokenRole,
+					TenantID:   "fake-other-restaurant-id",
+					Permissions: []string{
+						auth.PermissionMenuRead,
+						auth.PermissionMenuWrite,
+						auth.PermissionOrdersRead,
+						auth.PermissionOrdersWrite,
+					},
+				}).Return(authcore.TokenPair{
+					AccessToken:  "fake-token",
+					RefreshToken: "fake-refresh-token",
+					ExpiresIn:    3600,
+					TokenType:    "Bearer",
+				}, nil)
+			},
+			want: staff.SwitchStaffTenantOutput{
+				TenantSession: staff.TenantSession{
+					TokenPair: authcore.TokenPair{
+						AccessToken:  "fake-token",
+						RefreshToken: "fake-refresh-token",
+						ExpiresIn:    3600,
+						TokenType:    "Bearer",
+					},
+				},
 			},
 		},
 	}
@@ -346,7 +2700,429 @@ func TestService_RefreshStaff(t *testing.T) {
 			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
 			defer cleanup()
 
-			got, err := service.RefreshStaff(context.Background(), tt.input)
+			got, err := service.SwitchStaffTenant(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_LinkStaffRestaurant(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := staff.LinkStaffRestaurantInput{
+		StaffID:      "fake-staff-id",
+		RestaurantID: "fake-other-restaurant-id",
+		Role:         staff.RoleManager,
+	}
+
+	tests := []staffServiceTestCase[staff.LinkStaffRestaurantInput, staff.LinkStaffRestaurantOutput]{
+		{
+			name:  "when the staff does not exist, then it should return a staff not found error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().AddRestaurant(gomock.Any(), gomock.Any()).Return(staff.ErrStaffNotFound)
+			},
+			want:    staff.LinkStaffRestaurantOutput{},
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name: "when the staff is already linked to the restaurant, " +
+				"then it should return a restaurant already linked error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().AddRestaurant(gomock.Any(), gomock.Any()).Return(staff.ErrRestaurantAlreadyLinked)
+			},
+			want:    staff.LinkStaffRestaurantOutput{},
+			wantErr: staff.ErrRestaurantAlreadyLinked,
+		},
+		{
+			name:  "when there is an unexpected error when linking the restaurant, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().AddRestaurant(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    staff.LinkStaffRestaurantOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the staff is linked to the restaurant, then it should return no error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().AddRestaurant(gomock.Any(), staff.AddRestaurantParams{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-other-restaurant-id",
+					Role:         staff.RoleManager,
+				}).Return(nil)
+			},
+			want:    staff.LinkStaffRestaurantOutput{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.LinkStaffRestaurant(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ImpersonateStaff(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	input := staff.ImpersonateStaffInput{StaffID: "fake-staff-id", RestaurantID: "fake-restaurant-id"}
+	membership := staff.Membership{RestaurantID: "fake-restaurant-id", Role: staff.RoleManager, Active: true}
+	staffUser := staff.Staff{
+		StaffID:     "fake-staff-id",
+		Email:       "test@example.com",
+		Restaurants: []staff.Membership{membership},
+		Active:      true,
+	}
+
+	tests := []staffServiceTestCase[staff.ImpersonateStaffInput, staff.ImpersonateStaffOutput]{
+		{
+			name:  "when there is no authentication context, then it should return an invalid token error",
+			input: input,
+			mocksSetup: func(
+				_ *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("", false)
+			},
+			want:    staff.ImpersonateStaffOutput{},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:  "when the staff is not active in the restaurant, then it should return a staff not found error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-admin-id", true)
+				repo.EXPECT().FindByStaffID(gomock.Any(), staff.FindByStaffIDParams{
+					StaffID:      "fake-staff-id",
+					RestaurantID: "fake-restaurant-id",
+				}).Return(staff.Staff{}, staff.ErrStaffNotFound)
+			},
+			want:    staff.ImpersonateStaffOutput{},
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name:  "when there is an unexpected error finding the staff, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-admin-id", true)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staff.Staff{}, errRepo)
+			},
+			want:    staff.ImpersonateStaffOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when there is an error generating the token, then it should propagate the error",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-admin-id", true)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staffUser, nil)
+				authCoreService.EXPECT().GenerateImpersonationToken(gomock.Any(), gomock.Any()).
+					Return(authcore.ImpersonationToken{}, errToken)
+			},
+			want:    staff.ImpersonateStaffOutput{},
+			wantErr: errToken,
+		},
+		{
+			name: "when the staff is impersonated, " +
+				"then it should return a token with its permissions in the restaurant naming the admin as actor",
+			input: input,
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				authCoreService *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				authctx *authmocks.MockContextReader,
+			) {
+				authctx.EXPECT().GetSubject(gomock.Any()).Return("fake-admin-id", true)
+				repo.EXPECT().FindByStaffID(gomock.Any(), gomock.Any()).Return(staffUser, nil)
+				authCoreService.EXPECT().GenerateImpersonationToken(
+					gomock.Any(), authcore.GenerateImpersonationTokenInput{
+						UserID:      "fake-staff-id",
+						Role:        staff.DefaultTokenRole,
+						TenantID:    "fake-restaurant-id",
+						Permissions: membership.Permissions(),
+						ActorID:     "fake-admin-id",
+					},
+				).Return(authcore.ImpersonationToken{
+					AccessToken: "fake-token",
+					ExpiresIn:   authcore.ImpersonationTokenExpiration,
+					TokenType:   "Bearer",
+				}, nil)
+			},
+			want: staff.ImpersonateStaffOutput{
+				ImpersonationToken: authcore.ImpersonationToken{
+					AccessToken: "fake-token",
+					ExpiresIn:   authcore.ImpersonationTokenExpiration,
+					TokenType:   "Bearer",
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.ImpersonateStaff(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_MigrateLegacyStaff(t *testing.T) {
+	logger, _ := log.NewTest()
+
+	legacy := []staff.LegacyStaff{
+		{
+			ID:           "fake-id",
+			StaffID:      "fake-staff-id",
+			Email:        "test@example.com",
+			RestaurantID: "fake-restaurant-id",
+			Owner:        true,
+			Active:       true,
+			Password:     "fake-hashed-password",
+		},
+		{
+			ID:           "fake-other-id",
+			StaffID:      "fake-other-staff-id",
+			Email:        "other@example.com",
+			RestaurantID: "fake-other-restaurant-id",
+			Active:       false,
+			Password:     "fake-other-hashed-password",
+		},
+	}
+
+	tests := []staffServiceTestCase[staff.MigrateLegacyStaffInput, staff.MigrateLegacyStaffOutput]{
+		{
+			name: "when there is an error finding the legacy staff, then it should propagate the error",
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindLegacyStaff(gomock.Any(), gomock.Any()).Return(nil, errRepo)
+			},
+			want:    staff.MigrateLegacyStaffOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is an error merging the legacy staff, then it should propagate the error",
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindLegacyStaff(gomock.Any(), gomock.Any()).Return(legacy, nil)
+				repo.EXPECT().MergeLegacyStaff(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    staff.MigrateLegacyStaffOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when there is no legacy staff, then it should not migrate any staff",
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				repo.EXPECT().FindLegacyStaff(gomock.Any(), staff.FindLegacyStaffParams{
+					Limit: staff.LegacyStaffBatchSize,
+				}).Return(nil, nil)
+			},
+			want:    staff.MigrateLegacyStaffOutput{MigratedStaff: 0},
+			wantErr: nil,
+		},
+		{
+			name: "when there is legacy staff, " +
+				"then it should merge it into the staff with the same email or fold it in place otherwise",
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				gomock.InOrder(
+					repo.EXPECT().FindLegacyStaff(gomock.Any(), gomock.Any()).Return(legacy, nil),
+					repo.EXPECT().MergeLegacyStaff(gomock.Any(), staff.MergeLegacyStaffParams{
+						ID:       "fake-id",
+						StaffID:  "fake-staff-id",
+						Email:    "test@example.com",
+						Password: "fake-hashed-password",
+						Membership: staff.Membership{
+							RestaurantID: "fake-restaurant-id",
+							Owner:        true,
+							Active:       true,
+						},
+					}).Return(nil),
+					repo.EXPECT().MergeLegacyStaff(gomock.Any(), gomock.Any()).Return(staff.ErrStaffNotFound),
+					repo.EXPECT().FoldLegacyStaff(gomock.Any(), staff.FoldLegacyStaffParams{
+						ID:         "fake-other-id",
+						Membership: staff.Membership{RestaurantID: "fake-other-restaurant-id", Active: false},
+					}).Return(nil),
+					repo.EXPECT().FindLegacyStaff(gomock.Any(), staff.FindLegacyStaffParams{
+						AfterID: "fake-other-id",
+						Limit:   staff.LegacyStaffBatchSize,
+					}).Return(nil, nil),
+				)
+			},
+			want:    staff.MigrateLegacyStaffOutput{MigratedStaff: 2},
+			wantErr: nil,
+		},
+		{
+			name: "when the password of the legacy staff conflicts with the staff with the same email, " +
+				"then it should report the conflict and keep migrating the rest of the staff",
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				gomock.InOrder(
+					repo.EXPECT().FindLegacyStaff(gomock.Any(), gomock.Any()).Return(legacy, nil),
+					repo.EXPECT().MergeLegacyStaff(gomock.Any(), gomock.Any()).Return(staff.ErrLegacyStaffConflict),
+					repo.EXPECT().MergeLegacyStaff(gomock.Any(), gomock.Any()).Return(staff.ErrStaffNotFound),
+					repo.EXPECT().FoldLegacyStaff(gomock.Any(), gomock.Any()).Return(nil),
+					repo.EXPECT().FindLegacyStaff(gomock.Any(), staff.FindLegacyStaffParams{
+						AfterID: "fake-other-id",
+						Limit:   staff.LegacyStaffBatchSize,
+					}).Return(nil, nil),
+				)
+			},
+			want: staff.MigrateLegacyStaffOutput{
+				MigratedStaff: 1,
+				Conflicts: []staff.LegacyStaffConflict{
+					{StaffID: "fake-staff-id", Email: "test@example.com", RestaurantID: "fake-restaurant-id"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "when the legacy staff is migrated by another instance in the meantime, " +
+				"then it should skip it",
+			mocksSetup: func(
+				repo *staffmocks.MockRepository,
+				_ *authcoremocks.MockService,
+				_ *passwordresetmocks.MockService,
+				_ *mfamocks.MockService,
+				_ *lockoutmocks.MockService,
+				_ *tenantselectionmocks.MockService,
+				_ *authmocks.MockContextReader,
+			) {
+				gomock.InOrder(
+					repo.EXPECT().FindLegacyStaff(gomock.Any(), gomock.Any()).Return(legacy[:1], nil),
+					repo.EXPECT().MergeLegacyStaff(gomock.Any(), gomock.Any()).Return(staff.ErrStaffNotFound),
+					repo.EXPECT().FoldLegacyStaff(gomock.Any(), gomock.Any()).Return(staff.ErrStaffNotFound),
+					repo.EXPECT().FindLegacyStaff(gomock.Any(), gomock.Any()).Return(nil, nil),
+				)
+			},
+			want:    staff.MigrateLegacyStaffOutput{MigratedStaff: 0},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.MigrateLegacyStaff(context.Background(), tt.input)
 
 			assert.ErrorIs(t, err, tt.wantErr)
 			assert.Equal(t, tt.want, got)
@@ -358,18 +3134,36 @@ func serviceSetup(
 	t *testing.T, logger log.Logger, mocksSetup func(
 		repo *staffmocks.MockRepository,
 		authCoreService *authcoremocks.MockService,
+		passwordResetService *passwordresetmocks.MockService,
+		mfaService *mfamocks.MockService,
+		lockoutService *lockoutmocks.MockService,
+		_ *tenantselectionmocks.MockService,
+		authctx *authmocks.MockContextReader,
 	),
 ) (staff.Service, func()) {
 	ctrl := gomock.NewController(t)
 
 	repo := staffmocks.NewMockRepository(ctrl)
 	authCoreService := authcoremocks.NewMockService(ctrl)
+	passwordResetService := passwordresetmocks.NewMockService(ctrl)
+	mfaService := mfamocks.NewMockService(ctrl)
+	lockoutService := lockoutmocks.NewMockService(ctrl)
+	tenantSelectionService := tenantselectionmocks.NewMockService(ctrl)
+	authctx := authmocks.NewMockContextReader(ctrl)
+	// The audit log is best effort and never affects the result, so the recorded events are not asserted here
+	authEventsService := autheventsmocks.NewMockService(ctrl)
+	authEventsService.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
 
 	if mocksSetup != nil {
-		mocksSetup(repo, authCoreService)
+		mocksSetup(
+			repo, authCoreService, passwordResetService, mfaService, lockoutService, tenantSelectionService, authctx,
+		)
 	}
 
-	service := staff.NewService(logger, repo, authCoreService)
+	service := staff.NewService(
+		logger, repo, authCoreService, passwordResetService, mfaService, lockoutService, tenantSelectionService,
+		testPasswordPolicy, authctx, authEventsService,
+	)
 	return service, func() {
 		ctrl.Finish()
 	}
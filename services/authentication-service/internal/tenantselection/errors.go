@@ -0,0 +1,9 @@
+package tenantselection
+
+import "errors"
+
+var (
+	// ErrSelectionTokenNotFound indicates that the specified selection token could not be found, or it can no longer
+	// be used because it is expired or was already consumed.
+	ErrSelectionTokenNotFound = errors.New("selection token not found")
+)
@@ -0,0 +1,16 @@
+package tenantselection
+
+import "time"
+
+// Token represents a single-use token issued once the password of a user linked to several tenants has been verified,
+// so the user can pick one of them without sending the password again. Only the TokenHash is stored, and UsedAt is set
+// once the token has been consumed.
+type Token struct {
+	ID        string     `bson:"_id,omitempty"`
+	UserID    string     `bson:"user_id"`
+	Role      string     `bson:"role"`
+	TokenHash string     `bson:"token_hash"`
+	ExpiresAt time.Time  `bson:"expires_at"`
+	UsedAt    *time.Time `bson:"used_at,omitempty"`
+	CreatedAt time.Time  `bson:"created_at"`
+}
@@ -0,0 +1,124 @@
+package tenantselection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// CollectionName defines the name of the database collection used to store the tenant selection tokens.
+	CollectionName = "tenant_selection_tokens"
+
+	// FieldUserID represents the database field name for storing the ID of the token owner.
+	FieldUserID = "user_id"
+	// FieldRole represents the database field name for storing the role of the token owner.
+	FieldRole = "role"
+	// FieldTokenHash represents the database field name for storing the token digests.
+	FieldTokenHash = "token_hash"
+	// FieldExpiresAt represents the database field name for storing the expiration time of a token.
+	FieldExpiresAt = "expires_at"
+	// FieldUsedAt represents the database field name for storing when the token was consumed.
+	FieldUsedAt = "used_at"
+)
+
+// Repository defines a contract for storing and consuming tenant selection tokens in a persistence layer.
+//
+//go:generate mockgen -destination=./mocks/repository_mock.go -package=tenantselection_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/tenantselection Repository
+type Repository interface {
+	Create(ctx context.Context, params CreateTokenParams) (Token, error)
+	Consume(ctx context.Context, params ConsumeTokenParams) (Token, error)
+}
+
+type repository struct {
+	logger     log.Logger
+	collection *mongo.Collection
+	clock      clock.Clock
+}
+
+// NewRepository creates a new Repository instance.
+func NewRepository(logger log.Logger, db *mongo.Database, clk clock.Clock) Repository {
+	return &repository{
+		logger:     logger,
+		collection: db.Collection(CollectionName),
+		clock:      clk,
+	}
+}
+
+// CreateTokenParams defines the parameters required to create a new selection token for a user.
+type CreateTokenParams struct {
+	UserID    string
+	Role      string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+func (r *repository) Create(ctx context.Context, params CreateTokenParams) (Token, error) {
+	logger := r.logger.WithContext(ctx)
+
+	token := Token{
+		UserID:    params.UserID,
+		Role:      params.Role,
+		TokenHash: params.TokenHash,
+		ExpiresAt: params.ExpiresAt,
+		CreatedAt: r.clock.Now(),
+	}
+
+	res, err := r.collection.InsertOne(ctx, token)
+	if err != nil {
+		logger.Error("Failed to store selection token", err)
+		return Token{}, err
+	}
+
+	token.ID = res.InsertedID.(primitive.ObjectID).Hex()
+	return token, nil
+}
+
+// ConsumeTokenParams defines the parameters needed to consume a selection token.
+// Role restricts the consumption to the tokens issued for that role.
+type ConsumeTokenParams struct {
+	TokenHash string
+	Role      string
+}
+
+func (r *repository) Consume(ctx context.Context, params ConsumeTokenParams) (Token, error) {
+	logger := r.logger.WithContext(ctx)
+
+	var token Token
+	now := r.clock.Now()
+	// The token is marked as used in the same operation it is found, so it can't be consumed twice
+	filter := bson.M{
+		FieldTokenHash: params.TokenHash,
+		FieldRole:      params.Role,
+		FieldUsedAt:    bson.M{"$exists": false},
+		FieldExpiresAt: bson.M{
+			"$gt": now,
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			FieldUsedAt: now,
+		},
+	}
+
+	// Returning the updated document
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.Warn("Selection token not found")
+			return Token{}, ErrSelectionTokenNotFound
+		}
+		logger.Error("Failed to consume selection token", err)
+		return Token{}, err
+	}
+	return token, nil
+}
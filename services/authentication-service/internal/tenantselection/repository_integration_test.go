@@ -0,0 +1,257 @@
+//go:build integration
+
+package tenantselection_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/infraestructure/mongodb"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/tenantselection"
+)
+
+const testDBPrefix = "tenantselection_test_authentication_service"
+
+type tenantSelectionRepositoryTestCase[P, W any] struct {
+	name            string
+	insertDocuments func(t *testing.T, coll *mongo.Collection)
+	params          P
+	want            W
+	wantErr         error
+}
+
+func TestRepository_Create(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt = now.Add(5 * time.Minute)
+	)
+	logger, _ := log.NewTest()
+
+	tests := []tenantSelectionRepositoryTestCase[tenantselection.CreateTokenParams, tenantselection.Token]{
+		{
+			name: "when the token is stored successfully, then it should return the stored token",
+			params: tenantselection.CreateTokenParams{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TokenHash: "fake-token-hash",
+				ExpiresAt: expiresAt,
+			},
+			want: tenantselection.Token{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TokenHash: "fake-token-hash",
+				ExpiresAt: expiresAt,
+				CreatedAt: now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestSelectionTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := tenantselection.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			token, err := repo.Create(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				// As the ID is generated by MongoDB, we just check that it is not empty
+				assert.NotEmpty(t, token.ID, "ID should not be empty")
+
+				tt.want.ID = token.ID
+				assert.Equal(t, tt.want, token)
+			}
+		})
+	}
+}
+
+func TestRepository_Create_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestSelectionTokenCollection(t, tdb.DB)
+
+	repo := tenantselection.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.Create(context.Background(), tenantselection.CreateTokenParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func TestRepository_Consume(t *testing.T) {
+	var (
+		now       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt = now.Add(5 * time.Minute)
+		expiredAt = now.Add(-time.Minute)
+		usedAt    = now.Add(-time.Minute)
+	)
+	logger, _ := log.NewTest()
+
+	consumeParams := tenantselection.ConsumeTokenParams{
+		TokenHash: "fake-token-hash",
+		Role:      "fake-role",
+	}
+
+	tests := []tenantSelectionRepositoryTestCase[tenantselection.ConsumeTokenParams, tenantselection.Token]{
+		{
+			name: "when the token does not exist, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, tenantselection.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-other-token-hash",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params:  consumeParams,
+			want:    tenantselection.Token{},
+			wantErr: tenantselection.ErrSelectionTokenNotFound,
+		},
+		{
+			name: "when the token was issued for another role, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, tenantselection.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-other-role",
+					TokenHash: "fake-token-hash",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params:  consumeParams,
+			want:    tenantselection.Token{},
+			wantErr: tenantselection.ErrSelectionTokenNotFound,
+		},
+		{
+			name: "when the token is expired, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, tenantselection.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-token-hash",
+					ExpiresAt: expiredAt,
+					CreatedAt: now,
+				})
+			},
+			params:  consumeParams,
+			want:    tenantselection.Token{},
+			wantErr: tenantselection.ErrSelectionTokenNotFound,
+		},
+		{
+			name: "when the token was already used, then it should return a token not found error",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, tenantselection.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-token-hash",
+					ExpiresAt: expiresAt,
+					UsedAt:    &usedAt,
+					CreatedAt: now,
+				})
+			},
+			params:  consumeParams,
+			want:    tenantselection.Token{},
+			wantErr: tenantselection.ErrSelectionTokenNotFound,
+		},
+		{
+			name: "when the token is valid, then it should mark it as used and return it",
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, tenantselection.Token{
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: "fake-token-hash",
+					ExpiresAt: expiresAt,
+					CreatedAt: now,
+				})
+			},
+			params: consumeParams,
+			want: tenantselection.Token{
+				UserID:    "fake-user-id",
+				Role:      "fake-role",
+				TokenHash: "fake-token-hash",
+				ExpiresAt: expiresAt,
+				UsedAt:    &now,
+				CreatedAt: now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, testDBPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestSelectionTokenCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := tenantselection.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			token, err := repo.Consume(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, token.ID, "ID should not be empty")
+
+				tt.want.ID = token.ID
+				assert.Equal(t, tt.want, token)
+
+				// A consumed token can't be consumed again
+				_, err = repo.Consume(context.Background(), tt.params)
+				assert.ErrorIs(t, err, tenantselection.ErrSelectionTokenNotFound)
+			}
+		})
+	}
+}
+
+func TestRepository_Consume_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, testDBPrefix)
+	setupTestSelectionTokenCollection(t, tdb.DB)
+
+	repo := tenantselection.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	// Simulating an unexpected failure by closing the opened connection
+	tdb.Close(t)
+
+	_, err := repo.Consume(context.Background(), tenantselection.ConsumeTokenParams{})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+}
+
+func setupTestSelectionTokenCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := db.Collection(tenantselection.CollectionName)
+
+	// Create unique index on the token digest
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: tenantselection.FieldTokenHash, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := coll.Indexes().CreateOne(ctx, indexModel); err != nil {
+		t.Fatalf("Failed to create unique index: %v", err)
+	}
+
+	return coll
+}
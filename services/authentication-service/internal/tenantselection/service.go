@@ -0,0 +1,121 @@
+// Package tenantselection provides the functionality for issuing and consuming the single-use, short-lived tokens that
+// allow the users linked to several tenants to pick one of them once their password has been verified.
+package tenantselection
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+)
+
+const (
+	// DefaultSelectionTokenLength defines the default length, in bytes, of a generated selection token.
+	DefaultSelectionTokenLength = 32
+	// DefaultTokenExpiration specifies the default duration for which a selection token remains valid. It only needs to
+	// outlive the time the user takes to pick the tenant.
+	DefaultTokenExpiration = 5 * time.Minute
+)
+
+// Service represents the core interface for tenant selection tokens.
+//
+//go:generate mockgen -destination=./mocks/service_mock.go -package=tenantselection_mocks github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/tenantselection Service
+type Service interface {
+	Issue(ctx context.Context, input IssueInput) (IssueOutput, error)
+	Consume(ctx context.Context, input ConsumeInput) (ConsumeOutput, error)
+}
+
+type service struct {
+	logger log.Logger
+	repo   Repository
+	clock  clock.Clock
+}
+
+// NewService initializes and returns a new Service implementation.
+func NewService(logger log.Logger, repo Repository, clk clock.Clock) Service {
+	return &service{logger: logger, repo: repo, clock: clk}
+}
+
+// IssueInput represents the input required to issue a selection token for a user whose password has been verified.
+type IssueInput struct {
+	UserID string
+	Role   string
+}
+
+// IssueOutput contains the selection token, which must be exchanged together with the selected tenant.
+type IssueOutput struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func (s *service) Issue(ctx context.Context, input IssueInput) (IssueOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	token, err := generateToken()
+	if err != nil {
+		logger.Error("failed to generate selection token", err)
+		return IssueOutput{}, err
+	}
+
+	expiresAt := s.clock.Now().Add(DefaultTokenExpiration)
+	if _, err := s.repo.Create(ctx, CreateTokenParams{
+		UserID:    input.UserID,
+		Role:      input.Role,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		logger.Error("failed to store selection token", err)
+		return IssueOutput{}, err
+	}
+
+	return IssueOutput{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// ConsumeInput represents the input required to consume a selection token.
+// Role must match the role the token was issued for.
+type ConsumeInput struct {
+	Token string
+	Role  string
+}
+
+// ConsumeOutput represents the owner of a consumed selection token.
+type ConsumeOutput struct {
+	UserID string
+	Role   string
+}
+
+// Consume marks the selection token as used and returns its owner.
+func (s *service) Consume(ctx context.Context, input ConsumeInput) (ConsumeOutput, error) {
+	logger := s.logger.WithContext(ctx)
+
+	token, err := s.repo.Consume(ctx, ConsumeTokenParams{
+		TokenHash: hashToken(input.Token),
+		Role:      input.Role,
+	})
+	if err != nil {
+		logger.Error("failed to consume selection token", err)
+		return ConsumeOutput{}, err
+	}
+
+	return ConsumeOutput{UserID: token.UserID, Role: token.Role}, nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, DefaultSelectionTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+// hashToken computes the digest the tokens are stored with. The tokens are long random values, so a plain SHA-256 is
+// enough to prevent them from being used if the database is leaked.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
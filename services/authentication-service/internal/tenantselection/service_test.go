@@ -0,0 +1,189 @@
+//go:build unit
+
+package tenantselection_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/clock"
+	"github.com/alexgrauroca/practice-food-delivery-platform/pkg/log"
+	"github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/tenantselection"
+	tenantselectionmocks "github.com/alexgrauroca/practice-food-delivery-platform/services/authentication-service/internal/tenantselection/mocks"
+)
+
+var errRepo = errors.New("repository error")
+
+type tenantSelectionServiceTestCase[I, W any] struct {
+	name       string
+	input      I
+	mocksSetup func(repo *tenantselectionmocks.MockRepository)
+	want       W
+	wantErr    error
+}
+
+func TestService_Issue(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(tenantselection.DefaultTokenExpiration)
+	logger, _ := log.NewTest()
+
+	input := tenantselection.IssueInput{
+		UserID: "fake-user-id",
+		Role:   "fake-role",
+	}
+
+	tests := []struct {
+		name       string
+		input      tenantselection.IssueInput
+		mocksSetup func(repo *tenantselectionmocks.MockRepository, storedHash *string)
+		wantErr    error
+	}{
+		{
+			name:  "when there is an error storing the token, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *tenantselectionmocks.MockRepository, _ *string) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(tenantselection.Token{}, errRepo)
+			},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the token is issued, then it stores only the token digest and returns the token",
+			input: input,
+			mocksSetup: func(repo *tenantselectionmocks.MockRepository, storedHash *string) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(
+						_ context.Context,
+						params tenantselection.CreateTokenParams,
+					) (tenantselection.Token, error) {
+						require.Equal(t, "fake-user-id", params.UserID)
+						require.Equal(t, "fake-role", params.Role)
+						require.Equal(t, expiresAt, params.ExpiresAt)
+						require.NotEmpty(t, params.TokenHash)
+
+						*storedHash = params.TokenHash
+						return tenantselection.Token{TokenHash: params.TokenHash}, nil
+					})
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var storedHash string
+			service, cleanup := serviceSetup(t, logger, now, func(repo *tenantselectionmocks.MockRepository) {
+				tt.mocksSetup(repo, &storedHash)
+			})
+			defer cleanup()
+
+			got, err := service.Issue(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.Equal(t, tenantselection.IssueOutput{}, got)
+				return
+			}
+			assert.Equal(t, expiresAt, got.ExpiresAt)
+			assert.NotEmpty(t, got.Token)
+			assert.Equal(t, hashToken(got.Token), storedHash)
+		})
+	}
+}
+
+func TestService_Consume(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	input := tenantselection.ConsumeInput{
+		Token: "fake-token",
+		Role:  "fake-role",
+	}
+
+	tests := []tenantSelectionServiceTestCase[tenantselection.ConsumeInput, tenantselection.ConsumeOutput]{
+		{
+			name:  "when the token can't be consumed, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *tenantselectionmocks.MockRepository) {
+				repo.EXPECT().Consume(gomock.Any(), gomock.Any()).
+					Return(tenantselection.Token{}, tenantselection.ErrSelectionTokenNotFound)
+			},
+			want:    tenantselection.ConsumeOutput{},
+			wantErr: tenantselection.ErrSelectionTokenNotFound,
+		},
+		{
+			name:  "when there is an unexpected error consuming the token, then it propagates the error",
+			input: input,
+			mocksSetup: func(repo *tenantselectionmocks.MockRepository) {
+				repo.EXPECT().Consume(gomock.Any(), gomock.Any()).Return(tenantselection.Token{}, errRepo)
+			},
+			want:    tenantselection.ConsumeOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name:  "when the token is consumed, then it returns the token owner",
+			input: input,
+			mocksSetup: func(repo *tenantselectionmocks.MockRepository) {
+				repo.EXPECT().Consume(gomock.Any(), tenantselection.ConsumeTokenParams{
+					TokenHash: hashToken("fake-token"),
+					Role:      "fake-role",
+				}).Return(tenantselection.Token{
+					ID:        "fake-id",
+					UserID:    "fake-user-id",
+					Role:      "fake-role",
+					TokenHash: hashToken("fake-token"),
+					ExpiresAt: now.Add(time.Minute),
+					UsedAt:    &now,
+				}, nil)
+			},
+			want: tenantselection.ConsumeOutput{
+				UserID: "fake-user-id",
+				Role:   "fake-role",
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cleanup := serviceSetup(t, logger, now, tt.mocksSetup)
+			defer cleanup()
+
+			got, err := service.Consume(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func serviceSetup(
+	t *testing.T,
+	logger log.Logger,
+	now time.Time,
+	mocksSetup func(repo *tenantselectionmocks.MockRepository),
+) (tenantselection.Service, func()) {
+	ctrl := gomock.NewController(t)
+
+	repo := tenantselectionmocks.NewMockRepository(ctrl)
+	if mocksSetup != nil {
+		mocksSetup(repo)
+	}
+
+	service := tenantselection.NewService(logger, repo, clock.FixedClock{FixedTime: now})
+	return service, func() {
+		ctrl.Finish()
+	}
+}
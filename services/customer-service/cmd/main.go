@@ -54,7 +54,11 @@ func main() {
 	db := client.Database(dbName)
 
 	// Initialize features
-	authcli, authMiddleware, authctx := initAuthenticationFeature(logger)
+	authcli, authMiddleware, authctx, err := initAuthenticationFeature(logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize authentication", err)
+		return
+	}
 	initCustomersFeature(logger, db, router, authcli, authMiddleware, authctx)
 
 	logger.Info("Starting http server")
@@ -68,14 +72,36 @@ func initAuthenticationFeature(logger customlog.Logger) (
 	authentication.Client,
 	auth.Middleware,
 	auth.ContextReader,
+	error,
 ) {
-	authcli := authentication.NewClient(logger, authentication.Config{Debug: false})
-	//TODO configure secret by env vars
-	authService := auth.NewService(logger, []byte("a-string-secret-at-least-256-bits-long"), clock.RealClock{})
-	authMiddleware := auth.NewMiddleware(logger, authService)
+	cfg, err := auth.LoadConfig(logger)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	clientCfg, err := authentication.LoadConfig(logger)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	authcli := authentication.NewClient(logger, clientCfg)
+	// Tokens are verified with the public keys published by the authentication service
+	keys := auth.NewJWKSKeyProvider(logger, auth.JWKSConfig{
+		URL:      cfg.JWKSURL,
+		CacheTTL: cfg.JWKSCacheTTL,
+	}, clock.RealClock{})
+	authService := auth.NewService(logger, keys, clock.RealClock{})
+	// The revoked tokens are only known by the authentication service, so they are checked through its introspection
+	introspectionCfg := auth.IntrospectionConfig{
+		CacheSize: cfg.IntrospectionCacheSize,
+		CacheTTL:  cfg.IntrospectionCacheTTL,
+	}
+	authMiddleware := auth.NewMiddlewareWithIntrospection(
+		logger, authService, authcli, clock.RealClock{}, introspectionCfg,
+	)
 	authctx := auth.NewContextReader(logger)
 
-	return authcli, authMiddleware, authctx
+	return authcli, authMiddleware, authctx, nil
 }
 
 func initCustomersFeature(
@@ -53,7 +53,11 @@ func main() {
 	db := client.Database(dbName)
 
 	// Initialize features
-	authcli := initAuthenticationFeature(logger)
+	authcli, err := initAuthenticationFeature(logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize authentication", err)
+		return
+	}
 	staffService := initStaffFeature(logger, db, authcli)
 	initRestaurantsFeature(router, logger, db, staffService)
 
@@ -64,8 +68,12 @@ func main() {
 	}
 }
 
-func initAuthenticationFeature(logger customlog.Logger) authentication.Client {
-	return authentication.NewClient(logger, authentication.Config{Debug: false})
+func initAuthenticationFeature(logger customlog.Logger) (authentication.Client, error) {
+	cfg, err := authentication.LoadConfig(logger)
+	if err != nil {
+		return nil, err
+	}
+	return authentication.NewClient(logger, cfg), nil
 }
 
 func initStaffFeature(logger customlog.Logger, db *mongo.Database, authcli authentication.Client) staff.Service {
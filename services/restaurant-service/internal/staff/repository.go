@@ -26,11 +26,17 @@ const (
 	FieldRestaurantID = "restaurant_id"
 	// FieldOwner represents the field name used to indicate whether a staff user is a restaurant owner or not.
 	FieldOwner = "owner"
+	// FieldAuthStaffID represents the field name used to store the staff ID carried by the tokens of a staff user.
+	FieldAuthStaffID = "auth_staff_id"
+	// FieldUpdatedAt represents the field name used to store the timestamp of the last update in the database.
+	FieldUpdatedAt = "updated_at"
 )
 
-// Staff represents the structure of a restaurant staff user.
+// Staff represents the structure of a restaurant staff user. AuthStaffID is the staff ID carried by its tokens, which
+// is the one of the existing credentials when the restaurant was linked to them at the authentication service.
 type Staff struct {
 	ID           string    `bson:"_id,omitempty"`
+	AuthStaffID  string    `bson:"auth_staff_id,omitempty"`
 	Email        string    `bson:"email"`
 	RestaurantID string    `bson:"restaurant_id"`
 	Owner        bool      `bson:"owner"`
@@ -50,6 +56,7 @@ type Staff struct {
 type Repository interface {
 	CreateStaff(ctx context.Context, params CreateStaffParams) (Staff, error)
 	PurgeStaff(ctx context.Context, email string) error
+	SetAuthStaffID(ctx context.Context, params SetAuthStaffIDParams) error
 }
 
 type repository struct {
@@ -135,3 +142,41 @@ func (r repository) PurgeStaff(ctx context.Context, email string) error {
 	logger.Info("staff purged successfully", log.Field{Key: "email", Value: email})
 	return nil
 }
+
+// SetAuthStaffIDParams represents the input data required for storing the staff ID carried by the tokens of a staff
+// user.
+type SetAuthStaffIDParams struct {
+	ID          string
+	AuthStaffID string
+}
+
+func (r repository) SetAuthStaffID(ctx context.Context, params SetAuthStaffIDParams) error {
+	logger := r.logger.WithContext(ctx)
+
+	id, err := primitive.ObjectIDFromHex(params.ID)
+	if err != nil {
+		logger.Error("invalid staff ID", err)
+		return err
+	}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{
+			FieldAuthStaffID: params.AuthStaffID,
+			FieldUpdatedAt:   r.clock.Now(),
+		},
+	})
+	if err != nil {
+		logger.Error("failed to set the auth staff ID", err)
+		return err
+	}
+	if res.MatchedCount == 0 {
+		logger.Warn("staff not found", log.Field{Key: "staff_id", Value: params.ID})
+		return ErrStaffNotFound
+	}
+	logger.Info(
+		"auth staff ID set successfully",
+		log.Field{Key: "staff_id", Value: params.ID},
+		log.Field{Key: "auth_staff_id", Value: params.AuthStaffID},
+	)
+	return nil
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -284,6 +286,75 @@ func TestRepository_PurgeStaff_UnexpectedFailure(t *testing.T) {
 	assert.NotErrorIs(t, err, staff.ErrStaffNotFound)
 }
 
+func TestRepository_SetAuthStaffID(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	staffID := primitive.NewObjectID()
+	params := staff.SetAuthStaffIDParams{ID: staffID.Hex(), AuthStaffID: "fake-auth-staff-id"}
+
+	tests := []repoTestCase[staff.SetAuthStaffIDParams, any]{
+		{
+			name:    "when the staff does not exist, then it should return a staff not found error",
+			params:  params,
+			wantErr: staff.ErrStaffNotFound,
+		},
+		{
+			name:   "when the staff exists, then it should store the auth staff ID",
+			params: params,
+			insertDocuments: func(t *testing.T, coll *mongo.Collection) {
+				mongodb.InsertTestDocument(t, coll, bson.M{
+					"_id":                   staffID,
+					staff.FieldEmail:        "test@example.com",
+					staff.FieldRestaurantID: "fake-restaurant-id",
+					staff.FieldActive:       true,
+				})
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tdb := mongodb.NewTestDB(t, dbPrefix)
+			defer tdb.Close(t)
+
+			coll := setupTestStaffCollection(t, tdb.DB)
+			if tt.insertDocuments != nil {
+				tt.insertDocuments(t, coll)
+			}
+
+			repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+			err := repo.SetAuthStaffID(context.Background(), tt.params)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			// Validating the stored auth staff ID only if the staff existed
+			if tt.wantErr == nil {
+				var got staff.Staff
+				err = coll.FindOne(context.Background(), bson.M{"_id": staffID}).Decode(&got)
+				require.NoError(t, err)
+				assert.Equal(t, "fake-auth-staff-id", got.AuthStaffID)
+				assert.Equal(t, now, got.UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestRepository_SetAuthStaffID_UnexpectedFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger, _ := log.NewTest()
+
+	tdb := mongodb.NewTestDB(t, dbPrefix)
+	repo := staff.NewRepository(logger, tdb.DB, clock.FixedClock{FixedTime: now})
+
+	tdb.Close(t)
+
+	err := repo.SetAuthStaffID(context.Background(), staff.SetAuthStaffIDParams{ID: primitive.NewObjectID().Hex()})
+	assert.Error(t, err, "Expected an error due to unexpected failure")
+	assert.NotErrorIs(t, err, staff.ErrStaffNotFound)
+}
+
 func setupTestStaffCollection(t *testing.T, db *mongo.Database) *mongo.Collection {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
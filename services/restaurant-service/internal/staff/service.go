@@ -86,8 +86,10 @@ func (s service) RegisterStaffOwner(ctx context.Context, input RegisterStaffOwne
 		Email:        input.Email,
 		Password:     input.Password,
 		RestaurantID: input.RestaurantID,
+		Owner:        staff.Owner,
 	}
-	if _, err := s.authcli.RegisterStaff(ctx, req); err != nil {
+	resp, err := s.authcli.RegisterStaff(ctx, req)
+	if err != nil {
 		logger.Error("failed to register staff owner at auth service", err)
 
 		// Roll back the created staff in case of error when registering the staff at auth service.
@@ -97,7 +99,19 @@ func (s service) RegisterStaffOwner(ctx context.Context, input RegisterStaffOwne
 		return RegisterStaffOwnerOutput{}, err
 	}
 
-	logger.Info("staff owner registered successfully", log.Field{Key: "id", Value: staff.ID})
+	// The restaurant is linked to the existing credentials when they share the email, so the tokens carry their staff
+	// ID instead of the one of the created staff. The staff is not purged if it cannot be stored, as the restaurant is
+	// already linked to the credentials.
+	if err := s.repo.SetAuthStaffID(ctx, SetAuthStaffIDParams{ID: staff.ID, AuthStaffID: resp.StaffID}); err != nil {
+		logger.Error("failed to set the auth staff ID of the staff owner", err)
+		return RegisterStaffOwnerOutput{}, err
+	}
+
+	logger.Info(
+		"staff owner registered successfully",
+		log.Field{Key: "id", Value: staff.ID},
+		log.Field{Key: "auth_staff_id", Value: resp.StaffID},
+	)
 	return RegisterStaffOwnerOutput{
 		ID:           staff.ID,
 		Email:        staff.Email,
@@ -78,6 +78,59 @@ func TestService_RegisterStaffOwner(t *testing.T) {
 			want:    staff.RegisterStaffOwnerOutput{},
 			wantErr: errAuthService,
 		},
+		{
+			name: "when the auth staff ID cannot be stored, " +
+				"then it should propagate the error without purging the staff",
+			input: staff.RegisterStaffOwnerInput{Email: "test@example.com"},
+			mocksSetup: func(repo *staffmocks.MockRepository, authcli *authclimocks.MockClient) {
+				repo.EXPECT().CreateStaff(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{ID: "fake-staff-id"}, nil)
+
+				authcli.EXPECT().RegisterStaff(gomock.Any(), gomock.Any()).
+					Return(authentication.RegisterStaffResponse{StaffID: "fake-staff-id"}, nil)
+
+				repo.EXPECT().SetAuthStaffID(gomock.Any(), gomock.Any()).Return(errRepo)
+			},
+			want:    staff.RegisterStaffOwnerOutput{},
+			wantErr: errRepo,
+		},
+		{
+			name: "when the restaurant is linked to existing credentials, " +
+				"then it should store the staff ID of those credentials",
+			input: staff.RegisterStaffOwnerInput{Email: "test@example.com", RestaurantID: "valid-restaurant-id"},
+			mocksSetup: func(repo *staffmocks.MockRepository, authcli *authclimocks.MockClient) {
+				repo.EXPECT().CreateStaff(gomock.Any(), gomock.Any()).
+					Return(staff.Staff{
+						ID:           "fake-staff-id",
+						Email:        "test@example.com",
+						RestaurantID: "valid-restaurant-id",
+						Owner:        true,
+						CreatedAt:    now,
+						UpdatedAt:    now,
+					}, nil)
+
+				authcli.EXPECT().RegisterStaff(gomock.Any(), gomock.Any()).
+					Return(authentication.RegisterStaffResponse{
+						ID:           "fake-auth-staff-id",
+						StaffID:      "fake-existing-staff-id",
+						Email:        "test@example.com",
+						RestaurantID: "valid-restaurant-id",
+					}, nil)
+
+				repo.EXPECT().SetAuthStaffID(gomock.Any(), staff.SetAuthStaffIDParams{
+					ID:          "fake-staff-id",
+					AuthStaffID: "fake-existing-staff-id",
+				}).Return(nil)
+			},
+			want: staff.RegisterStaffOwnerOutput{
+				ID:           "fake-staff-id",
+				Email:        "test@example.com",
+				RestaurantID: "valid-restaurant-id",
+				Owner:        true,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			},
+		},
 		{
 			name: "when the staff owner is registered successfully, then it should return the created staff owner",
 			input: staff.RegisterStaffOwnerInput{
@@ -119,6 +172,7 @@ func TestService_RegisterStaffOwner(t *testing.T) {
 					Email:        "test@example.com",
 					RestaurantID: "valid-restaurant-id",
 					Password:     "ValidPassword123",
+					Owner:        true,
 				}).Return(authentication.RegisterStaffResponse{
 					ID:           "fake-auth-staff-id",
 					StaffID:      "fake-staff-id",
@@ -127,6 +181,11 @@ func TestService_RegisterStaffOwner(t *testing.T) {
 					CreatedAt:    now,
 					UpdatedAt:    now,
 				}, nil)
+
+				repo.EXPECT().SetAuthStaffID(gomock.Any(), staff.SetAuthStaffIDParams{
+					ID:          "fake-staff-id",
+					AuthStaffID: "fake-staff-id",
+				}).Return(nil)
 			},
 			want: staff.RegisterStaffOwnerOutput{
 				ID:           "fake-staff-id",